@@ -17,13 +17,12 @@ package cmd
 import (
 	"fmt"
 	"os"
-	"os/exec"
 	"strings"
 
-	"github.com/uprockcom/maestro/pkg/paths"
+	"github.com/uprockcom/maestro/pkg/configfile"
+	"github.com/uprockcom/maestro/pkg/system"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
-	"gopkg.in/yaml.v3"
 )
 
 var addDomainCmd = &cobra.Command{
@@ -33,13 +32,22 @@ var addDomainCmd = &cobra.Command{
 The domain will be resolved and added to the container's firewall rules.
 
 This is a temporary addition for the running container. To make it permanent,
-add the domain to your configuration file.`,
+add the domain to your configuration file. When run interactively you are asked
+whether to do that; use --save or --no-save to decide up front (for scripts).`,
 	Args: cobra.ExactArgs(2),
 	RunE: runAddDomain,
 }
 
+var (
+	addDomainSave   bool
+	addDomainNoSave bool
+)
+
 func init() {
 	rootCmd.AddCommand(addDomainCmd)
+	addDomainCmd.Flags().BoolVar(&addDomainSave, "save", false, "Also add the domain to firewall.allowed_domains in the config file")
+	addDomainCmd.Flags().BoolVar(&addDomainNoSave, "no-save", false, "Don't update the config file or ask about it")
+	addDomainCmd.MarkFlagsMutuallyExclusive("save", "no-save")
 }
 
 func runAddDomain(cmd *cobra.Command, args []string) error {
@@ -49,7 +57,7 @@ func runAddDomain(cmd *cobra.Command, args []string) error {
 	containerName := resolveContainerName(shortName)
 
 	// Check if container is running
-	checkCmd := exec.Command("docker", "ps", "--filter", fmt.Sprintf("name=%s", containerName), "--format", "{{.State}}")
+	checkCmd := system.DockerCommand("ps", "--filter", fmt.Sprintf("name=%s", containerName), "--format", "{{.State}}")
 	output, err := checkCmd.Output()
 	if err != nil {
 		return fmt.Errorf("failed to check container status: %w", err)
@@ -60,32 +68,32 @@ func runAddDomain(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("container %s is not running", shortName)
 	}
 
-	fmt.Printf("Adding %s to firewall whitelist for %s...\n", domain, containerName)
+	progressf("Adding %s to firewall whitelist for %s...\n", domain, containerName)
 
 	// Add domain to dnsmasq configuration so it automatically tracks all IPs
-	fmt.Println("  Updating dnsmasq configuration...")
+	progressln("  Updating dnsmasq configuration...")
 	dnsmasqConf := "/tmp/dnsmasq-firewall.conf"
 
 	// Check if domain already in config
-	checkConfCmd := exec.Command("docker", "exec", containerName, "grep", "-q", fmt.Sprintf("ipset=/%s/", domain), dnsmasqConf)
+	checkConfCmd := system.DockerCommand("exec", containerName, "grep", "-q", fmt.Sprintf("ipset=/%s/", domain), dnsmasqConf)
 	if checkConfCmd.Run() == nil {
-		fmt.Printf("  Domain %s already in dnsmasq config\n", domain)
+		progressf("  Domain %s already in dnsmasq config\n", domain)
 	} else {
 		// Append domain to dnsmasq config
 		// This tells dnsmasq to automatically add all resolved IPs for this domain to the ipset
 		// Run as root since the config file is owned by root
-		appendCmd := exec.Command("docker", "exec", "-u", "root", containerName, "sh", "-c",
+		appendCmd := system.DockerCommand("exec", "-u", "root", containerName, "sh", "-c",
 			fmt.Sprintf("echo 'ipset=/%s/allowed-domains' >> %s && echo 'server=/%s/8.8.8.8' >> %s",
 				domain, dnsmasqConf, domain, dnsmasqConf))
 		if err := appendCmd.Run(); err != nil {
 			return fmt.Errorf("failed to update dnsmasq config: %w", err)
 		}
-		fmt.Println("  Updated dnsmasq config")
+		progressln("  Updated dnsmasq config")
 	}
 
 	// Restart dnsmasq to pick up new config
-	fmt.Println("  Restarting dnsmasq...")
-	restartCmd := exec.Command("docker", "exec", "-u", "root", containerName, "sh", "-c",
+	progressln("  Restarting dnsmasq...")
+	restartCmd := system.DockerCommand("exec", "-u", "root", containerName, "sh", "-c",
 		"pkill -9 dnsmasq 2>/dev/null || true; sleep 0.2; dnsmasq --conf-file=/tmp/dnsmasq-firewall.conf")
 	if err := restartCmd.Run(); err != nil {
 		return fmt.Errorf("failed to restart dnsmasq: %w", err)
@@ -95,24 +103,46 @@ func runAddDomain(cmd *cobra.Command, args []string) error {
 	// time.Sleep(500 * time.Millisecond)
 
 	// Now do an initial resolution to populate the ipset
-	fmt.Println("  Performing initial DNS resolution...")
-	resolveCmd := exec.Command("docker", "exec", containerName, "sh", "-c",
+	progressln("  Performing initial DNS resolution...")
+	resolveCmd := system.DockerCommand("exec", containerName, "sh", "-c",
 		fmt.Sprintf("dig +short %s | head -5", domain))
 	output, err = resolveCmd.Output()
 	if err != nil {
 		fmt.Printf("  Warning: initial resolution failed: %v\n", err)
 	} else {
 		ips := strings.Split(strings.TrimSpace(string(output)), "\n")
-		fmt.Printf("  Resolved %d IPs (dnsmasq will track all future resolutions)\n", len(ips))
+		progressf("  Resolved %d IPs (dnsmasq will track all future resolutions)\n", len(ips))
+	}
+
+	progressf("\n✅ Domain %s added to %s\n", domain, containerName)
+	progressln("   DNS queries for this domain will now automatically populate the firewall whitelist.")
+
+	switch {
+	case addDomainSave:
+		if err := updateConfigWithDomain(domain); err != nil {
+			return fmt.Errorf("domain added to container but failed to update config: %w", err)
+		}
+		progressf("✅ Updated %s\n", configFilePath())
+		return nil
+	case addDomainNoSave:
+		return nil
 	}
 
-	fmt.Printf("\n✅ Domain %s added to %s\n", domain, containerName)
-	fmt.Println("   DNS queries for this domain will now automatically populate the firewall whitelist.")
-	fmt.Printf("\nTo make this permanent, add it to %s:\n", paths.ConfigFile())
+	// Without an explicit choice, only ask when someone can answer
+	if quiet {
+		return nil
+	}
+
+	fmt.Printf("\nTo make this permanent, add it to %s:\n", configFilePath())
 	fmt.Printf("  firewall:\n    allowed_domains:\n      - %s\n", domain)
 
+	if !isTerminal(os.Stdin) {
+		fmt.Println("Or re-run with --save.")
+		return nil
+	}
+
 	// Offer to update config
-	fmt.Printf("\nWould you like to add this domain to %s now? [y/N]: ", paths.ConfigFile())
+	fmt.Printf("\nWould you like to add this domain to %s now? [y/N]: ", configFilePath())
 	var response string
 	fmt.Scanln(&response)
 
@@ -120,7 +150,7 @@ func runAddDomain(cmd *cobra.Command, args []string) error {
 		if err := updateConfigWithDomain(domain); err != nil {
 			fmt.Printf("Failed to update config: %v\n", err)
 		} else {
-			fmt.Printf("✅ Updated %s\n", paths.ConfigFile())
+			fmt.Printf("✅ Updated %s\n", configFilePath())
 		}
 	}
 
@@ -128,63 +158,31 @@ func runAddDomain(cmd *cobra.Command, args []string) error {
 }
 
 func updateConfigWithDomain(domain string) error {
-	configPath := paths.ConfigFile()
-
-	// Read current config
-	var configData map[string]interface{}
-
-	if _, err := os.Stat(configPath); err == nil {
-		// File exists, read it
-		content, err := os.ReadFile(configPath)
+	err := configfile.Update(configFilePath(), func(doc *configfile.Document) error {
+		var domains []string
+		found, err := doc.Get("firewall.allowed_domains", &domains)
 		if err != nil {
 			return err
 		}
-
-		if err := yaml.Unmarshal(content, &configData); err != nil {
-			return err
-		}
-	} else {
-		// File doesn't exist, create new config
-		configData = make(map[string]interface{})
-	}
-
-	// Add domain to firewall.allowed_domains
-	if configData["firewall"] == nil {
-		configData["firewall"] = make(map[string]interface{})
-	}
-
-	firewall := configData["firewall"].(map[string]interface{})
-
-	var domains []string
-	if firewall["allowed_domains"] != nil {
-		// Convert existing domains to string slice
-		existingDomains := firewall["allowed_domains"].([]interface{})
-		for _, d := range existingDomains {
-			domains = append(domains, d.(string))
+		if !found {
+			// Use defaults from viper
+			domains = viper.GetStringSlice("firewall.allowed_domains")
 		}
-	} else {
-		// Use defaults from viper
-		domains = viper.GetStringSlice("firewall.allowed_domains")
-	}
 
-	// Check if domain already exists
-	for _, d := range domains {
-		if d == domain {
-			fmt.Printf("Domain %s already in config\n", domain)
-			return nil
+		// Check if domain already exists
+		for _, d := range domains {
+			if d == domain {
+				fmt.Printf("Domain %s already in config\n", domain)
+				return nil
+			}
 		}
-	}
 
-	// Add new domain
-	domains = append(domains, domain)
-	firewall["allowed_domains"] = domains
-	configData["firewall"] = firewall
-
-	// Write updated config
-	output, err := yaml.Marshal(configData)
+		// Add new domain
+		domains = append(domains, domain)
+		return doc.Set("firewall.allowed_domains", domains)
+	})
 	if err != nil {
 		return err
 	}
-
-	return os.WriteFile(configPath, output, 0644)
-}
\ No newline at end of file
+	return ReloadConfig()
+}
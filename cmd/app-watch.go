@@ -0,0 +1,145 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// appWatchDebounce is how long the source must be quiet before a sync, so a
+// build writing the file in several steps triggers one sync
+const appWatchDebounce = 500 * time.Millisecond
+
+// watchApp syncs an app to running containers, then again every time its
+// source changes, until interrupted
+func watchApp(appName string) error {
+	sourcePath, exists := currentConfig().Apps[appName]
+	if !exists {
+		return fmt.Errorf("app '%s' not configured", appName)
+	}
+	source := filepath.Clean(expandPath(sourcePath))
+	info, err := os.Stat(source)
+	if err != nil {
+		return fmt.Errorf("source not found: %s", source)
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to start watcher: %w", err)
+	}
+	defer watcher.Close()
+
+	// Builds often replace a file rather than write it in place, so files are
+	// watched through their directory
+	isDir := info.IsDir()
+	if isDir {
+		err = addWatchTree(watcher, source)
+	} else {
+		err = watcher.Add(filepath.Dir(source))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to watch %s: %w", source, err)
+	}
+
+	if err := syncApp(appName, quiet); err != nil {
+		fmt.Printf("⚠  Failed to update %s: %v\n", appName, err)
+	}
+	fmt.Printf("\n👀 Watching %s for changes (Ctrl+C to stop)\n", sourcePath)
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigChan)
+
+	debounce := time.NewTimer(appWatchDebounce)
+	debounce.Stop()
+	var changed string
+
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if !appWatchMatches(source, isDir, event) {
+				continue
+			}
+			// New subdirectories of a directory app need watching too
+			if isDir && event.Has(fsnotify.Create) {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					addWatchTree(watcher, event.Name)
+				}
+			}
+			changed = event.Name
+			debounce.Reset(appWatchDebounce)
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			fmt.Printf("⚠  Watch error: %v\n", err)
+
+		case <-debounce.C:
+			rel, err := filepath.Rel(filepath.Dir(source), changed)
+			if err != nil {
+				rel = changed
+			}
+			fmt.Printf("\n[%s] %s changed, syncing %s\n", time.Now().Format("15:04:05"), rel, appName)
+			if err := syncApp(appName, quiet); err != nil {
+				fmt.Printf("⚠  Failed to update %s: %v\n", appName, err)
+			}
+
+		case <-sigChan:
+			fmt.Printf("\nStopped watching %s\n", appName)
+			return nil
+		}
+	}
+}
+
+// addWatchTree watches dir and every directory below it
+func addWatchTree(watcher *fsnotify.Watcher, dir string) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return watcher.Add(path)
+		}
+		return nil
+	})
+}
+
+// appWatchMatches reports whether event changes the content of the app at
+// source. For a file that is the file itself or its Linux variant, which
+// resolveAppSource prefers; for a directory, anything inside it.
+// Permission-only changes are ignored.
+func appWatchMatches(source string, isDir bool, event fsnotify.Event) bool {
+	if event.Op == fsnotify.Chmod {
+		return false
+	}
+	name := filepath.Clean(event.Name)
+	if isDir {
+		rel, err := filepath.Rel(source, name)
+		return err == nil && filepath.IsLocal(rel)
+	}
+	return name == source || name == source+".linux_aarch64"
+}
@@ -16,23 +16,34 @@ package cmd
 
 import (
 	"crypto/sha256"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
+	"text/tabwriter"
+	"time"
 
+	"github.com/uprockcom/maestro/pkg/configfile"
 	"github.com/uprockcom/maestro/pkg/container"
+	"github.com/uprockcom/maestro/pkg/system"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 )
 
 var (
-	appSyncNow bool
-	appCleanup bool
-	appAll     bool
-	appQuiet   bool
+	appSyncNow  bool
+	appCleanup  bool
+	appAll      bool
+	appDryRun   bool
+	appBuild    string
+	appWatch    bool
+	appListJSON bool
 )
 
 var appCmd = &cobra.Command{
@@ -41,13 +52,21 @@ var appCmd = &cobra.Command{
 	Long: `Manage custom binaries that are automatically copied to all containers.
 
 Apps are configured in ~/.maestro/config.yml and copied to /usr/local/bin in each container.
+Directory apps are copied to /opt/<name> (override per app with app_destinations).
 Use 'app update' to sync changes to running containers.`,
 }
 
 var appListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List configured apps",
-	RunE:  runAppList,
+	Long: `List configured apps, their sources, and whether each source exists.
+
+Use --json for scripts that check the app configuration, e.g. in CI.
+
+Examples:
+  maestro app list
+  maestro app list --json | jq -r '.apps[] | select(.exists | not) | .name'`,
+	RunE: runAppList,
 }
 
 var appAddCmd = &cobra.Command{
@@ -56,7 +75,11 @@ var appAddCmd = &cobra.Command{
 	Long: `Add an app to the configuration file.
 
 The app will be copied to /usr/local/bin/<name> in all new containers.
-Use --sync to immediately update all running containers.`,
+Use --sync to immediately update all running containers.
+
+Use --build to give a command that produces the source, e.g. "make -C ~/src/tool".
+It runs now, and again before every sync of the app ('app update'); if it
+fails, the app isn't synced. Commands run with sh -c in the current directory.`,
 	Args: cobra.ExactArgs(2),
 	RunE: runAppAdd,
 }
@@ -67,11 +90,31 @@ var appUpdateCmd = &cobra.Command{
 	Long: `Update apps in all running containers.
 
 Specify an app name to update just that app, or use --all to update all apps.
-Uses checksums to skip copying if the file hasn't changed.`,
+Apps with a build command (app add --build) are rebuilt first.
+Uses checksums to skip copying if the file hasn't changed.
+Use --dry-run to see which containers would be updated without building or copying anything.
+
+Use --watch to keep running and re-sync the app whenever its source changes,
+until Ctrl+C. The build command isn't run on changes in watch mode (the watch
+picks up your own rebuilds), so 'make' in one terminal and
+'maestro app update <name> --watch' in another syncs every build.`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runAppUpdate,
 }
 
+var appDiffCmd = &cobra.Command{
+	Use:   "diff <name>",
+	Short: "Compare an app's source with the copy in each running container",
+	Long: `Show, for every running container, whether its copy of an app matches the
+configured source: the checksum, size, and modification time of the installed
+copy next to the source's. Mismatches are highlighted.
+
+Use this to find out why a container still runs an old version; fix it with
+'maestro app update <name>'.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAppDiff,
+}
+
 var appRemoveCmd = &cobra.Command{
 	Use:   "remove <name>",
 	Short: "Remove an app from configuration",
@@ -85,37 +128,92 @@ Use --cleanup to also remove it from all running containers.`,
 func init() {
 	rootCmd.AddCommand(appCmd)
 	appCmd.AddCommand(appListCmd)
+	appListCmd.Flags().BoolVar(&appListJSON, "json", false, "Print a JSON object of apps and the running container count")
 	appCmd.AddCommand(appAddCmd)
 	appCmd.AddCommand(appUpdateCmd)
 	appCmd.AddCommand(appRemoveCmd)
+	appCmd.AddCommand(appDiffCmd)
 
 	appAddCmd.Flags().BoolVarP(&appSyncNow, "sync", "s", false, "Sync to running containers immediately")
+	appAddCmd.Flags().StringVar(&appBuild, "build", "", "Command to build the app before each sync")
 	appUpdateCmd.Flags().BoolVarP(&appAll, "all", "a", false, "Update all configured apps")
-	appUpdateCmd.Flags().BoolVarP(&appQuiet, "quiet", "q", false, "Suppress output (for Makefile integration)")
+	appUpdateCmd.Flags().BoolVar(&appDryRun, "dry-run", false, "Show which containers would be updated without copying")
+	appUpdateCmd.Flags().BoolVarP(&appWatch, "watch", "w", false, "Keep running and re-sync whenever the source changes")
+	appUpdateCmd.MarkFlagsMutuallyExclusive("watch", "dry-run")
+	appUpdateCmd.MarkFlagsMutuallyExclusive("watch", "all")
 	appRemoveCmd.Flags().BoolVar(&appCleanup, "cleanup", false, "Remove from running containers")
-	appRemoveCmd.Flags().BoolVarP(&appQuiet, "quiet", "q", false, "Suppress output")
+}
+
+// appListEntry is one configured app, as 'app list --json' prints it
+type appListEntry struct {
+	Name         string `json:"name"`
+	Source       string `json:"source"` // As written in the config
+	ExpandedPath string `json:"expanded_path"`
+	Exists       bool   `json:"exists"`
+	Directory    bool   `json:"directory"`
+	SizeBytes    int64  `json:"size_bytes"` // 0 for directories and missing sources
+}
+
+// appListOutput is the 'app list --json' document
+type appListOutput struct {
+	Apps              []appListEntry `json:"apps"`
+	RunningContainers int            `json:"running_containers"`
+}
+
+// listApps stats each configured app's source, sorted by name
+func listApps(apps map[string]string) []appListEntry {
+	entries := make([]appListEntry, 0, len(apps))
+	for name, source := range apps {
+		entry := appListEntry{Name: name, Source: source, ExpandedPath: expandPath(source)}
+		if info, err := os.Stat(entry.ExpandedPath); err == nil {
+			entry.Exists = true
+			entry.Directory = info.IsDir()
+			if !entry.Directory {
+				entry.SizeBytes = info.Size()
+			}
+		}
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+	return entries
 }
 
 func runAppList(cmd *cobra.Command, args []string) error {
-	if len(config.Apps) == 0 {
+	apps := listApps(currentConfig().Apps)
+
+	if appListJSON {
+		containers, err := container.GetRunningContainers(currentConfig().Containers.Prefix)
+		if err != nil {
+			return fmt.Errorf("failed to list containers: %w", err)
+		}
+		out, err := json.MarshalIndent(appListOutput{Apps: apps, RunningContainers: len(containers)}, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode apps: %w", err)
+		}
+		fmt.Println(string(out))
+		return nil
+	}
+
+	if len(apps) == 0 {
 		fmt.Println("No apps configured.")
 		fmt.Println("\nAdd an app with: maestro app add <name> <path>")
 		return nil
 	}
 
 	fmt.Println("Configured apps:")
-	for name, source := range config.Apps {
-		expandedPath := expandPath(source)
-		if info, err := os.Stat(expandedPath); err == nil {
-			size := formatFileSize(info.Size())
-			fmt.Printf("  %-20s → %s (%s)\n", name, source, size)
-		} else {
-			fmt.Printf("  %-20s → %s (⚠ not found)\n", name, source)
+	for _, app := range apps {
+		switch {
+		case !app.Exists:
+			fmt.Printf("  %-20s → %s (⚠ not found)\n", app.Name, app.Source)
+		case app.Directory:
+			fmt.Printf("  %-20s → %s (directory)\n", app.Name, app.Source)
+		default:
+			fmt.Printf("  %-20s → %s (%s)\n", app.Name, app.Source, formatFileSize(app.SizeBytes))
 		}
 	}
 
 	// Check status in running containers
-	containers, err := container.GetRunningContainers(config.Containers.Prefix)
+	containers, err := container.GetRunningContainers(currentConfig().Containers.Prefix)
 	if err != nil {
 		return fmt.Errorf("failed to list containers: %w", err)
 	}
@@ -131,42 +229,55 @@ func runAppAdd(cmd *cobra.Command, args []string) error {
 	name := args[0]
 	source := args[1]
 
+	// Build first, since the build may be what creates the source
+	if appBuild != "" {
+		if err := runAppBuild(name, appBuild); err != nil {
+			return err
+		}
+	}
+
 	// Expand and validate source path
 	expandedPath := expandPath(source)
 	info, err := os.Stat(expandedPath)
 	if err != nil {
-		return fmt.Errorf("source file not found: %s", expandedPath)
+		return fmt.Errorf("source not found: %s", expandedPath)
 	}
 
-	if !appQuiet {
-		fmt.Printf("✓ Verified source exists (%s)\n", formatFileSize(info.Size()))
+	if !quiet {
+		if info.IsDir() {
+			fmt.Printf("✓ Verified source directory exists (installs to %s)\n", appDestination(name, true))
+		} else {
+			fmt.Printf("✓ Verified source exists (%s)\n", formatFileSize(info.Size()))
+		}
 	}
 
 	// Check if already exists
-	if _, exists := config.Apps[name]; exists {
-		if !appQuiet {
+	if _, exists := currentConfig().Apps[name]; exists {
+		if !quiet {
 			fmt.Printf("⚠  App '%s' already configured, updating path\n", name)
 		}
 	}
 
 	// Add to config
-	if config.Apps == nil {
-		config.Apps = make(map[string]string)
-	}
-	config.Apps[name] = source
+	updateConfig(func(c *Config) {
+		c.Apps[name] = source
+		if appBuild != "" {
+			c.AppBuilds[name] = appBuild
+		}
+	})
 
 	// Write config
 	if err := writeConfigFile(); err != nil {
 		return fmt.Errorf("failed to write config: %w", err)
 	}
 
-	if !appQuiet {
+	if !quiet {
 		fmt.Printf("✓ Added %s to configuration\n", name)
 	}
 
 	// Sync to running containers if requested
 	if appSyncNow {
-		if err := updateSingleApp(name, appQuiet); err != nil {
+		if err := updateSingleApp(name, quiet); err != nil {
 			return err
 		}
 	}
@@ -180,11 +291,11 @@ func runAppUpdate(cmd *cobra.Command, args []string) error {
 
 	if appAll {
 		// Update all apps
-		for name := range config.Apps {
+		for name := range currentConfig().Apps {
 			appsToUpdate = append(appsToUpdate, name)
 		}
 		if len(appsToUpdate) == 0 {
-			if !appQuiet {
+			if !quiet {
 				fmt.Println("No apps configured to update")
 			}
 			return nil
@@ -192,7 +303,7 @@ func runAppUpdate(cmd *cobra.Command, args []string) error {
 	} else if len(args) > 0 {
 		// Update specific app
 		name := args[0]
-		if _, exists := config.Apps[name]; !exists {
+		if _, exists := currentConfig().Apps[name]; !exists {
 			return fmt.Errorf("app '%s' not found in configuration", name)
 		}
 		appsToUpdate = []string{name}
@@ -200,10 +311,20 @@ func runAppUpdate(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("specify an app name or use --all")
 	}
 
+	if appWatch {
+		return watchApp(appsToUpdate[0])
+	}
+
 	// Update each app
 	for _, name := range appsToUpdate {
-		if err := updateSingleApp(name, appQuiet); err != nil {
-			if !appQuiet {
+		if appDryRun {
+			if err := planSingleApp(name); err != nil {
+				fmt.Printf("⚠  Failed to check %s: %v\n", name, err)
+			}
+			continue
+		}
+		if err := updateSingleApp(name, quiet); err != nil {
+			if !quiet {
 				fmt.Printf("⚠  Failed to update %s: %v\n", name, err)
 			}
 			continue
@@ -217,38 +338,49 @@ func runAppRemove(cmd *cobra.Command, args []string) error {
 	name := args[0]
 
 	// Check if exists
-	if _, exists := config.Apps[name]; !exists {
+	if _, exists := currentConfig().Apps[name]; !exists {
 		return fmt.Errorf("app '%s' not found in configuration", name)
 	}
 
+	// Work out the install location before the app disappears from config
+	destPaths := []string{appDestination(name, false)}
+	if info, err := os.Stat(expandPath(currentConfig().Apps[name])); err != nil {
+		// Source is gone, so check both possible destinations
+		destPaths = append(destPaths, appDestination(name, true))
+	} else if info.IsDir() {
+		destPaths = []string{appDestination(name, true)}
+	}
+
 	// Remove from config
-	delete(config.Apps, name)
+	updateConfig(func(c *Config) {
+		delete(c.Apps, name)
+		delete(c.AppBuilds, name)
+	})
 
 	// Write config
 	if err := writeConfigFile(); err != nil {
 		return fmt.Errorf("failed to write config: %w", err)
 	}
 
-	if !appQuiet {
+	if !quiet {
 		fmt.Printf("✓ Removed %s from configuration\n", name)
 	}
 
 	// Cleanup from containers if requested
 	if appCleanup {
-		containers, err := container.GetRunningContainers(config.Containers.Prefix)
+		containers, err := container.GetRunningContainers(currentConfig().Containers.Prefix)
 		if err != nil {
 			return fmt.Errorf("failed to list containers: %w", err)
 		}
 
-		if !appQuiet {
+		if !quiet {
 			fmt.Printf("Removing from %d container(s)...\n", len(containers))
 		}
 
 		for _, c := range containers {
-			destPath := fmt.Sprintf("/usr/local/bin/%s", name)
-			rmCmd := exec.Command("docker", "exec", "-u", "root", c.Name, "rm", "-f", destPath)
+			rmCmd := system.DockerCommand(append([]string{"exec", "-u", "root", c.Name, "rm", "-rf"}, destPaths...)...)
 			rmCmd.Run() // Ignore errors (file might not exist)
-			if !appQuiet {
+			if !quiet {
 				fmt.Printf("  ✓ %s\n", c.ShortName)
 			}
 		}
@@ -257,11 +389,19 @@ func runAppRemove(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-// updateSingleApp updates a single app in all running containers
-func updateSingleApp(appName string, quiet bool) error {
-	sourcePath, exists := config.Apps[appName]
+// appSource describes the host-side source of a configured app
+type appSource struct {
+	Path     string // Path to copy (may be a platform-specific variant)
+	Checksum string // SHA256 of the file, or of the file manifest for directories
+	IsDir    bool
+}
+
+// resolveAppSource returns the host path to copy for an app and its checksum.
+// A Linux-specific variant (<path>.linux_aarch64) is preferred when present.
+func resolveAppSource(appName string) (appSource, error) {
+	sourcePath, exists := currentConfig().Apps[appName]
 	if !exists {
-		return fmt.Errorf("app '%s' not configured", appName)
+		return appSource{}, fmt.Errorf("app '%s' not configured", appName)
 	}
 
 	expandedPath := expandPath(sourcePath)
@@ -273,18 +413,207 @@ func updateSingleApp(appName string, quiet bool) error {
 		actualPath = linuxPath
 	}
 
-	if _, err := os.Stat(actualPath); err != nil {
-		return fmt.Errorf("source file not found: %s", actualPath)
+	info, err := os.Stat(actualPath)
+	if err != nil {
+		return appSource{}, fmt.Errorf("source not found: %s", actualPath)
+	}
+
+	src := appSource{Path: actualPath, IsDir: info.IsDir()}
+	if src.IsDir {
+		src.Checksum, err = calculateDirChecksum(actualPath)
+	} else {
+		src.Checksum, err = calculateChecksum(actualPath)
+	}
+	if err != nil {
+		return appSource{}, fmt.Errorf("failed to calculate checksum: %w", err)
+	}
+
+	return src, nil
+}
+
+// appDestination returns where an app is installed inside containers.
+// Files go to /usr/local/bin/<name> and directories to /opt/<name>,
+// unless overridden in app_destinations.
+func appDestination(appName string, isDir bool) string {
+	if dest, ok := currentConfig().AppDestinations[appName]; ok && dest != "" {
+		return dest
+	}
+	if isDir {
+		return fmt.Sprintf("/opt/%s", appName)
+	}
+	return fmt.Sprintf("/usr/local/bin/%s", appName)
+}
+
+// appNeedsUpdate reports whether the app in the container differs from the source checksum
+func appNeedsUpdate(containerName, appName string, src appSource) bool {
+	sum, err := containerAppChecksum(containerName, appName, src)
+	return err != nil || sum != src.Checksum
+}
+
+// containerAppChecksum returns the checksum of the installed app in a
+// container, computed the same way as the source checksum. It returns ""
+// if the app isn't installed.
+func containerAppChecksum(containerName, appName string, src appSource) (string, error) {
+	script := appChecksumScript(appDestination(appName, src.IsDir), src.IsDir)
+	output, err := system.DockerCommand("exec", containerName, "sh", "-c", script).Output()
+	return parseAppChecksum(output, err)
+}
+
+// appChecksumScript returns a shell script printing the checksum of the app
+// at destPath. For directories it hashes the same manifest format
+// calculateDirChecksum produces.
+func appChecksumScript(destPath string, isDir bool) string {
+	quoted := shellCommand(destPath)
+	if isDir {
+		return fmt.Sprintf("ls -d %s >/dev/null && cd %s && find . -type f -print0 | LC_ALL=C sort -z | xargs -0 -r sha256sum | sha256sum | awk '{print $1}'",
+			quoted, quoted)
 	}
+	return fmt.Sprintf("ls -d %s >/dev/null && sha256sum %s | awk '{print $1}'", quoted, quoted)
+}
 
-	// Calculate source checksum once
-	sourceChecksum, err := calculateChecksum(actualPath)
+// parseAppChecksum turns the result of running appChecksumScript into a
+// checksum, with "" for an app that isn't installed
+func parseAppChecksum(output []byte, err error) (string, error) {
 	if err != nil {
-		return fmt.Errorf("failed to calculate checksum: %w", err)
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && container.IsMissingPathError(string(exitErr.Stderr)) {
+			return "", nil
+		}
+		return "", err
 	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// copyAppToContainer copies an app into a container and fixes its permissions.
+// Directories replace any previous copy so removed files don't linger.
+func copyAppToContainer(containerName, appName string, src appSource) error {
+	destPath := appDestination(appName, src.IsDir)
+
+	if src.IsDir {
+		prepCmd := system.DockerCommand("exec", "-u", "root", containerName,
+			"sh", "-c", shellCommand("rm", "-rf", destPath)+" && "+shellCommand("mkdir", "-p", destPath))
+		if err := prepCmd.Run(); err != nil {
+			return fmt.Errorf("failed to prepare %s: %w", destPath, err)
+		}
+
+		cpCmd := system.DockerCommand("cp", src.Path+"/.", fmt.Sprintf("%s:%s", containerName, destPath))
+		if err := cpCmd.Run(); err != nil {
+			return err
+		}
+
+		chownCmd := system.DockerCommand("exec", "-u", "root", containerName,
+			"chown", "-R", "node:node", destPath)
+		if err := chownCmd.Run(); err != nil {
+			return fmt.Errorf("copied but failed to set permissions")
+		}
+		return nil
+	}
+
+	cpCmd := system.DockerCommand("cp", src.Path, fmt.Sprintf("%s:%s", containerName, destPath))
+	if err := cpCmd.Run(); err != nil {
+		return err
+	}
+
+	// Make executable and set ownership
+	chmodCmd := system.DockerCommand("exec", "-u", "root", containerName,
+		"sh", "-c", shellCommand("chmod", "+x", destPath)+" && "+shellCommand("chown", "node:node", destPath))
+	if err := chmodCmd.Run(); err != nil {
+		return fmt.Errorf("copied but failed to set permissions")
+	}
+	return nil
+}
+
+// planSingleApp reports which running containers would receive a copy of the app
+func planSingleApp(appName string) error {
+	src, err := resolveAppSource(appName)
+	if err != nil {
+		return err
+	}
+
+	containers, err := container.GetRunningContainers(currentConfig().Containers.Prefix)
+	if err != nil {
+		return fmt.Errorf("failed to list containers: %w", err)
+	}
+
+	if len(containers) == 0 {
+		fmt.Println("No running containers to update")
+		return nil
+	}
+
+	fmt.Printf("%s (dry run):\n", appName)
+
+	// Compare checksums concurrently, then print in container order
+	needsUpdate := make([]bool, len(containers))
+	var wg sync.WaitGroup
+	for i, c := range containers {
+		wg.Add(1)
+		go func(idx int, name string) {
+			defer wg.Done()
+			needsUpdate[idx] = appNeedsUpdate(name, appName, src)
+		}(i, c.Name)
+	}
+	wg.Wait()
+
+	copyCount := 0
+	for i, c := range containers {
+		if needsUpdate[i] {
+			fmt.Printf("  → %s (would copy)\n", c.ShortName)
+			copyCount++
+		} else {
+			fmt.Printf("  ✓ %s (already up to date)\n", c.ShortName)
+		}
+	}
+	fmt.Printf("%d to copy, %d up to date\n", copyCount, len(containers)-copyCount)
+
+	return nil
+}
+
+// runAppBuild runs an app's build command, returning its output on failure
+func runAppBuild(appName, command string) error {
+	if !quiet {
+		fmt.Printf("Building %s: %s\n", appName, command)
+	}
+	buildCmd := exec.Command("sh", "-c", command)
+	if output, err := buildCmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("build failed: %w\n%s", err, strings.TrimRight(string(output), "\n"))
+	}
+	if !quiet {
+		fmt.Printf("✓ Built %s\n", appName)
+	}
+	return nil
+}
+
+// updateSingleApp rebuilds an app if it has a build command, then updates it
+// in all running containers
+func updateSingleApp(appName string, quiet bool) error {
+	if command := currentConfig().AppBuilds[appName]; command != "" {
+		if err := runAppBuild(appName, command); err != nil {
+			return err
+		}
+	}
+	return syncApp(appName, quiet)
+}
+
+// syncApp copies an app to every running container whose copy differs from
+// the source
+func syncApp(appName string, quiet bool) error {
+	src, err := resolveAppSource(appName)
+	if err != nil {
+		return err
+	}
+
+	unlock, err := container.LockFleet("app update "+appName, func(holder string) {
+		if !quiet {
+			fmt.Printf("⏳ Waiting for another maestro operation to finish: %s\n", holder)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	defer unlock()
 
 	// Get running containers
-	containers, err := container.GetRunningContainers(config.Containers.Prefix)
+	containers, err := container.GetRunningContainers(currentConfig().Containers.Prefix)
 	if err != nil {
 		return fmt.Errorf("failed to list containers: %w", err)
 	}
@@ -309,32 +638,14 @@ func updateSingleApp(appName string, quiet bool) error {
 		go func(container container.Info) {
 			defer wg.Done()
 
-			destPath := fmt.Sprintf("/usr/local/bin/%s", appName)
-			containerPath := fmt.Sprintf("%s:%s", container.Name, destPath)
-
-			// Check if file exists and compare checksums
-			checkCmd := exec.Command("docker", "exec", container.Name, "sh", "-c",
-				fmt.Sprintf("sha256sum %s 2>/dev/null | awk '{print $1}'", destPath))
-			if output, err := checkCmd.Output(); err == nil {
-				existingChecksum := strings.TrimSpace(string(output))
-				if existingChecksum == sourceChecksum {
-					results <- fmt.Sprintf("  ✓ %s (already up to date)", container.ShortName)
-					return
-				}
-			}
-
-			// Copy file
-			cpCmd := exec.Command("docker", "cp", actualPath, containerPath)
-			if err := cpCmd.Run(); err != nil {
-				results <- fmt.Sprintf("  ✗ %s: %v", container.ShortName, err)
+			// Check if app exists and compare checksums
+			if !appNeedsUpdate(container.Name, appName, src) {
+				results <- fmt.Sprintf("  ✓ %s (already up to date)", container.ShortName)
 				return
 			}
 
-			// Make executable and set ownership
-			chmodCmd := exec.Command("docker", "exec", "-u", "root", container.Name,
-				"sh", "-c", fmt.Sprintf("chmod +x %s && chown node:node %s", destPath, destPath))
-			if err := chmodCmd.Run(); err != nil {
-				results <- fmt.Sprintf("  ⚠ %s: copied but failed to set permissions", container.ShortName)
+			if err := copyAppToContainer(container.Name, appName, src); err != nil {
+				results <- fmt.Sprintf("  ✗ %s: %v", container.ShortName, err)
 				return
 			}
 
@@ -370,6 +681,125 @@ func updateSingleApp(appName string, quiet bool) error {
 	return nil
 }
 
+// appCopyInfo describes an installed copy of an app inside a container
+type appCopyInfo struct {
+	checksum string // "" if not installed
+	size     int64
+	modTime  time.Time
+	err      error
+}
+
+func runAppDiff(cmd *cobra.Command, args []string) error {
+	appName := args[0]
+	src, err := resolveAppSource(appName)
+	if err != nil {
+		return err
+	}
+
+	srcInfo, err := os.Stat(src.Path)
+	if err != nil {
+		return fmt.Errorf("source not found: %s", src.Path)
+	}
+
+	containers, err := container.GetRunningContainers(currentConfig().Containers.Prefix)
+	if err != nil {
+		return fmt.Errorf("failed to list containers: %w", err)
+	}
+
+	destPath := appDestination(appName, src.IsDir)
+	size := formatFileSize(srcInfo.Size())
+	if src.IsDir {
+		size = "directory"
+	}
+	fmt.Printf("%s → %s\n", appName, destPath)
+	fmt.Printf("Source: %s (%s, modified %s)\n", src.Path, size, srcInfo.ModTime().Format("2006-01-02 15:04:05"))
+	fmt.Printf("Checksum: %s\n\n", shortChecksum(src.Checksum))
+
+	if len(containers) == 0 {
+		fmt.Println("No running containers")
+		return nil
+	}
+
+	// Inspect containers concurrently, then print in container order
+	copies := make([]appCopyInfo, len(containers))
+	var wg sync.WaitGroup
+	for i, c := range containers {
+		wg.Add(1)
+		go func(idx int, name string) {
+			defer wg.Done()
+			copies[idx] = inspectAppCopy(name, appName, src)
+		}(i, c.Name)
+	}
+	wg.Wait()
+
+	color := isTerminal(os.Stdout)
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "CONTAINER\tCHECKSUM\tSIZE\tMODIFIED\tSTATUS")
+	mismatches := 0
+	for i, c := range containers {
+		cp := copies[i]
+		switch {
+		case cp.err != nil:
+			mismatches++
+			fmt.Fprintf(w, "%s\t-\t-\t-\t%s\n", c.ShortName, colorize(color, ansiRed, "✗ "+cp.err.Error()))
+		case cp.checksum == "":
+			mismatches++
+			fmt.Fprintf(w, "%s\t-\t-\t-\t%s\n", c.ShortName, colorize(color, ansiRed, "✗ not installed"))
+		default:
+			size := formatFileSize(cp.size)
+			if src.IsDir {
+				size = "directory"
+			}
+			status := colorize(color, ansiGreen, "✓ matches")
+			if cp.checksum != src.Checksum {
+				mismatches++
+				status = colorize(color, ansiRed, "✗ differs")
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", c.ShortName, shortChecksum(cp.checksum), size,
+				cp.modTime.Format("2006-01-02 15:04:05"), status)
+		}
+	}
+	w.Flush()
+
+	if mismatches > 0 {
+		fmt.Printf("\n%d of %d container(s) out of date. Run 'maestro app update %s' to sync.\n",
+			mismatches, len(containers), appName)
+	}
+	return nil
+}
+
+// inspectAppCopy reads the checksum, size, and mtime of an app installed in a container
+func inspectAppCopy(containerName, appName string, src appSource) appCopyInfo {
+	sum, err := containerAppChecksum(containerName, appName, src)
+	if err != nil {
+		return appCopyInfo{err: fmt.Errorf("failed to read checksum")}
+	}
+	if sum == "" {
+		return appCopyInfo{}
+	}
+
+	info := appCopyInfo{checksum: sum}
+	output, err := system.DockerCommand("exec", containerName,
+		"stat", "-c", "%s %Y", appDestination(appName, src.IsDir)).Output()
+	if err != nil {
+		return appCopyInfo{err: fmt.Errorf("failed to stat installed copy")}
+	}
+	var mtime int64
+	if _, err := fmt.Sscanf(strings.TrimSpace(string(output)), "%d %d", &info.size, &mtime); err != nil {
+		return appCopyInfo{err: fmt.Errorf("unexpected stat output")}
+	}
+	info.modTime = time.Unix(mtime, 0)
+	return info
+}
+
+// shortChecksum abbreviates a hex checksum for display
+func shortChecksum(sum string) string {
+	if len(sum) > 12 {
+		return sum[:12]
+	}
+	return sum
+}
+
 // calculateChecksum calculates SHA256 checksum of a file
 func calculateChecksum(filePath string) (string, error) {
 	file, err := os.Open(filePath)
@@ -386,12 +816,63 @@ func calculateChecksum(filePath string) (string, error) {
 	return fmt.Sprintf("%x", hash.Sum(nil)), nil
 }
 
-// writeConfigFile writes the current config to the config file
+// calculateDirChecksum calculates a SHA256 checksum over a directory tree.
+// It hashes a manifest in the same format as
+// `find . -type f -print0 | LC_ALL=C sort -z | xargs -0 sha256sum`
+// so the result can be compared against a checksum computed inside a container.
+func calculateDirChecksum(dir string) (string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.Type().IsRegular() {
+			rel, err := filepath.Rel(dir, path)
+			if err != nil {
+				return err
+			}
+			files = append(files, "./"+filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	sort.Strings(files)
+
+	manifest := sha256.New()
+	for _, rel := range files {
+		sum, err := calculateChecksum(filepath.Join(dir, filepath.FromSlash(strings.TrimPrefix(rel, "./"))))
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(manifest, "%s  %s\n", sum, rel)
+	}
+
+	return fmt.Sprintf("%x", manifest.Sum(nil)), nil
+}
+
+// writeConfigFile saves the apps and app_builds sections of the config file
 func writeConfigFile() error {
-	// Write all settings back to viper
-	viper.Set("apps", config.Apps)
+	cfg := currentConfig()
 
-	return viper.WriteConfig()
+	err := configfile.Update(configFilePath(), func(doc *configfile.Document) error {
+		if err := doc.Set("apps", cfg.Apps); err != nil {
+			return err
+		}
+		// Leave configs that never used builds alone
+		var builds map[string]string
+		exists, err := doc.Get("app_builds", &builds)
+		if err != nil || (!exists && len(cfg.AppBuilds) == 0) {
+			return err
+		}
+		return doc.Set("app_builds", cfg.AppBuilds)
+	})
+	if err != nil {
+		return err
+	}
+	return ReloadConfig()
 }
 
 // formatFileSize formats bytes to human-readable format
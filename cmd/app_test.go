@@ -0,0 +1,145 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+func TestRunAppBuild(t *testing.T) {
+	if err := runAppBuild("tool", "true"); err != nil {
+		t.Errorf("runAppBuild() error = %v for a passing build", err)
+	}
+
+	err := runAppBuild("tool", "echo 'undefined: foo'; exit 2")
+	if err == nil {
+		t.Fatal("runAppBuild() succeeded for a failing build")
+	}
+	if !strings.Contains(err.Error(), "undefined: foo") {
+		t.Errorf("error %q is missing the build output", err)
+	}
+}
+
+func TestAppWatchMatches(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		isDir  bool
+		event  fsnotify.Event
+		want   bool
+	}{
+		{"file written", "/src/bin/tool", false,
+			fsnotify.Event{Name: "/src/bin/tool", Op: fsnotify.Write}, true},
+		{"file replaced", "/src/bin/tool", false,
+			fsnotify.Event{Name: "/src/bin/tool", Op: fsnotify.Create}, true},
+		{"linux variant", "/src/bin/tool", false,
+			fsnotify.Event{Name: "/src/bin/tool.linux_aarch64", Op: fsnotify.Write}, true},
+		{"sibling file", "/src/bin/tool", false,
+			fsnotify.Event{Name: "/src/bin/tool.o", Op: fsnotify.Write}, false},
+		{"chmod only", "/src/bin/tool", false,
+			fsnotify.Event{Name: "/src/bin/tool", Op: fsnotify.Chmod}, false},
+		{"file in directory app", "/src/scripts", true,
+			fsnotify.Event{Name: "/src/scripts/lib/run.sh", Op: fsnotify.Write}, true},
+		{"outside directory app", "/src/scripts", true,
+			fsnotify.Event{Name: "/src/scripts-old/run.sh", Op: fsnotify.Write}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := appWatchMatches(tt.source, tt.isDir, tt.event); got != tt.want {
+				t.Errorf("appWatchMatches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListApps(t *testing.T) {
+	dir := t.TempDir()
+	binary := filepath.Join(dir, "tool")
+	if err := os.WriteFile(binary, []byte("12345"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	missing := filepath.Join(dir, "gone")
+
+	got := listApps(map[string]string{"tool": binary, "sdk": dir, "gone": missing})
+	want := []appListEntry{
+		{Name: "gone", Source: missing, ExpandedPath: missing},
+		{Name: "sdk", Source: dir, ExpandedPath: dir, Exists: true, Directory: true},
+		{Name: "tool", Source: binary, ExpandedPath: binary, Exists: true, SizeBytes: 5},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("listApps() = %+v, want %+v", got, want)
+	}
+}
+
+func TestAppChecksumScript(t *testing.T) {
+	root := t.TempDir()
+	file := filepath.Join(root, "tool")
+	if err := os.WriteFile(file, []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	dir := filepath.Join(root, "sdk dir")
+	if err := os.MkdirAll(filepath.Join(dir, "bin"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "bin", "run"), []byte("run"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	fileSum, err := calculateChecksum(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dirSum, err := calculateDirChecksum(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		path  string
+		isDir bool
+		want  string
+	}{
+		{"file", file, false, fileSum},
+		{"directory", dir, true, dirSum},
+		// Not installed yet: no checksum, and no error
+		{"missing file", filepath.Join(root, "gone"), false, ""},
+		{"missing directory", filepath.Join(root, "gone-dir"), true, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := exec.Command("sh", "-c", appChecksumScript(tt.path, tt.isDir)).Output()
+			got, err := parseAppChecksum(output, err)
+			if err != nil {
+				t.Fatalf("parseAppChecksum() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("checksum = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	// Other failures still count as errors
+	output, err := exec.Command("sh", "-c", "echo 'permission denied' >&2; exit 1").Output()
+	if _, err := parseAppChecksum(output, err); err == nil {
+		t.Error("parseAppChecksum() ignored a failure other than a missing path")
+	}
+}
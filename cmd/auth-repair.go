@@ -0,0 +1,87 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/uprockcom/maestro/pkg/container"
+)
+
+var authRepairForce bool
+
+var authRepairCmd = &cobra.Command{
+	Use:   "repair <name>",
+	Short: "Fix a container whose credentials are missing or corrupted",
+	Long: `Check a single container's Claude credentials and, if they are missing,
+invalid, or expired, copy in the freshest valid token from the host or
+another container. The result is validated after copying.
+
+Unlike 'maestro refresh-tokens', no other container is touched.
+
+Examples:
+  maestro auth repair feat-auth-1
+  maestro auth repair feat-auth-1 --force   # Replace even valid credentials`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAuthRepair,
+}
+
+func init() {
+	authCmd.AddCommand(authRepairCmd)
+	authRepairCmd.Flags().BoolVar(&authRepairForce, "force", false, "Replace credentials even if they look valid")
+}
+
+func runAuthRepair(cmd *cobra.Command, args []string) error {
+	containerName := resolveContainerName(args[0])
+	if !isContainerRunning(containerName) {
+		return fmt.Errorf("container %s is not running", args[0])
+	}
+
+	status, problem := container.CheckAuthStatus(containerName)
+	fmt.Printf("Current auth status: %s\n", status)
+	if problem != nil {
+		fmt.Printf("  %v\n", problem)
+	}
+
+	switch {
+	case strings.HasPrefix(status, "?"):
+		return fmt.Errorf("could not read credentials from %s (container may be restarting); try again shortly", args[0])
+	case !strings.HasPrefix(status, "✗") && !authRepairForce:
+		fmt.Println("✓ Credentials are valid, nothing to repair (use --force to replace anyway)")
+		return nil
+	}
+
+	fmt.Println("Copying freshest valid token into container...")
+	if err := container.RefreshTokens(containerName, currentConfig().Containers.Prefix); err != nil {
+		return fmt.Errorf("failed to repair credentials: %w", err)
+	}
+
+	// Validate what actually landed in the container
+	creds, err := container.ReadContainerCredentials(containerName)
+	if err != nil {
+		return fmt.Errorf("credentials still unreadable after repair: %w", err)
+	}
+	if err := container.ValidateCredentials(creds); err != nil {
+		return fmt.Errorf("repaired credentials are invalid: %w", err)
+	}
+	if container.IsTokenExpired(creds) {
+		return fmt.Errorf("repaired credentials are expired; run 'maestro auth' to re-authenticate")
+	}
+
+	fmt.Printf("✅ Credentials repaired: %s\n", container.FormatExpiration(creds))
+	return nil
+}
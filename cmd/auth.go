@@ -22,6 +22,9 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/uprockcom/maestro/pkg/container"
+	"github.com/uprockcom/maestro/pkg/paths"
+	"github.com/uprockcom/maestro/pkg/system"
 )
 
 var authCmd = &cobra.Command{
@@ -64,7 +67,7 @@ func runBedrockAuth() error {
 	}
 
 	sourceClaudeDir := filepath.Join(homeDir, ".claude")
-	destAuthPath := expandPath(config.Claude.AuthPath)
+	destAuthPath := expandPath(currentConfig().Claude.AuthPath)
 
 	// Ensure destination directory exists
 	if err := os.MkdirAll(destAuthPath, 0755); err != nil {
@@ -76,10 +79,10 @@ func runBedrockAuth() error {
 		fmt.Printf("Warning: ~/.claude directory not found\n")
 		fmt.Println("You may need to run 'claude' once on the host to create initial config")
 	} else {
-		// Copy .credentials.json if exists
-		srcCreds := filepath.Join(sourceClaudeDir, ".credentials.json")
+		// Copy the credentials file if exists, under whichever name Claude used
+		srcCreds := paths.FindCredentials(sourceClaudeDir)
 		if _, err := os.Stat(srcCreds); err == nil {
-			destCreds := filepath.Join(destAuthPath, ".credentials.json")
+			destCreds := filepath.Join(destAuthPath, paths.CredentialsFile())
 			if err := copyFile(srcCreds, destCreds); err != nil {
 				fmt.Printf("Warning: Failed to copy credentials: %v\n", err)
 			} else {
@@ -111,11 +114,11 @@ func runBedrockAuth() error {
 	}
 
 	// Run AWS SSO login if profile is configured
-	if config.AWS.Profile != "" {
-		fmt.Printf("\nRunning AWS SSO login for profile: %s\n", config.AWS.Profile)
+	if currentConfig().AWS.Profile != "" {
+		fmt.Printf("\nRunning AWS SSO login for profile: %s\n", currentConfig().AWS.Profile)
 		fmt.Println("This will open a browser window for authentication...")
 
-		ssoCmd := exec.Command("aws", "sso", "login", "--profile", config.AWS.Profile)
+		ssoCmd := exec.Command("aws", "sso", "login", "--profile", currentConfig().AWS.Profile)
 		ssoCmd.Stdin = os.Stdin
 		ssoCmd.Stdout = os.Stdout
 		ssoCmd.Stderr = os.Stderr
@@ -127,13 +130,13 @@ func runBedrockAuth() error {
 	}
 
 	fmt.Println("\n✅ Bedrock authentication setup complete!")
-	fmt.Printf("AWS Profile: %s\n", config.AWS.Profile)
-	fmt.Printf("AWS Region: %s\n", config.AWS.Region)
-	fmt.Printf("Bedrock Model: %s\n", config.Bedrock.Model)
+	fmt.Printf("AWS Profile: %s\n", currentConfig().AWS.Profile)
+	fmt.Printf("AWS Region: %s\n", currentConfig().AWS.Region)
+	fmt.Printf("Bedrock Model: %s\n", currentConfig().Bedrock.Model)
 
 	// Ask user if they want to set up GitHub CLI (same as non-Bedrock flow)
 	fmt.Println("\n========================================================================")
-	hostname := config.GitHub.Hostname
+	hostname := currentConfig().GitHub.Hostname
 	if hostname == "" {
 		hostname = "github.com"
 	}
@@ -168,12 +171,12 @@ func copyFile(src, dst string) error {
 
 func runAuth(cmd *cobra.Command, cmdArgs []string) error {
 	// If Bedrock is enabled, use different auth flow
-	if config.Bedrock.Enabled {
+	if currentConfig().Bedrock.Enabled {
 		return runBedrockAuth()
 	}
 
 	// Ensure MCL Claude directory exists
-	authPath := expandPath(config.Claude.AuthPath)
+	authPath := expandPath(currentConfig().Claude.AuthPath)
 	if err := os.MkdirAll(authPath, 0755); err != nil {
 		return fmt.Errorf("failed to create MCL Claude directory: %w", err)
 	}
@@ -199,15 +202,15 @@ func runAuth(cmd *cobra.Command, cmdArgs []string) error {
 		return fmt.Errorf("failed to ensure Docker image: %w", err)
 	}
 
-	authContainerName := config.Containers.Prefix + "auth"
+	authContainerName := currentConfig().Containers.Prefix + "auth"
 
 	// Check if auth container already exists
-	checkCmd := exec.Command("docker", "ps", "-a", "--filter", fmt.Sprintf("name=%s", authContainerName), "--format", "{{.Names}}")
+	checkCmd := system.DockerCommand("ps", "-a", "--filter", fmt.Sprintf("name=%s", authContainerName), "--format", "{{.Names}}")
 	output, _ := checkCmd.Output()
 	if len(output) > 0 {
 		// Remove existing auth container
 		fmt.Println("Removing existing auth container...")
-		exec.Command("docker", "rm", "-f", authContainerName).Run()
+		system.DockerCommand("rm", "-f", authContainerName).Run()
 	}
 
 	fmt.Println("\nStarting authentication container...")
@@ -241,11 +244,11 @@ func runAuth(cmd *cobra.Command, cmdArgs []string) error {
 	}
 
 	args = append(args,
-		config.Containers.Image,
+		currentConfig().Containers.Image,
 		"claude", "--dangerously-skip-permissions",
 	)
 
-	authCmd := exec.Command("docker", args...)
+	authCmd := system.DockerCommand(args...)
 	authCmd.Stdin = os.Stdin
 	authCmd.Stdout = os.Stdout
 	authCmd.Stderr = os.Stderr
@@ -258,7 +261,7 @@ func runAuth(cmd *cobra.Command, cmdArgs []string) error {
 
 	// Copy .claude.json from container's home directory to host
 	// This file contains onboarding state, permissions, and account info
-	copyConfigCmd := exec.Command("docker", "cp",
+	copyConfigCmd := system.DockerCommand("cp",
 		fmt.Sprintf("%s:/home/node/.claude.json", authContainerName),
 		filepath.Join(authPath, ".claude.json"))
 	if err := copyConfigCmd.Run(); err != nil {
@@ -267,10 +270,10 @@ func runAuth(cmd *cobra.Command, cmdArgs []string) error {
 
 	// Clean up auth container now that we've copied the files
 	fmt.Println("Cleaning up auth container...")
-	exec.Command("docker", "rm", "-f", authContainerName).Run()
+	system.DockerCommand("rm", "-f", authContainerName).Run()
 
 	// Check if both credentials and config were created
-	credPath := filepath.Join(authPath, ".credentials.json")
+	credPath := paths.FindCredentials(authPath)
 	configPath := filepath.Join(authPath, ".claude.json")
 
 	credExists := false
@@ -291,7 +294,7 @@ func runAuth(cmd *cobra.Command, cmdArgs []string) error {
 	} else {
 		fmt.Println("\n⚠️  Warning: Setup incomplete.")
 		if !credExists {
-			fmt.Println("  - Missing .credentials.json (authentication)")
+			fmt.Printf("  - Missing %s (authentication)\n", paths.CredentialsFile())
 		}
 		if !configExists {
 			fmt.Println("  - Missing .claude.json (configuration)")
@@ -331,13 +334,13 @@ func runAuth(cmd *cobra.Command, cmdArgs []string) error {
 
 func setupGitHubAuth() error {
 	// Ensure MCL gh directory exists
-	mclGhPath := expandPath(config.GitHub.ConfigPath)
+	mclGhPath := expandPath(currentConfig().GitHub.ConfigPath)
 	if err := os.MkdirAll(mclGhPath, 0755); err != nil {
 		return fmt.Errorf("failed to create MCL gh directory: %w", err)
 	}
 
 	// Determine hostname (default to github.com)
-	hostname := config.GitHub.Hostname
+	hostname := currentConfig().GitHub.Hostname
 	if hostname == "" {
 		hostname = "github.com"
 	}
@@ -356,15 +359,15 @@ func setupGitHubAuth() error {
 	}
 	fmt.Println("✓ Cleared existing GitHub authentication data")
 
-	ghAuthContainerName := config.Containers.Prefix + "gh-auth"
+	ghAuthContainerName := currentConfig().Containers.Prefix + "gh-auth"
 
 	// Check if gh auth container already exists
-	checkCmd := exec.Command("docker", "ps", "-a", "--filter", fmt.Sprintf("name=%s", ghAuthContainerName), "--format", "{{.Names}}")
+	checkCmd := system.DockerCommand("ps", "-a", "--filter", fmt.Sprintf("name=%s", ghAuthContainerName), "--format", "{{.Names}}")
 	output, _ := checkCmd.Output()
 	if len(output) > 0 {
 		// Remove existing gh auth container
 		fmt.Println("Removing existing gh auth container...")
-		exec.Command("docker", "rm", "-f", ghAuthContainerName).Run()
+		system.DockerCommand("rm", "-f", ghAuthContainerName).Run()
 	}
 
 	fmt.Println("\nStarting GitHub CLI authentication container...")
@@ -394,23 +397,23 @@ func setupGitHubAuth() error {
 
 	// Build gh auth login command with hostname
 	ghAuthArgs := []string{"gh", "auth", "login", "--hostname", hostname}
-	args = append(args, config.Containers.Image)
+	args = append(args, currentConfig().Containers.Image)
 	args = append(args, ghAuthArgs...)
 
-	ghAuthCmd := exec.Command("docker", args...)
+	ghAuthCmd := system.DockerCommand(args...)
 	ghAuthCmd.Stdin = os.Stdin
 	ghAuthCmd.Stdout = os.Stdout
 	ghAuthCmd.Stderr = os.Stderr
 
 	if err := ghAuthCmd.Run(); err != nil {
 		// Clean up container even on error
-		exec.Command("docker", "rm", "-f", ghAuthContainerName).Run()
+		system.DockerCommand("rm", "-f", ghAuthContainerName).Run()
 		return fmt.Errorf("GitHub authentication failed: %w", err)
 	}
 
 	// Clean up gh auth container
 	fmt.Println("\nCleaning up GitHub auth container...")
-	exec.Command("docker", "rm", "-f", ghAuthContainerName).Run()
+	system.DockerCommand("rm", "-f", ghAuthContainerName).Run()
 
 	// Check if authentication was successful
 	hostsPath := filepath.Join(mclGhPath, "hosts.yml")
@@ -431,7 +434,7 @@ func syncCredentialsToContainers() error {
 	fmt.Println("Syncing credentials to running containers...")
 
 	// Get all running containers
-	dockerCmd := exec.Command("docker", "ps", "--format", "{{.Names}}\t{{.State}}")
+	dockerCmd := system.DockerCommand("ps", "--format", "{{.Names}}\t{{.State}}")
 	output, err := dockerCmd.Output()
 	if err != nil {
 		return fmt.Errorf("failed to list containers: %w", err)
@@ -453,7 +456,7 @@ func syncCredentialsToContainers() error {
 		state := parts[1]
 
 		// Skip auth containers and non-MCL containers
-		if !strings.HasPrefix(name, config.Containers.Prefix) {
+		if !strings.HasPrefix(name, currentConfig().Containers.Prefix) {
 			continue
 		}
 		if strings.Contains(name, "-auth") {
@@ -474,8 +477,8 @@ func syncCredentialsToContainers() error {
 	fmt.Printf("Found %d running container(s) to update\n", len(runningContainers))
 
 	// Get the credentials path
-	authPath := expandPath(config.Claude.AuthPath)
-	credPath := filepath.Join(authPath, ".credentials.json")
+	authPath := expandPath(currentConfig().Claude.AuthPath)
+	credPath := paths.FindCredentials(authPath)
 
 	// Check if credentials exist
 	if _, err := os.Stat(credPath); err != nil {
@@ -488,17 +491,17 @@ func syncCredentialsToContainers() error {
 		fmt.Printf("  Updating %s... ", containerName)
 
 		// Copy credentials to container
-		copyCmd := exec.Command("docker", "cp",
+		copyCmd := system.DockerCommand("cp",
 			credPath,
-			fmt.Sprintf("%s:/home/node/.claude/.credentials.json", containerName))
+			fmt.Sprintf("%s:%s", containerName, container.CredentialsPath()))
 		if err := copyCmd.Run(); err != nil {
 			fmt.Printf("FAILED: %v\n", err)
 			continue
 		}
 
 		// Fix ownership (run as root)
-		chownCmd := exec.Command("docker", "exec", "-u", "root", containerName,
-			"chown", "node:node", "/home/node/.claude/.credentials.json")
+		chownCmd := system.DockerCommand("exec", "-u", "root", containerName,
+			"chown", "node:node", container.CredentialsPath())
 		if err := chownCmd.Run(); err != nil {
 			fmt.Printf("WARNING: ownership fix failed: %v\n", err)
 		}
@@ -0,0 +1,177 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+)
+
+// maxOutlineHeadings limits the document outline included in each container's prompt
+const maxOutlineHeadings = 40
+
+// mdSection is a markdown heading and everything up to the next heading of the same or higher level
+type mdSection struct {
+	heading string
+	level   int
+	text    string // Includes the heading line
+}
+
+// truncateDocument cuts content to at most max bytes, preferring a line boundary.
+// Returns the content unchanged if max <= 0 or it already fits.
+func truncateDocument(content string, max int) (string, bool) {
+	if max <= 0 || len(content) <= max {
+		return content, false
+	}
+	cut := content[:max]
+	if i := strings.LastIndex(cut, "\n"); i > 0 {
+		cut = cut[:i+1]
+	}
+	return cut, true
+}
+
+// headingLevel returns the level of a markdown ATX heading line, or 0
+func headingLevel(line string) int {
+	level := 0
+	for level < len(line) && line[level] == '#' {
+		level++
+	}
+	if level == 0 || level > 6 || level >= len(line) || line[level] != ' ' {
+		return 0
+	}
+	return level
+}
+
+// markdownSections splits a document into sections by heading, ignoring
+// "#" lines inside fenced code blocks
+func markdownSections(doc string) []mdSection {
+	lines := strings.Split(doc, "\n")
+
+	type heading struct {
+		line  int
+		level int
+		text  string
+	}
+	var headings []heading
+	inFence := false
+	for i, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		if level := headingLevel(line); level > 0 {
+			headings = append(headings, heading{i, level, strings.TrimSpace(line[level:])})
+		}
+	}
+
+	sections := make([]mdSection, 0, len(headings))
+	for i, h := range headings {
+		end := len(lines)
+		for _, next := range headings[i+1:] {
+			if next.level <= h.level {
+				end = next.line
+				break
+			}
+		}
+		sections = append(sections, mdSection{
+			heading: h.text,
+			level:   h.level,
+			text:    strings.TrimSpace(strings.Join(lines[h.line:end], "\n")),
+		})
+	}
+	return sections
+}
+
+// findTaskSection returns the section whose heading best matches the task title,
+// or "" if no heading shares a significant word with it. The most specific
+// (deepest) section wins ties so sub-sections are preferred over whole chapters.
+func findTaskSection(doc, title string) string {
+	var words []string
+	for _, w := range strings.Fields(strings.ToLower(title)) {
+		w = strings.Trim(w, ".,:;!?()[]\"'`")
+		if len(w) >= 3 {
+			words = append(words, w)
+		}
+	}
+
+	best, bestScore, bestLevel := "", 0, 0
+	for _, s := range markdownSections(doc) {
+		heading := strings.ToLower(s.heading)
+		score := 0
+		for _, w := range words {
+			if strings.Contains(heading, w) {
+				score++
+			}
+		}
+		if score > bestScore || (score == bestScore && score > 0 && s.level > bestLevel) {
+			best, bestScore, bestLevel = s.text, score, s.level
+		}
+	}
+	return best
+}
+
+// documentOutline lists the document's headings, indented by level
+func documentOutline(doc string) string {
+	var b strings.Builder
+	sections := markdownSections(doc)
+	for i, s := range sections {
+		if i == maxOutlineHeadings {
+			fmt.Fprintf(&b, "... (%d more headings)\n", len(sections)-maxOutlineHeadings)
+			break
+		}
+		fmt.Fprintf(&b, "%s- %s\n", strings.Repeat("  ", s.level-1), s.heading)
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// buildTaskPrompt builds a container's prompt from its task, the matching section
+// of the source document, a document outline, and the other tasks in the batch
+func buildTaskPrompt(task Task, all []Task, doc string, maxSize int) string {
+	description := task.Description
+	if description == "" {
+		description = task.Title
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "You are working on Task %d: %s\n\nYOUR SPECIFIC TASK:\n%s\n", task.Number, task.Title, description)
+
+	if section := findTaskSection(doc, task.Title); section != "" {
+		section, truncated := truncateDocument(section, maxSize)
+		if truncated {
+			section += "\n[... section truncated ...]"
+		}
+		fmt.Fprintf(&b, "\nRELEVANT SECTION OF THE SOURCE DOCUMENT:\n---\n%s\n---\n", section)
+	}
+
+	if outline := documentOutline(doc); outline != "" {
+		fmt.Fprintf(&b, "\nSOURCE DOCUMENT OUTLINE:\n%s\n", outline)
+	}
+
+	var others []string
+	for _, t := range all {
+		if t.Number != task.Number {
+			others = append(others, fmt.Sprintf("%d. %s", t.Number, t.Title))
+		}
+	}
+	if len(others) > 0 {
+		fmt.Fprintf(&b, "\nOTHER TASKS IN THIS BATCH (handled separately, do not work on these):\n%s\n", strings.Join(others, "\n"))
+	}
+
+	b.WriteString("\nFocus ONLY on your assigned task above. The rest is provided for context only.")
+	return b.String()
+}
@@ -16,21 +16,31 @@ package cmd
 
 import (
 	"bufio"
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
+	"os/signal"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"syscall"
 
 	"github.com/spf13/cobra"
+	"github.com/uprockcom/maestro/pkg/container"
 )
 
 var (
-	batchFile    string
-	extraCommand string
+	batchFile       string
+	extraCommand    string
+	allowDuplicates bool
+	batchKeepFailed bool
+	batchDryRun     bool
 )
 
 // Task represents a single task extracted from the markdown file
@@ -38,6 +48,7 @@ type Task struct {
 	Number      int    `json:"number"`
 	Title       string `json:"title"`
 	Description string `json:"description"`
+	DependsOn   []int  `json:"depends_on,omitempty"` // Numbers of tasks that must be created first
 }
 
 var batchCmd = &cobra.Command{
@@ -46,16 +57,44 @@ var batchCmd = &cobra.Command{
 	Long: `Analyze a markdown file with multiple tasks and create separate containers for each.
 
 Uses AI to identify distinct tasks in the file, then lets you select which ones
-to start as separate Maestro containers.
+to start as separate Maestro containers. Each container's prompt includes its own
+task, the matching section of the file, and an outline of the rest. Files larger
+than batch.max_document_size are truncated (with a warning) before analysis.
 
 The --extra-command flag allows you to add an instruction that will be sent to Claude
 in every container after the main task is complete. This is useful for common follow-up
 actions like committing, pushing, and creating PRs.
 
+Tasks can depend on each other: the analyzer records a task that has to wait
+for another (e.g. "after task 1") in its depends_on list, and a task whose
+description says "after task N" waits for task N too. A task's container is
+created once all of its prerequisites' containers are; independent tasks are
+still created in parallel. If a prerequisite fails, the tasks depending on it
+are skipped. Dependencies on tasks that aren't selected are ignored.
+
+If setting up a container fails after it has started, it is removed along with
+its volumes so failed tasks don't leave orphans. Use --keep-failed to keep such
+containers around for debugging.
+
+Enter 'e' at the task selection prompt to open the extracted tasks as JSON in
+$EDITOR; merge, split, rename, or drop tasks, and the edited list is shown for
+selection again.
+
+Use --dry-run to check the task split and naming first: after you select tasks,
+it prints the branch and container name each would get and exits without
+creating anything.
+
+Press Ctrl+C during creation to cancel the batch: containers that haven't
+started are skipped, and ones still being set up are removed once their current
+step finishes. Containers that were already complete are kept. Press Ctrl+C
+again to quit immediately, leaving any in-progress containers behind.
+
 Examples:
   maestro batch --file tasks.md
   maestro batch -f sprint-backlog.md
-  maestro batch -f tasks.md -e "When done, commit your changes, push to origin, and open a PR against main"`,
+  maestro batch -f tasks.md -e "When done, commit your changes, push to origin, and open a PR against main"
+  maestro batch -f tasks.md --mount ~/datasets:/data:ro
+  maestro batch -f tasks.md --dry-run`,
 	RunE: runBatch,
 }
 
@@ -63,20 +102,49 @@ func init() {
 	rootCmd.AddCommand(batchCmd)
 	batchCmd.Flags().StringVarP(&batchFile, "file", "f", "", "Markdown file containing tasks (required)")
 	batchCmd.Flags().StringVarP(&extraCommand, "extra-command", "e", "", "Extra command to send to Claude in all containers after the main task")
+	batchCmd.Flags().BoolVar(&allowDuplicates, "allow-duplicates", false, "Create containers even if their branch already has a running container")
+	batchCmd.Flags().StringArrayVar(&extraMounts, "mount", nil, "Bind mount a host path into every container as host:container[:ro] (repeatable)")
+	batchCmd.Flags().StringArrayVar(&allowDomains, "allow-domain", nil, "Allow a domain through every container's firewall on top of the config (repeatable)")
+	batchCmd.Flags().StringVar(&imagePullPolicy, "image-pull-policy", "", "When to pull the container image: always, missing, or never (default from config)")
+	batchCmd.Flags().StringVar(&presetName, "preset", "", "Apply a named preset from config to every container")
+	batchCmd.Flags().BoolVar(&batchKeepFailed, "keep-failed", false, "Keep containers whose setup failed instead of removing them")
+	batchCmd.Flags().BoolVar(&batchDryRun, "dry-run", false, "Print the branch and container name for each selected task, then exit")
 	batchCmd.MarkFlagRequired("file")
 }
 
 func runBatch(cmd *cobra.Command, args []string) error {
+	if err := applyPreset(presetName); err != nil {
+		return err
+	}
+
+	// Validate mounts, domains, and pull policy before doing any expensive work
+	if _, err := parseMounts(extraMounts); err != nil {
+		return err
+	}
+	if err := validateAllowDomains(allowDomains); err != nil {
+		return err
+	}
+	if _, err := effectivePullPolicy(); err != nil {
+		return err
+	}
+
 	// Read the markdown file
 	content, err := os.ReadFile(batchFile)
 	if err != nil {
 		return fmt.Errorf("failed to read file: %w", err)
 	}
 
+	// Keep the analysis prompt within the configured size
+	document, truncated := truncateDocument(string(content), currentConfig().Batch.MaxDocumentSize)
+	if truncated {
+		fmt.Printf("⚠️  %s is %d bytes, over batch.max_document_size (%d).\n", batchFile, len(content), currentConfig().Batch.MaxDocumentSize)
+		fmt.Printf("   Only the first %d bytes will be analyzed; split the file or raise the limit to include the rest.\n\n", len(document))
+	}
+
 	fmt.Println("Analyzing tasks...")
 
 	// Use LLM to analyze and extract tasks
-	tasks, err := analyzeTasks(string(content))
+	tasks, err := analyzeTasks(document)
 	if err != nil {
 		return fmt.Errorf("failed to analyze tasks: %w", err)
 	}
@@ -86,26 +154,48 @@ func runBatch(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	// Display found tasks
-	fmt.Printf("\nFound %d task(s):\n", len(tasks))
-	for _, task := range tasks {
-		fmt.Printf("  %d. %s\n", task.Number, task.Title)
-	}
+	// Display found tasks and prompt for selection, letting the user edit
+	// the list in between as often as they like
+	var selectedTasks []Task
+	for {
+		fmt.Printf("\nFound %d task(s):\n", len(tasks))
+		for _, task := range tasks {
+			fmt.Printf("  %d. %s\n", task.Number, task.Title)
+		}
 
-	// Prompt for selection
-	selectedTasks, err := promptTaskSelection(tasks)
-	if err != nil {
-		return err
+		selectedTasks, err = promptTaskSelection(tasks)
+		if errors.Is(err, errEditTasks) {
+			if tasks, err = editTasks(tasks); err != nil {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		break
 	}
 
 	if len(selectedTasks) == 0 {
 		fmt.Println("No tasks selected. Exiting.")
 		return nil
 	}
+	if _, _, err := batchDependencies(selectedTasks); err != nil {
+		return err
+	}
+
+	if batchDryRun {
+		planned, skipped, err := planBatch(selectedTasks, string(content), extraCommand, currentConfig())
+		if err != nil {
+			return err
+		}
+		printBatchPlan(planned, skipped)
+		return nil
+	}
 
 	fmt.Printf("\nStarting %d container(s)...\n\n", len(selectedTasks))
 
-	// Create containers in parallel, passing full markdown as reference and extra command
+	// Create containers in parallel, passing the markdown for per-task context and extra command
 	if err := createContainersInParallel(selectedTasks, string(content), extraCommand); err != nil {
 		return err
 	}
@@ -135,6 +225,8 @@ Instructions:
    - Different bug fixes that affect unrelated code
 4. Extract a short title (max 60 chars) and include ALL related steps in the description
 5. Number them starting from 1
+6. If a task can only start once another is done (e.g. it builds on shared setup, or the
+   document says "after task 1"), list the numbers of those tasks in "depends_on"
 
 Examples of WRONG splitting:
 - "Create UserService class" and "Add methods to UserService" → Should be ONE task
@@ -145,17 +237,13 @@ Examples of CORRECT splitting:
 - "Fix login bug" and "Add export feature" → TWO separate tasks (unrelated work)
 
 Respond ONLY with valid JSON in this exact format (no markdown, no explanation):
-{"tasks": [{"number": 1, "title": "Short task title", "description": "Full task description with all sub-steps..."}, ...]}
+{"tasks": [{"number": 1, "title": "Short task title", "description": "Full task description with all sub-steps...", "depends_on": []}, ...]}
 
 If no distinct tasks are found, respond with: {"tasks": []}`, content)
 
-	// Call Claude CLI to analyze tasks (--print is read-only, no permissions needed)
-	cmd := exec.Command("claude", "--print")
-	cmd.Stdin = strings.NewReader(prompt)
-
-	output, err := cmd.CombinedOutput()
+	output, err := runAnalyzer(prompt)
 	if err != nil {
-		return nil, fmt.Errorf("failed to call Claude: %w\nOutput: %s", err, string(output))
+		return nil, err
 	}
 
 	// Parse JSON response
@@ -190,10 +278,56 @@ If no distinct tasks are found, respond with: {"tasks": []}`, content)
 	return result.Tasks, nil
 }
 
+// Supported values for batch.analyzer
+const (
+	analyzerClaudeCLI = "claude-cli"
+	analyzerCommand   = "command"
+)
+
+// runAnalyzer sends the prompt to the configured analyzer and returns its raw response
+func runAnalyzer(prompt string) ([]byte, error) {
+	switch currentConfig().Batch.Analyzer {
+	case analyzerClaudeCLI, "":
+		// Call Claude CLI to analyze tasks (--print is read-only, no permissions needed)
+		cmd := exec.Command("claude", "--print")
+		cmd.Stdin = strings.NewReader(prompt)
+
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			return nil, fmt.Errorf("failed to call Claude: %w\nOutput: %s", err, string(output))
+		}
+		return output, nil
+
+	case analyzerCommand:
+		if strings.TrimSpace(currentConfig().Batch.AnalyzerCommand) == "" {
+			return nil, fmt.Errorf("batch.analyzer is %q but batch.analyzer_command is empty", analyzerCommand)
+		}
+
+		// Prompt goes in on stdin; only stdout is parsed so tools can log to stderr
+		cmd := exec.Command("sh", "-c", currentConfig().Batch.AnalyzerCommand)
+		cmd.Stdin = strings.NewReader(prompt)
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+
+		output, err := cmd.Output()
+		if err != nil {
+			return nil, fmt.Errorf("analyzer command failed: %w\nOutput: %s", err, strings.TrimSpace(stderr.String()))
+		}
+		return output, nil
+
+	default:
+		return nil, fmt.Errorf("unknown batch.analyzer %q (expected %q or %q)", currentConfig().Batch.Analyzer, analyzerClaudeCLI, analyzerCommand)
+	}
+}
+
+// errEditTasks is returned by promptTaskSelection when the user asks to edit
+// the task list instead of selecting from it
+var errEditTasks = errors.New("edit tasks")
+
 // promptTaskSelection prompts the user to select which tasks to start
 func promptTaskSelection(tasks []Task) ([]Task, error) {
 	fmt.Printf("\nWhich tasks to start? ")
-	fmt.Printf("[1-%d, 'all', or comma-separated like '1,3,5'] (default: all): ", len(tasks))
+	fmt.Printf("[1-%d, 'all', comma-separated like '1,3,5', or 'e' to edit the list] (default: all): ", len(tasks))
 
 	reader := bufio.NewReader(os.Stdin)
 	input, err := reader.ReadString('\n')
@@ -213,6 +347,10 @@ func promptTaskSelection(tasks []Task) ([]Task, error) {
 		return tasks, nil
 	}
 
+	if input == "edit" || input == "e" {
+		return nil, errEditTasks
+	}
+
 	// Handle range like '1-3'
 	if strings.Contains(input, "-") && !strings.Contains(input, ",") {
 		parts := strings.Split(input, "-")
@@ -247,14 +385,123 @@ func promptTaskSelection(tasks []Task) ([]Task, error) {
 	return selected, nil
 }
 
+// editTasks opens tasks as JSON in the user's editor and returns the edited
+// list. Tasks can be merged, split, renamed, reordered, or removed; numbers are
+// reassigned in order and depends_on is updated to match. If the edit doesn't
+// parse, the user can fix it or keep the original list.
+func editTasks(tasks []Task) ([]Task, error) {
+	data, err := json.MarshalIndent(struct {
+		Tasks []Task `json:"tasks"`
+	}{tasks}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.CreateTemp("", "maestro-tasks-*.json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create task file: %w", err)
+	}
+	defer os.Remove(f.Name())
+	_, err = f.Write(append(data, '\n'))
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to write task file: %w", err)
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	for {
+		if err := editFile(f.Name()); err != nil {
+			return nil, err
+		}
+		edited, err := os.ReadFile(f.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read task file: %w", err)
+		}
+		parsed, err := parseEditedTasks(edited)
+		if err == nil {
+			return parsed, nil
+		}
+
+		fmt.Printf("\n⚠️  Edited task list is invalid: %v\n", err)
+		fmt.Print("Edit again? [Y/n] (n keeps the previous list): ")
+		response, _ := reader.ReadString('\n')
+		response = strings.ToLower(strings.TrimSpace(response))
+		if response == "n" || response == "no" {
+			return tasks, nil
+		}
+	}
+}
+
+// parseEditedTasks parses a task list edited by the user, in the same
+// {"tasks": [...]} format the analyzer returns. Every task needs a title, and
+// tasks are renumbered from 1 in the order given, with depends_on following
+// the new numbers.
+func parseEditedTasks(data []byte) ([]Task, error) {
+	var result struct {
+		Tasks []Task `json:"tasks"`
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&result); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	if decoder.More() {
+		return nil, fmt.Errorf("invalid JSON: unexpected content after the task list")
+	}
+	if len(result.Tasks) == 0 {
+		return nil, fmt.Errorf("no tasks left")
+	}
+
+	renumbered := make(map[int]int, len(result.Tasks))
+	for i, task := range result.Tasks {
+		if _, dup := renumbered[task.Number]; task.Number > 0 && !dup {
+			renumbered[task.Number] = i + 1
+		}
+	}
+
+	for i := range result.Tasks {
+		task := &result.Tasks[i]
+		task.Title = strings.TrimSpace(task.Title)
+		if task.Title == "" {
+			return nil, fmt.Errorf("task %d has no title", i+1)
+		}
+		for j, dep := range task.DependsOn {
+			n, ok := renumbered[dep]
+			if !ok {
+				return nil, fmt.Errorf("task %q depends on task %d, which isn't in the list", task.Title, dep)
+			}
+			task.DependsOn[j] = n
+		}
+		task.Number = i + 1
+	}
+	return result.Tasks, nil
+}
+
 // ContainerResult holds the result of creating a container
 type ContainerResult struct {
 	TaskNumber int
 	TaskTitle  string
 	Success    bool
+	Aborted    bool // Cancelled with Ctrl+C before the container was complete
 	Message    string
 }
 
+// errBatchCancelled is returned by createBatchContainer when the batch is
+// cancelled before the container is complete
+var errBatchCancelled = errors.New("batch cancelled")
+
+// asBatchCancelled marks err as errBatchCancelled once ctx is cancelled. The
+// Ctrl+C that cancels the batch also reaches the docker commands still
+// running, so a step failing after that was interrupted rather than broken.
+func asBatchCancelled(ctx context.Context, err error) error {
+	if err == nil || ctx.Err() == nil || errors.Is(err, errBatchCancelled) {
+		return err
+	}
+	return fmt.Errorf("%w: %v", errBatchCancelled, err)
+}
+
 // createContainersInParallel creates containers for selected tasks concurrently
 func createContainersInParallel(tasks []Task, fullMarkdown string, extraCmd string) error {
 	var wg sync.WaitGroup
@@ -267,95 +514,109 @@ func createContainersInParallel(tasks []Task, fullMarkdown string, extraCmd stri
 	// Initialize multi-progress display for copy operations
 	mp := InitMultiProgress()
 
-	// Pre-generate container names so we can add them to progress display
-	type taskInfo struct {
-		task          Task
-		containerName string
-		branchName    string
-		fullPrompt    string
-	}
-	var taskInfos []taskInfo
-
+	// Pre-generate container names so we can add them to progress display,
+	// from one config snapshot so every container gets the same settings
 	fmt.Println("Preparing containers...")
-	for _, task := range tasks {
-		taskDescription := task.Description
-		if taskDescription == "" {
-			taskDescription = task.Title
-		}
-
-		// Build the full prompt with markdown as reference
-		fullPrompt := fmt.Sprintf(`You are working on Task %d: %s
-
-YOUR SPECIFIC TASK:
-%s
-
-FULL DOCUMENT FOR REFERENCE:
----
-%s
----
-
-Focus ONLY on your assigned task above. The document is provided for context only.`,
-			task.Number, task.Title, taskDescription, fullMarkdown)
-
-		// Append extra command if provided
-		if extraCmd != "" {
-			fullPrompt += fmt.Sprintf(`
-
-ADDITIONAL INSTRUCTION (execute after completing the task above):
-%s`, extraCmd)
-		}
-
-		// Generate branch name from the specific task
-		branchName, _, err := generateBranchAndPrompt(taskDescription, false)
-		if err != nil {
-			return fmt.Errorf("failed to generate branch for task %d: %w", task.Number, err)
-		}
-
-		if !isValidBranchName(branchName) {
-			branchName = generateSimpleBranch(task.Title)
-		}
-
-		containerName, err := getNextContainerName(branchName)
-		if err != nil {
-			return fmt.Errorf("failed to get container name for task %d: %w", task.Number, err)
-		}
+	taskInfos, skipped, err := planBatch(tasks, fullMarkdown, extraCmd, currentConfig())
+	if err != nil {
+		return err
+	}
+	for _, info := range taskInfos {
+		mp.AddItem(info.containerName, 0)
+	}
 
-		taskInfos = append(taskInfos, taskInfo{
-			task:          task,
-			containerName: containerName,
-			branchName:    branchName,
-			fullPrompt:    fullPrompt,
-		})
+	for _, result := range skipped {
+		fmt.Printf("  ⚠️  Task %d: %s\n", result.TaskNumber, result.Message)
+	}
+	if len(taskInfos) == 0 {
+		fmt.Println("\nNo containers to create (use --allow-duplicates to create them anyway).")
+		return nil
+	}
 
-		// Add to progress display
-		mp.AddItem(containerName, 0)
+	if err := checkDiskSpace(len(taskInfos)); err != nil {
+		return err
 	}
 
 	// Start progress display
 	fmt.Println("\nCopying source code to containers:")
 	mp.Start()
 
-	// Start container creation in parallel
+	// First Ctrl+C cancels the batch, a second one quits immediately
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sigChan := make(chan os.Signal, 2)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigChan)
+	go func() {
+		select {
+		case <-sigChan:
+		case <-ctx.Done():
+			return
+		}
+		mp.Println("⚠️  Cancelling batch: finishing current steps and removing incomplete containers (Ctrl+C again to quit now)")
+		cancel()
+		<-sigChan
+		fmt.Println("\nAborted; incomplete containers may be left behind (see 'maestro list').")
+		os.Exit(130)
+	}()
+
+	// Start container creation in parallel. Each task's channel is closed
+	// when it finishes, releasing the tasks that depend on it.
+	done := make(map[int]chan struct{}, len(taskInfos))
+	succeeded := make(map[int]bool, len(taskInfos))
+	for _, ti := range taskInfos {
+		done[ti.task.Number] = make(chan struct{})
+	}
 	for _, ti := range taskInfos {
 		wg.Add(1)
-		go func(info taskInfo) {
+		go func(info batchTask) {
 			defer wg.Done()
+			defer close(done[info.task.Number])
 
 			result := ContainerResult{
 				TaskNumber: info.task.Number,
 				TaskTitle:  info.task.Title,
 			}
 
+			// Wait for prerequisites; if one didn't make it, neither does this task
+			for _, dep := range info.after {
+				select {
+				case <-done[dep]:
+				case <-ctx.Done():
+				}
+				mu.Lock()
+				ok := succeeded[dep]
+				mu.Unlock()
+				if ok {
+					continue
+				}
+				if ctx.Err() != nil {
+					result.Aborted = true
+					result.Message = fmt.Sprintf("aborted %s: %v", info.containerName, errBatchCancelled)
+				} else {
+					result.Message = fmt.Sprintf("skipped %s: prerequisite task %d was not created", info.containerName, dep)
+				}
+				mp.ErrorItem(info.containerName, errors.New(result.Message))
+				results <- result
+				return
+			}
+
 			// Create the container
-			if err := createBatchContainer(info.containerName, info.branchName, info.fullPrompt); err != nil {
+			if err := createBatchContainer(ctx, info.containerName, info.branchName, info.fullPrompt, info.task.Title); err != nil {
 				result.Success = false
-				result.Message = fmt.Sprintf("failed to create container: %v", err)
+				if errors.Is(err, errBatchCancelled) {
+					result.Aborted = true
+					result.Message = fmt.Sprintf("aborted %s: %v", info.containerName, err)
+				} else {
+					result.Message = fmt.Sprintf("failed to create container: %v", err)
+				}
 				results <- result
 				return
 			}
 
 			mu.Lock()
 			createdContainers = append(createdContainers, info.containerName)
+			succeeded[info.task.Number] = true
 			mu.Unlock()
 
 			result.Success = true
@@ -371,7 +632,7 @@ ADDITIONAL INSTRUCTION (execute after completing the task above):
 	}()
 
 	// Collect results (don't print yet, progress display is active)
-	var resultsList []ContainerResult
+	resultsList := skipped
 	for result := range results {
 		resultsList = append(resultsList, result)
 	}
@@ -381,47 +642,321 @@ ADDITIONAL INSTRUCTION (execute after completing the task above):
 
 	// Print final summary
 	fmt.Println("\nContainer creation results:")
-	successCount := 0
+	successCount, abortedCount := 0, 0
 	for _, result := range resultsList {
-		if result.Success {
+		switch {
+		case result.Success:
 			fmt.Printf("  [%d] ✓ %s\n", result.TaskNumber, result.Message)
 			successCount++
-		} else {
+		case result.Aborted:
+			fmt.Printf("  [%d] ⊘ %s\n", result.TaskNumber, result.Message)
+			abortedCount++
+		default:
 			fmt.Printf("  [%d] ✗ %s\n", result.TaskNumber, result.Message)
 		}
 	}
 
 	fmt.Printf("\nCreated %d/%d containers successfully.\n", successCount, len(tasks))
+	if abortedCount > 0 {
+		fmt.Printf("Batch cancelled: %d container(s) aborted.\n", abortedCount)
+	}
+	if successCount > 0 {
+		fmt.Println("\nNext steps (<name> is a container from the list above, without the prefix):")
+		for _, line := range nextStepHints("<name>", currentConfig().Containers.Prefix+"<name>", false) {
+			fmt.Println("  " + line)
+		}
+	}
 	return nil
 }
 
-// createBatchContainer creates a single container without connecting
-func createBatchContainer(containerName, branchName, planningPrompt string) error {
+// batchTask is a selected task with the branch and container it will get
+type batchTask struct {
+	task          Task
+	containerName string
+	branchName    string
+	fullPrompt    string
+	after         []int // Numbers of planned tasks whose containers must be created first
+}
+
+// planBatch works out the branch, container name, and prompt for each task,
+// skipping tasks whose branch is already in use unless --allow-duplicates is
+// set. Nothing is created.
+func planBatch(tasks []Task, fullMarkdown, extraCmd string, cfg *Config) ([]batchTask, []ContainerResult, error) {
+	// Track branches already in use so colliding tasks can be skipped
+	existingContainers, _ := container.GetRunningContainers(cfg.Containers.Prefix)
+	usedBranches := make(map[string]bool)
+	var planned []batchTask
+	var skipped []ContainerResult
+
+	for _, task := range tasks {
+		taskDescription := task.Description
+		if taskDescription == "" {
+			taskDescription = task.Title
+		}
+
+		// Only the matching section and an outline are sent, not the whole document
+		fullPrompt := buildTaskPrompt(task, tasks, fullMarkdown, cfg.Batch.MaxDocumentSize)
+
+		// Append extra command if provided
+		if extraCmd != "" {
+			fullPrompt += fmt.Sprintf(`
+
+ADDITIONAL INSTRUCTION (execute after completing the task above):
+%s`, extraCmd)
+		}
+
+		// Generate branch name from the specific task
+		branchName, _, err := generateBranchAndPrompt(taskDescription, false)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to generate branch for task %d: %w", task.Number, err)
+		}
+
+		if !isValidBranchName(branchName) {
+			// Similar titles can reduce to the same simple name; suffix it
+			// rather than skip the task as a duplicate
+			branchName = generateSimpleBranch(task.Title, func(branch string) bool {
+				return usedBranches[branch] || len(container.FindByBranch(existingContainers, branch)) > 0
+			})
+		}
+
+		if !allowDuplicates {
+			if existing := container.FindByBranch(existingContainers, branchName); len(existing) > 0 {
+				skipped = append(skipped, ContainerResult{
+					TaskNumber: task.Number,
+					TaskTitle:  task.Title,
+					Message:    fmt.Sprintf("skipped: branch %s already used by %s", branchName, existing[0].ShortName),
+				})
+				continue
+			}
+			if usedBranches[branchName] {
+				skipped = append(skipped, ContainerResult{
+					TaskNumber: task.Number,
+					TaskTitle:  task.Title,
+					Message:    fmt.Sprintf("skipped: branch %s already used by another task in this batch", branchName),
+				})
+				continue
+			}
+		}
+		usedBranches[branchName] = true
+
+		containerName, err := getNextContainerName(branchName)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get container name for task %d: %w", task.Number, err)
+		}
+
+		planned = append(planned, batchTask{
+			task:          task,
+			containerName: containerName,
+			branchName:    branchName,
+			fullPrompt:    fullPrompt,
+		})
+	}
+
+	// Skipped tasks drop out of the dependency graph like unselected ones
+	plannedTasks := make([]Task, len(planned))
+	for i, info := range planned {
+		plannedTasks[i] = info.task
+	}
+	deps, warnings, err := batchDependencies(plannedTasks)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, warning := range warnings {
+		fmt.Printf("  ⚠️  %s\n", warning)
+	}
+	for i := range planned {
+		planned[i].after = deps[planned[i].task.Number]
+	}
+	return planned, skipped, nil
+}
+
+// afterTaskPattern matches references like "after task 1" or "after tasks 2 and 3"
+var afterTaskPattern = regexp.MustCompile(`(?i)\bafter\s+tasks?\s+#?(\d+(?:\s*(?:,|and|&)\s*#?\d+)*)`)
+
+// taskDependencies returns the numbers of the tasks task depends on: its
+// depends_on list plus any "after task N" references in its title or
+// description
+func taskDependencies(task Task) []int {
+	seen := make(map[int]bool)
+	var deps []int
+	add := func(n int) {
+		if n != task.Number && !seen[n] {
+			seen[n] = true
+			deps = append(deps, n)
+		}
+	}
+	for _, n := range task.DependsOn {
+		add(n)
+	}
+	for _, match := range afterTaskPattern.FindAllStringSubmatch(task.Title+"\n"+task.Description, -1) {
+		for _, number := range strings.FieldsFunc(match[1], func(r rune) bool { return r < '0' || r > '9' }) {
+			n, _ := strconv.Atoi(number)
+			add(n)
+		}
+	}
+	sort.Ints(deps)
+	return deps
+}
+
+// batchDependencies maps each task's number to the tasks among tasks it has
+// to wait for. Dependencies on tasks that aren't in the list are dropped with
+// a warning; a dependency cycle is an error.
+func batchDependencies(tasks []Task) (map[int][]int, []string, error) {
+	present := make(map[int]bool, len(tasks))
+	for _, task := range tasks {
+		present[task.Number] = true
+	}
+
+	deps := make(map[int][]int, len(tasks))
+	var warnings []string
+	for _, task := range tasks {
+		for _, dep := range taskDependencies(task) {
+			if !present[dep] {
+				warnings = append(warnings, fmt.Sprintf("Task %d depends on task %d, which isn't part of this batch; not waiting for it", task.Number, dep))
+				continue
+			}
+			deps[task.Number] = append(deps[task.Number], dep)
+		}
+	}
+
+	// Depth-first search for a cycle, reporting the tasks on it
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[int]int, len(tasks))
+	var path []int
+	var visit func(n int) error
+	visit = func(n int) error {
+		switch state[n] {
+		case visiting:
+			start := 0
+			for path[start] != n {
+				start++
+			}
+			cycle := make([]string, 0, len(path)-start+1)
+			for _, m := range append(path[start:], n) {
+				cycle = append(cycle, strconv.Itoa(m))
+			}
+			return fmt.Errorf("tasks depend on each other in a cycle (%s); edit the task list to break it", strings.Join(cycle, " → "))
+		case visited:
+			return nil
+		}
+		state[n] = visiting
+		path = append(path, n)
+		for _, dep := range deps[n] {
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		state[n] = visited
+		return nil
+	}
+	for _, task := range tasks {
+		if err := visit(task.Number); err != nil {
+			return nil, nil, err
+		}
+	}
+	return deps, warnings, nil
+}
+
+// printBatchPlan prints what 'maestro batch' would create, for --dry-run
+func printBatchPlan(planned []batchTask, skipped []ContainerResult) {
+	fmt.Printf("\nDry run: %d container(s) would be created:\n", len(planned))
+	for _, info := range planned {
+		fmt.Printf("\n  [%d] %s\n", info.task.Number, info.task.Title)
+		fmt.Printf("      Branch:    %s\n", info.branchName)
+		fmt.Printf("      Container: %s\n", info.containerName)
+		if len(info.after) > 0 {
+			fmt.Printf("      After:     %s\n", formatTaskNumbers(info.after))
+		}
+	}
+	if len(skipped) > 0 {
+		fmt.Println()
+		for _, result := range skipped {
+			fmt.Printf("  ⚠️  Task %d: %s\n", result.TaskNumber, result.Message)
+		}
+	}
+	fmt.Println("\nNothing was created. Run again without --dry-run to create them.")
+}
+
+// formatTaskNumbers formats task numbers as "task 1" or "tasks 1, 3"
+func formatTaskNumbers(numbers []int) string {
+	parts := make([]string, len(numbers))
+	for i, n := range numbers {
+		parts[i] = strconv.Itoa(n)
+	}
+	if len(parts) == 1 {
+		return "task " + parts[0]
+	}
+	return "tasks " + strings.Join(parts, ", ")
+}
+
+// createBatchContainer creates a single container without connecting.
+// If a step fails after the container has started, the container and its
+// volumes are removed unless --keep-failed is set. Cancelling ctx stops the
+// creation between steps and always removes the container.
+func createBatchContainer(ctx context.Context, containerName, branchName, planningPrompt, taskTitle string) (err error) {
+	defer func() { err = asBatchCancelled(ctx, err) }()
+
 	// Step 1: Ensure Docker image
 	if err := ensureDockerImage(); err != nil {
 		return fmt.Errorf("failed to ensure Docker image: %w", err)
 	}
+	if ctx.Err() != nil {
+		return fmt.Errorf("%w before the container started", errBatchCancelled)
+	}
 
 	// Step 2: Start container
 	if err := startContainer(containerName); err != nil {
 		return fmt.Errorf("failed to start container: %w", err)
 	}
 
+	defer func() {
+		if err == nil {
+			return
+		}
+		err = asBatchCancelled(ctx, err)
+		if batchKeepFailed && !errors.Is(err, errBatchCancelled) {
+			err = fmt.Errorf("%w (kept %s for debugging)", err, containerName)
+			return
+		}
+		if rmErr := container.DeleteContainer(containerName); rmErr != nil {
+			err = fmt.Errorf("%w (rollback failed, remove %s manually: %v)", err, containerName, rmErr)
+		} else {
+			err = fmt.Errorf("%w (removed partially created container)", err)
+		}
+	}()
+
 	// Step 3: Copy project files
 	if err := copyProjectToContainer(containerName); err != nil {
 		return fmt.Errorf("failed to copy project: %w", err)
 	}
 
+	if ctx.Err() != nil {
+		return errBatchCancelled
+	}
+
 	// Step 4: Copy additional folders
 	if err := copyAdditionalFolders(containerName); err != nil {
 		return fmt.Errorf("failed to copy additional folders: %w", err)
 	}
 
+	if ctx.Err() != nil {
+		return errBatchCancelled
+	}
+
 	// Step 5: Initialize git branch
 	if err := initializeGitBranch(containerName, branchName); err != nil {
 		return fmt.Errorf("failed to initialize git branch: %w", err)
 	}
 
+	if ctx.Err() != nil {
+		return errBatchCancelled
+	}
+
 	// Step 6: Configure git user
 	if err := configureGitUser(containerName); err != nil {
 		// Just warn, don't fail
@@ -432,10 +967,16 @@ func createBatchContainer(containerName, branchName, planningPrompt string) erro
 		// Just warn, don't fail
 	}
 
+	if ctx.Err() != nil {
+		return errBatchCancelled
+	}
+
 	// Step 8: Start tmux session
 	if err := startTmuxSession(containerName, branchName, planningPrompt, false); err != nil {
 		return fmt.Errorf("failed to start tmux session: %w", err)
 	}
 
+	firePostCreateHook(containerName, branchName, taskTitle)
+
 	return nil
 }
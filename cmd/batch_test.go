@@ -0,0 +1,141 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseEditedTasks(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    []Task
+		wantErr bool
+	}{
+		{
+			name: "renumbers in order",
+			data: `{"tasks": [{"number": 3, "title": "Add export", "description": "CSV and JSON"}, {"number": 1, "title": " Fix login "}]}`,
+			want: []Task{{Number: 1, Title: "Add export", Description: "CSV and JSON"}, {Number: 2, Title: "Fix login"}},
+		},
+		{
+			name: "depends_on follows renumbering",
+			data: `{"tasks": [{"number": 2, "title": "Use it", "depends_on": [1]}, {"number": 1, "title": "Shared setup"}]}`,
+			want: []Task{{Number: 1, Title: "Use it", DependsOn: []int{2}}, {Number: 2, Title: "Shared setup"}},
+		},
+		{name: "unknown dependency", data: `{"tasks": [{"number": 1, "title": "a", "depends_on": [4]}]}`, wantErr: true},
+		{name: "missing title", data: `{"tasks": [{"number": 1, "description": "no title"}]}`, wantErr: true},
+		{name: "empty list", data: `{"tasks": []}`, wantErr: true},
+		{name: "broken JSON", data: `{"tasks": [{"title": "a",}]}`, wantErr: true},
+		{name: "misspelled field", data: `{"tasks": [{"titel": "a"}]}`, wantErr: true},
+		{name: "trailing content", data: `{"tasks": [{"title": "a"}]} {"tasks": []}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseEditedTasks([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseEditedTasks() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseEditedTasks() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTaskDependencies(t *testing.T) {
+	tests := []struct {
+		name string
+		task Task
+		want []int
+	}{
+		{"none", Task{Number: 1, Title: "Fix login", Description: "Do this after lunch"}, nil},
+		{"depends_on", Task{Number: 3, DependsOn: []int{2, 1}}, []int{1, 2}},
+		{"after task in description", Task{Number: 2, Description: "Start after task 1 is merged"}, []int{1}},
+		{"several tasks", Task{Number: 5, Title: "After tasks 1, 2 and #3"}, []int{1, 2, 3}},
+		{"merged and deduplicated", Task{Number: 4, Description: "after task 2", DependsOn: []int{2, 4}}, []int{2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := taskDependencies(tt.task); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("taskDependencies() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBatchDependencies(t *testing.T) {
+	tasks := []Task{
+		{Number: 1, Title: "Shared setup"},
+		{Number: 2, Title: "Feature A", DependsOn: []int{1}},
+		{Number: 3, Title: "Feature B", Description: "after task 1"},
+		{Number: 4, Title: "Docs", DependsOn: []int{2, 7}},
+	}
+	deps, warnings, err := batchDependencies(tasks)
+	if err != nil {
+		t.Fatalf("batchDependencies() error = %v", err)
+	}
+	want := map[int][]int{2: {1}, 3: {1}, 4: {2}}
+	if !reflect.DeepEqual(deps, want) {
+		t.Errorf("deps = %v, want %v", deps, want)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "task 7") {
+		t.Errorf("warnings = %q, want one about task 7", warnings)
+	}
+
+	cyclic := []Task{
+		{Number: 1, DependsOn: []int{3}},
+		{Number: 2, DependsOn: []int{1}},
+		{Number: 3, DependsOn: []int{2}},
+	}
+	if _, _, err := batchDependencies(cyclic); err == nil || !strings.Contains(err.Error(), "1 → 3 → 2 → 1") {
+		t.Errorf("batchDependencies() error = %v, want the cycle 1 → 3 → 2 → 1", err)
+	}
+}
+
+func TestAsBatchCancelled(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	stepErr := errors.New("failed to copy project: signal: interrupt")
+
+	tests := []struct {
+		name          string
+		ctx           context.Context
+		err           error
+		wantCancelled bool
+	}{
+		{"success", cancelled, nil, false},
+		{"failure while running", context.Background(), stepErr, false},
+		{"failure after Ctrl+C", cancelled, stepErr, true},
+		{"already cancelled", cancelled, errBatchCancelled, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := asBatchCancelled(tt.ctx, tt.err)
+			if tt.err == nil && got != nil {
+				t.Fatalf("asBatchCancelled() = %v, want nil", got)
+			}
+			if errors.Is(got, errBatchCancelled) != tt.wantCancelled {
+				t.Errorf("asBatchCancelled() = %v, want cancelled = %v", got, tt.wantCancelled)
+			}
+		})
+	}
+}
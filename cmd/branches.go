@@ -0,0 +1,86 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/uprockcom/maestro/pkg/container"
+)
+
+var branchesCmd = &cobra.Command{
+	Use:   "branches",
+	Short: "List containers grouped by git branch",
+	Long: `List maestro containers grouped by the git branch checked out in /workspace.
+
+Branches with more than one container are highlighted, since they are usually
+accidental duplicates of the same task.
+
+Note: stopped containers can't report their branch and are grouped under "unknown".`,
+	Args: cobra.NoArgs,
+	RunE: runBranches,
+}
+
+func init() {
+	rootCmd.AddCommand(branchesCmd)
+}
+
+func runBranches(cmd *cobra.Command, args []string) error {
+	if !container.IsDockerResponsive() {
+		fmt.Println("No maestro containers found.")
+		fmt.Println("\nHint: Is Docker running?")
+		return nil
+	}
+
+	containers, err := container.GetAllContainers(currentConfig().Containers.Prefix)
+	if err != nil {
+		return fmt.Errorf("failed to list containers: %w", err)
+	}
+
+	if len(containers) == 0 {
+		fmt.Println("No maestro containers found.")
+		return nil
+	}
+
+	groups := container.GroupByBranch(containers)
+	duplicates := 0
+
+	for _, g := range groups {
+		marker := ""
+		if g.IsDuplicate() {
+			marker = "  ⚠️  possible duplicate"
+			duplicates++
+		}
+		fmt.Printf("%s (%d)%s\n", g.Branch, len(g.Containers), marker)
+
+		for _, c := range g.Containers {
+			status := container.FormatState(c.Status)
+			if c.NeedsAttention {
+				status += " 🔔"
+			} else if c.IsDormant {
+				status += " 💤"
+			}
+			fmt.Printf("  - %s  %s\n", c.ShortName, status)
+		}
+		fmt.Println()
+	}
+
+	if duplicates > 0 {
+		fmt.Printf("⚠️  %d branch(es) have multiple containers. Remove extras with: maestro stop <name> && maestro cleanup\n", duplicates)
+	}
+
+	return nil
+}
@@ -18,10 +18,11 @@ import (
 	"bufio"
 	"fmt"
 	"os"
-	"os/exec"
 	"strings"
 
 	"github.com/spf13/cobra"
+
+	"github.com/uprockcom/maestro/pkg/system"
 )
 
 var forceVolumeCleanup bool
@@ -40,14 +41,14 @@ func init() {
 
 func runCleanupVolumes(cmd *cobra.Command, args []string) error {
 	// Get all MCL volumes
-	volumeCmd := exec.Command("docker", "volume", "ls", "--format", "{{.Name}}")
+	volumeCmd := system.DockerCommand("volume", "ls", "--format", "{{.Name}}")
 	volumeOutput, err := volumeCmd.Output()
 	if err != nil {
 		return fmt.Errorf("failed to list volumes: %w", err)
 	}
 
 	var mclVolumes []string
-	prefix := config.Containers.Prefix
+	prefix := currentConfig().Containers.Prefix
 	for _, line := range strings.Split(string(volumeOutput), "\n") {
 		if strings.HasPrefix(line, prefix) {
 			mclVolumes = append(mclVolumes, line)
@@ -60,7 +61,7 @@ func runCleanupVolumes(cmd *cobra.Command, args []string) error {
 	}
 
 	// Get all MCL containers (including stopped)
-	containerCmd := exec.Command("docker", "ps", "-a", "--filter", fmt.Sprintf("name=%s", prefix), "--format", "{{.Names}}")
+	containerCmd := system.DockerCommand("ps", "-a", "--filter", fmt.Sprintf("name=%s", prefix), "--format", "{{.Names}}")
 	containerOutput, err := containerCmd.Output()
 	if err != nil {
 		return fmt.Errorf("failed to list containers: %w", err)
@@ -119,7 +120,7 @@ func runCleanupVolumes(cmd *cobra.Command, args []string) error {
 	// Remove orphaned volumes
 	removed := 0
 	for _, vol := range orphaned {
-		volCmd := exec.Command("docker", "volume", "rm", vol)
+		volCmd := system.DockerCommand("volume", "rm", vol)
 		if err := volCmd.Run(); err != nil {
 			fmt.Printf("Warning: failed to remove %s: %v\n", vol, err)
 		} else {
@@ -18,10 +18,11 @@ import (
 	"bufio"
 	"fmt"
 	"os"
-	"os/exec"
 	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/uprockcom/maestro/pkg/container"
+	"github.com/uprockcom/maestro/pkg/system"
 )
 
 var (
@@ -44,8 +45,8 @@ func init() {
 
 func runCleanup(cmd *cobra.Command, args []string) error {
 	// Get containers to remove
-	filter := config.Containers.Prefix
-	dockerCmd := exec.Command("docker", "ps", "-a", "--filter", fmt.Sprintf("name=%s", filter), "--format", "{{.Names}}\t{{.State}}")
+	filter := currentConfig().Containers.Prefix
+	dockerCmd := system.DockerCommand("ps", "-a", "--filter", fmt.Sprintf("name=%s", filter), "--format", "{{.Names}}\t{{.State}}")
 	output, err := dockerCmd.Output()
 	if err != nil {
 		return fmt.Errorf("failed to list containers: %w", err)
@@ -104,10 +105,17 @@ func runCleanup(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Capture hook details while containers are still running
+	deleteEvents := make(map[string]container.HookEvent)
+	for _, name := range toRemove {
+		deleteEvents[name] = container.CaptureHookEvent(container.EventPostDelete, name)
+	}
+
 	// Stop running containers if needed
 	for _, name := range running {
 		fmt.Printf("Stopping %s...\n", name)
-		stopCmd := exec.Command("docker", "stop", name)
+		container.FireLifecycleEvent(container.EventPreStop, name)
+		stopCmd := system.DockerCommand("stop", name)
 		if err := stopCmd.Run(); err != nil {
 			fmt.Printf("Warning: failed to stop %s: %v\n", name, err)
 		}
@@ -119,12 +127,14 @@ func runCleanup(cmd *cobra.Command, args []string) error {
 		fmt.Printf("Removing %s...\n", name)
 
 		// Remove container
-		rmCmd := exec.Command("docker", "rm", "-f", "-v", name)
+		rmCmd := system.DockerCommand("rm", "-f", "-v", name)
 		if err := rmCmd.Run(); err != nil {
 			fmt.Printf("Warning: failed to remove %s: %v\n", name, err)
 			continue
 		}
 
+		container.FireHook(deleteEvents[name])
+
 		// Remove associated named volumes
 		volumes := []string{
 			fmt.Sprintf("%s-npm", name),
@@ -134,7 +144,7 @@ func runCleanup(cmd *cobra.Command, args []string) error {
 		}
 
 		for _, vol := range volumes {
-			volCmd := exec.Command("docker", "volume", "rm", vol)
+			volCmd := system.DockerCommand("volume", "rm", vol)
 			output, err := volCmd.CombinedOutput()
 			if err != nil {
 				// Only warn if it's not a "volume not found" error
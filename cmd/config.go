@@ -0,0 +1,428 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/uprockcom/maestro/pkg/configfile"
+	"github.com/uprockcom/maestro/pkg/paths"
+)
+
+// Layout of a config bundle: the config file, plus one entry (file or
+// directory) per app under bundleAppsDir
+const (
+	bundleConfigName = "config.yml"
+	bundleAppsDir    = "apps"
+)
+
+var (
+	configExportNoApps bool
+	configImportForce  bool
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Export or import the maestro configuration",
+	Long: `Move a maestro setup between machines.
+
+'config export' bundles config.yml and the app binaries it references into a
+gzipped tarball; 'config import' restores them. Apps whose source path doesn't
+exist on the new machine are restored to ~/.maestro/apps and the config is
+pointed at the restored copy.
+
+Credentials are not included; run 'maestro auth' on the new machine.
+
+Examples:
+  maestro config export maestro-setup.tar.gz
+  maestro config import maestro-setup.tar.gz`,
+}
+
+var configExportCmd = &cobra.Command{
+	Use:   "export <file>",
+	Short: "Bundle config.yml and app binaries into a tarball",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runConfigExport,
+}
+
+var configImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Restore config.yml and app binaries from a bundle",
+	Long: `Restore a bundle created by 'maestro config export'.
+
+The current config.yml is replaced; you are asked first if it differs from the
+bundled one (use --force to skip the question). Apps whose configured source
+path exists on this machine keep using it. Missing ones are restored from the
+bundle to ~/.maestro/apps, or reported if the bundle has no copy.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runConfigImport,
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configExportCmd)
+	configCmd.AddCommand(configImportCmd)
+	configExportCmd.Flags().BoolVar(&configExportNoApps, "no-apps", false, "Only export app paths, not the binaries themselves")
+	configImportCmd.Flags().BoolVar(&configImportForce, "force", false, "Replace the existing config without asking")
+}
+
+func runConfigExport(cmd *cobra.Command, args []string) error {
+	configData, err := os.ReadFile(configFilePath())
+	if err != nil {
+		return fmt.Errorf("failed to read config: %w", err)
+	}
+
+	apps := currentConfig().Apps
+	if configExportNoApps {
+		apps = nil
+	}
+
+	out, err := os.Create(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to create bundle: %w", err)
+	}
+	defer out.Close()
+
+	missing, skipped, err := writeConfigBundle(out, configData, apps)
+	if err != nil {
+		os.Remove(args[0])
+		return fmt.Errorf("failed to write bundle: %w", err)
+	}
+	if err := out.Close(); err != nil {
+		return fmt.Errorf("failed to write bundle: %w", err)
+	}
+
+	for _, name := range missing {
+		fmt.Printf("⚠  App '%s' source not found (%s); only its path was exported\n", name, apps[name])
+	}
+	for _, p := range skipped {
+		fmt.Printf("⚠  Skipped %s: not a regular file or directory\n", p)
+	}
+	progressf("✅ Exported config and %d app(s) to %s\n", len(apps)-len(missing), args[0])
+	return nil
+}
+
+func runConfigImport(cmd *cobra.Command, args []string) error {
+	in, err := os.Open(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to open bundle: %w", err)
+	}
+	defer in.Close()
+
+	if err := paths.EnsureConfigDir(); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	// Unpack bundled apps next to where they'll live, so moving them is a rename
+	appsDir := filepath.Join(paths.GetConfigDir(), bundleAppsDir)
+	staging, err := os.MkdirTemp(paths.GetConfigDir(), ".import-")
+	if err != nil {
+		return fmt.Errorf("failed to create staging directory: %w", err)
+	}
+	defer os.RemoveAll(staging)
+
+	configData, bundled, err := readConfigBundle(in, staging)
+	if err != nil {
+		return fmt.Errorf("failed to read bundle: %w", err)
+	}
+	// Check before replacing anything: a config that fails to load would stop
+	// every later command, including another import
+	if err := validateConfigData(configData); err != nil {
+		return fmt.Errorf("bundled config is invalid: %w", err)
+	}
+
+	configPath := configFilePath()
+	if existing, err := os.ReadFile(configPath); err == nil && !bytes.Equal(existing, configData) && !configImportForce {
+		if !isTerminal(os.Stdin) {
+			return fmt.Errorf("%s already exists; use --force to replace it", configPath)
+		}
+		fmt.Printf("Replace existing %s? [y/N]: ", configPath)
+		response, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		response = strings.ToLower(strings.TrimSpace(response))
+		if response != "y" && response != "yes" {
+			fmt.Println("Import cancelled")
+			return nil
+		}
+	}
+	if err := os.WriteFile(configPath, configData, 0644); err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+	progressf("✓ Restored %s\n", configPath)
+
+	// Point apps whose source is missing here at the bundled copy
+	err = configfile.Update(configPath, func(doc *configfile.Document) error {
+		var apps map[string]string
+		if _, err := doc.Get("apps", &apps); err != nil {
+			return err
+		}
+		names := make([]string, 0, len(apps))
+		for name := range apps {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			source := apps[name]
+			if _, err := os.Stat(expandPath(source)); err == nil {
+				progressf("✓ App '%s' uses existing %s\n", name, source)
+				continue
+			}
+			if !bundled[name] {
+				fmt.Printf("⚠  App '%s' source not found (%s) and not in the bundle; fix with 'maestro app add %s <path>'\n", name, source, name)
+				continue
+			}
+			restored, err := restoreBundledApp(staging, appsDir, name)
+			if err != nil {
+				return err
+			}
+			apps[name] = restored
+			progressf("✓ App '%s' restored to %s\n", name, restored)
+		}
+		if len(apps) == 0 {
+			return nil
+		}
+		return doc.Set("apps", apps)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to restore apps: %w", err)
+	}
+
+	if err := ReloadConfig(); err != nil {
+		return fmt.Errorf("imported config is invalid: %w", err)
+	}
+	progressf("✅ Imported %s\n", args[0])
+	return nil
+}
+
+// configFilePath returns the config file maestro reads: --config if given,
+// otherwise the default location
+func configFilePath() string {
+	if cfgFile != "" {
+		return cfgFile
+	}
+	return paths.ConfigFile()
+}
+
+// validateConfigData checks that config file contents would load: they parse,
+// and both the top-level prefix and the active profile's are valid
+func validateConfigData(data []byte) error {
+	v := viper.New()
+	setConfigDefaults(v)
+	v.SetConfigType("yaml")
+	if err := v.ReadConfig(bytes.NewReader(data)); err != nil {
+		return fmt.Errorf("failed to read config: %w", err)
+	}
+	c := &Config{}
+	if err := v.Unmarshal(c); err != nil {
+		return fmt.Errorf("failed to parse config: %w", err)
+	}
+
+	if _, err := withProfile(c, ""); err != nil {
+		return err
+	}
+	_, err := withProfile(c, c.ActiveProfile)
+	return err
+}
+
+// restoreBundledApp moves an app unpacked into staging to appsDir, replacing
+// any previous copy, and returns its new path
+func restoreBundledApp(staging, appsDir, name string) (string, error) {
+	if err := os.MkdirAll(appsDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", appsDir, err)
+	}
+	dest := filepath.Join(appsDir, name)
+	if err := os.RemoveAll(dest); err != nil {
+		return "", fmt.Errorf("failed to replace %s: %w", dest, err)
+	}
+	if err := os.Rename(filepath.Join(staging, name), dest); err != nil {
+		return "", fmt.Errorf("failed to restore app %s: %w", name, err)
+	}
+	return dest, nil
+}
+
+// writeConfigBundle writes a gzipped tarball holding configData and a copy of
+// each app's source. Apps whose source doesn't exist are left out and returned,
+// along with the paths of entries inside the sources that couldn't be bundled.
+func writeConfigBundle(w io.Writer, configData []byte, apps map[string]string) (missing, skipped []string, err error) {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	err = tw.WriteHeader(&tar.Header{
+		Name:     bundleConfigName,
+		Mode:     0644,
+		Size:     int64(len(configData)),
+		Typeflag: tar.TypeReg,
+	})
+	if err == nil {
+		_, err = tw.Write(configData)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	names := make([]string, 0, len(apps))
+	for name := range apps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		// WalkDir doesn't follow a symlinked root, so resolve it first
+		source, err := filepath.EvalSymlinks(expandPath(apps[name]))
+		if err != nil {
+			missing = append(missing, name)
+			continue
+		}
+		appSkipped, err := addToBundle(tw, source, path.Join(bundleAppsDir, name))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to add app %s: %w", name, err)
+		}
+		skipped = append(skipped, appSkipped...)
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, nil, err
+	}
+	return missing, skipped, gz.Close()
+}
+
+// addToBundle adds the file or directory tree at source under name, returning
+// the paths of entries that aren't regular files or directories, which are left out
+func addToBundle(tw *tar.Writer, source, name string) (skipped []string, err error) {
+	err = filepath.WalkDir(source, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		// Sockets, devices, and symlinks have no place in an app bundle
+		if !info.Mode().IsRegular() && !info.IsDir() {
+			skipped = append(skipped, p)
+			return nil
+		}
+
+		rel, err := filepath.Rel(source, p)
+		if err != nil {
+			return err
+		}
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		header.Name = path.Join(name, filepath.ToSlash(rel))
+		if info.IsDir() {
+			header.Name += "/"
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	return skipped, err
+}
+
+// readConfigBundle reads a bundle written by writeConfigBundle, unpacking the
+// apps into appsDir. It returns the config data and the set of app names found.
+func readConfigBundle(r io.Reader, appsDir string) ([]byte, map[string]bool, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("not a maestro config bundle: %w", err)
+	}
+	defer gz.Close()
+	tr := tar.NewReader(gz)
+
+	var configData []byte
+	apps := make(map[string]bool)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if header.Name == bundleConfigName {
+			if configData, err = io.ReadAll(tr); err != nil {
+				return nil, nil, err
+			}
+			continue
+		}
+
+		rel, ok := strings.CutPrefix(path.Clean(header.Name), bundleAppsDir+"/")
+		if !ok || !filepath.IsLocal(rel) {
+			return nil, nil, fmt.Errorf("unexpected entry %q in bundle", header.Name)
+		}
+		apps[strings.SplitN(rel, "/", 2)[0]] = true
+
+		target := filepath.Join(appsDir, filepath.FromSlash(rel))
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return nil, nil, err
+			}
+		case tar.TypeReg:
+			if err := extractBundleFile(tr, target, header.FileInfo().Mode().Perm()); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	if configData == nil {
+		return nil, nil, fmt.Errorf("bundle has no %s", bundleConfigName)
+	}
+	return configData, apps, nil
+}
+
+// extractBundleFile writes the current tar entry to target with mode perm
+func extractBundleFile(r io.Reader, target string, perm fs.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,182 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestConfigBundleRoundTrip(t *testing.T) {
+	src := t.TempDir()
+	binary := filepath.Join(src, "mytool")
+	if err := os.WriteFile(binary, []byte("#!/bin/sh\necho hi\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	dir := filepath.Join(src, "sdk")
+	if err := os.MkdirAll(filepath.Join(dir, "bin"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "bin", "run"), []byte("run"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	// A symlinked app is bundled as its target; a symlink inside an app is left out
+	linked := filepath.Join(src, "linked")
+	if err := os.Symlink(binary, linked); err != nil {
+		t.Fatal(err)
+	}
+	innerLink := filepath.Join(dir, "bin", "run-link")
+	if err := os.Symlink("run", innerLink); err != nil {
+		t.Fatal(err)
+	}
+
+	configData := []byte("containers:\n  prefix: maestro-\n")
+	apps := map[string]string{
+		"mytool": binary,
+		"linked": linked,
+		"sdk":    dir,
+		"gone":   filepath.Join(src, "missing"),
+	}
+
+	var bundle bytes.Buffer
+	missing, skipped, err := writeConfigBundle(&bundle, configData, apps)
+	if err != nil {
+		t.Fatalf("writeConfigBundle() error = %v", err)
+	}
+	if want := []string{"gone"}; !reflect.DeepEqual(missing, want) {
+		t.Errorf("missing = %q, want %q", missing, want)
+	}
+	if want := []string{innerLink}; !reflect.DeepEqual(skipped, want) {
+		t.Errorf("skipped = %q, want %q", skipped, want)
+	}
+
+	dest := t.TempDir()
+	gotConfig, bundled, err := readConfigBundle(&bundle, dest)
+	if err != nil {
+		t.Fatalf("readConfigBundle() error = %v", err)
+	}
+	if !bytes.Equal(gotConfig, configData) {
+		t.Errorf("config = %q, want %q", gotConfig, configData)
+	}
+	if want := map[string]bool{"mytool": true, "linked": true, "sdk": true}; !reflect.DeepEqual(bundled, want) {
+		t.Errorf("bundled apps = %v, want %v", bundled, want)
+	}
+
+	info, err := os.Stat(filepath.Join(dest, "mytool"))
+	if err != nil {
+		t.Fatalf("binary not restored: %v", err)
+	}
+	if info.Mode().Perm()&0100 == 0 {
+		t.Errorf("binary lost its executable bit: %v", info.Mode())
+	}
+	if data, err := os.ReadFile(filepath.Join(dest, "sdk", "bin", "run")); err != nil || string(data) != "run" {
+		t.Errorf("directory app not restored: %q, %v", data, err)
+	}
+}
+
+func TestReadConfigBundleRejectsEscapes(t *testing.T) {
+	for _, name := range []string{"apps/../../evil", "/etc/passwd", "apps//../evil", "other/file"} {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			gz := gzip.NewWriter(&buf)
+			tw := tar.NewWriter(gz)
+			tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: 1, Typeflag: tar.TypeReg})
+			tw.Write([]byte("x"))
+			tw.Close()
+			gz.Close()
+
+			if _, _, err := readConfigBundle(&buf, t.TempDir()); err == nil {
+				t.Errorf("readConfigBundle() accepted entry %q", name)
+			}
+		})
+	}
+}
+
+func TestReadConfigBundleRequiresConfig(t *testing.T) {
+	var buf bytes.Buffer
+	if _, _, err := writeConfigBundle(&buf, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	// An empty config is still a config; a bundle with no entry at all is not
+	if _, _, err := readConfigBundle(&buf, t.TempDir()); err != nil {
+		t.Errorf("readConfigBundle() error = %v for an empty config", err)
+	}
+
+	if _, _, err := readConfigBundle(bytes.NewReader([]byte("not gzip")), t.TempDir()); err == nil {
+		t.Error("readConfigBundle() accepted a non-gzip file")
+	}
+}
+
+func TestValidateConfigData(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr bool
+	}{
+		{"empty uses defaults", "", false},
+		{"valid prefix", "containers:\n  prefix: work-\n", false},
+		{"invalid prefix", "containers:\n  prefix: -bad prefix\n", true},
+		{"invalid active profile prefix", "active_profile: broken\nprofiles:\n  broken:\n    prefix: bad/\n", true},
+		{"unknown active profile", "active_profile: nope\n", true},
+		{"not yaml", "containers: [\n", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateConfigData([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateConfigData() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfigImportKeepsConfigOnInvalidBundle(t *testing.T) {
+	origFile, origForce := cfgFile, configImportForce
+	t.Cleanup(func() { cfgFile, configImportForce = origFile, origForce })
+	t.Setenv("HOME", t.TempDir())
+
+	original := []byte("containers:\n  prefix: maestro-\n")
+	cfgFile = filepath.Join(t.TempDir(), "config.yml")
+	if err := os.WriteFile(cfgFile, original, 0644); err != nil {
+		t.Fatal(err)
+	}
+	configImportForce = true
+
+	var bundle bytes.Buffer
+	if _, _, err := writeConfigBundle(&bundle, []byte("containers:\n  prefix: -bad prefix\n"), nil); err != nil {
+		t.Fatal(err)
+	}
+	bundlePath := filepath.Join(t.TempDir(), "bundle.tar.gz")
+	if err := os.WriteFile(bundlePath, bundle.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := runConfigImport(nil, []string{bundlePath}); err == nil {
+		t.Fatal("runConfigImport() accepted a config with an invalid prefix")
+	}
+	data, err := os.ReadFile(cfgFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, original) {
+		t.Errorf("config file changed to:\n%s", data)
+	}
+}
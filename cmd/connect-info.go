@@ -0,0 +1,191 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/uprockcom/maestro/pkg/container"
+	"github.com/uprockcom/maestro/pkg/system"
+)
+
+// containerWorkspace is where the project lives inside every container
+const containerWorkspace = "/workspace"
+
+var connectInfoJSON bool
+
+var connectInfoCmd = &cobra.Command{
+	Use:   "connect-info [name]",
+	Short: "Show how to attach to containers, without attaching",
+	Long: `Show, for every container (or just the named one), the docker command that
+'maestro connect' would run, its tmux sessions and windows, the workspace path,
+branch, and whether it is running. Nothing is attached.
+
+Use --json for editor and terminal integrations, e.g. a VS Code or Neovim
+container switcher. Each session carries its own attach command.
+
+Examples:
+  maestro connect-info
+  maestro connect-info --json
+  maestro connect-info feat-auth-1 --json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runConnectInfo,
+}
+
+func init() {
+	rootCmd.AddCommand(connectInfoCmd)
+	connectInfoCmd.Flags().BoolVar(&connectInfoJSON, "json", false, "Print a JSON array instead of a table")
+}
+
+// connectInfo describes how to attach to one container
+type connectInfo struct {
+	Name          string               `json:"name"`
+	ShortName     string               `json:"short_name"`
+	State         string               `json:"state"`
+	Running       bool                 `json:"running"`
+	Branch        string               `json:"branch"`
+	Workspace     string               `json:"workspace"`
+	AttachCommand []string             `json:"attach_command"` // docker argv, as 'maestro connect' runs it
+	Tmux          bool                 `json:"tmux"`           // Whether AttachCommand attaches to tmux
+	Sessions      []connectInfoSession `json:"sessions"`
+}
+
+// connectInfoSession is a tmux session in a container
+type connectInfoSession struct {
+	Name          string              `json:"name"`
+	AttachCommand []string            `json:"attach_command"`
+	Windows       []connectInfoWindow `json:"windows"`
+}
+
+type connectInfoWindow struct {
+	Index int    `json:"index"`
+	Name  string `json:"name"`
+}
+
+func runConnectInfo(cmd *cobra.Command, args []string) error {
+	containers, err := container.GetAllContainers(currentConfig().Containers.Prefix)
+	if err != nil {
+		return fmt.Errorf("failed to list containers: %w", err)
+	}
+
+	if len(args) > 0 {
+		containerName := resolveContainerName(args[0])
+		var match []container.Info
+		for _, c := range containers {
+			if c.Name == containerName {
+				match = append(match, c)
+			}
+		}
+		if len(match) == 0 {
+			return fmt.Errorf("container %s not found", args[0])
+		}
+		containers = match
+	}
+
+	// Inspect containers concurrently, keeping their order
+	infos := make([]connectInfo, len(containers))
+	var wg sync.WaitGroup
+	for i, c := range containers {
+		wg.Add(1)
+		go func(idx int, c container.Info) {
+			defer wg.Done()
+			infos[idx] = gatherConnectInfo(c)
+		}(i, c)
+	}
+	wg.Wait()
+
+	if connectInfoJSON {
+		out, err := json.MarshalIndent(infos, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode connect info: %w", err)
+		}
+		fmt.Println(string(out))
+		return nil
+	}
+
+	if len(infos) == 0 {
+		fmt.Println("No maestro containers found.")
+		return nil
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tSTATE\tBRANCH\tSESSIONS\tATTACH")
+	for _, info := range infos {
+		sessions := "-"
+		for i, s := range info.Sessions {
+			if i == 0 {
+				sessions = s.Name
+			} else {
+				sessions += "," + s.Name
+			}
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", info.ShortName, info.State, orDash(info.Branch), sessions,
+			shellCommand(info.AttachCommand...))
+	}
+	return w.Flush()
+}
+
+// gatherConnectInfo works out how 'maestro connect' would attach to c
+func gatherConnectInfo(c container.Info) connectInfo {
+	info := connectInfo{
+		Name:      c.Name,
+		ShortName: c.ShortName,
+		State:     c.Status,
+		Running:   c.Status == "running",
+		Branch:    c.Branch,
+		Workspace: containerWorkspace,
+		Sessions:  []connectInfoSession{},
+	}
+
+	// Same choice as resolveConnectArgs, minus the warnings
+	customCommand := container.GetConnectCommand(c.Name)
+	switch {
+	case customCommand != "":
+		info.AttachCommand = container.ConnectArgs(c.Name, customCommand)
+	case info.Running && !container.HasTmux(c.Name):
+		info.AttachCommand = container.ShellArgs(c.Name)
+	default:
+		info.AttachCommand = container.ConnectArgs(c.Name, "")
+		info.Tmux = true
+	}
+	info.AttachCommand = append([]string{system.DockerBinary()}, info.AttachCommand...)
+
+	// Only running containers with tmux have sessions to list
+	shellOnly := customCommand == "" && !info.Tmux
+	if !info.Running || shellOnly {
+		return info
+	}
+	windows, err := container.ListTmuxWindows(c.Name)
+	if err != nil {
+		return info
+	}
+	for _, w := range windows {
+		n := len(info.Sessions)
+		if n == 0 || info.Sessions[n-1].Name != w.Session {
+			attach, _ := container.TmuxAttachArgs(c.Name, w.Session, nil)
+			info.Sessions = append(info.Sessions, connectInfoSession{
+				Name:          w.Session,
+				AttachCommand: append([]string{system.DockerBinary()}, attach...),
+			})
+			n++
+		}
+		info.Sessions[n-1].Windows = append(info.Sessions[n-1].Windows, connectInfoWindow{Index: w.Index, Name: w.Name})
+	}
+	return info
+}
@@ -18,11 +18,13 @@ import (
 	"bufio"
 	"fmt"
 	"os"
-	"os/exec"
+	"path/filepath"
 	"strconv"
 	"strings"
 
 	"github.com/uprockcom/maestro/pkg/container"
+	"github.com/uprockcom/maestro/pkg/paths"
+	"github.com/uprockcom/maestro/pkg/system"
 	"github.com/spf13/cobra"
 )
 
@@ -33,30 +35,106 @@ var connectCmd = &cobra.Command{
 
 If no name is provided:
   - Auto-connects if only one container is running
-  - Shows interactive selection if multiple containers are running`,
+  - Shows interactive selection if multiple containers are running
+
+Use "-" as the name to reconnect to the most recently used container. Any part
+of a name works too: "maestro connect auth" finds feat-auth-login-1, and you
+pick from a list if several containers match.
+
+Containers without tmux (e.g. custom images) get an interactive shell instead.
+If Claude isn't running in the container, you're offered a restart first, and
+if tmux itself was killed you're offered a fresh session.
+
+Use --run to type a command into the shell window (window 1) before attaching,
+and add --no-attach to run it and return immediately.
+
+Use --session to attach to (and --run into) a tmux session other than "main";
+if it doesn't exist you're offered to create it. Use --tmux-args to pass extra
+attach options: -d, -E, -r, -x, -c <dir>, -f <flags>, and the socket options
+-L <name> and -S <path>. The session is checked before attaching so a bad
+option fails with a clear error.
+
+Use --print-cmd to print the docker command that would be run instead of
+running it, e.g. to use in your own scripts or aliases.
+
+If the name matches no container, the running containers are listed to pick
+from instead. Use --no-interactive (or run without a terminal) to fail instead.
+
+Examples:
+  maestro connect feat-auth-1 --run "npm install"
+  maestro connect feat-auth-1 --run "npm test" --no-attach
+  maestro connect feat-auth-1 --tmux-args "-d"
+  maestro connect feat-auth-1 --session scratch --tmux-args "-L other"
+  maestro connect feat-auth-1 --print-cmd`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runConnect,
 }
 
+var lastCmd = &cobra.Command{
+	Use:   "last",
+	Short: "Reconnect to the most recently used container",
+	Long:  `Reconnect to the container you last connected to. Same as 'maestro connect -'.`,
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runConnect(cmd, []string{"-"})
+	},
+}
+
+var (
+	connectRun      string
+	connectNoAttach bool
+	connectSession  string
+	connectTmuxArgs string
+	connectPrintCmd bool
+	connectNoPicker bool
+)
+
 func init() {
 	rootCmd.AddCommand(connectCmd)
+	rootCmd.AddCommand(lastCmd)
+	connectCmd.Flags().StringVar(&connectRun, "run", "", "Command to run in the shell window after connecting")
+	connectCmd.Flags().BoolVar(&connectNoAttach, "no-attach", false, "With --run, run the command without attaching")
+	connectCmd.Flags().StringVar(&connectSession, "session", "main", "tmux session to attach to")
+	connectCmd.Flags().StringVar(&connectTmuxArgs, "tmux-args", "", "Extra options passed to tmux attach (e.g. \"-d\" or \"-L other\")")
+	connectCmd.Flags().BoolVar(&connectPrintCmd, "print-cmd", false, "Print the docker command instead of running it")
+	connectCmd.Flags().BoolVar(&connectNoPicker, "no-interactive", false, "Fail if the name matches no container instead of offering a list")
 }
 
 func runConnect(cmd *cobra.Command, args []string) error {
+	if connectNoAttach && connectRun == "" {
+		return fmt.Errorf("--no-attach requires --run")
+	}
+	if _, err := container.TmuxAttachArgs("", connectSession, strings.Fields(connectTmuxArgs)); err != nil {
+		return err
+	}
+	if connectPrintCmd {
+		if connectRun != "" {
+			return fmt.Errorf("--print-cmd can't be combined with --run")
+		}
+		// Keep stdout to just the command so it can be captured
+		quiet = true
+	}
+
 	var containerName string
 
-	// If no argument provided, show interactive selection
-	if len(args) == 0 {
-		// Check both configured prefix and legacy "mcl-" prefix for backward compatibility
-		containers, err := container.GetRunningContainers(config.Containers.Prefix)
-		if err != nil {
-			return fmt.Errorf("failed to get running containers: %w", err)
+	// "-" reconnects to the last-used container, falling back to the picker
+	if len(args) == 1 && args[0] == "-" {
+		args = nil
+		if last := readLastConnected(); last != "" && isContainerRunning(last) {
+			containerName = last
+		} else if last != "" {
+			progressf("Last container %s is no longer running.\n", container.GetShortName(last, currentConfig().Containers.Prefix))
+		} else {
+			progressln("No recently used container.")
 		}
+	}
 
-		// Also check legacy prefix if different from configured
-		if config.Containers.Prefix != "mcl-" {
-			legacyContainers, _ := container.GetRunningContainers("mcl-")
-			containers = append(containers, legacyContainers...)
+	if containerName == "" && len(args) == 0 {
+		// If no argument provided, show interactive selection
+		// Includes the legacy prefix, per scan_legacy_prefix
+		containers, err := container.GetRunningContainersWithLegacy(currentConfig().Containers.Prefix)
+		if err != nil {
+			return fmt.Errorf("failed to get running containers: %w", err)
 		}
 
 		if len(containers) == 0 {
@@ -66,7 +144,7 @@ func runConnect(cmd *cobra.Command, args []string) error {
 		if len(containers) == 1 {
 			// Auto-connect to the only container
 			containerName = containers[0].Name
-			fmt.Printf("Auto-connecting to %s\n", containers[0].ShortName)
+			progressf("Auto-connecting to %s\n", containers[0].ShortName)
 		} else {
 			// Multiple containers - show selection
 			selected, err := selectContainer(containers)
@@ -75,13 +153,13 @@ func runConnect(cmd *cobra.Command, args []string) error {
 			}
 			containerName = selected.Name
 		}
-	} else {
+	} else if containerName == "" {
 		// Argument provided - resolve container name
 		shortName := args[0]
-		containerName = resolveContainerName(shortName)
+		containerName = resolveContainerNameFuzzy(shortName)
 
 		// Check if container exists and is running
-		checkCmd := exec.Command("docker", "ps", "--filter", fmt.Sprintf("name=%s", containerName), "--format", "{{.State}}")
+		checkCmd := system.DockerCommand("ps", "--filter", fmt.Sprintf("name=%s", containerName), "--format", "{{.State}}")
 		output, err := checkCmd.Output()
 		if err != nil {
 			return fmt.Errorf("failed to check container status: %w", err)
@@ -89,19 +167,147 @@ func runConnect(cmd *cobra.Command, args []string) error {
 
 		state := strings.TrimSpace(string(output))
 		if state == "" {
-			return fmt.Errorf("container %s not found", shortName)
+			selected, err := pickAfterNoMatch(shortName)
+			if err != nil {
+				return err
+			}
+			containerName, state = selected.Name, "running"
+		}
+		if state == "paused" {
+			return fmt.Errorf("container %s is paused; resume it with 'maestro unpause %s'", shortName, shortName)
 		}
 		if state != "running" {
 			return fmt.Errorf("container %s is not running (status: %s)", shortName, state)
 		}
 	}
 
-	fmt.Printf("Connecting to %s...\n", containerName)
-	fmt.Println("Detach with: Ctrl+b d")
-	fmt.Println("Switch windows: Ctrl+b 0 (Claude), Ctrl+b 1 (shell)")
+	if connectRun != "" {
+		if err := runInShellWindow(containerName, connectRun); err != nil {
+			return err
+		}
+		if connectNoAttach {
+			progressf("✓ Sent to shell window of %s: %s\n", containerName, connectRun)
+			return nil
+		}
+	}
+
+	return attachToContainer(containerName)
+}
+
+// runInShellWindow types command into the shell window (window 1) of the
+// --session tmux session and selects it, recreating the window if it was
+// closed. The command is passed as a single argument and sent literally, so
+// it never goes through a shell on the way in.
+func runInShellWindow(containerName, command string) error {
+	if !container.HasTmux(containerName) || container.GetConnectCommand(containerName) != "" {
+		return fmt.Errorf("--run needs the container's tmux session")
+	}
+
+	// tmux treats an argument ending in ";" as a command separator unless escaped
+	literal := command
+	if strings.HasSuffix(literal, ";") {
+		literal = strings.TrimSuffix(literal, ";") + `\;`
+	}
+
+	sendKeys := func() error {
+		if err := system.DockerCommand("exec", "-u", "node", containerName,
+			"tmux", "send-keys", "-t", connectSession+":1", "-l", literal).Run(); err != nil {
+			return err
+		}
+		return system.DockerCommand("exec", "-u", "node", containerName,
+			"tmux", "send-keys", "-t", connectSession+":1", "Enter").Run()
+	}
+
+	if err := sendKeys(); err != nil {
+		// The shell window may have been closed; recreate it and retry
+		newWinCmd := system.DockerCommand("exec", "-u", "node", containerName,
+			"tmux", "new-window", "-t", connectSession+":1", "-n", "shell", "-c", "/workspace")
+		if err := newWinCmd.Run(); err != nil {
+			return fmt.Errorf("failed to create shell window: %w", err)
+		}
+		if err := sendKeys(); err != nil {
+			return fmt.Errorf("failed to send command to shell window: %w", err)
+		}
+	}
+
+	system.DockerCommand("exec", "-u", "node", containerName,
+		"tmux", "select-window", "-t", connectSession+":1").Run()
+	return nil
+}
+
+// customAttachArgs builds the attach command for --session and --tmux-args,
+// first checking that tmux can find the session with those options so a bad
+// passthrough fails with a clear message instead of a broken attach
+func customAttachArgs(containerName string) ([]string, error) {
+	args, err := container.TmuxAttachArgs(containerName, connectSession, strings.Fields(connectTmuxArgs))
+	if err != nil {
+		return nil, err
+	}
+
+	// Reuse the socket options (everything between "tmux" and "attach")
+	check := []string{"exec", containerName, "tmux"}
+	for _, arg := range args[4:] {
+		if arg == "attach" {
+			break
+		}
+		check = append(check, arg)
+	}
+	check = append(check, "has-session", "-t", connectSession)
+
+	if output, err := system.DockerCommand(check...).CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("tmux session %q not found with the given options: %s", connectSession, strings.TrimSpace(string(output)))
+	}
+	return args, nil
+}
+
+// attachToContainer connects the terminal to a container, using its custom
+// connect command if one is set and the tmux session otherwise. With
+// --print-cmd the command is printed instead.
+func attachToContainer(containerName string) error {
+	args, tmux, err := resolveConnectArgs(containerName)
+	if err != nil {
+		return err
+	}
+	if connectPrintCmd {
+		fmt.Println(shellCommand(append([]string{system.DockerBinary()}, args...)...))
+		return nil
+	}
+
+	// tmux attach fails with a bare "no server running" or "can't find
+	// session" when tmux was killed, so offer to start it again
+	if tmux && connectTmuxArgs == "" {
+		if err := offerTmuxRecovery(containerName); err != nil {
+			return err
+		}
+	}
+
+	// Attaching to a session whose Claude died shows a dead window with no
+	// explanation, so offer a restart first
+	if tmux && connectSession == "main" && !container.IsClaudeRunning(containerName) {
+		shortName := container.GetShortName(containerName, currentConfig().Containers.Prefix)
+		fmt.Print("Claude isn't running in this container — restart it? [y/N]: ")
+		reader := bufio.NewReader(os.Stdin)
+		response, _ := reader.ReadString('\n')
+		response = strings.ToLower(strings.TrimSpace(response))
+		if response == "y" || response == "yes" {
+			if err := performClaudeRestart(containerName, shortName, connectSession); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			}
+		}
+	}
 
-	// Connect to tmux session
-	connectCmd := exec.Command("docker", "exec", "-it", containerName, "tmux", "attach", "-t", "main")
+	progressf("Connecting to %s...\n", containerName)
+	if tmux {
+		progressln("Detach with: Ctrl+b d")
+		progressln("Switch windows: Ctrl+b 0 (Claude), Ctrl+b 1 (shell)")
+	}
+
+	recordLastConnected(containerName)
+
+	// Apply shell fixes to containers created before they existed (no-op otherwise)
+	container.EnsureShellConfig(containerName)
+
+	connectCmd := system.DockerCommand(args...)
 	connectCmd.Stdin = os.Stdin
 	connectCmd.Stdout = os.Stdout
 	connectCmd.Stderr = os.Stderr
@@ -109,6 +315,109 @@ func runConnect(cmd *cobra.Command, args []string) error {
 	return connectCmd.Run()
 }
 
+// offerTmuxRecovery checks the --session tmux session and, if it or the
+// whole tmux server is gone, offers to start a fresh one. Declining
+// returns an error, since there is nothing to attach to.
+func offerTmuxRecovery(containerName string) error {
+	var question, problem string
+	switch container.GetTmuxState(containerName, connectSession) {
+	case container.TmuxNoServer:
+		problem = "tmux server is not running"
+		question = "The tmux server in this container has died — start a fresh session? [y/N]: "
+	case container.TmuxNoSession:
+		problem = fmt.Sprintf("tmux session %q does not exist", connectSession)
+		question = "The tmux session in this container is gone — recreate it? [y/N]: "
+	default:
+		// Running, or unknown: let attach report whatever is wrong
+		return nil
+	}
+
+	fmt.Print(question)
+	reader := bufio.NewReader(os.Stdin)
+	response, _ := reader.ReadString('\n')
+	response = strings.ToLower(strings.TrimSpace(response))
+	if response != "y" && response != "yes" {
+		shortName := container.GetShortName(containerName, currentConfig().Containers.Prefix)
+		return fmt.Errorf("%s in %s; run 'maestro restart %s' to recover", problem, shortName, shortName)
+	}
+
+	if err := ensureTmuxSession(containerName, connectSession); err != nil {
+		return err
+	}
+	progressln("✓ Started a new tmux session")
+	return nil
+}
+
+// resolveConnectArgs returns the docker arguments used to connect to a
+// container, and whether they attach to its tmux session
+func resolveConnectArgs(containerName string) ([]string, bool, error) {
+	customCommand := container.GetConnectCommand(containerName)
+	if customCommand != "" {
+		return container.ConnectArgs(containerName, customCommand), false, nil
+	}
+
+	if !container.HasTmux(containerName) {
+		fmt.Fprintln(os.Stderr, "Warning: tmux is not available in this container; opening a shell instead")
+		return container.ShellArgs(containerName), false, nil
+	}
+
+	if connectSession != "main" || connectTmuxArgs != "" {
+		args, err := customAttachArgs(containerName)
+		return args, true, err
+	}
+	return container.ConnectArgs(containerName, ""), true, nil
+}
+
+// lastConnectedFile stores the name of the most recently connected container
+func lastConnectedFile() string {
+	return filepath.Join(paths.StateDir(), "last-connected")
+}
+
+// recordLastConnected remembers containerName for 'maestro connect -'.
+// Failures are ignored; this is a convenience only.
+func recordLastConnected(containerName string) {
+	if err := paths.EnsureStateDir(); err != nil {
+		return
+	}
+	os.WriteFile(lastConnectedFile(), []byte(containerName+"\n"), 0644)
+}
+
+// readLastConnected returns the most recently connected container, or "" if none
+func readLastConnected() string {
+	data, err := os.ReadFile(lastConnectedFile())
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// isContainerRunning reports whether the named container exists and is running
+func isContainerRunning(containerName string) bool {
+	output, err := system.DockerCommand("inspect", "-f", "{{.State.Status}}", containerName).Output()
+	return err == nil && strings.TrimSpace(string(output)) == "running"
+}
+
+// pickAfterNoMatch handles a name that matched no container: on a terminal
+// the running containers are offered to pick from, so a typo doesn't mean
+// starting over. With --no-interactive, --print-cmd, or no terminal it fails.
+func pickAfterNoMatch(shortName string) (container.Info, error) {
+	notFound := fmt.Errorf("container %s not found", shortName)
+	if connectNoPicker || connectPrintCmd || !isTerminal(os.Stdin) {
+		return container.Info{}, notFound
+	}
+
+	containers, err := container.GetRunningContainersWithLegacy(currentConfig().Containers.Prefix)
+	if err != nil {
+		return container.Info{}, notFound
+	}
+	if len(containers) == 0 {
+		return container.Info{}, fmt.Errorf("%w, and no containers are running", notFound)
+	}
+
+	fmt.Printf("No container matches %q.\n", shortName)
+	return selectContainer(containers)
+}
+
 // selectContainer shows an interactive menu to select a container
 func selectContainer(containers []container.Info) (container.Info, error) {
 	// Display containers with numbers using unified display
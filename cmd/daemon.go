@@ -15,10 +15,14 @@
 package cmd
 
 import (
+	"bufio"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
+	"os/signal"
 	"path/filepath"
+	"regexp"
 	"runtime"
 	"strconv"
 	"strings"
@@ -26,7 +30,10 @@ import (
 	"time"
 
 	"github.com/uprockcom/maestro/assets"
+	"github.com/uprockcom/maestro/pkg/api"
+	"github.com/uprockcom/maestro/pkg/configfile"
 	"github.com/uprockcom/maestro/pkg/daemon"
+	"github.com/uprockcom/maestro/pkg/paths"
 	"github.com/spf13/cobra"
 )
 
@@ -44,9 +51,18 @@ Commands:
   mcl daemon start   - Start the daemon
   mcl daemon stop    - Stop the daemon
   mcl daemon status  - Show daemon status
-  mcl daemon logs    - View daemon logs`,
+  mcl daemon logs    - View daemon logs
+
+Control API:
+  mcl daemon --socket /path/to/maestro.sock
+  Serves a JSON control API on a Unix socket in the foreground.
+  See docs/API.md for the message format.`,
+	Args: cobra.NoArgs,
+	RunE: runDaemonSocket,
 }
 
+var daemonSocketPath string
+
 var daemonStartCmd = &cobra.Command{
 	Use:   "start",
 	Short: "Start the MCL daemon",
@@ -68,23 +84,53 @@ var daemonStatusCmd = &cobra.Command{
 var daemonLogsCmd = &cobra.Command{
 	Use:   "logs",
 	Short: "View daemon logs",
-	RunE:  runDaemonLogs,
+	Long: `Show the last 50 lines of the daemon log.
+
+Use --grep to show only lines matching a regular expression, and --invert to
+show only lines that don't match. The last 50 lines are taken after filtering.
+
+Examples:
+  mcl daemon logs --grep "token|refresh"
+  mcl daemon logs --grep "(?i)error"
+  mcl daemon logs --grep "check" --invert`,
+	Args: cobra.NoArgs,
+	RunE: runDaemonLogs,
 }
 
+var daemonNagCmd = &cobra.Command{
+	Use:   "nag <on|off>",
+	Short: "Turn the 'start the daemon' reminder on or off",
+	Long: `Turn the reminder that 'maestro list' and the TUI show when the daemon isn't
+running on or off. This sets daemon.show_nag in the config file.`,
+	Args:      cobra.ExactArgs(1),
+	ValidArgs: []string{"on", "off"},
+	RunE:      runDaemonNag,
+}
+
+var (
+	daemonLogsGrep   string
+	daemonLogsInvert bool
+)
+
 func init() {
 	rootCmd.AddCommand(daemonCmd)
 	daemonCmd.AddCommand(daemonStartCmd)
 	daemonCmd.AddCommand(daemonStopCmd)
 	daemonCmd.AddCommand(daemonStatusCmd)
 	daemonCmd.AddCommand(daemonLogsCmd)
+	daemonCmd.AddCommand(daemonNagCmd)
+
+	daemonCmd.Flags().StringVar(&daemonSocketPath, "socket", "", "Serve the JSON control API on this Unix socket")
+	daemonLogsCmd.Flags().StringVar(&daemonLogsGrep, "grep", "", "Only show lines matching this regular expression")
+	daemonLogsCmd.Flags().BoolVar(&daemonLogsInvert, "invert", false, "With --grep, only show lines that don't match")
 }
 
 func runDaemonStart(cmd *cobra.Command, args []string) error {
-	authDir := expandPath(config.Claude.AuthPath)
-	pidFile := filepath.Join(authDir, "daemon.pid")
+	authDir := expandPath(currentConfig().Claude.AuthPath)
+	pidFile := filepath.Join(authDir, daemon.PIDFile)
 
 	// Check if already running
-	if pid, running := isDaemonRunning(pidFile); running {
+	if pid, running := daemon.IsRunning(pidFile); running {
 		fmt.Printf("Daemon is already running (PID %d)\n", pid)
 		return nil
 	}
@@ -92,7 +138,7 @@ func runDaemonStart(cmd *cobra.Command, args []string) error {
 	fmt.Println("Starting MCL daemon...")
 
 	// Check notification support if notifications are enabled
-	if config.Daemon.Notifications.Enabled {
+	if currentConfig().Daemon.Notifications.Enabled {
 		if err := checkNotificationSupport(); err != nil {
 			fmt.Printf("\n⚠️  Warning: %v\n", err)
 			fmt.Println("   Daemon will run but notifications will be disabled.")
@@ -132,9 +178,9 @@ func runDaemonStart(cmd *cobra.Command, args []string) error {
 	// Wait a moment and check if it's running
 	time.Sleep(1 * time.Second)
 
-	if pid, running := isDaemonRunning(pidFile); running {
+	if pid, running := daemon.IsRunning(pidFile); running {
 		fmt.Printf("✅ Daemon started successfully (PID %d)\n", pid)
-		if config.Daemon.Notifications.Enabled {
+		if currentConfig().Daemon.Notifications.Enabled {
 			fmt.Println("   You should receive a notification confirming it's working")
 		}
 		fmt.Printf("\nView logs: maestro daemon logs\n")
@@ -145,10 +191,10 @@ func runDaemonStart(cmd *cobra.Command, args []string) error {
 }
 
 func runDaemonStop(cmd *cobra.Command, args []string) error {
-	authDir := expandPath(config.Claude.AuthPath)
-	pidFile := filepath.Join(authDir, "daemon.pid")
+	authDir := expandPath(currentConfig().Claude.AuthPath)
+	pidFile := filepath.Join(authDir, daemon.PIDFile)
 
-	pid, running := isDaemonRunning(pidFile)
+	pid, running := daemon.IsRunning(pidFile)
 	if !running {
 		fmt.Println("Daemon is not running")
 		return nil
@@ -168,7 +214,7 @@ func runDaemonStop(cmd *cobra.Command, args []string) error {
 
 	// Wait for process to exit (up to 5 seconds)
 	for i := 0; i < 50; i++ {
-		if _, running := isDaemonRunning(pidFile); !running {
+		if _, running := daemon.IsRunning(pidFile); !running {
 			fmt.Println("✅ Daemon stopped")
 			return nil
 		}
@@ -179,10 +225,10 @@ func runDaemonStop(cmd *cobra.Command, args []string) error {
 }
 
 func runDaemonStatus(cmd *cobra.Command, args []string) error {
-	authDir := expandPath(config.Claude.AuthPath)
-	pidFile := filepath.Join(authDir, "daemon.pid")
+	authDir := expandPath(currentConfig().Claude.AuthPath)
+	pidFile := filepath.Join(authDir, daemon.PIDFile)
 
-	pid, running := isDaemonRunning(pidFile)
+	pid, running := daemon.IsRunning(pidFile)
 
 	if running {
 		fmt.Printf("Status: Running (PID %d)\n", pid)
@@ -194,11 +240,11 @@ func runDaemonStatus(cmd *cobra.Command, args []string) error {
 
 		// Show config
 		fmt.Printf("\nConfiguration:\n")
-		fmt.Printf("  Check interval: %s\n", config.Daemon.CheckInterval)
-		fmt.Printf("  Token threshold: %s\n", config.Daemon.TokenRefresh.Threshold)
-		fmt.Printf("  Notifications: %v\n", config.Daemon.Notifications.Enabled)
-		if config.Daemon.Notifications.Enabled {
-			fmt.Printf("  Attention threshold: %s\n", config.Daemon.Notifications.AttentionThreshold)
+		fmt.Printf("  Check interval: %s\n", currentConfig().Daemon.CheckInterval)
+		fmt.Printf("  Token threshold: %s\n", currentConfig().Daemon.TokenRefresh.Threshold)
+		fmt.Printf("  Notifications: %v\n", currentConfig().Daemon.Notifications.Enabled)
+		if currentConfig().Daemon.Notifications.Enabled {
+			fmt.Printf("  Attention threshold: %s\n", currentConfig().Daemon.Notifications.AttentionThreshold)
 		}
 	} else {
 		fmt.Println("Status: Not running")
@@ -207,21 +253,91 @@ func runDaemonStatus(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runDaemonNag(cmd *cobra.Command, args []string) error {
+	var show bool
+	switch args[0] {
+	case "on":
+		show = true
+	case "off":
+		show = false
+	default:
+		return fmt.Errorf("expected 'on' or 'off', got %q", args[0])
+	}
+
+	if err := paths.EnsureConfigDir(); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	err := configfile.Update(configFilePath(), func(doc *configfile.Document) error {
+		return doc.Set("daemon.show_nag", show)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update config: %w", err)
+	}
+	if err := ReloadConfig(); err != nil {
+		return err
+	}
+
+	if show {
+		progressln("✓ Daemon reminder turned on")
+	} else {
+		progressln("✓ Daemon reminder turned off (turn it back on with: maestro daemon nag on)")
+	}
+	return nil
+}
+
 func runDaemonLogs(cmd *cobra.Command, args []string) error {
-	authDir := expandPath(config.Claude.AuthPath)
+	if daemonLogsInvert && daemonLogsGrep == "" {
+		return fmt.Errorf("--invert requires --grep")
+	}
+	var pattern *regexp.Regexp
+	if daemonLogsGrep != "" {
+		var err error
+		if pattern, err = regexp.Compile(daemonLogsGrep); err != nil {
+			return fmt.Errorf("invalid --grep pattern: %w", err)
+		}
+	}
+
+	authDir := expandPath(currentConfig().Claude.AuthPath)
 	logFile := filepath.Join(authDir, "daemon.log")
 
-	if _, err := os.Stat(logFile); os.IsNotExist(err) {
+	f, err := os.Open(logFile)
+	if os.IsNotExist(err) {
 		fmt.Println("No daemon logs found")
 		return nil
 	}
+	if err != nil {
+		return fmt.Errorf("failed to open daemon log: %w", err)
+	}
+	defer f.Close()
 
-	// Use tail to show last 50 lines
-	tailCmd := exec.Command("tail", "-n", "50", logFile)
-	tailCmd.Stdout = os.Stdout
-	tailCmd.Stderr = os.Stderr
+	// Filter in Go rather than shelling out to tail/grep, so it works the same everywhere
+	lines, err := tailMatching(f, 50, pattern, daemonLogsInvert)
+	if err != nil {
+		return fmt.Errorf("failed to read daemon log: %w", err)
+	}
+	for _, line := range lines {
+		fmt.Println(line)
+	}
+	return nil
+}
 
-	return tailCmd.Run()
+// tailMatching returns the last n lines of r that match pattern (or don't,
+// with invert). A nil pattern matches every line.
+func tailMatching(r io.Reader, n int, pattern *regexp.Regexp, invert bool) ([]string, error) {
+	lines := make([]string, 0, n)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if pattern != nil && pattern.MatchString(line) == invert {
+			continue
+		}
+		if len(lines) == n {
+			lines = append(lines[:0], lines[1:]...)
+		}
+		lines = append(lines, line)
+	}
+	return lines, scanner.Err()
 }
 
 // Hidden command that actually runs the daemon
@@ -236,7 +352,7 @@ func init() {
 }
 
 func runDaemonBackground(cmd *cobra.Command, args []string) error {
-	authDir := expandPath(config.Claude.AuthPath)
+	authDir := expandPath(currentConfig().Claude.AuthPath)
 
 	// Ensure directory exists
 	if err := os.MkdirAll(authDir, 0755); err != nil {
@@ -245,14 +361,16 @@ func runDaemonBackground(cmd *cobra.Command, args []string) error {
 
 	// Parse config
 	daemonConfig := daemon.Config{
-		CheckInterval:      parseDuration(config.Daemon.CheckInterval, 30*time.Minute),
-		TokenThreshold:     parseDuration(config.Daemon.TokenRefresh.Threshold, 6*time.Hour),
-		NotificationsOn:    config.Daemon.Notifications.Enabled,
-		AttentionThreshold: parseDuration(config.Daemon.Notifications.AttentionThreshold, 5*time.Minute),
-		NotifyOn:           config.Daemon.Notifications.NotifyOn,
-		QuietHoursStart:    config.Daemon.Notifications.QuietHours.Start,
-		QuietHoursEnd:      config.Daemon.Notifications.QuietHours.End,
-		ContainerPrefix:    config.Containers.Prefix,
+		CheckInterval:      parseDuration(currentConfig().Daemon.CheckInterval, 30*time.Minute),
+		TokenThreshold:     parseDuration(currentConfig().Daemon.TokenRefresh.Threshold, 6*time.Hour),
+		NotificationsOn:    currentConfig().Daemon.Notifications.Enabled,
+		AttentionThreshold: parseDuration(currentConfig().Daemon.Notifications.AttentionThreshold, 5*time.Minute),
+		NotifyOn:           currentConfig().Daemon.Notifications.NotifyOn,
+		QuietHoursStart:    currentConfig().Daemon.Notifications.QuietHours.Start,
+		QuietHoursEnd:      currentConfig().Daemon.Notifications.QuietHours.End,
+		ContainerPrefix:    currentConfig().Containers.Prefix,
+		WebhookURL:         currentConfig().Daemon.Notifications.WebhookURL,
+		WebhookSecret:      currentConfig().Daemon.Notifications.WebhookSecret,
 	}
 
 	// Create and start daemon with embedded icon
@@ -264,14 +382,34 @@ func runDaemonBackground(cmd *cobra.Command, args []string) error {
 	return d.Start()
 }
 
+// runDaemonSocket serves the control API until interrupted
+func runDaemonSocket(cmd *cobra.Command, args []string) error {
+	if daemonSocketPath == "" {
+		return cmd.Help()
+	}
+
+	server := api.NewServer(expandPath(daemonSocketPath), currentConfig().Containers.Prefix)
+
+	// Clean up the socket on Ctrl+C / SIGTERM
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-sigChan
+		server.Close()
+	}()
+
+	fmt.Printf("Serving control API on %s (Ctrl+C to stop)\n", daemonSocketPath)
+	return server.ListenAndServe()
+}
+
 // EnsureDaemonRunning starts the daemon if it's not already running.
 // This is called automatically when the TUI starts.
 func EnsureDaemonRunning() {
-	authDir := expandPath(config.Claude.AuthPath)
-	pidFile := filepath.Join(authDir, "daemon.pid")
+	authDir := expandPath(currentConfig().Claude.AuthPath)
+	pidFile := filepath.Join(authDir, daemon.PIDFile)
 
 	// Check if already running
-	if _, running := isDaemonRunning(pidFile); running {
+	if _, running := daemon.IsRunning(pidFile); running {
 		return // Already running, nothing to do
 	}
 
@@ -296,28 +434,6 @@ func EnsureDaemonRunning() {
 
 // Helper functions
 
-func isDaemonRunning(pidFile string) (int, bool) {
-	data, err := os.ReadFile(pidFile)
-	if err != nil {
-		return 0, false
-	}
-
-	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
-	if err != nil {
-		return 0, false
-	}
-
-	// Check if process exists
-	process, err := os.FindProcess(pid)
-	if err != nil {
-		return 0, false
-	}
-
-	// Send signal 0 to check if process is alive
-	err = process.Signal(syscall.Signal(0))
-	return pid, err == nil
-}
-
 func getProcessUptime(pid int) string {
 	// Use ps to get process start time
 	cmd := exec.Command("ps", "-p", strconv.Itoa(pid), "-o", "etime=")
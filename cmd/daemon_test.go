@@ -0,0 +1,67 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestTailMatching(t *testing.T) {
+	log := strings.Join([]string{
+		"check: 3 containers",
+		"token refresh: ok",
+		"check: 3 containers",
+		"ERROR token refresh failed",
+		"check: 2 containers",
+	}, "\n")
+
+	tests := []struct {
+		name    string
+		n       int
+		pattern string
+		invert  bool
+		want    []string
+	}{
+		{"no filter keeps the last n", 2, "", false,
+			[]string{"ERROR token refresh failed", "check: 2 containers"}},
+		{"grep", 10, "token", false,
+			[]string{"token refresh: ok", "ERROR token refresh failed"}},
+		{"last n are taken after filtering", 1, "^check", false,
+			[]string{"check: 2 containers"}},
+		{"invert", 10, "^check", true,
+			[]string{"token refresh: ok", "ERROR token refresh failed"}},
+		{"case-insensitive flag", 10, "(?i)error", false,
+			[]string{"ERROR token refresh failed"}},
+		{"no matches", 10, "webhook", false, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var pattern *regexp.Regexp
+			if tt.pattern != "" {
+				pattern = regexp.MustCompile(tt.pattern)
+			}
+			got, err := tailMatching(strings.NewReader(log), tt.n, pattern, tt.invert)
+			if err != nil {
+				t.Fatalf("tailMatching() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("tailMatching() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
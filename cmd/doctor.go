@@ -0,0 +1,341 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/uprockcom/maestro/pkg/container"
+	"github.com/uprockcom/maestro/pkg/paths"
+	"github.com/uprockcom/maestro/pkg/system"
+	"github.com/uprockcom/maestro/pkg/version"
+)
+
+var (
+	doctorFix bool
+	doctorYes bool
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Diagnose (and optionally fix) common problems",
+	Long: `Check Docker, credentials, and running containers for common problems.
+
+Checks:
+  - Docker is running and responsive
+  - Container name prefixes (config, profiles, legacy mcl-) don't overlap
+  - Host credentials are valid
+  - Container tokens are not older than the host token
+  - Each running container has its tmux session
+  - Each running container has the maestro shell config
+  - The container image is current, and running containers use it
+
+With --fix, each problem that can be fixed automatically is fixed after
+confirmation (or without asking with --yes).
+
+Examples:
+  maestro doctor
+  maestro doctor --fix
+  maestro doctor --fix --yes`,
+	Args: cobra.NoArgs,
+	RunE: runDoctor,
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+	doctorCmd.Flags().BoolVar(&doctorFix, "fix", false, "Attempt to fix detected problems")
+	doctorCmd.Flags().BoolVarP(&doctorYes, "yes", "y", false, "Don't ask for confirmation before each fix")
+}
+
+// doctorIssue is a detected problem and, if possible, how to fix it
+type doctorIssue struct {
+	Description string
+	Fix         func() error // nil if the problem can't be fixed automatically
+	Hint        string       // Shown when there is no automatic fix
+}
+
+func runDoctor(cmd *cobra.Command, args []string) error {
+	fmt.Println("Checking maestro health...")
+	fmt.Println()
+
+	issues := diagnose()
+
+	if len(issues) == 0 {
+		fmt.Println("\n✅ No problems found")
+		return nil
+	}
+
+	fmt.Printf("\nFound %d problem(s):\n", len(issues))
+	for _, issue := range issues {
+		fmt.Printf("  ✗ %s\n", issue.Description)
+		if issue.Fix == nil && issue.Hint != "" {
+			fmt.Printf("    💡 %s\n", issue.Hint)
+		}
+	}
+
+	if !doctorFix {
+		fmt.Println("\nRun 'maestro doctor --fix' to attempt automatic fixes.")
+		return nil
+	}
+
+	fmt.Println()
+	// One reader for every prompt: a fresh one per prompt could buffer and
+	// lose answers typed ahead or piped in
+	reader := bufio.NewReader(os.Stdin)
+	var fixed, failed, skipped int
+	for _, issue := range issues {
+		if issue.Fix == nil {
+			continue
+		}
+		if !doctorYes && !confirmFix(reader, issue.Description) {
+			skipped++
+			continue
+		}
+		if err := issue.Fix(); err != nil {
+			fmt.Printf("  ✗ Could not fix: %v\n", err)
+			failed++
+			continue
+		}
+		fmt.Printf("  ✓ Fixed: %s\n", issue.Description)
+		fixed++
+	}
+
+	fmt.Printf("\nFixed %d, failed %d, skipped %d", fixed, failed, skipped)
+	if unfixable := len(issues) - fixed - failed - skipped; unfixable > 0 {
+		fmt.Printf(", %d need manual attention", unfixable)
+	}
+	fmt.Println()
+
+	if failed > 0 {
+		return fmt.Errorf("%d fix(es) failed", failed)
+	}
+	return nil
+}
+
+// diagnose runs all checks, printing a line per check, and returns the problems found
+func diagnose() []doctorIssue {
+	var issues []doctorIssue
+
+	// Docker
+	if ok, message := system.IsDockerAvailable(); !ok && message == system.DockerNoAccess {
+		fmt.Println("  ✗ Your user can't access the docker socket")
+		issues = append(issues, doctorIssue{
+			Description: message,
+			Hint:        strings.Join(system.DockerGuidance(message), " "),
+		})
+		return issues
+	}
+	if !container.IsDockerResponsive() {
+		fmt.Println("  ✗ Docker is not responding")
+		issues = append(issues, doctorIssue{
+			Description: "Docker is not running",
+			Fix:         startDocker,
+		})
+		// Everything else needs docker
+		return issues
+	}
+	fmt.Println("  ✓ Docker is running")
+
+	// Container name prefixes: with "dev-" and "dev-api-", dev-api-x-1 is
+	// also listed (as api-x-1) among the dev- containers
+	if overlaps := container.OverlappingPrefixes(knownPrefixes()); len(overlaps) > 0 {
+		for _, o := range overlaps {
+			fmt.Printf("  ✗ Container prefix %q overlaps %q\n", o.Short, o.Long)
+			issues = append(issues, doctorIssue{
+				Description: fmt.Sprintf("Prefix %q is the start of prefix %q, so %s... containers are also listed under %s", o.Short, o.Long, o.Long, o.Short),
+				Hint:        "Pick prefixes that don't start with one another (containers.prefix, profiles)",
+			})
+		}
+	} else {
+		fmt.Println("  ✓ Container prefixes don't overlap")
+	}
+
+	// Host credentials
+	hostCredPath := paths.FindCredentials(paths.AuthDir())
+	hostCreds, err := container.ReadCredentials(hostCredPath)
+	if err == nil {
+		err = container.ValidateCredentials(hostCreds)
+	}
+	hostValid := err == nil && !container.IsTokenExpired(hostCreds)
+	var credErr *container.CredentialsError
+	switch {
+	case errors.As(err, &credErr):
+		fmt.Printf("  ✗ Host credentials invalid: %v\n", err)
+		issues = append(issues, doctorIssue{
+			Description: "Host credentials are invalid",
+			Hint:        "Run 'maestro auth' to re-authenticate",
+		})
+	case err != nil:
+		fmt.Println("  ✗ Host credentials not found")
+		issues = append(issues, doctorIssue{
+			Description: "Host credentials are missing or unreadable",
+			Hint:        "Run 'maestro auth' to authenticate",
+		})
+	case !hostValid:
+		fmt.Printf("  ✗ Host credentials: %s\n", container.FormatExpiration(hostCreds))
+		issues = append(issues, doctorIssue{
+			Description: "Host token is expired",
+			Hint:        "Run 'maestro auth' to re-authenticate",
+		})
+	default:
+		fmt.Printf("  ✓ Host credentials: %s\n", container.FormatExpiration(hostCreds))
+	}
+
+	// Running containers
+	containers, err := container.GetRunningContainersWithLegacy(currentConfig().Containers.Prefix)
+	if err != nil {
+		fmt.Printf("  ✗ Could not list containers: %v\n", err)
+		return issues
+	}
+
+	var stale []string
+	for _, c := range containers {
+		// Tokens: stale if missing, invalid, or older than the host's valid token
+		creds, err := container.ReadContainerCredentials(c.Name)
+		if hostValid && (err != nil || creds.ClaudeAiOauth.ExpiresAt < hostCreds.ClaudeAiOauth.ExpiresAt) {
+			fmt.Printf("  ✗ %s: token is older than the host's\n", c.ShortName)
+			stale = append(stale, c.Name)
+		}
+
+		// Short name label: a mismatch means the container was created under
+		// a longer, overlapping prefix and is listed under the wrong name
+		name := c.Name
+		if label := container.GetShortNameLabel(name); label != "" && label != c.ShortName {
+			fmt.Printf("  ✗ %s: created as %q under another prefix\n", c.ShortName, label)
+			issues = append(issues, doctorIssue{
+				Description: fmt.Sprintf("%s is listed as %s but was created as %s under a different prefix", c.Name, c.ShortName, label),
+				Hint:        "Its prefix overlaps containers.prefix; pick prefixes that don't start with one another",
+			})
+		}
+
+		// tmux session
+		if system.DockerCommand("exec", name, "tmux", "has-session", "-t", "main").Run() != nil {
+			fmt.Printf("  ✗ %s: tmux session is missing\n", c.ShortName)
+			issues = append(issues, doctorIssue{
+				Description: fmt.Sprintf("%s has no tmux session", c.ShortName),
+				Fix:         func() error { return ensureTmuxSession(name, "main") },
+			})
+		}
+
+		// Shell config
+		if !container.HasShellConfig(name) {
+			fmt.Printf("  ✗ %s: shell config not applied\n", c.ShortName)
+			issues = append(issues, doctorIssue{
+				Description: fmt.Sprintf("%s is missing the maestro shell config", c.ShortName),
+				Fix:         func() error { return container.EnsureShellConfig(name) },
+			})
+		}
+	}
+	fmt.Printf("  ✓ Checked %d running container(s)\n", len(containers))
+
+	// Container image
+	if freshness, err := checkImageFreshness(containers); err != nil {
+		fmt.Printf("  - Container image %s is not present locally (pulled on next create)\n", getDockerImage())
+	} else {
+		if freshness.Outdated {
+			fmt.Printf("  ✗ Container image is at revision %d, expected %d\n", freshness.Revision, version.ImageRevision)
+			issues = append(issues, doctorIssue{
+				Description: fmt.Sprintf("Container image %s is outdated", freshness.Image),
+				Fix:         func() error { return runRebuildImage(nil, nil) },
+			})
+		} else if freshness.Revision == 0 {
+			fmt.Printf("  - Container image %s has no revision label; can't tell if it's current\n", freshness.Image)
+		} else {
+			fmt.Println("  ✓ Container image is current")
+		}
+		if len(freshness.Stale) > 0 {
+			fmt.Printf("  ✗ %d container(s) run an older build of the image\n", len(freshness.Stale))
+			issues = append(issues, doctorIssue{
+				Description: fmt.Sprintf("%s run an older build of the image", strings.Join(freshness.Stale, ", ")),
+				Hint:        "Recreate them to pick up the current image",
+			})
+		}
+	}
+
+	if len(stale) > 0 {
+		issues = append(issues, doctorIssue{
+			Description: fmt.Sprintf("%d container(s) have stale tokens", len(stale)),
+			Fix: func() error {
+				result := &refreshResult{}
+				return refreshTokens(result, stale, func(string, ...any) {})
+			},
+		})
+	}
+
+	return issues
+}
+
+// knownPrefixes returns every container prefix in use: the active one, the
+// top-level and per-profile config values, and the legacy "mcl-" if scanned
+func knownPrefixes() []string {
+	prefixes := []string{currentConfig().Containers.Prefix, viper.GetString("containers.prefix")}
+	if currentConfig().Containers.ScanLegacyPrefix {
+		prefixes = append(prefixes, legacyPrefix)
+	}
+	for _, p := range currentConfig().Profiles {
+		prefixes = append(prefixes, p.Prefix)
+	}
+	return prefixes
+}
+
+// startDocker tries to start the Docker daemon (or podman machine) and waits
+// for it to respond
+func startDocker() error {
+	var startCmd *exec.Cmd
+	switch {
+	case system.IsPodman() && runtime.GOOS == "linux":
+		// Podman on Linux has no daemon; if it isn't answering, something else is wrong
+		return fmt.Errorf("podman has no daemon to start; check that '%s ps' works", system.DockerBinary())
+	case system.IsPodman():
+		startCmd = system.DockerCommand("machine", "start")
+	case runtime.GOOS == "darwin":
+		startCmd = exec.Command("open", "-a", "Docker")
+	case runtime.GOOS == "linux":
+		// Non-interactive so we never hang on a password prompt
+		startCmd = exec.Command("systemctl", "start", "--no-ask-password", "docker")
+	default:
+		return fmt.Errorf("don't know how to start Docker on %s; start it manually", runtime.GOOS)
+	}
+
+	if output, err := startCmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("not permitted to start Docker (%s); start it manually", strings.TrimSpace(string(output)))
+	}
+
+	fmt.Println("  Waiting for Docker to start...")
+	for i := 0; i < 60; i++ {
+		if container.IsDockerResponsive() {
+			return nil
+		}
+		time.Sleep(1 * time.Second)
+	}
+	return fmt.Errorf("docker did not become responsive within 60s")
+}
+
+// confirmFix asks whether to apply a fix, reading the answer from reader
+func confirmFix(reader *bufio.Reader, description string) bool {
+	fmt.Printf("Fix: %s? [y/N]: ", description)
+	response, _ := reader.ReadString('\n')
+	response = strings.ToLower(strings.TrimSpace(response))
+	return response == "y" || response == "yes"
+}
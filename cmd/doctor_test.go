@@ -0,0 +1,31 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+)
+
+func TestConfirmFixSharesReader(t *testing.T) {
+	// Answers piped in together must each reach their own prompt
+	reader := bufio.NewReader(strings.NewReader("y\nn\nYES\n"))
+	for i, want := range []bool{true, false, true, false} {
+		if got := confirmFix(reader, "issue"); got != want {
+			t.Errorf("answer %d: confirmFix() = %v, want %v", i+1, got, want)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/uprockcom/maestro/pkg/container"
+)
+
+var (
+	envShowSecrets bool
+	envJSON        bool
+)
+
+var envCmd = &cobra.Command{
+	Use:   "env <name>",
+	Short: "Print a container's environment variables",
+	Long: `Print the environment variables a container was started with, e.g. to check
+that passthrough or preset variables made it in.
+
+Values of variables whose names match containers.env_redact (by default
+anything containing TOKEN, SECRET, or PASSWORD) are shown as <redacted>.
+--show-secrets reveals them after asking for confirmation.
+
+Examples:
+  maestro env feat-auth-1
+  maestro env feat-auth-1 --json
+  maestro env feat-auth-1 --show-secrets`,
+	Args: cobra.ExactArgs(1),
+	RunE: runEnv,
+}
+
+func init() {
+	rootCmd.AddCommand(envCmd)
+	envCmd.Flags().BoolVar(&envShowSecrets, "show-secrets", false, "Reveal redacted values (asks for confirmation)")
+	envCmd.Flags().BoolVar(&envJSON, "json", false, "Print a JSON object of name to value")
+}
+
+func runEnv(cmd *cobra.Command, args []string) error {
+	containerName := resolveContainerName(args[0])
+
+	env, err := container.GetContainerEnv(containerName)
+	if err != nil {
+		return err
+	}
+
+	redacted := container.RedactEnv(env)
+	if envShowSecrets && hasRedacted(redacted) {
+		if !confirmShowSecrets(containerName) {
+			return fmt.Errorf("secrets not revealed")
+		}
+		redacted = env
+	}
+
+	if envJSON {
+		values := make(map[string]string, len(redacted))
+		for _, entry := range redacted {
+			name, value, _ := strings.Cut(entry, "=")
+			values[name] = value
+		}
+		out, err := json.MarshalIndent(values, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode environment: %w", err)
+		}
+		fmt.Println(string(out))
+		return nil
+	}
+
+	for _, entry := range redacted {
+		fmt.Println(entry)
+	}
+	return nil
+}
+
+// hasRedacted reports whether any entry of env has a redacted value
+func hasRedacted(env []string) bool {
+	for _, entry := range env {
+		if strings.HasSuffix(entry, "="+container.RedactedValue) {
+			return true
+		}
+	}
+	return false
+}
+
+// confirmShowSecrets asks before secret values are printed. The question goes
+// to stderr so it doesn't end up in redirected output.
+func confirmShowSecrets(containerName string) bool {
+	if !isTerminal(os.Stdin) {
+		fmt.Fprintln(os.Stderr, "--show-secrets needs confirmation from a terminal")
+		return false
+	}
+	fmt.Fprintf(os.Stderr, "Print secret values from %s to the terminal? [y/N]: ", containerName)
+	response, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	response = strings.ToLower(strings.TrimSpace(response))
+	return response == "y" || response == "yes"
+}
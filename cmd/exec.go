@@ -0,0 +1,238 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sort"
+	"sync"
+
+	"github.com/spf13/cobra"
+	"github.com/uprockcom/maestro/pkg/container"
+	"github.com/uprockcom/maestro/pkg/system"
+)
+
+var (
+	execAll             bool
+	execContinueOnError bool
+	execFailFast        bool
+	execPrintCmd        bool
+)
+
+var execCmd = &cobra.Command{
+	Use:   "exec [name] [--all] -- <command> [args...]",
+	Short: "Run a command in one or all running containers",
+	Long: `Run a command inside maestro containers.
+
+With --all, the command runs concurrently in every running container. Each
+output line is prefixed with the container's short name, and any non-zero
+exits are summarized at the end.
+
+Examples:
+  maestro exec feat-auth-1 -- git status
+  maestro exec --all -- git fetch --all
+  maestro exec --all --fail-fast -- npm cache clean --force
+  maestro exec feat-auth-1 --print-cmd -- git status   # Print the docker command only`,
+	RunE: runExec,
+}
+
+func init() {
+	rootCmd.AddCommand(execCmd)
+
+	execCmd.Flags().BoolVar(&execAll, "all", false, "Run the command in every running container")
+	execCmd.Flags().BoolVar(&execContinueOnError, "continue-on-error", true, "Keep running in other containers when one fails")
+	execCmd.Flags().BoolVar(&execFailFast, "fail-fast", false, "Cancel remaining containers as soon as one fails")
+	execCmd.Flags().BoolVar(&execPrintCmd, "print-cmd", false, "Print the docker command(s) instead of running them")
+}
+
+// execResult holds the outcome of running a command in one container
+type execResult struct {
+	ShortName string
+	ExitCode  int
+	Err       error
+}
+
+func runExec(cmd *cobra.Command, args []string) error {
+	dash := cmd.ArgsLenAtDash()
+	if dash < 0 {
+		return fmt.Errorf("missing command: use 'maestro exec [name] [--all] -- <command>'")
+	}
+
+	targets := args[:dash]
+	command := args[dash:]
+	if len(command) == 0 {
+		return fmt.Errorf("missing command after '--'")
+	}
+
+	if execAll && len(targets) > 0 {
+		return fmt.Errorf("cannot combine a container name with --all")
+	}
+	if !execAll && len(targets) != 1 {
+		return fmt.Errorf("specify exactly one container name, or use --all")
+	}
+
+	if !execAll {
+		containerName := resolveContainerName(targets[0])
+		dockerArgs := append([]string{"exec", "-i", containerName}, command...)
+		if execPrintCmd {
+			fmt.Println(shellCommand(append([]string{system.DockerBinary()}, dockerArgs...)...))
+			return nil
+		}
+		dockerCmd := system.DockerCommand(dockerArgs...)
+		dockerCmd.Stdin = os.Stdin
+		dockerCmd.Stdout = os.Stdout
+		dockerCmd.Stderr = os.Stderr
+		return dockerCmd.Run()
+	}
+
+	failFast := execFailFast || !execContinueOnError
+
+	containers, err := container.GetRunningContainersWithLegacy(currentConfig().Containers.Prefix)
+	if err != nil {
+		return fmt.Errorf("failed to list containers: %w", err)
+	}
+
+	if len(containers) == 0 {
+		fmt.Println("No running containers found.")
+		return nil
+	}
+
+	if execPrintCmd {
+		for _, c := range containers {
+			fmt.Println(shellCommand(append([]string{system.DockerBinary(), "exec", c.Name}, command...)...))
+		}
+		return nil
+	}
+
+	fmt.Printf("Running in %d container(s): %v\n\n", len(containers), command)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Pad prefixes to the longest short name so output lines up
+	width := 0
+	for _, c := range containers {
+		if len(c.ShortName) > width {
+			width = len(c.ShortName)
+		}
+	}
+
+	var outMu sync.Mutex
+	var wg sync.WaitGroup
+	results := make([]execResult, len(containers))
+
+	for i, c := range containers {
+		wg.Add(1)
+		go func(idx int, c container.Info) {
+			defer wg.Done()
+
+			prefix := fmt.Sprintf("[%-*s] ", width, c.ShortName)
+			stdout := &prefixWriter{prefix: prefix, out: os.Stdout, mu: &outMu}
+			stderr := &prefixWriter{prefix: prefix, out: os.Stderr, mu: &outMu}
+
+			dockerCmd := system.DockerCommandContext(ctx, append([]string{"exec", c.Name}, command...)...)
+			dockerCmd.Stdout = stdout
+			dockerCmd.Stderr = stderr
+			err := dockerCmd.Run()
+			stdout.Flush()
+			stderr.Flush()
+
+			result := execResult{ShortName: c.ShortName, Err: err}
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) {
+				result.ExitCode = exitErr.ExitCode()
+			} else if err != nil {
+				result.ExitCode = -1
+			}
+			results[idx] = result
+
+			if err != nil && failFast {
+				cancel()
+			}
+		}(i, c)
+	}
+
+	wg.Wait()
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].ShortName < results[j].ShortName
+	})
+
+	var failed []execResult
+	for _, r := range results {
+		if r.Err != nil {
+			failed = append(failed, r)
+		}
+	}
+
+	fmt.Println()
+	if len(failed) == 0 {
+		fmt.Printf("✅ Command succeeded in %d container(s)\n", len(results))
+		return nil
+	}
+
+	fmt.Printf("⚠️  Command failed in %d/%d container(s):\n", len(failed), len(results))
+	for _, r := range failed {
+		if ctx.Err() != nil && r.ExitCode == -1 {
+			fmt.Printf("  ✗ %s: cancelled\n", r.ShortName)
+			continue
+		}
+		fmt.Printf("  ✗ %s: exit %d\n", r.ShortName, r.ExitCode)
+	}
+
+	return fmt.Errorf("command failed in %d container(s)", len(failed))
+}
+
+// prefixWriter writes complete lines to out, each prefixed with prefix.
+// Partial lines are buffered until a newline arrives or Flush is called.
+type prefixWriter struct {
+	prefix string
+	out    io.Writer
+	mu     *sync.Mutex
+	buf    bytes.Buffer
+}
+
+func (w *prefixWriter) Write(p []byte) (int, error) {
+	w.buf.Write(p)
+	for {
+		line, err := w.buf.ReadBytes('\n')
+		if err != nil {
+			// Incomplete line - put it back and wait for more
+			w.buf.Write(line)
+			break
+		}
+		w.mu.Lock()
+		fmt.Fprintf(w.out, "%s%s", w.prefix, line)
+		w.mu.Unlock()
+	}
+	return len(p), nil
+}
+
+// Flush writes any buffered partial line
+func (w *prefixWriter) Flush() {
+	if w.buf.Len() == 0 {
+		return
+	}
+	w.mu.Lock()
+	fmt.Fprintf(w.out, "%s%s\n", w.prefix, w.buf.String())
+	w.mu.Unlock()
+	w.buf.Reset()
+}
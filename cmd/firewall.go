@@ -0,0 +1,149 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/uprockcom/maestro/pkg/container"
+)
+
+var firewallCmd = &cobra.Command{
+	Use:   "firewall",
+	Short: "Test, disable, or re-enable a container's firewall",
+	Long: `Control the egress firewall of a running container.
+
+Disabling the firewall is an escape hatch for debugging network issues: all
+outbound traffic is allowed until the firewall is re-enabled. Containers with a
+disabled firewall are flagged in 'maestro list' and the TUI.
+
+Use 'test' to check whether a domain is reachable from inside the container.
+
+Examples:
+  maestro firewall test feat-auth-1 api.example.com
+  maestro firewall disable feat-auth-1
+  maestro firewall enable feat-auth-1`,
+}
+
+var firewallTestCmd = &cobra.Command{
+	Use:   "test <name> <domain>",
+	Short: "Check that a domain resolves and is reachable from a container",
+	Long: `Resolve a domain and make an HTTPS request to it from inside a running
+container. Reports DNS and HTTPS separately, so "DNS allowed but connections
+blocked" can be told apart from "not allowed at all". Any HTTP response,
+including errors like 403, counts as reachable.
+
+Exits non-zero unless the domain is fully reachable.`,
+	Args: cobra.ExactArgs(2),
+	RunE: runFirewallTest,
+}
+
+var firewallDisableCmd = &cobra.Command{
+	Use:   "disable <name>",
+	Short: "Allow unrestricted egress from a container",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runFirewallDisable,
+}
+
+var firewallEnableCmd = &cobra.Command{
+	Use:   "enable <name>",
+	Short: "Re-apply the firewall from config",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runFirewallEnable,
+}
+
+func init() {
+	rootCmd.AddCommand(firewallCmd)
+	firewallCmd.AddCommand(firewallTestCmd)
+	firewallCmd.AddCommand(firewallDisableCmd)
+	firewallCmd.AddCommand(firewallEnableCmd)
+}
+
+func runFirewallDisable(cmd *cobra.Command, args []string) error {
+	containerName := resolveContainerName(args[0])
+	if !isContainerRunning(containerName) {
+		return fmt.Errorf("container %s is not running", args[0])
+	}
+
+	if err := container.DisableFirewall(containerName); err != nil {
+		return err
+	}
+
+	progressf("⚠️  Firewall disabled for %s; all outbound traffic is allowed.\n", args[0])
+	progressf("Re-enable with: maestro firewall enable %s\n", args[0])
+	return nil
+}
+
+func runFirewallEnable(cmd *cobra.Command, args []string) error {
+	containerName := resolveContainerName(args[0])
+	if !isContainerRunning(containerName) {
+		return fmt.Errorf("container %s is not running", args[0])
+	}
+
+	steps := newStepSpinner()
+	steps.Step("Re-applying firewall from config")
+	if err := initializeFirewall(containerName); err != nil {
+		steps.Fail()
+		return fmt.Errorf("failed to enable firewall: %w", err)
+	}
+	if err := container.ClearFirewallDisabled(containerName); err != nil {
+		steps.Warnf("Failed to clear disabled marker: %v", err)
+	}
+	steps.Done()
+
+	progressf("✅ Firewall enabled for %s\n", args[0])
+	return nil
+}
+
+func runFirewallTest(cmd *cobra.Command, args []string) error {
+	containerName := resolveContainerName(args[0])
+	if !isContainerRunning(containerName) {
+		return fmt.Errorf("container %s is not running", args[0])
+	}
+	domain := args[1]
+
+	progressf("Testing %s from %s...\n", domain, args[0])
+	check := container.CheckDomain(containerName, domain)
+
+	if check.Resolved() {
+		fmt.Printf("  ✓ DNS:   resolves to %s\n", strings.Join(check.Addresses, ", "))
+	} else {
+		fmt.Println("  ✗ DNS:   does not resolve")
+	}
+	switch {
+	case check.Reachable():
+		fmt.Printf("  ✓ HTTPS: reachable (HTTP %s)\n", check.HTTPStatus)
+	case check.ConnectError != "":
+		fmt.Printf("  ✗ HTTPS: unreachable (%s)\n", check.ConnectError)
+	default:
+		fmt.Println("  ✗ HTTPS: unreachable")
+	}
+
+	switch {
+	case check.Resolved() && check.Reachable():
+		progressf("✅ %s is reachable from %s\n", domain, args[0])
+		return nil
+	case check.Resolved():
+		progressf("DNS is allowed but connections fail; the firewall rules may be stale, or the service may be down.\n")
+		progressf("Re-apply it with: maestro firewall enable %s\n", args[0])
+		return fmt.Errorf("%s resolves but is not reachable", domain)
+	default:
+		progressf("The domain is not allowed by the firewall (or does not exist).\n")
+		progressf("Allow it with: maestro add-domain %s %s\n", args[0], domain)
+		return fmt.Errorf("%s does not resolve", domain)
+	}
+}
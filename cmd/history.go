@@ -0,0 +1,79 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/uprockcom/maestro/pkg/container"
+)
+
+var (
+	historyLast int
+	historyTime bool
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history <name>",
+	Short: "Print a container's shell command history",
+	Long: `Print the commands run in a container's shell, yours and Claude's, oldest
+first. The history is kept on the container's history volume, so it can be
+read after the container has stopped.
+
+Examples:
+  maestro history feat-auth-1
+  maestro history feat-auth-1 --last 20
+  maestro history feat-auth-1 --time | grep "git push"`,
+	Args: cobra.ExactArgs(1),
+	RunE: runHistory,
+}
+
+func init() {
+	rootCmd.AddCommand(historyCmd)
+	historyCmd.Flags().IntVarP(&historyLast, "last", "n", 0, "Only print the last N commands (0 prints all)")
+	historyCmd.Flags().BoolVar(&historyTime, "time", false, "Prefix each command with when it ran, if the shell recorded it")
+}
+
+func runHistory(cmd *cobra.Command, args []string) error {
+	if historyLast < 0 {
+		return fmt.Errorf("--last must not be negative")
+	}
+	containerName := resolveContainerName(args[0])
+
+	entries, err := container.ReadShellHistory(containerName)
+	if err != nil {
+		return err
+	}
+	if historyLast > 0 && len(entries) > historyLast {
+		entries = entries[len(entries)-historyLast:]
+	}
+
+	for _, entry := range entries {
+		if !historyTime {
+			fmt.Println(entry.Command)
+			continue
+		}
+		when := "-"
+		if !entry.Time.IsZero() {
+			when = entry.Time.Local().Format("2006-01-02 15:04:05")
+		}
+		// Line up the rest of a multi-line command under its first line
+		command := strings.ReplaceAll(entry.Command, "\n", "\n"+strings.Repeat(" ", 21))
+		fmt.Printf("%-19s  %s\n", when, command)
+	}
+	return nil
+}
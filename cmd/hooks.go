@@ -0,0 +1,110 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"text/template"
+
+	"github.com/uprockcom/maestro/pkg/container"
+)
+
+// hookFuncs are the helper functions available in hook command templates
+var hookFuncs = template.FuncMap{
+	// quote wraps a value in single quotes for safe use in shell commands
+	"quote": func(v any) string {
+		if task, ok := v.(hookTask); ok {
+			return task.String()
+		}
+		return "'" + strings.ReplaceAll(fmt.Sprint(v), "'", `'\''`) + "'"
+	},
+}
+
+// hookTask is the task text as hook templates see it. It's free text, so
+// rather than pasting it into the sh -c command line, where quoting in the
+// template could let it run commands, it expands to the $MAESTRO_TASK variable.
+type hookTask string
+
+func (t hookTask) String() string {
+	return `"$MAESTRO_TASK"`
+}
+
+// hookData is what hook command templates are executed with
+type hookData struct {
+	container.HookEvent
+	Task hookTask
+}
+
+// renderHookCommand expands a hook command template for e
+func renderHookCommand(command string, e container.HookEvent) (string, error) {
+	tmpl, err := template.New(e.Event).Funcs(hookFuncs).Parse(command)
+	if err != nil {
+		return "", fmt.Errorf("invalid %s hook template: %w", e.Event, err)
+	}
+
+	var rendered bytes.Buffer
+	if err := tmpl.Execute(&rendered, hookData{HookEvent: e, Task: hookTask(e.Task)}); err != nil {
+		return "", fmt.Errorf("failed to render %s hook: %w", e.Event, err)
+	}
+	return rendered.String(), nil
+}
+
+// runLifecycleHook runs the configured command for a lifecycle event.
+// Hook failures are reported as warnings and never abort the operation.
+func runLifecycleHook(e container.HookEvent) {
+	command, ok := currentConfig().Hooks[e.Event]
+	if !ok || strings.TrimSpace(command) == "" {
+		return
+	}
+
+	if e.ShortName == "" {
+		e.ShortName = container.GetShortName(e.Name, currentConfig().Containers.Prefix)
+	}
+
+	rendered, err := renderHookCommand(command, e)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		return
+	}
+
+	hookCmd := exec.Command("sh", "-c", rendered)
+	hookCmd.Env = append(os.Environ(),
+		"MAESTRO_EVENT="+e.Event,
+		"MAESTRO_CONTAINER="+e.Name,
+		"MAESTRO_SHORT_NAME="+e.ShortName,
+		"MAESTRO_BRANCH="+e.Branch,
+		"MAESTRO_TASK="+e.Task,
+	)
+	if output, err := hookCmd.CombinedOutput(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %s hook failed for %s: %v\n", e.Event, e.ShortName, err)
+		if out := strings.TrimSpace(string(output)); out != "" {
+			fmt.Fprintf(os.Stderr, "  %s\n", truncateString(out, 200))
+		}
+	}
+}
+
+// firePostCreateHook fires the post_create hook for a newly created container
+func firePostCreateHook(containerName, branchName, task string) {
+	container.FireHook(container.HookEvent{
+		Event:  container.EventPostCreate,
+		Name:   containerName,
+		Branch: branchName,
+		Task:   task,
+	})
+}
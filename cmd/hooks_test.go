@@ -0,0 +1,78 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/uprockcom/maestro/pkg/container"
+)
+
+func TestRenderHookCommand(t *testing.T) {
+	event := container.HookEvent{
+		Event:     container.EventPostCreate,
+		Name:      "maestro-feat-auth-1",
+		ShortName: "feat-auth-1",
+		Branch:    "feat/auth",
+		Task:      `fix "it"; $(touch pwned) 'now'`,
+	}
+
+	tests := []struct {
+		command string
+		want    string
+	}{
+		{`echo {{.ShortName}} {{.Branch}}`, `echo feat-auth-1 feat/auth`},
+		{`echo {{.Task}}`, `echo "$MAESTRO_TASK"`},
+		{`echo {{quote .Task}}`, `echo "$MAESTRO_TASK"`},
+		{`echo "task: {{.Task}}"`, `echo "task: "$MAESTRO_TASK""`},
+		{`echo {{quote .ShortName}}`, `echo 'feat-auth-1'`},
+	}
+	for _, tt := range tests {
+		got, err := renderHookCommand(tt.command, event)
+		if err != nil {
+			t.Fatalf("renderHookCommand(%q) error = %v", tt.command, err)
+		}
+		if got != tt.want {
+			t.Errorf("renderHookCommand(%q) = %q, want %q", tt.command, got, tt.want)
+		}
+	}
+
+	// The shell sees the task as data, not as a command line
+	rendered, err := renderHookCommand(`printf %s {{.Task}}`, event)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	sh := exec.Command("sh", "-c", rendered)
+	sh.Dir = dir
+	sh.Env = append(os.Environ(), "MAESTRO_TASK="+event.Task)
+	output, err := sh.Output()
+	if err != nil {
+		t.Fatalf("sh -c %q: %v", rendered, err)
+	}
+	if string(output) != event.Task {
+		t.Errorf("hook printed %q, want the task %q", output, event.Task)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "pwned")); err == nil {
+		t.Error("task text was run as a command")
+	}
+	if strings.Contains(rendered, "pwned") {
+		t.Errorf("task text pasted into the command line: %q", rendered)
+	}
+}
@@ -15,7 +15,13 @@
 package cmd
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"text/template"
+	"time"
 
 	"github.com/uprockcom/maestro/pkg/container"
 	"github.com/spf13/cobra"
@@ -25,27 +31,99 @@ var listCmd = &cobra.Command{
 	Use:     "list",
 	Aliases: []string{"ls", "ps"},
 	Short:   "List all maestro containers",
-	Long:    `List all maestro containers with their status and attention indicators.`,
-	RunE:    runList,
+	Long: `List all maestro containers with their status and attention indicators.
+
+Use --format to print one line per container from a Go template instead of
+the table, like docker's --format. Every container field is available:
+  .Name .ShortName .Status .StatusDetails .Branch .NeedsAttention .IsDormant
+  .AuthStatus .LastActivity .GitStatus .CreatedAt .FirewallOff
+The json function prints a value as JSON.
+
+Examples:
+  maestro list --format '{{.ShortName}} {{.Branch}} {{.AuthStatus}}'
+  maestro list --format '{{if .NeedsAttention}}{{.ShortName}}{{end}}'   # Blank lines are skipped
+  maestro list --format '{{json .}}'`,
+	RunE: runList,
+}
+
+var (
+	listShowAge bool
+	listFormat  string
+)
+
+// listFuncs are the helper functions available in --format templates
+var listFuncs = template.FuncMap{
+	"json": func(v any) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
 }
 
 func init() {
 	rootCmd.AddCommand(listCmd)
+	listCmd.Flags().BoolVar(&listShowAge, "age", false, "Show an AGE column (time since creation)")
+	listCmd.Flags().StringVar(&listFormat, "format", "", "Print each container with a Go template instead of the table")
 }
 
 func runList(cmd *cobra.Command, args []string) error {
+	start := time.Now()
+	defer func() { reportTiming("total", time.Since(start)) }()
+
+	// Check the template before spending time gathering
+	var format *template.Template
+	if listFormat != "" {
+		var err error
+		if format, err = template.New("format").Funcs(listFuncs).Parse(listFormat); err != nil {
+			return fmt.Errorf("invalid --format template: %w", err)
+		}
+	}
+
 	// Check if Docker is responsive
-	if !container.IsDockerResponsive() {
+	phase := time.Now()
+	responsive := container.IsDockerResponsive()
+	reportTiming("docker responsiveness check", time.Since(phase))
+	if !responsive {
+		if format != nil {
+			return fmt.Errorf("docker is not responding")
+		}
 		fmt.Println("No maestro containers found.")
 		fmt.Println("\nHint: Is Docker running?")
 		return nil
 	}
 
-	// Get all containers (including stopped ones)
-	containers, err := container.GetAllContainers(config.Containers.Prefix)
+	// Get all containers (including stopped ones). Gathering details can take
+	// a while on large fleets, so show a spinner on a terminal.
+	var spinner *stepSpinner
+	if isTerminal(os.Stdout) {
+		spinner = newStepSpinner()
+	}
+	var gatherTiming container.GatherTiming
+	containers, err := container.GatherAllContainers(currentConfig().Containers.Prefix, container.GatherOptions{
+		Progress: func(done, total int) {
+			step := fmt.Sprintf("Gathering details for %d containers (%d/%d)", total, done, total)
+			switch {
+			case total == 0: // Nothing to gather
+			case done == 0:
+				spinner.Step(step)
+			default:
+				spinner.Update(step)
+			}
+		},
+		Timing: &gatherTiming,
+	})
+	spinner.Clear()
 	if err != nil {
 		return fmt.Errorf("failed to list containers: %w", err)
 	}
+	reportTiming("docker ps", gatherTiming.List)
+	reportTiming(fmt.Sprintf("gather (%d containers)", len(containers)), gatherTiming.Gather)
+	if gatherTiming.Slowest != "" {
+		reportTiming("slowest: "+container.GetShortName(gatherTiming.Slowest, currentConfig().Containers.Prefix), gatherTiming.SlowestGather)
+	}
+
+	if format != nil {
+		return renderListFormat(os.Stdout, format, container.SortByPriority(containers))
+	}
 
 	if len(containers) == 0 {
 		fmt.Println("No maestro containers found.")
@@ -54,10 +132,13 @@ func runList(cmd *cobra.Command, args []string) error {
 	}
 
 	// Display using unified display function
+	phase = time.Now()
 	container.Display(containers, container.DisplayOptions{
 		ShowNumbers: false,
 		ShowTable:   true,
+		ShowAge:     listShowAge,
 	})
+	reportTiming("render", time.Since(phase))
 
 	// Show quick help
 	fmt.Println("\nCommands:")
@@ -65,8 +146,26 @@ func runList(cmd *cobra.Command, args []string) error {
 	fmt.Println("  maestro stop <name>       - Stop container")
 	fmt.Println("  maestro cleanup           - Remove stopped containers")
 
+	printImageFreshnessWarning(containers)
+
 	// Show daemon nag if not running
 	showDaemonNag()
 
 	return nil
-}
\ No newline at end of file
+}
+
+// renderListFormat writes one line per container from the --format template.
+// Containers the template renders as nothing are skipped, so templates can filter.
+func renderListFormat(w io.Writer, format *template.Template, containers []container.Info) error {
+	var line bytes.Buffer
+	for _, c := range containers {
+		line.Reset()
+		if err := format.Execute(&line, c); err != nil {
+			return fmt.Errorf("failed to render --format template: %w", err)
+		}
+		if line.Len() > 0 {
+			fmt.Fprintln(w, line.String())
+		}
+	}
+	return nil
+}
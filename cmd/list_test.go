@@ -0,0 +1,59 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+	"text/template"
+
+	"github.com/uprockcom/maestro/pkg/container"
+)
+
+func TestRenderListFormat(t *testing.T) {
+	containers := []container.Info{
+		{Name: "maestro-feat-a-1", ShortName: "feat-a-1", Branch: "feat/a", NeedsAttention: true},
+		{Name: "maestro-feat-b-1", ShortName: "feat-b-1", Branch: "feat/b"},
+	}
+
+	tests := []struct {
+		name   string
+		format string
+		want   string
+	}{
+		{"fields", "{{.ShortName}} {{.Branch}}", "feat-a-1 feat/a\nfeat-b-1 feat/b\n"},
+		{"empty lines are skipped", "{{if .NeedsAttention}}{{.ShortName}}{{end}}", "feat-a-1\n"},
+		{"json", "{{json .ShortName}}", "\"feat-a-1\"\n\"feat-b-1\"\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			format := template.Must(template.New("format").Funcs(listFuncs).Parse(tt.format))
+			var out bytes.Buffer
+			if err := renderListFormat(&out, format, containers); err != nil {
+				t.Fatalf("renderListFormat() error = %v", err)
+			}
+			if out.String() != tt.want {
+				t.Errorf("renderListFormat() = %q, want %q", out.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderListFormatUnknownField(t *testing.T) {
+	format := template.Must(template.New("format").Parse("{{.Nope}}"))
+	if err := renderListFormat(&bytes.Buffer{}, format, []container.Info{{Name: "x"}}); err == nil {
+		t.Error("renderListFormat() accepted an unknown field")
+	}
+}
@@ -28,17 +28,29 @@ import (
 	"strings"
 	"sync"
 	"time"
+	"unicode"
 
 	"github.com/spf13/cobra"
 	"github.com/uprockcom/maestro/assets"
 	"github.com/uprockcom/maestro/pkg/container"
+	"github.com/uprockcom/maestro/pkg/ignore"
+	"github.com/uprockcom/maestro/pkg/paths"
+	"github.com/uprockcom/maestro/pkg/system"
 	"github.com/uprockcom/maestro/pkg/version"
+	"golang.org/x/text/unicode/norm"
 )
 
 var (
-	specFile    string
-	noConnect   bool
-	exactPrompt bool
+	specFile          string
+	noConnect         bool
+	exactPrompt       bool
+	privatePrompt     bool
+	connectAfterReady bool
+	extraMounts       []string
+	customConnect     string
+	imagePullPolicy   string
+	newDryRun         bool
+	allowDomains      []string
 )
 
 var newCmd = &cobra.Command{
@@ -50,24 +62,62 @@ Examples:
   mcl new "implement user authentication"
   mcl new --file specs/auth-design.md
   mcl new -f requirements.txt
+  mcl new --task-file task.md                     # Same as --file
+  pbpaste | mcl new --task-file -                 # Read the task from stdin (implies --no-connect)
   mcl new "add tests" --no-connect
+  mcl new "add tests" --connect-after-ready       # Attach once Claude is running
   mcl new -e "/pr_review 123"     # Use exact prompt (no AI transformation)
-  mcl new -en "/help"              # Combine flags: exact + no-connect`,
+  mcl new -en "/help"              # Combine flags: exact + no-connect
+  mcl new "train model" --mount ~/datasets:/data:ro
+  mcl new "explore data" --connect-cmd "python repl.py"
+  mcl new "call billing api" --allow-domain billing.internal.example.com
+  mcl new "fix typo" --image-pull-policy never   # Offline: use the local image only
+  mcl new "profile api" --preset big-backend      # Apply a preset from config
+  mcl new "add caching" --dry-run                 # Print the plan without creating anything
+  mcl new -f incident.md --private-prompt         # Keep the prompt out of docker's records`,
 	RunE: runNew,
 }
 
 func init() {
 	rootCmd.AddCommand(newCmd)
-	newCmd.Flags().StringVarP(&specFile, "file", "f", "", "Read task specification from file")
+	newCmd.Flags().StringVarP(&specFile, "file", "f", "", "Read task specification from file ('-' for stdin)")
+	newCmd.Flags().StringVar(&specFile, "task-file", "", "Read the task description from file ('-' for stdin); same as --file")
 	newCmd.Flags().BoolVarP(&noConnect, "no-connect", "n", false, "Don't automatically connect after creation")
+	newCmd.Flags().BoolVar(&connectAfterReady, "connect-after-ready", false, "Wait until Claude is running before connecting")
+	newCmd.MarkFlagsMutuallyExclusive("no-connect", "connect-after-ready")
 	newCmd.Flags().BoolVarP(&exactPrompt, "exact", "e", false, "Use exact prompt without AI transformation")
+	newCmd.Flags().StringArrayVar(&extraMounts, "mount", nil, "Bind mount a host path as host:container[:ro] (repeatable)")
+	newCmd.Flags().StringArrayVar(&allowDomains, "allow-domain", nil, "Allow a domain through this container's firewall on top of the config (repeatable)")
+	newCmd.Flags().StringVar(&customConnect, "connect-cmd", "", "Command to run on connect instead of attaching to tmux")
+	newCmd.Flags().StringVar(&imagePullPolicy, "image-pull-policy", "", "When to pull the container image: always, missing, or never (default from config)")
+	newCmd.Flags().StringVar(&presetName, "preset", "", "Apply a named preset from config (see 'maestro preset list')")
+	newCmd.Flags().BoolVar(&privatePrompt, "private-prompt", false, "Send the task prompt without it appearing in docker exec arguments, and delete it from the container once sent")
+	newCmd.Flags().BoolVar(&newDryRun, "dry-run", false, "Print the branch, container name, and settings that would be used, then exit")
 }
 
 func runNew(cmd *cobra.Command, args []string) error {
+	if err := applyPreset(presetName); err != nil {
+		return err
+	}
+
+	// Validate mounts, domains, and pull policy before doing any expensive work
+	if _, err := parseMounts(extraMounts); err != nil {
+		return err
+	}
+	if err := validateAllowDomains(allowDomains); err != nil {
+		return err
+	}
+	if _, err := effectivePullPolicy(); err != nil {
+		return err
+	}
+
 	// Get task description
 	var taskDescription string
 	if specFile != "" {
-		content, err := os.ReadFile(specFile)
+		if len(args) > 0 {
+			return fmt.Errorf("give the task either as arguments or with --file/--task-file, not both")
+		}
+		content, err := readTaskFile(specFile)
 		if err != nil {
 			return fmt.Errorf("failed to read spec file: %w", err)
 		}
@@ -81,10 +131,18 @@ func runNew(cmd *cobra.Command, args []string) error {
 		taskDescription = strings.TrimSpace(desc)
 	}
 
-	if taskDescription == "" {
+	if strings.TrimSpace(taskDescription) == "" {
 		return fmt.Errorf("task description is required")
 	}
 
+	// Once the task came from stdin, or stdin isn't a terminal, nobody can
+	// answer prompts and there's no TTY to attach to
+	interactive := specFile != "-" && isTerminal(os.Stdin)
+	if !interactive && !noConnect && !newDryRun {
+		progressln("stdin isn't a terminal, so not connecting afterwards (implies --no-connect)")
+		noConnect = true
+	}
+
 	fmt.Printf("Creating container for: %s\n", truncateString(taskDescription, 80))
 
 	// Step 1: Generate branch name and planning prompt using Claude
@@ -95,6 +153,9 @@ func runNew(cmd *cobra.Command, args []string) error {
 
 	// Validate the branch name and prompt user if invalid
 	if !isValidBranchName(branchName) {
+		if !interactive {
+			return fmt.Errorf("generated branch name '%s' is invalid", branchName)
+		}
 		fmt.Printf("Generated branch name '%s' is invalid.\n", branchName)
 		branchName, err = promptUserForBranchName(taskDescription)
 		if err != nil {
@@ -102,12 +163,21 @@ func runNew(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Offer to reuse an existing container on the same branch
+	if !newDryRun && offerExistingContainer(branchName, interactive) {
+		return nil
+	}
+
 	// Step 2: Get next container number
 	containerName, err := getNextContainerName(branchName)
 	if err != nil {
 		return fmt.Errorf("failed to generate container name: %w", err)
 	}
 
+	if newDryRun {
+		return printCreatePlan(containerName, branchName, planningPrompt, exactPrompt)
+	}
+
 	fmt.Printf("Container name: %s\n", containerName)
 	fmt.Printf("Branch name: %s\n", branchName)
 
@@ -116,6 +186,10 @@ func runNew(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to ensure Docker image: %w", err)
 	}
 
+	if err := checkDiskSpace(1); err != nil {
+		return err
+	}
+
 	// Step 4: Start container
 	if err := startContainer(containerName); err != nil {
 		return fmt.Errorf("failed to start container: %w", err)
@@ -153,39 +227,65 @@ func runNew(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to start tmux session: %w", err)
 	}
 
+	firePostCreateHook(containerName, branchName, taskDescription)
+
 	fmt.Printf("\n✅ Container %s is ready!\n", containerName)
 
 	// Auto-connect unless --no-connect flag is set
 	if !noConnect {
-		fmt.Println("\nConnecting to container...")
-		fmt.Println("Detach with: Ctrl+b d")
-		fmt.Println("Switch windows: Ctrl+b 0 (Claude), Ctrl+b 1 (shell)")
-
-		// Connect to tmux session
-		connectCmd := exec.Command("docker", "exec", "-it", containerName, "tmux", "attach", "-t", "main")
-		connectCmd.Stdin = os.Stdin
-		connectCmd.Stdout = os.Stdout
-		connectCmd.Stderr = os.Stderr
-
-		if err := connectCmd.Run(); err != nil {
-			fmt.Printf("\nWarning: Failed to connect: %v\n", err)
-			fmt.Printf("You can connect later with: maestro connect %s\n", container.GetShortName(containerName, config.Containers.Prefix))
-		}
+		connectNewContainer(containerName, branchName, connectAfterReady)
 	} else {
-		fmt.Printf("Connect with: maestro connect %s\n", container.GetShortName(containerName, config.Containers.Prefix))
-		fmt.Printf("Detach with: Ctrl+b d\n")
+		printCreateSummary(containerName, branchName, false)
 	}
 
 	return nil
 }
 
+// printCreateSummary prints the name and branch of a newly created container
+// and the commands to use it. attached says whether the user has already
+// been connected (and has now detached), which turns "connect" into
+// "reconnect".
+func printCreateSummary(containerName, branchName string, attached bool) {
+	fmt.Println()
+	fmt.Printf("Container: %s\n", containerName)
+	fmt.Printf("Branch:    %s\n", branchName)
+	fmt.Println("\nNext steps:")
+	for _, line := range nextStepHints(container.GetShortName(containerName, currentConfig().Containers.Prefix), containerName, attached) {
+		fmt.Println("  " + line)
+	}
+}
+
+// nextStepHints returns the commands to connect to, inspect, and stop a
+// container, aligned and commented for printing
+func nextStepHints(shortName, containerName string, attached bool) []string {
+	connect := "Attach to Claude (detach with Ctrl+b d)"
+	if attached {
+		connect = "Reconnect (detach with Ctrl+b d)"
+	}
+	hints := [][2]string{
+		{"maestro connect " + shortName, connect},
+		{"docker logs " + containerName, "Container logs (startup, firewall)"},
+		{"maestro stop " + shortName, "Stop it when you're done"},
+	}
+
+	width := 0
+	for _, h := range hints {
+		width = max(width, len(h[0]))
+	}
+	lines := make([]string, len(hints))
+	for i, h := range hints {
+		lines[i] = fmt.Sprintf("%-*s  # %s", width, h[0], h[1])
+	}
+	return lines
+}
+
 func generateBranchAndPrompt(taskDescription string, exact bool) (string, string, error) {
 	// In exact mode, still generate branch name via AI but use literal prompt
 	if exact {
 		branchName, err := generateBranchNameOnly(taskDescription)
 		if err != nil {
 			// Fallback to simple branch name generation
-			branchName = generateSimpleBranch(taskDescription)
+			branchName = generateSimpleBranch(taskDescription, nil)
 		}
 		// Return the exact task description as the prompt
 		return branchName, taskDescription, nil
@@ -283,7 +383,7 @@ Prefixes: feat/ fix/ refactor/ docs/ test/ review/ chore/`, taskDescription)
 	}
 
 	// Fallback to simple branch name generation
-	simpleBranch := generateSimpleBranch(taskDescription)
+	simpleBranch := generateSimpleBranch(taskDescription, nil)
 	planningPrompt := fmt.Sprintf(`Please plan the implementation for the following task:
 
 %s
@@ -381,6 +481,41 @@ Output ONLY the branch name:`, taskDescription)
 	return "", fmt.Errorf("failed to generate valid branch name after %d attempts", maxRetries)
 }
 
+// parseMounts converts --mount specs (host:container[:ro]) into docker -v arguments.
+// Host paths must exist; container paths must be absolute.
+func parseMounts(specs []string) ([]string, error) {
+	var args []string
+	for _, spec := range specs {
+		parts := strings.Split(spec, ":")
+		if len(parts) < 2 || len(parts) > 3 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("invalid --mount %q: expected host:container[:ro]", spec)
+		}
+
+		mode := ""
+		if len(parts) == 3 {
+			if parts[2] != "ro" && parts[2] != "rw" {
+				return nil, fmt.Errorf("invalid --mount %q: mode must be 'ro' or 'rw'", spec)
+			}
+			mode = ":" + parts[2]
+		}
+
+		hostPath, err := filepath.Abs(expandPath(parts[0]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid --mount %q: %w", spec, err)
+		}
+		if _, err := os.Stat(hostPath); err != nil {
+			return nil, fmt.Errorf("invalid --mount %q: host path %s does not exist", spec, hostPath)
+		}
+
+		if !strings.HasPrefix(parts[1], "/") {
+			return nil, fmt.Errorf("invalid --mount %q: container path must be absolute", spec)
+		}
+
+		args = append(args, "-v", fmt.Sprintf("%s:%s%s", hostPath, parts[1], mode))
+	}
+	return args, nil
+}
+
 // isValidBranchName checks if a string looks like a valid git branch name
 // (lowercase with optional prefix like feat/, fix/, etc. containing only alphanumeric and hyphens)
 func isValidBranchName(name string) bool {
@@ -426,44 +561,116 @@ func promptUserForBranchName(taskDescription string) (string, error) {
 	return branchName, nil
 }
 
-func generateSimpleBranch(description string) string {
-	// Simple branch name generation from description
-	desc := strings.ToLower(description)
+// simpleBranchMaxLen caps the description part of a generated branch name
+const simpleBranchMaxLen = 35
 
-	// Remove common filler words to keep it concise
-	fillerWords := []string{"the", "a", "an", "and", "or", "but", "in", "on", "at", "to", "for"}
-	words := strings.Fields(desc)
-	var filtered []string
-	for _, word := range words {
-		isFillerWord := false
-		for _, filler := range fillerWords {
-			if word == filler {
-				isFillerWord = true
-				break
-			}
-		}
-		if !isFillerWord {
-			filtered = append(filtered, word)
-		}
+// simpleBranchFillers are words dropped from generated branch names
+var simpleBranchFillers = map[string]bool{
+	"the": true, "a": true, "an": true, "and": true, "or": true, "but": true,
+	"in": true, "on": true, "at": true, "to": true, "for": true,
+}
+
+// generateSimpleBranch builds a feat/ branch name from a description without
+// the AI. Accents are folded to plain letters, apostrophes dropped, other
+// punctuation and non-Latin text treated as word breaks, and filler words
+// removed. If taken is non-nil and reports the name as in use, a -2, -3, ...
+// suffix is added until it isn't.
+func generateSimpleBranch(description string, taken func(branch string) bool) string {
+	words := simpleBranchWords(description)
+	if len(words) == 0 {
+		words = []string{fmt.Sprintf("task-%d", time.Now().Unix()%100000)}
+	}
+
+	branch := "feat/" + joinBranchWords(words, simpleBranchMaxLen)
+	if taken == nil {
+		return branch
+	}
+	for n := 2; taken(branch); n++ {
+		suffix := fmt.Sprintf("-%d", n)
+		branch = "feat/" + joinBranchWords(words, simpleBranchMaxLen-len(suffix)) + suffix
 	}
-	desc = strings.Join(filtered, " ")
+	return branch
+}
 
-	// Convert to branch-safe format
-	desc = regexp.MustCompile(`[^a-z0-9-]+`).ReplaceAllString(desc, "-")
-	desc = strings.Trim(desc, "-")
+// simpleBranchWords splits a description into lowercase ASCII words suitable
+// for a branch name, without filler words
+func simpleBranchWords(description string) []string {
+	// Decompose accented letters and drop the accents: "café" -> "cafe"
+	decomposed := norm.NFD.String(strings.ToLower(description))
+	cleaned := strings.Map(func(r rune) rune {
+		switch {
+		case unicode.Is(unicode.Mn, r), r == '\'', r == '’':
+			return -1
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			return r
+		default:
+			return ' '
+		}
+	}, decomposed)
+
+	var words []string
+	for _, word := range strings.Fields(cleaned) {
+		if !simpleBranchFillers[word] {
+			words = append(words, word)
+		}
+	}
+	return words
+}
 
-	// Keep it short (max 35 chars for the description part)
-	if len(desc) > 35 {
-		desc = desc[:35]
+// joinBranchWords joins words with hyphens, cutting at a word boundary to fit
+// maxLen where possible
+func joinBranchWords(words []string, maxLen int) string {
+	joined := strings.Join(words, "-")
+	if len(joined) <= maxLen {
+		return joined
+	}
+	cut := joined[:maxLen]
+	if i := strings.LastIndexByte(cut, '-'); i > 0 && joined[maxLen] != '-' {
+		cut = cut[:i]
 	}
-	desc = strings.TrimRight(desc, "-")
+	return strings.Trim(cut, "-")
+}
 
-	// Handle edge case where description has no usable characters
-	if desc == "" {
-		desc = fmt.Sprintf("task-%d", time.Now().Unix()%100000)
+// findContainersOnBranch returns running containers that already have branchName checked out
+func findContainersOnBranch(branchName string) []container.Info {
+	containers, err := container.GetRunningContainers(currentConfig().Containers.Prefix)
+	if err != nil {
+		return nil
 	}
+	return container.FindByBranch(containers, branchName)
+}
 
-	return fmt.Sprintf("feat/%s", desc)
+// offerExistingContainer warns that branchName is already in use and, if ask is
+// set, asks whether to connect to the existing container instead. Returns true if
+// the user chose to connect (and the connection was attempted), false to continue creating.
+func offerExistingContainer(branchName string, ask bool) bool {
+	existing := findContainersOnBranch(branchName)
+	if len(existing) == 0 {
+		return false
+	}
+
+	fmt.Printf("\n⚠️  Branch '%s' already has %d running container(s):\n", branchName, len(existing))
+	for _, c := range existing {
+		fmt.Printf("  - %s\n", c.ShortName)
+	}
+	if !ask {
+		fmt.Println("Creating another one.")
+		return false
+	}
+
+	fmt.Printf("Connect to %s instead of creating a duplicate? (y/N): ", existing[0].ShortName)
+	reader := bufio.NewReader(os.Stdin)
+	response, _ := reader.ReadString('\n')
+	response = strings.TrimSpace(strings.ToLower(response))
+	if response != "y" && response != "yes" {
+		fmt.Println()
+		return false
+	}
+
+	if err := attachToContainer(existing[0].Name); err != nil {
+		fmt.Printf("\nWarning: Failed to connect: %v\n", err)
+	}
+	return true
 }
 
 func getNextContainerName(branchName string) (string, error) {
@@ -482,14 +689,14 @@ func getNextContainerName(branchName string) (string, error) {
 	}
 
 	// Check existing containers
-	cmd := exec.Command("docker", "ps", "-a", "--format", "{{.Names}}")
+	cmd := system.DockerCommand("ps", "-a", "--format", "{{.Names}}")
 	output, err := cmd.Output()
 	if err != nil {
 		return "", err
 	}
 
 	// Find highest number for this base name
-	containerPrefix := config.Containers.Prefix + baseName
+	containerPrefix := currentConfig().Containers.Prefix + baseName
 	maxNum := 0
 	for _, name := range strings.Split(string(output), "\n") {
 		if strings.HasPrefix(name, containerPrefix+"-") {
@@ -508,6 +715,68 @@ func getNextContainerName(branchName string) (string, error) {
 	return fmt.Sprintf("%s-%d", containerPrefix, maxNum+1), nil
 }
 
+// printCreatePlan prints what 'maestro new' would create, for --dry-run
+func printCreatePlan(containerName, branchName, planningPrompt string, exact bool) error {
+	mountArgs, err := parseMounts(extraMounts)
+	if err != nil {
+		return err
+	}
+	policy, err := effectivePullPolicy()
+	if err != nil {
+		return err
+	}
+
+	cfg := currentConfig()
+	fmt.Println("\nDry run - nothing will be created.")
+	fmt.Printf("\nContainer: %s\n", containerName)
+	fmt.Printf("Branch:    %s\n", branchName)
+	fmt.Printf("Image:     %s (pull policy: %s)\n", getDockerImage(), policy)
+	fmt.Printf("Memory:    %s\n", cfg.Containers.Resources.Memory)
+	fmt.Printf("CPUs:      %s\n", cfg.Containers.Resources.CPUs)
+
+	if len(mountArgs) > 0 {
+		fmt.Println("\nExtra mounts:")
+		for i := 1; i < len(mountArgs); i += 2 {
+			fmt.Printf("  %s\n", mountArgs[i])
+		}
+	}
+	if len(presetEnv) > 0 {
+		fmt.Println("\nEnvironment:")
+		for _, kv := range presetEnv {
+			fmt.Printf("  %s\n", kv)
+		}
+	}
+
+	fmt.Printf("\nAllowed domains (%d):\n", len(cfg.Firewall.AllowedDomains))
+	for _, domain := range cfg.Firewall.AllowedDomains {
+		fmt.Printf("  %s\n", domain)
+	}
+	if len(allowDomains) > 0 {
+		fmt.Printf("\nAllowed for this container only (%d):\n", len(allowDomains))
+		for _, domain := range allowDomains {
+			fmt.Printf("  %s\n", domain)
+		}
+	}
+	if len(cfg.Firewall.InternalDomains) > 0 {
+		fmt.Printf("\nInternal domains (%d):\n", len(cfg.Firewall.InternalDomains))
+		for _, domain := range cfg.Firewall.InternalDomains {
+			fmt.Printf("  %s\n", domain)
+		}
+	}
+
+	launch := claudeLaunchCommand
+	if customConnect != "" {
+		launch += fmt.Sprintf(" (connect command: %s)", customConnect)
+	}
+	fmt.Printf("\nClaude command: %s\n", launch)
+	if privatePromptEnabled() {
+		fmt.Println("\nInitial prompt: (private, not shown)")
+		return nil
+	}
+	fmt.Printf("\nInitial prompt:\n%s\n", initialTaskPrompt(planningPrompt, exact))
+	return nil
+}
+
 // getDockerImage returns the container image to use, prioritizing embedded version.
 // Priority:
 //  1. Embedded version (from pkg/version) - PRODUCTION PATH
@@ -517,32 +786,115 @@ func getDockerImage() string {
 	versionImage := version.GetContainerImage()
 
 	// If config is empty or matches default, use version-synchronized image
-	if config.Containers.Image == "" || config.Containers.Image == "ghcr.io/uprockcom/maestro:latest" {
+	if currentConfig().Containers.Image == "" || currentConfig().Containers.Image == "ghcr.io/uprockcom/maestro:latest" {
 		return versionImage
 	}
 
 	// User has explicitly overridden - respect their choice
 	// This allows advanced users to pin to specific versions or use local builds
-	return config.Containers.Image
+	return currentConfig().Containers.Image
+}
+
+// Image pull policies for ensureDockerImage
+const (
+	pullAlways  = "always"  // Pull on every create; fall back to the local image if the pull fails
+	pullMissing = "missing" // Pull (or build) only if the image isn't present locally
+	pullNever   = "never"   // Never touch the network; the image must already exist locally
+)
+
+// effectivePullPolicy returns the --image-pull-policy flag if set, else the configured policy
+func effectivePullPolicy() (string, error) {
+	policy := currentConfig().Containers.ImagePullPolicy
+	if imagePullPolicy != "" {
+		policy = imagePullPolicy
+	}
+	switch policy {
+	case pullAlways, pullMissing, pullNever:
+		return policy, nil
+	case "":
+		return pullMissing, nil
+	default:
+		return "", fmt.Errorf("invalid image pull policy %q (expected always, missing, or never)", policy)
+	}
+}
+
+// checkDiskSpace warns when the disk holding docker's data is below
+// containers.disk_space.warn_below, and fails when it is below block_below.
+// count is the number of containers about to be created. If the free space
+// can't be measured, creation goes ahead.
+func checkDiskSpace(count int) error {
+	cfg := currentConfig()
+	var warnBelow, blockBelow int64
+	var err error
+	if cfg.Containers.DiskSpace.WarnBelow != "" {
+		if warnBelow, err = container.ParseByteSize(cfg.Containers.DiskSpace.WarnBelow); err != nil {
+			return fmt.Errorf("invalid containers.disk_space.warn_below: %w", err)
+		}
+	}
+	if cfg.Containers.DiskSpace.BlockBelow != "" {
+		if blockBelow, err = container.ParseByteSize(cfg.Containers.DiskSpace.BlockBelow); err != nil {
+			return fmt.Errorf("invalid containers.disk_space.block_below: %w", err)
+		}
+	}
+	if warnBelow == 0 && blockBelow == 0 {
+		return nil
+	}
+
+	free, err := container.DockerFreeSpace(getDockerImage())
+	if err != nil {
+		return nil
+	}
+
+	if free < blockBelow {
+		return fmt.Errorf("only %s free on docker's disk, below containers.disk_space.block_below (%s)\nFree up space with 'maestro cleanup' or 'docker system prune', or lower the threshold",
+			formatBytes(free), cfg.Containers.DiskSpace.BlockBelow)
+	}
+	if free < warnBelow {
+		fmt.Printf("⚠️  Warning: Only %s free on docker's disk (containers.disk_space.warn_below is %s).\n",
+			formatBytes(free), cfg.Containers.DiskSpace.WarnBelow)
+		if count > 1 {
+			fmt.Printf("   Each of the %d containers gets its own copy of the project.\n", count)
+		}
+		fmt.Println("   Creation may fail with 'no space left on device'; run 'maestro cleanup' or 'docker system prune' to free space.")
+	}
+	return nil
 }
 
 func ensureDockerImage() error {
+	policy, err := effectivePullPolicy()
+	if err != nil {
+		return err
+	}
+
 	// Use the image determined by priority logic
 	imageName := getDockerImage()
-	cmd := exec.Command("docker", "images", "-q", imageName)
+	cmd := system.DockerCommand("images", "-q", imageName)
 	output, err := cmd.Output()
 	if err != nil {
 		return err
 	}
+	exists := len(output) > 0
+
+	if policy == pullNever {
+		if !exists {
+			return fmt.Errorf("image %s not found locally and image pull policy is 'never'\nTry: docker pull %s", imageName, imageName)
+		}
+		return nil
+	}
+
+	isRegistryImage := strings.Contains(imageName, "ghcr.io") || strings.Contains(imageName, "docker.io")
+
+	if exists && policy == pullAlways && isRegistryImage {
+		if err := pullDockerImage(imageName); err != nil {
+			fmt.Println("Warning: Failed to pull from registry, using local image")
+		}
+		return nil
+	}
 
-	if len(output) == 0 {
+	if !exists {
 		// Image doesn't exist - try to pull from registry first
-		if strings.Contains(imageName, "ghcr.io") || strings.Contains(imageName, "docker.io") {
-			fmt.Printf("Pulling Docker image from registry: %s\n", imageName)
-			pullCmd := exec.Command("docker", "pull", imageName)
-			pullCmd.Stdout = os.Stdout
-			pullCmd.Stderr = os.Stderr
-			if err := pullCmd.Run(); err == nil {
+		if isRegistryImage {
+			if err := pullDockerImage(imageName); err == nil {
 				fmt.Println("✓ Image pulled successfully")
 				return nil
 			}
@@ -550,37 +902,62 @@ func ensureDockerImage() error {
 		}
 
 		// Fall back to building locally (for development)
-		fmt.Println("Building Docker image locally...")
-		dockerDir := "docker"
-		if _, err := os.Stat(dockerDir); os.IsNotExist(err) {
-			// Try relative to mcl binary location
-			mclDir := filepath.Dir(os.Args[0])
-			dockerDir = filepath.Join(mclDir, "docker")
-		}
+		return buildDockerImage(imageName, false)
+	}
 
-		// Check if docker directory exists
-		if _, err := os.Stat(dockerDir); os.IsNotExist(err) {
-			return fmt.Errorf("docker image not found and cannot build (no docker/ directory found)\nTry: docker pull %s", imageName)
-		}
+	return nil
+}
 
-		buildCmd := exec.Command("docker", "build", "-t", imageName, dockerDir)
-		buildCmd.Stdout = os.Stdout
-		buildCmd.Stderr = os.Stderr
-		return buildCmd.Run()
+// pullDockerImage pulls imageName from its registry, streaming docker's output
+func pullDockerImage(imageName string) error {
+	fmt.Printf("Pulling Docker image from registry: %s\n", imageName)
+	pullCmd := system.DockerCommand("pull", imageName)
+	pullCmd.Stdout = os.Stdout
+	pullCmd.Stderr = os.Stderr
+	return pullCmd.Run()
+}
+
+// buildDockerImage builds imageName from the docker/ directory next to the
+// working directory or the maestro binary
+func buildDockerImage(imageName string, noCache bool) error {
+	fmt.Println("Building Docker image locally...")
+	dockerDir := "docker"
+	if _, err := os.Stat(dockerDir); os.IsNotExist(err) {
+		// Try relative to mcl binary location
+		mclDir := filepath.Dir(os.Args[0])
+		dockerDir = filepath.Join(mclDir, "docker")
 	}
 
-	return nil
+	// Check if docker directory exists
+	if _, err := os.Stat(dockerDir); os.IsNotExist(err) {
+		return fmt.Errorf("docker image not found and cannot build (no docker/ directory found)\nTry: docker pull %s", imageName)
+	}
+
+	args := []string{"build", "-t", imageName}
+	if noCache {
+		args = append(args, "--no-cache")
+	}
+	buildCmd := system.DockerCommand(append(args, dockerDir)...)
+	buildCmd.Stdout = os.Stdout
+	buildCmd.Stderr = os.Stderr
+	return buildCmd.Run()
 }
 
 func startContainer(containerName string) error {
+	// Use version-synchronized image (or config override if set)
+	return startContainerFromImage(containerName, getDockerImage())
+}
+
+// startContainerFromImage starts and initializes a container from the given image
+func startContainerFromImage(containerName, image string) error {
 	// Ensure Claude auth directory exists
-	authPath := expandPath(config.Claude.AuthPath)
+	authPath := expandPath(currentConfig().Claude.AuthPath)
 	if err := os.MkdirAll(authPath, 0755); err != nil {
 		return fmt.Errorf("failed to create Claude auth directory: %w", err)
 	}
 
 	// Check if credentials and config exist, warn if not
-	credPath := filepath.Join(authPath, ".credentials.json")
+	credPath := paths.FindCredentials(authPath)
 	configPath := filepath.Join(authPath, ".claude.json")
 
 	credExists := false
@@ -594,7 +971,7 @@ func startContainer(containerName string) error {
 	}
 
 	// Skip credential checks when using Bedrock (uses AWS auth instead)
-	if config.Bedrock.Enabled {
+	if currentConfig().Bedrock.Enabled {
 		if !configExists {
 			fmt.Println("⚠️  Warning: Missing .claude.json configuration.")
 			fmt.Println("Run 'maestro auth' to copy config from ~/.claude")
@@ -602,7 +979,7 @@ func startContainer(containerName string) error {
 	} else if !credExists || !configExists {
 		fmt.Println("⚠️  Warning: Claude authentication/configuration incomplete.")
 		if !credExists {
-			fmt.Println("  - Missing .credentials.json")
+			fmt.Printf("  - Missing %s\n", paths.CredentialsFile())
 		}
 		if !configExists {
 			fmt.Println("  - Missing .claude.json")
@@ -637,8 +1014,8 @@ func startContainer(containerName string) error {
 		"--name", containerName,
 		"--hostname", containerName,
 		"--cap-add", "NET_ADMIN", // For iptables
-		"--memory", config.Containers.Resources.Memory,
-		"--cpus", config.Containers.Resources.CPUs,
+		"--memory", currentConfig().Containers.Resources.Memory,
+		"--cpus", currentConfig().Containers.Resources.CPUs,
 	}
 
 	// Add cache volumes for persistence
@@ -662,7 +1039,7 @@ func startContainer(containerName string) error {
 	}
 
 	// Mount AWS config and credentials for Bedrock support
-	if config.AWS.Enabled || config.Bedrock.Enabled {
+	if currentConfig().AWS.Enabled || currentConfig().Bedrock.Enabled {
 		homeDir, _ := os.UserHomeDir()
 		awsDir := filepath.Join(homeDir, ".aws")
 		if _, err := os.Stat(awsDir); err == nil {
@@ -673,26 +1050,26 @@ func startContainer(containerName string) error {
 		}
 
 		// Set AWS environment variables
-		if config.AWS.Profile != "" {
-			args = append(args, "-e", fmt.Sprintf("AWS_PROFILE=%s", config.AWS.Profile))
+		if currentConfig().AWS.Profile != "" {
+			args = append(args, "-e", fmt.Sprintf("AWS_PROFILE=%s", currentConfig().AWS.Profile))
 		}
-		if config.AWS.Region != "" {
-			args = append(args, "-e", fmt.Sprintf("AWS_REGION=%s", config.AWS.Region))
-			args = append(args, "-e", fmt.Sprintf("AWS_DEFAULT_REGION=%s", config.AWS.Region))
+		if currentConfig().AWS.Region != "" {
+			args = append(args, "-e", fmt.Sprintf("AWS_REGION=%s", currentConfig().AWS.Region))
+			args = append(args, "-e", fmt.Sprintf("AWS_DEFAULT_REGION=%s", currentConfig().AWS.Region))
 		}
 
 		// Set Bedrock environment variables
-		if config.Bedrock.Enabled {
+		if currentConfig().Bedrock.Enabled {
 			args = append(args, "-e", "CLAUDE_CODE_USE_BEDROCK=1")
-			if config.Bedrock.Model != "" {
-				args = append(args, "-e", fmt.Sprintf("ANTHROPIC_MODEL=%s", config.Bedrock.Model))
+			if currentConfig().Bedrock.Model != "" {
+				args = append(args, "-e", fmt.Sprintf("ANTHROPIC_MODEL=%s", currentConfig().Bedrock.Model))
 			}
 		}
 	}
 
 	// Mount SSH agent socket for git authentication (more secure than mounting keys)
 	// Only the agent socket is exposed - private keys stay on the host
-	if config.SSH.Enabled {
+	if currentConfig().SSH.Enabled {
 		sshAuthSock := os.Getenv("SSH_AUTH_SOCK")
 		if sshAuthSock != "" {
 			args = append(args,
@@ -704,8 +1081,8 @@ func startContainer(containerName string) error {
 		}
 
 		// Mount known_hosts from host to avoid SSH host key verification prompts
-		if config.SSH.KnownHostsPath != "" {
-			knownHostsPath := expandPath(config.SSH.KnownHostsPath)
+		if currentConfig().SSH.KnownHostsPath != "" {
+			knownHostsPath := expandPath(currentConfig().SSH.KnownHostsPath)
 			if _, err := os.Stat(knownHostsPath); err == nil {
 				args = append(args,
 					"-v", fmt.Sprintf("%s:/home/node/.ssh/known_hosts:ro", knownHostsPath),
@@ -715,8 +1092,8 @@ func startContainer(containerName string) error {
 	}
 
 	// Mount Android SDK if configured (read-only for safety)
-	if config.Android.SDKPath != "" {
-		sdkPath := expandPath(config.Android.SDKPath)
+	if currentConfig().Android.SDKPath != "" {
+		sdkPath := expandPath(currentConfig().Android.SDKPath)
 		if _, err := os.Stat(sdkPath); err == nil {
 			args = append(args,
 				"-v", fmt.Sprintf("%s:/home/node/Android/Sdk:ro", sdkPath),
@@ -725,69 +1102,63 @@ func startContainer(containerName string) error {
 		}
 	}
 
-	// Use version-synchronized image (or config override if set)
-	args = append(args, getDockerImage())
+	// Record the short name so the container can be resolved after a prefix change
+	args = append(args, "--label", fmt.Sprintf("%s=%s", container.ShortNameLabel,
+		container.GetShortName(containerName, currentConfig().Containers.Prefix)))
+
+	// Record how Claude is launched so details can show it even when stopped
+	args = append(args, "--label", fmt.Sprintf("%s=%s", container.LaunchCommandLabel, claudeLaunchCommand))
+
+	// Custom connect command requested with --connect-cmd
+	if customConnect != "" {
+		args = append(args, "--label", fmt.Sprintf("%s=%s", container.ConnectCommandLabel, customConnect))
+	}
+
+	// Per-container domains requested with --allow-domain; the firewall reads
+	// them back whenever it is (re-)applied
+	if len(allowDomains) > 0 {
+		args = append(args, "--label", fmt.Sprintf("%s=%s", container.AllowedDomainsLabel, strings.Join(allowDomains, ",")))
+	}
+
+	// Extra bind mounts requested with --mount
+	mountArgs, err := parseMounts(extraMounts)
+	if err != nil {
+		return err
+	}
+	args = append(args, mountArgs...)
+
+	// Extra environment from --preset
+	for _, kv := range presetEnv {
+		args = append(args, "-e", kv)
+	}
+
+	args = append(args, image)
 
-	cmd := exec.Command("docker", args...)
+	cmd := system.DockerCommand(args...)
 	if err := cmd.Run(); err != nil {
 		return fmt.Errorf("failed to start container: %w", err)
 	}
 
 	// Wait for container startup script to complete
 	// The startup script runs npm update and claude --version, which can take several seconds
-	fmt.Println("Waiting for container initialization...")
+	steps := newStepSpinner()
+	steps.Step("Waiting for container initialization")
 	for i := 0; i < 30; i++ {
 		// Check if startup script has finished by looking for the "sleep infinity" process
-		checkCmd := exec.Command("docker", "exec", containerName, "pgrep", "-f", "sleep infinity")
+		checkCmd := system.DockerCommand("exec", containerName, "pgrep", "-f", "sleep infinity")
 		if err := checkCmd.Run(); err == nil {
 			// Found sleep infinity - startup is complete
 			break
 		}
 		if i == 29 {
-			fmt.Println("Warning: Container startup taking longer than expected, continuing anyway...")
+			steps.Warnf("Container startup taking longer than expected, continuing anyway...")
 		}
 		time.Sleep(1 * time.Second)
 	}
+	steps.Done()
 
 	// Fix shell config for better terminal experience
-	shellFixCmd := exec.Command("docker", "exec", containerName, "sh", "-c",
-		`# Remove TERM override
-sed -i '/^export TERM=xterm$/d' /home/node/.zshrc
-
-# Disable powerlevel10k theme (causes missing font glyphs)
-sed -i 's/^ZSH_THEME=.*/ZSH_THEME=""/' /home/node/.zshrc
-
-# Add custom prompt with readable symbols and colors
-cat >> /home/node/.zshrc << 'PROMPT_EOF'
-
-# Custom MCL prompt with colors and git status
-autoload -Uz vcs_info
-precmd_vcs_info() { vcs_info }
-precmd_functions+=( precmd_vcs_info )
-setopt prompt_subst
-zstyle ':vcs_info:git:*' formats '%b'
-zstyle ':vcs_info:*' enable git
-
-# Git status indicators (matching maestro list command)
-git_status_symbols() {
-    if [[ -n ${vcs_info_msg_0_} ]]; then
-        local git_status=""
-        local changes=$(git status --porcelain 2>/dev/null | wc -l | tr -d ' ')
-        local ahead=$(git rev-list --count @{u}..HEAD 2>/dev/null || echo "0")
-        local behind=$(git rev-list --count HEAD..@{u} 2>/dev/null || echo "0")
-
-        [[ $changes -gt 0 ]] && git_status+="Δ$changes "
-        [[ $ahead -gt 0 ]] && git_status+="↑$ahead "
-        [[ $behind -gt 0 ]] && git_status+="↓$behind "
-        [[ -z $git_status ]] && git_status="✓ "
-
-        echo "$git_status"
-    fi
-}
-
-PROMPT='%F{green}%n%f  %F{blue}%~%f  %F{magenta}${vcs_info_msg_0_}%f %F{yellow}$(git_status_symbols)%f'
-PROMPT_EOF`)
-	if err := shellFixCmd.Run(); err != nil {
+	if err := container.EnsureShellConfig(containerName); err != nil {
 		fmt.Printf("Warning: Failed to configure shell: %v\n", err)
 	}
 
@@ -797,14 +1168,14 @@ PROMPT_EOF`)
 		fmt.Println("Copying Claude credentials and configuration to container...")
 
 		// Create .claude directory in container
-		mkdirCmd := exec.Command("docker", "exec", containerName, "mkdir", "-p", "/home/node/.claude")
+		mkdirCmd := system.DockerCommand("exec", containerName, "mkdir", "-p", "/home/node/.claude")
 		if err := mkdirCmd.Run(); err != nil {
 			fmt.Printf("Warning: Failed to create .claude directory: %v\n", err)
 		}
 
 		// Copy credentials file to .claude directory
 		if credExists {
-			copyCredCmd := exec.Command("docker", "cp", credPath, fmt.Sprintf("%s:/home/node/.claude/.credentials.json", containerName))
+			copyCredCmd := system.DockerCommand("cp", credPath, fmt.Sprintf("%s:%s", containerName, container.CredentialsPath()))
 			if err := copyCredCmd.Run(); err != nil {
 				fmt.Printf("Warning: Failed to copy credentials: %v\n", err)
 			}
@@ -813,20 +1184,20 @@ PROMPT_EOF`)
 		// Copy config file to home directory (NOT inside .claude/)
 		// .claude.json lives at /home/node/.claude.json, not /home/node/.claude/.claude.json
 		if configExists {
-			copyConfigCmd := exec.Command("docker", "cp", configPath, fmt.Sprintf("%s:/home/node/.claude.json", containerName))
+			copyConfigCmd := system.DockerCommand("cp", configPath, fmt.Sprintf("%s:/home/node/.claude.json", containerName))
 			if err := copyConfigCmd.Run(); err != nil {
 				fmt.Printf("Warning: Failed to copy config: %v\n", err)
 			}
 		}
 
 		// Fix ownership of .claude directory and .claude.json file
-		chownCmd := exec.Command("docker", "exec", "-u", "root", containerName, "chown", "-R", "node:node", "/home/node/.claude")
+		chownCmd := system.DockerCommand("exec", "-u", "root", containerName, "chown", "-R", "node:node", "/home/node/.claude")
 		if err := chownCmd.Run(); err != nil {
 			fmt.Printf("Warning: Failed to fix .claude ownership: %v\n", err)
 		}
 
 		if configExists {
-			chownConfigCmd := exec.Command("docker", "exec", "-u", "root", containerName, "chown", "node:node", "/home/node/.claude.json")
+			chownConfigCmd := system.DockerCommand("exec", "-u", "root", containerName, "chown", "node:node", "/home/node/.claude.json")
 			if err := chownConfigCmd.Run(); err != nil {
 				fmt.Printf("Warning: Failed to fix .claude.json ownership: %v\n", err)
 			}
@@ -834,24 +1205,24 @@ PROMPT_EOF`)
 	}
 
 	// Copy GitHub CLI config if enabled
-	if config.GitHub.Enabled {
-		ghConfigPath := expandPath(config.GitHub.ConfigPath)
+	if currentConfig().GitHub.Enabled {
+		ghConfigPath := expandPath(currentConfig().GitHub.ConfigPath)
 		if _, err := os.Stat(ghConfigPath); err == nil {
 			fmt.Println("Copying GitHub CLI configuration to container...")
 
 			// Create .config directory in container
-			mkdirCmd := exec.Command("docker", "exec", containerName, "mkdir", "-p", "/home/node/.config")
+			mkdirCmd := system.DockerCommand("exec", containerName, "mkdir", "-p", "/home/node/.config")
 			if err := mkdirCmd.Run(); err != nil {
 				fmt.Printf("Warning: Failed to create .config directory: %v\n", err)
 			}
 
 			// Copy entire gh config directory
-			copyGhCmd := exec.Command("docker", "cp", ghConfigPath, fmt.Sprintf("%s:/home/node/.config/gh", containerName))
+			copyGhCmd := system.DockerCommand("cp", ghConfigPath, fmt.Sprintf("%s:/home/node/.config/gh", containerName))
 			if err := copyGhCmd.Run(); err != nil {
 				fmt.Printf("Warning: Failed to copy GitHub config: %v\n", err)
 			} else {
 				// Fix ownership
-				chownGhCmd := exec.Command("docker", "exec", "-u", "root", containerName, "chown", "-R", "node:node", "/home/node/.config")
+				chownGhCmd := system.DockerCommand("exec", "-u", "root", containerName, "chown", "-R", "node:node", "/home/node/.config")
 				if err := chownGhCmd.Run(); err != nil {
 					fmt.Printf("Warning: Failed to fix .config ownership: %v\n", err)
 				}
@@ -873,10 +1244,12 @@ PROMPT_EOF`)
 	}
 
 	// Initialize firewall
-	fmt.Println("Setting up firewall...")
+	steps.Step("Setting up firewall")
 	if err := initializeFirewall(containerName); err != nil {
+		steps.Fail()
 		fmt.Printf("Warning: Failed to initialize firewall: %v\n", err)
 	}
+	steps.Done()
 
 	return nil
 }
@@ -995,6 +1368,20 @@ func (mp *MultiProgress) Stop() {
 	mp.renderFinal()
 }
 
+// Println prints a message above the progress lines without disturbing them
+func (mp *MultiProgress) Println(msg string) {
+	mp.mu.Lock()
+	defer mp.mu.Unlock()
+
+	if mp.initialized && mp.lineCount > 0 {
+		fmt.Printf("\033[%dA", mp.lineCount)
+	}
+	fmt.Print("\033[K")
+	fmt.Println(msg)
+	// The next render redraws the progress lines below the message
+	mp.initialized = false
+}
+
 func (mp *MultiProgress) render() {
 	mp.mu.Lock()
 	defer mp.mu.Unlock()
@@ -1133,26 +1520,21 @@ func formatBytes(bytes int64) string {
 	}
 }
 
-// readMaestroIgnore reads exclusion patterns from .maestroignore file
-func readMaestroIgnore(dir string) []string {
-	ignorePath := filepath.Join(dir, ".maestroignore")
-	file, err := os.Open(ignorePath)
+// projectSkipDirs are never copied by the project tar (.git is copied separately)
+var projectSkipDirs = []string{"node_modules", ".git"}
+
+// listProjectFiles returns the project files to copy, honoring .maestroignore
+// (or .gitignore as a fallback). Also returns the ignore file used, if any.
+func listProjectFiles(dir string) ([]string, string, error) {
+	matcher, source, err := ignore.Load(dir)
 	if err != nil {
-		return nil // No .maestroignore file, that's fine
+		return nil, "", fmt.Errorf("failed to read ignore file: %w", err)
 	}
-	defer file.Close()
-
-	var patterns []string
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		// Skip empty lines and comments
-		if line == "" || strings.HasPrefix(line, "#") {
-			continue
-		}
-		patterns = append(patterns, line)
+	files, err := ignore.ListFiles(dir, matcher, projectSkipDirs)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list project files: %w", err)
 	}
-	return patterns
+	return files, source, nil
 }
 
 func copyProjectToContainer(containerName string) error {
@@ -1162,7 +1544,7 @@ func copyProjectToContainer(containerName string) error {
 	}
 
 	// Determine compression setting (default: true for backward compatibility)
-	useCompression := config.Sync.Compress == nil || *config.Sync.Compress
+	useCompression := currentConfig().Sync.Compress == nil || *currentConfig().Sync.Compress
 
 	// Check if we're in batch mode (MultiProgress active)
 	mp := GetMultiProgress()
@@ -1177,29 +1559,33 @@ func copyProjectToContainer(containerName string) error {
 
 	startTime := time.Now()
 
-	// Build exclude arguments (defaults + .maestroignore)
-	excludeArgs := []string{"--exclude=node_modules", "--exclude=.git"}
-	for _, pattern := range readMaestroIgnore(cwd) {
-		excludeArgs = append(excludeArgs, "--exclude="+pattern)
+	// Build the file list (defaults + .maestroignore, or .gitignore as a fallback)
+	files, ignoreSource, err := listProjectFiles(cwd)
+	if err != nil {
+		if isBatchMode {
+			mp.ErrorItem(containerName, err)
+		}
+		return err
+	}
+	if ignoreSource != "" && !isBatchMode {
+		fmt.Printf("  Excluding paths from %s\n", ignoreSource)
 	}
 
-	// Create tar of current directory (excluding .git which is copied separately)
+	// Create tar of the listed files (excluding .git which is copied separately)
+	listArgs := []string{"--no-recursion", "--null", "-T", "-"}
 	var tarCmd *exec.Cmd
 	var dockerCmd *exec.Cmd
 	if useCompression {
 		// Use gzip compression (slower for large projects but smaller transfer)
-		tarArgs := append([]string{"-czf", "-"}, excludeArgs...)
-		tarArgs = append(tarArgs, ".")
-		tarCmd = exec.Command("tar", tarArgs...)
-		dockerCmd = exec.Command("docker", "exec", "-i", containerName, "tar", "-xzf", "-", "-C", "/workspace")
+		tarCmd = exec.Command("tar", append([]string{"-czf", "-"}, listArgs...)...)
+		dockerCmd = system.DockerCommand("exec", "-i", containerName, "tar", "-xzf", "-", "-C", "/workspace")
 	} else {
 		// No compression (faster for large projects on local Docker)
-		tarArgs := append([]string{"-cf", "-"}, excludeArgs...)
-		tarArgs = append(tarArgs, ".")
-		tarCmd = exec.Command("tar", tarArgs...)
-		dockerCmd = exec.Command("docker", "exec", "-i", containerName, "tar", "-xf", "-", "-C", "/workspace")
+		tarCmd = exec.Command("tar", append([]string{"-cf", "-"}, listArgs...)...)
+		dockerCmd = system.DockerCommand("exec", "-i", containerName, "tar", "-xf", "-", "-C", "/workspace")
 	}
 	tarCmd.Dir = cwd
+	tarCmd.Stdin = strings.NewReader(strings.Join(files, "\x00"))
 
 	// Connect pipes with progress tracking
 	pipe, err := tarCmd.StdoutPipe()
@@ -1262,14 +1648,14 @@ func copyProjectToContainer(containerName string) error {
 
 	// Copy .git separately if it exists
 	if _, err := os.Stat(".git"); err == nil {
-		gitCmd := exec.Command("docker", "cp", ".git", fmt.Sprintf("%s:/workspace/", containerName))
+		gitCmd := system.DockerCommand("cp", ".git", fmt.Sprintf("%s:/workspace/", containerName))
 		if err := gitCmd.Run(); err != nil {
 			fmt.Printf("Warning: Failed to copy .git: %v\n", err)
 		}
 	}
 
 	// Fix ownership of /workspace to node user
-	chownCmd := exec.Command("docker", "exec", containerName, "sh", "-c", "sudo chown -R node:node /workspace")
+	chownCmd := system.DockerCommand("exec", containerName, "sh", "-c", "sudo chown -R node:node /workspace")
 	if err := chownCmd.Run(); err != nil {
 		fmt.Printf("Warning: Failed to fix ownership: %v\n", err)
 	}
@@ -1278,7 +1664,7 @@ func copyProjectToContainer(containerName string) error {
 }
 
 func copyAdditionalFolders(containerName string) error {
-	for _, folder := range config.Sync.AdditionalFolders {
+	for _, folder := range currentConfig().Sync.AdditionalFolders {
 		expandedPath := expandPath(folder)
 		if _, err := os.Stat(expandedPath); err != nil {
 			fmt.Printf("Skipping %s (not found)\n", folder)
@@ -1288,7 +1674,7 @@ func copyAdditionalFolders(containerName string) error {
 		baseName := filepath.Base(expandedPath)
 		fmt.Printf("Copying %s...\n", baseName)
 
-		cmd := exec.Command("docker", "cp", expandedPath, fmt.Sprintf("%s:/workspace/../%s", containerName, baseName))
+		cmd := system.DockerCommand("cp", expandedPath, fmt.Sprintf("%s:/workspace/../%s", containerName, baseName))
 		if err := cmd.Run(); err != nil {
 			fmt.Printf("Warning: Failed to copy %s: %v\n", folder, err)
 		}
@@ -1297,37 +1683,42 @@ func copyAdditionalFolders(containerName string) error {
 }
 
 func initializeGitBranch(containerName, branchName string) error {
+	// Non-git projects: leave /workspace exactly as copied
+	if !currentConfig().Containers.GitEnabled {
+		return nil
+	}
+
 	// Fix git ownership issue first
-	safeCmd := exec.Command("docker", "exec", containerName, "git", "config", "--global", "--add", "safe.directory", "/workspace")
+	safeCmd := system.DockerCommand("exec", containerName, "git", "config", "--global", "--add", "safe.directory", "/workspace")
 	if err := safeCmd.Run(); err != nil {
 		fmt.Printf("Warning: Failed to set safe.directory: %v\n", err)
 	}
 
 	// Check if git repo exists
-	checkCmd := exec.Command("docker", "exec", containerName, "test", "-d", "/workspace/.git")
+	checkCmd := system.DockerCommand("exec", containerName, "test", "-d", "/workspace/.git")
 	if err := checkCmd.Run(); err != nil {
 		// Initialize git if not exists
-		initCmd := exec.Command("docker", "exec", containerName, "sh", "-c", "cd /workspace && git init")
+		initCmd := system.DockerCommand("exec", containerName, "sh", "-c", "cd /workspace && git init")
 		if err := initCmd.Run(); err != nil {
 			return err
 		}
 	}
 
 	// Create and checkout new branch
-	cmd := exec.Command("docker", "exec", containerName, "sh", "-c",
+	cmd := system.DockerCommand("exec", containerName, "sh", "-c",
 		fmt.Sprintf("cd /workspace && git checkout -b %s 2>/dev/null || git checkout %s", branchName, branchName))
 	return cmd.Run()
 }
 
 func configureGitUser(containerName string) error {
-	if config.Git.UserName != "" {
-		cmd := exec.Command("docker", "exec", containerName, "git", "config", "--global", "user.name", config.Git.UserName)
+	if currentConfig().Git.UserName != "" {
+		cmd := system.DockerCommand("exec", containerName, "git", "config", "--global", "user.name", currentConfig().Git.UserName)
 		if err := cmd.Run(); err != nil {
 			return fmt.Errorf("failed to set git user.name: %w", err)
 		}
 	}
-	if config.Git.UserEmail != "" {
-		cmd := exec.Command("docker", "exec", containerName, "git", "config", "--global", "user.email", config.Git.UserEmail)
+	if currentConfig().Git.UserEmail != "" {
+		cmd := system.DockerCommand("exec", containerName, "git", "config", "--global", "user.email", currentConfig().Git.UserEmail)
 		if err := cmd.Run(); err != nil {
 			return fmt.Errorf("failed to set git user.email: %w", err)
 		}
@@ -1337,7 +1728,7 @@ func configureGitUser(containerName string) error {
 
 func setupGitHubRemote(containerName string) error {
 	// Check if origin remote exists
-	getOriginCmd := exec.Command("docker", "exec", containerName, "sh", "-c",
+	getOriginCmd := system.DockerCommand("exec", containerName, "sh", "-c",
 		"cd /workspace && git config --get remote.origin.url")
 	originOutput, err := getOriginCmd.Output()
 	if err != nil {
@@ -1373,7 +1764,7 @@ func setupGitHubRemote(containerName string) error {
 	fmt.Printf("  New: %s\n", httpsURL)
 
 	// Update the origin URL
-	setOriginCmd := exec.Command("docker", "exec", containerName, "sh", "-c",
+	setOriginCmd := system.DockerCommand("exec", containerName, "sh", "-c",
 		fmt.Sprintf("cd /workspace && git remote set-url origin %s", httpsURL))
 	if err := setOriginCmd.Run(); err != nil {
 		return fmt.Errorf("failed to update origin URL: %w", err)
@@ -1381,9 +1772,9 @@ func setupGitHubRemote(containerName string) error {
 
 	// Configure git to use gh for authentication
 	// Only do this if GitHub integration is enabled
-	if config.GitHub.Enabled {
+	if currentConfig().GitHub.Enabled {
 		fmt.Println("Configuring git to use GitHub CLI for authentication...")
-		ghSetupCmd := exec.Command("docker", "exec", containerName, "sh", "-c",
+		ghSetupCmd := system.DockerCommand("exec", containerName, "sh", "-c",
 			"cd /workspace && gh auth setup-git")
 		if err := ghSetupCmd.Run(); err != nil {
 			return fmt.Errorf("failed to setup gh auth: %w", err)
@@ -1394,12 +1785,85 @@ func setupGitHubRemote(containerName string) error {
 	return nil
 }
 
+// How long --connect-after-ready waits for Claude, how long connect and wait
+// give a new container's Claude to start before taking it for exited, and
+// how often Claude's startup is checked (replaced in tests)
+var (
+	claudeReadyTimeout = 2 * time.Minute
+	claudeStartGrace   = 15 * time.Second
+	claudeReadyPoll    = 500 * time.Millisecond
+)
+
+// claudeProbe reports whether Claude is running in a container (replaced in tests)
+var claudeProbe = container.IsClaudeRunning
+
+// claudeStarted polls until Claude is running in the container, reporting
+// whether it started within timeout
+func claudeStarted(containerName string, timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for !claudeProbe(containerName) {
+		if time.Now().After(deadline) {
+			return false
+		}
+		time.Sleep(claudeReadyPoll)
+	}
+	return true
+}
+
+// waitForClaude shows a spinner until Claude is running in the container,
+// giving up with a warning after timeout. It reports whether Claude started.
+func waitForClaude(containerName string, timeout time.Duration) bool {
+	steps := newStepSpinner()
+	steps.Step("Waiting for Claude to start")
+	if !claudeStarted(containerName, timeout) {
+		steps.Fail()
+		fmt.Printf("⚠  Claude hasn't started after %s; connecting anyway\n", timeout)
+		return false
+	}
+	steps.Done()
+	return true
+}
+
+// connectNewContainer attaches to a freshly created container the way
+// 'maestro connect' does (custom connect command, tmux recovery, and so on)
+// and prints the create summary. With waitReady, a spinner shows until
+// Claude is running.
+func connectNewContainer(containerName, branchName string, waitReady bool) {
+	if waitReady {
+		waitForClaude(containerName, claudeReadyTimeout)
+	} else {
+		claudeStarted(containerName, claudeStartGrace)
+	}
+
+	fmt.Println()
+	if err := attachToContainer(containerName); err != nil {
+		fmt.Printf("\nWarning: Failed to connect: %v\n", err)
+		printCreateSummary(containerName, branchName, false)
+		return
+	}
+	printCreateSummary(containerName, branchName, true)
+}
+
+// claudeLaunchCommand is the command run in the Claude tmux window
+const claudeLaunchCommand = "claude --dangerously-skip-permissions"
+
+// initialTaskPrompt returns the prompt sent to Claude once it starts. Unless
+// exact is set, the planning prompt is wrapped with planning instructions.
+func initialTaskPrompt(planningPrompt string, exact bool) string {
+	if exact {
+		return planningPrompt
+	}
+	return fmt.Sprintf(`%s
+
+Please analyze this task and create a detailed implementation plan. Do not start coding yet - just plan the implementation.`, planningPrompt)
+}
+
 func startTmuxSession(containerName, branchName, planningPrompt string, exactPrompt bool) error {
 	// Create tmux configuration with status line showing container info and true color support
 	tmuxConfig := generateTmuxConfig(containerName, branchName)
 
 	// Write tmux config to container - use cat with heredoc to preserve newlines
-	writeCmd := exec.Command("docker", "exec", containerName, "sh", "-c",
+	writeCmd := system.DockerCommand("exec", containerName, "sh", "-c",
 		fmt.Sprintf("cat > /home/node/.tmux.conf << 'EOF'\n%s\nEOF", tmuxConfig))
 	if err := writeCmd.Run(); err != nil {
 		return err
@@ -1408,22 +1872,13 @@ func startTmuxSession(containerName, branchName, planningPrompt string, exactPro
 	// Note: Config will be loaded when tmux session starts below
 
 	// Prepare the task prompt that will be sent via tmux
-	var taskPrompt string
-	if exactPrompt {
-		// In exact mode, send the prompt as-is without any wrapper
-		taskPrompt = planningPrompt
-	} else {
-		// In normal mode, wrap with planning instructions
-		taskPrompt = fmt.Sprintf(`%s
-
-Please analyze this task and create a detailed implementation plan. Do not start coding yet - just plan the implementation.`, planningPrompt)
-	}
+	taskPrompt := initialTaskPrompt(planningPrompt, exactPrompt)
 
 	// Start tmux session with Claude running directly
 	// Running Claude as the tmux command (not via send-keys) preserves the environment correctly
 	// Explicitly set HOME and user to ensure credentials are found
-	tmuxCmd := exec.Command("docker", "exec", "-u", "node", containerName, "sh", "-c",
-		"cd /workspace && HOME=/home/node tmux new-session -d -s main 'claude --dangerously-skip-permissions'")
+	tmuxCmd := system.DockerCommand("exec", "-u", "node", containerName, "sh", "-c",
+		"cd /workspace && HOME=/home/node tmux new-session -d -s main '"+claudeLaunchCommand+"'")
 
 	// Capture output for debugging
 	var stdout, stderr bytes.Buffer
@@ -1439,7 +1894,7 @@ Please analyze this task and create a detailed implementation plan. Do not start
 	// Wait for tmux session to be ready
 	fmt.Println("Waiting for tmux session to start...")
 	for i := 0; i < 10; i++ {
-		checkCmd := exec.Command("docker", "exec", "-u", "node", containerName, "tmux", "has-session", "-t", "main")
+		checkCmd := system.DockerCommand("exec", "-u", "node", containerName, "tmux", "has-session", "-t", "main")
 		var checkOut, checkErr bytes.Buffer
 		checkCmd.Stdout = &checkOut
 		checkCmd.Stderr = &checkErr
@@ -1450,11 +1905,11 @@ Please analyze this task and create a detailed implementation plan. Do not start
 		if i == 9 {
 			fmt.Printf("Timeout waiting for tmux session. Last check stderr: %s\n", checkErr.String())
 			// List all tmux sessions for debugging
-			listCmd := exec.Command("docker", "exec", "-u", "node", containerName, "tmux", "ls")
+			listCmd := system.DockerCommand("exec", "-u", "node", containerName, "tmux", "ls")
 			listOut, _ := listCmd.CombinedOutput()
 			fmt.Printf("All tmux sessions: %s\n", string(listOut))
 			// Check if Claude process is running
-			psCmd := exec.Command("docker", "exec", "-u", "node", containerName, "ps", "aux")
+			psCmd := system.DockerCommand("exec", "-u", "node", containerName, "ps", "aux")
 			psOut, _ := psCmd.CombinedOutput()
 			fmt.Printf("Running processes:\n%s\n", string(psOut))
 			return fmt.Errorf("tmux session failed to start after 5 seconds")
@@ -1463,7 +1918,7 @@ Please analyze this task and create a detailed implementation plan. Do not start
 	}
 
 	// Enable bell monitoring on the Claude window so we can detect when it needs attention
-	monitorCmd := exec.Command("docker", "exec", "-u", "node", containerName,
+	monitorCmd := system.DockerCommand("exec", "-u", "node", containerName,
 		"tmux", "set-window-option", "-t", "main:0", "monitor-bell", "on")
 	if err := monitorCmd.Run(); err != nil {
 		fmt.Printf("Warning: Failed to enable bell monitoring: %v\n", err)
@@ -1471,7 +1926,7 @@ Please analyze this task and create a detailed implementation plan. Do not start
 
 	// Enable silence monitoring - triggers when Claude has no output for 10 seconds
 	// This catches when Claude is paused waiting for input
-	silenceCmd := exec.Command("docker", "exec", "-u", "node", containerName,
+	silenceCmd := system.DockerCommand("exec", "-u", "node", containerName,
 		"tmux", "set-window-option", "-t", "main:0", "monitor-silence", "10")
 	if err := silenceCmd.Run(); err != nil {
 		fmt.Printf("Warning: Failed to enable silence monitoring: %v\n", err)
@@ -1479,45 +1934,30 @@ Please analyze this task and create a detailed implementation plan. Do not start
 
 	// Create a background script to send the initial prompt
 	// First accepts the bypass permissions prompt, then sends the task
-	autoInputScript := fmt.Sprintf(`#!/bin/sh
-# Wait for Claude to start and show the bypass permissions prompt
-sleep 3
-
-# Accept the bypass permissions prompt by pressing Down then Enter
-tmux send-keys -t main:0 Down 2>/dev/null
-sleep 0.3
-tmux send-keys -t main:0 Enter 2>/dev/null
-
-# Wait for Claude to fully initialize after accepting
-sleep 3
-
-# Send the task prompt
-cat > /tmp/prompt-input.txt << 'PROMPT_EOF'
-%s
-PROMPT_EOF
-
-# Send the prompt line by line to avoid issues
-while IFS= read -r line || [ -n "$line" ]; do
-    printf "%%s\n" "$line" | tmux load-buffer -
-    tmux paste-buffer -t main:0 -d 2>/dev/null
-done < /tmp/prompt-input.txt
-
-# Send Enter to submit
-sleep 0.5
-tmux send-keys -t main:0 C-m 2>/dev/null
-`, taskPrompt)
+	private := privatePromptEnabled()
+	if private {
+		// Piped over stdin, the prompt never appears in a docker exec command
+		// line, which docker records in its event stream
+		promptCmd := system.DockerCommand("exec", "-i", "-u", "node", containerName, "sh", "-c",
+			"umask 077 && cat > "+promptInputFile)
+		promptCmd.Stdin = strings.NewReader(taskPrompt)
+		if err := promptCmd.Run(); err != nil {
+			return fmt.Errorf("failed to write task prompt: %w", err)
+		}
+	}
+	autoInputScript := buildAutoInputScript(taskPrompt, private)
 
 	fmt.Println("Setting up automated Claude startup...")
 
 	// Write and execute the auto-input script in the background
-	writeAutoInput := exec.Command("docker", "exec", containerName, "sh", "-c",
+	writeAutoInput := system.DockerCommand("exec", containerName, "sh", "-c",
 		fmt.Sprintf("cat > /tmp/auto-input.sh << 'EOF'\n%s\nEOF\nchmod +x /tmp/auto-input.sh", autoInputScript))
 	if err := writeAutoInput.Run(); err != nil {
 		return fmt.Errorf("failed to write auto-input script: %w", err)
 	}
 
 	// Run the auto-input script in the background as node user
-	runAutoInput := exec.Command("docker", "exec", "-d", "-u", "node", containerName, "/tmp/auto-input.sh")
+	runAutoInput := system.DockerCommand("exec", "-d", "-u", "node", containerName, "/tmp/auto-input.sh")
 	if err := runAutoInput.Run(); err != nil {
 		fmt.Printf("Warning: Failed to start auto-input script: %v\n", err)
 	}
@@ -1525,21 +1965,21 @@ tmux send-keys -t main:0 C-m 2>/dev/null
 	fmt.Println("Automated input started for Claude...")
 
 	// Window 1: Shell
-	newWinCmd := exec.Command("docker", "exec", "-u", "node", containerName,
+	newWinCmd := system.DockerCommand("exec", "-u", "node", containerName,
 		"tmux", "new-window", "-t", "main:1", "-n", "shell", "-c", "cd /workspace && exec zsh")
 	if err := newWinCmd.Run(); err != nil {
 		fmt.Printf("Warning: Failed to create shell window: %v\n", err)
 	}
 
 	// Rename window 0
-	renameCmd := exec.Command("docker", "exec", "-u", "node", containerName,
+	renameCmd := system.DockerCommand("exec", "-u", "node", containerName,
 		"tmux", "rename-window", "-t", "main:0", "claude")
 	if err := renameCmd.Run(); err != nil {
 		fmt.Printf("Warning: Failed to rename claude window: %v\n", err)
 	}
 
 	// Set Claude window as active
-	selectCmd := exec.Command("docker", "exec", containerName,
+	selectCmd := system.DockerCommand("exec", containerName,
 		"tmux", "select-window", "-t", "main:0")
 	if err := selectCmd.Run(); err != nil {
 		fmt.Printf("Warning: Failed to select claude window: %v\n", err)
@@ -1548,7 +1988,55 @@ tmux send-keys -t main:0 C-m 2>/dev/null
 	return nil
 }
 
-func initializeFirewall(containerName string) error {
+// promptInputFile is where the auto-input script reads the task prompt from
+const promptInputFile = "/tmp/prompt-input.txt"
+
+// privatePromptEnabled reports whether --private-prompt or
+// containers.private_prompt is set
+func privatePromptEnabled() bool {
+	return privatePrompt || currentConfig().Containers.PrivatePrompt
+}
+
+// buildAutoInputScript returns the script run in the container to accept the
+// bypass permissions prompt and then type taskPrompt into Claude. Normally the
+// prompt is embedded in the script; when private, it must already be in
+// promptInputFile, and the script deletes it and itself once it's sent.
+// The script's output never reaches docker logs, which only holds PID 1's.
+func buildAutoInputScript(taskPrompt string, private bool) string {
+	writePrompt := fmt.Sprintf("cat > %s << 'PROMPT_EOF'\n%s\nPROMPT_EOF\n", promptInputFile, taskPrompt)
+	cleanup := ""
+	if private {
+		writePrompt = ""
+		cleanup = fmt.Sprintf("\n# Leave no copy of the prompt behind\nrm -f %s \"$0\"\n", promptInputFile)
+	}
+
+	return fmt.Sprintf(`#!/bin/sh
+# Wait for Claude to start and show the bypass permissions prompt
+sleep 3
+
+# Accept the bypass permissions prompt by pressing Down then Enter
+tmux send-keys -t main:0 Down 2>/dev/null
+sleep 0.3
+tmux send-keys -t main:0 Enter 2>/dev/null
+
+# Wait for Claude to fully initialize after accepting
+sleep 3
+
+# Send the task prompt
+%s
+# Send the prompt line by line to avoid issues
+while IFS= read -r line || [ -n "$line" ]; do
+    printf "%%s\n" "$line" | tmux load-buffer -
+    tmux paste-buffer -t main:0 -d 2>/dev/null
+done < %s
+
+# Send Enter to submit
+sleep 0.5
+tmux send-keys -t main:0 C-m 2>/dev/null
+%s`, writePrompt, promptInputFile, cleanup)
+}
+
+func initializeFirewall(containerName string, extraDomains ...string) error {
 	// Write embedded firewall script to a temporary file
 	tmpFile, err := os.CreateTemp("", "init-firewall-*.sh")
 	if err != nil {
@@ -1563,38 +2051,39 @@ func initializeFirewall(containerName string) error {
 	tmpFile.Close()
 
 	// Copy script to container
-	copyCmd := exec.Command("docker", "cp", tmpFile.Name(), fmt.Sprintf("%s:/usr/local/bin/init-firewall.sh", containerName))
+	copyCmd := system.DockerCommand("cp", tmpFile.Name(), fmt.Sprintf("%s:/usr/local/bin/init-firewall.sh", containerName))
 	if err := copyCmd.Run(); err != nil {
 		return err
 	}
 
 	// Make the script executable (as root)
-	chmodCmd := exec.Command("docker", "exec", "-u", "root", containerName, "chmod", "+x", "/usr/local/bin/init-firewall.sh")
+	chmodCmd := system.DockerCommand("exec", "-u", "root", containerName, "chmod", "+x", "/usr/local/bin/init-firewall.sh")
 	if err := chmodCmd.Run(); err != nil {
 		return fmt.Errorf("failed to make firewall script executable: %w", err)
 	}
 
 	// Write allowed domains to container (using sudo for /etc write access)
-	domainsList := strings.Join(config.Firewall.AllowedDomains, "\n")
-	writeDomainsCmd := exec.Command("docker", "exec", "-u", "root", containerName, "sh", "-c",
+	extraDomains = append(container.GetAllowedDomainsLabel(containerName), extraDomains...)
+	domainsList := strings.Join(firewallDomainList(extraDomains), "\n")
+	writeDomainsCmd := system.DockerCommand("exec", "-u", "root", containerName, "sh", "-c",
 		fmt.Sprintf("echo '%s' > /etc/allowed-domains.txt", domainsList))
 	if err := writeDomainsCmd.Run(); err != nil {
 		return fmt.Errorf("failed to write allowed domains: %w", err)
 	}
 
 	// Write internal DNS config if configured (for corporate networks)
-	if config.Firewall.InternalDNS != "" {
-		writeInternalDNSCmd := exec.Command("docker", "exec", "-u", "root", containerName, "sh", "-c",
-			fmt.Sprintf("echo '%s' > /etc/internal-dns.txt", config.Firewall.InternalDNS))
+	if currentConfig().Firewall.InternalDNS != "" {
+		writeInternalDNSCmd := system.DockerCommand("exec", "-u", "root", containerName, "sh", "-c",
+			fmt.Sprintf("echo '%s' > /etc/internal-dns.txt", currentConfig().Firewall.InternalDNS))
 		if err := writeInternalDNSCmd.Run(); err != nil {
 			fmt.Printf("Warning: Failed to write internal DNS config: %v\n", err)
 		}
 	}
 
 	// Write internal domains if configured
-	if len(config.Firewall.InternalDomains) > 0 {
-		internalDomainsList := strings.Join(config.Firewall.InternalDomains, "\n")
-		writeInternalDomainsCmd := exec.Command("docker", "exec", "-u", "root", containerName, "sh", "-c",
+	if len(currentConfig().Firewall.InternalDomains) > 0 {
+		internalDomainsList := strings.Join(currentConfig().Firewall.InternalDomains, "\n")
+		writeInternalDomainsCmd := system.DockerCommand("exec", "-u", "root", containerName, "sh", "-c",
 			fmt.Sprintf("echo '%s' > /etc/internal-domains.txt", internalDomainsList))
 		if err := writeInternalDomainsCmd.Run(); err != nil {
 			fmt.Printf("Warning: Failed to write internal domains config: %v\n", err)
@@ -1603,8 +2092,8 @@ func initializeFirewall(containerName string) error {
 
 	// Write AWS config flag if Bedrock or AWS is enabled
 	// This tells the firewall script to add AWS domain rules
-	if config.AWS.Enabled || config.Bedrock.Enabled {
-		writeAWSConfigCmd := exec.Command("docker", "exec", "-u", "root", containerName, "sh", "-c",
+	if currentConfig().AWS.Enabled || currentConfig().Bedrock.Enabled {
+		writeAWSConfigCmd := system.DockerCommand("exec", "-u", "root", containerName, "sh", "-c",
 			"echo 'enabled' > /etc/aws-enabled.txt")
 		if err := writeAWSConfigCmd.Run(); err != nil {
 			fmt.Printf("Warning: Failed to write AWS config: %v\n", err)
@@ -1613,7 +2102,7 @@ func initializeFirewall(containerName string) error {
 
 	// Run firewall initialization as root (with timeout in background)
 	// We run it in the background because the verification steps can hang
-	firewallCmd := exec.Command("docker", "exec", "-u", "root", "-d", containerName, "/usr/local/bin/init-firewall.sh")
+	firewallCmd := system.DockerCommand("exec", "-u", "root", "-d", containerName, "/usr/local/bin/init-firewall.sh")
 	if err := firewallCmd.Run(); err != nil {
 		return fmt.Errorf("failed to start firewall initialization: %w", err)
 	}
@@ -1631,8 +2120,39 @@ func initializeFirewall(containerName string) error {
 	return nil
 }
 
+// validateAllowDomains checks the domains given with --allow-domain
+func validateAllowDomains(domains []string) error {
+	for _, domain := range domains {
+		if !container.IsValidDomain(domain) {
+			return fmt.Errorf("invalid --allow-domain %q (expected a hostname such as api.example.com)", domain)
+		}
+	}
+	return nil
+}
+
+// firewallDomainList returns the configured allowed domains followed by any
+// extra ones not already listed. Internal domains are left out, since the
+// firewall script routes those through the internal DNS server itself.
+func firewallDomainList(extraDomains []string) []string {
+	domains := append([]string{}, currentConfig().Firewall.AllowedDomains...)
+	seen := make(map[string]bool)
+	for _, d := range domains {
+		seen[d] = true
+	}
+	for _, d := range currentConfig().Firewall.InternalDomains {
+		seen[d] = true
+	}
+	for _, d := range extraDomains {
+		if !seen[d] {
+			seen[d] = true
+			domains = append(domains, d)
+		}
+	}
+	return domains
+}
+
 func setupAndroidSDK(containerName string) error {
-	sdkPath := expandPath(config.Android.SDKPath)
+	sdkPath := expandPath(currentConfig().Android.SDKPath)
 	if sdkPath == "" {
 		return nil // No Android SDK configured
 	}
@@ -1645,14 +2165,14 @@ func setupAndroidSDK(containerName string) error {
 	fmt.Println("Setting up Android SDK...")
 
 	// Set ANDROID_HOME environment variable in .zshrc
-	envCmd := exec.Command("docker", "exec", containerName, "sh", "-c",
+	envCmd := system.DockerCommand("exec", containerName, "sh", "-c",
 		`echo 'export ANDROID_HOME=/home/node/Android/Sdk' >> /home/node/.zshrc && echo 'export PATH=$PATH:$ANDROID_HOME/platform-tools:$ANDROID_HOME/cmdline-tools/latest/bin' >> /home/node/.zshrc`)
 	if err := envCmd.Run(); err != nil {
 		fmt.Printf("Warning: Failed to set ANDROID_HOME: %v\n", err)
 	}
 
 	// Update local.properties in workspace if it exists
-	updateLocalPropertiesCmd := exec.Command("docker", "exec", containerName, "sh", "-c",
+	updateLocalPropertiesCmd := system.DockerCommand("exec", containerName, "sh", "-c",
 		`if [ -f /workspace/local.properties ]; then
 			sed -i 's|sdk.dir=.*|sdk.dir=/home/node/Android/Sdk|' /workspace/local.properties
 			echo "  ✓ Updated local.properties"
@@ -1667,7 +2187,7 @@ func setupAndroidSDK(containerName string) error {
 }
 
 func copySSLCertificates(containerName string) error {
-	certsPath := expandPath(config.SSL.CertificatesPath)
+	certsPath := expandPath(currentConfig().SSL.CertificatesPath)
 	if certsPath == "" {
 		return nil // No certificates configured
 	}
@@ -1697,7 +2217,7 @@ func copySSLCertificates(containerName string) error {
 	fmt.Printf("Installing %d SSL certificate(s) for Java...\n", len(certFiles))
 
 	// Create temporary directory in container for certificates
-	mkdirCmd := exec.Command("docker", "exec", "-u", "root", containerName, "mkdir", "-p", "/tmp/host-certs")
+	mkdirCmd := system.DockerCommand("exec", "-u", "root", containerName, "mkdir", "-p", "/tmp/host-certs")
 	if err := mkdirCmd.Run(); err != nil {
 		return fmt.Errorf("failed to create temp certs directory: %w", err)
 	}
@@ -1707,7 +2227,7 @@ func copySSLCertificates(containerName string) error {
 		certPath := filepath.Join(certsPath, certFile)
 
 		// Copy certificate to container
-		copyCmd := exec.Command("docker", "cp", certPath, fmt.Sprintf("%s:/tmp/host-certs/%s", containerName, certFile))
+		copyCmd := system.DockerCommand("cp", certPath, fmt.Sprintf("%s:/tmp/host-certs/%s", containerName, certFile))
 		if err := copyCmd.Run(); err != nil {
 			fmt.Printf("  ⚠  Failed to copy %s: %v\n", certFile, err)
 			continue
@@ -1719,7 +2239,7 @@ func copySSLCertificates(containerName string) error {
 
 		// Import into Java keystore (using keytool)
 		// The default cacerts password is 'changeit'
-		importCmd := exec.Command("docker", "exec", "-u", "root", containerName, "keytool",
+		importCmd := system.DockerCommand("exec", "-u", "root", containerName, "keytool",
 			"-importcert",
 			"-noprompt",
 			"-trustcacerts",
@@ -1740,13 +2260,13 @@ func copySSLCertificates(containerName string) error {
 	}
 
 	// Cleanup temp directory
-	cleanupCmd := exec.Command("docker", "exec", "-u", "root", containerName, "rm", "-rf", "/tmp/host-certs")
+	cleanupCmd := system.DockerCommand("exec", "-u", "root", containerName, "rm", "-rf", "/tmp/host-certs")
 	cleanupCmd.Run() // Ignore errors on cleanup
 
 	// Change keystore password from default 'changeit' to a random password
 	// This prevents the default password from being used to tamper with the keystore
 	newPassword := generateRandomPassword(32)
-	changePassCmd := exec.Command("docker", "exec", "-u", "root", containerName, "keytool",
+	changePassCmd := system.DockerCommand("exec", "-u", "root", containerName, "keytool",
 		"-storepasswd",
 		"-keystore", "/usr/local/jdk-17.0.2/lib/security/cacerts",
 		"-storepass", "changeit",
@@ -1773,46 +2293,25 @@ func generateRandomPassword(length int) string {
 }
 
 func copyAppsToContainer(containerName string) error {
-	if len(config.Apps) == 0 {
+	if len(currentConfig().Apps) == 0 {
 		return nil // No apps configured
 	}
 
-	fmt.Printf("Copying %d configured app(s) to container...\n", len(config.Apps))
-
-	for name, sourcePath := range config.Apps {
-		expandedPath := expandPath(sourcePath)
-
-		// Check for Linux-specific variant first (for cross-platform binaries)
-		linuxPath := expandedPath + ".linux_aarch64"
-		actualPath := expandedPath
-		if _, err := os.Stat(linuxPath); err == nil {
-			actualPath = linuxPath
-		}
+	fmt.Printf("Copying %d configured app(s) to container...\n", len(currentConfig().Apps))
 
-		// Check if source exists
-		if _, err := os.Stat(actualPath); err != nil {
+	for name, sourcePath := range currentConfig().Apps {
+		src, err := resolveAppSource(name)
+		if err != nil {
 			fmt.Printf("  ⚠  Skipping %s (source not found: %s)\n", name, sourcePath)
 			continue
 		}
 
 		// Copy to container (with original name, not platform suffix)
-		destPath := fmt.Sprintf("/usr/local/bin/%s", name)
-		containerPath := fmt.Sprintf("%s:%s", containerName, destPath)
-
-		cpCmd := exec.Command("docker", "cp", actualPath, containerPath)
-		if err := cpCmd.Run(); err != nil {
+		if err := copyAppToContainer(containerName, name, src); err != nil {
 			fmt.Printf("  ⚠  Failed to copy %s: %v\n", name, err)
 			continue
 		}
 
-		// Make executable and set ownership
-		chmodCmd := exec.Command("docker", "exec", "-u", "root", containerName,
-			"sh", "-c", fmt.Sprintf("chmod +x %s && chown node:node %s", destPath, destPath))
-		if err := chmodCmd.Run(); err != nil {
-			fmt.Printf("  ⚠  %s copied but failed to set permissions\n", name)
-			continue
-		}
-
 		fmt.Printf("  ✓ %s\n", name)
 	}
 
@@ -1845,6 +2344,14 @@ func expandPath(path string) string {
 	return path
 }
 
+// readTaskFile reads a task description from path, or from stdin if path is "-"
+func readTaskFile(path string) ([]byte, error) {
+	if path == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+	return os.ReadFile(path)
+}
+
 func truncateString(s string, maxLen int) string {
 	if len(s) <= maxLen {
 		return s
@@ -1888,6 +2395,11 @@ func CreateContainerFromTUI(taskDescription, branchNameOverride string, skipConn
 		}
 	}
 
+	// Offer to reuse an existing container on the same branch
+	if offerExistingContainer(branchName, isTerminal(os.Stdin)) {
+		return nil
+	}
+
 	// Step 2: Get next container number
 	containerName, err := getNextContainerName(branchName)
 	if err != nil {
@@ -1902,6 +2414,10 @@ func CreateContainerFromTUI(taskDescription, branchNameOverride string, skipConn
 		return fmt.Errorf("failed to ensure Docker image: %w", err)
 	}
 
+	if err := checkDiskSpace(1); err != nil {
+		return err
+	}
+
 	// Step 4: Start container
 	if err := startContainer(containerName); err != nil {
 		return fmt.Errorf("failed to start container: %w", err)
@@ -1939,29 +2455,18 @@ func CreateContainerFromTUI(taskDescription, branchNameOverride string, skipConn
 		return fmt.Errorf("failed to start tmux session: %w", err)
 	}
 
+	firePostCreateHook(containerName, branchName, taskDescription)
+
 	fmt.Printf("\n✅ Container %s is ready!\n", containerName)
 
 	// Auto-connect unless skipConnect is true
 	if !skipConnect {
-		fmt.Println("\nConnecting to container...")
-		fmt.Println("Detach with: Ctrl+b d")
-		fmt.Println("Switch windows: Ctrl+b 0 (Claude), Ctrl+b 1 (shell)")
-
-		// Connect to tmux session
-		connectCmd := exec.Command("docker", "exec", "-it", containerName, "tmux", "attach", "-t", "main")
-		connectCmd.Stdin = os.Stdin
-		connectCmd.Stdout = os.Stdout
-		connectCmd.Stderr = os.Stderr
-
-		if err := connectCmd.Run(); err != nil {
-			fmt.Printf("\nWarning: Failed to connect: %v\n", err)
-			fmt.Printf("You can connect later with: maestro connect %s\n", container.GetShortName(containerName, config.Containers.Prefix))
-		}
+		connectNewContainer(containerName, branchName, false)
 	} else {
-		fmt.Printf("Connect with: maestro connect %s\n", container.GetShortName(containerName, config.Containers.Prefix))
-		fmt.Printf("Detach with: Ctrl+b d\n")
+		printCreateSummary(containerName, branchName, false)
 	}
 
 	return nil
 }
 
+
@@ -0,0 +1,173 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNextStepHints(t *testing.T) {
+	got := nextStepHints("feat-x-1", "maestro-feat-x-1", false)
+	want := []string{
+		"maestro connect feat-x-1      # Attach to Claude (detach with Ctrl+b d)",
+		"docker logs maestro-feat-x-1  # Container logs (startup, firewall)",
+		"maestro stop feat-x-1         # Stop it when you're done",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("nextStepHints() =\n%q\nwant\n%q", got, want)
+	}
+
+	got = nextStepHints("feat-x-1", "maestro-feat-x-1", true)
+	if want := "maestro connect feat-x-1      # Reconnect (detach with Ctrl+b d)"; got[0] != want {
+		t.Errorf("after attaching, first hint = %q, want %q", got[0], want)
+	}
+}
+
+func TestGenerateSimpleBranch(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		taken       []string
+		want        string
+	}{
+		{"plain", "Add user authentication", nil, "feat/add-user-authentication"},
+		{"filler words dropped", "Fix the bug in the parser", nil, "feat/fix-bug-parser"},
+		{"punctuation", "Fix: don't crash on `nil` (again!)", nil, "feat/fix-dont-crash-nil-again"},
+		{"accents folded", "Café menü rendering", nil, "feat/cafe-menu-rendering"},
+		{"non-latin text is a word break", "支持 unicode 名前 titles", nil, "feat/unicode-titles"},
+		{"emoji", "🚀 launch 🎉 page", nil, "feat/launch-page"},
+		{
+			"long titles cut at a word boundary",
+			"Implement the distributed rate limiter for public endpoints",
+			nil,
+			"feat/implement-distributed-rate-limiter",
+		},
+		{"one long word is cut", strings.Repeat("x", 50), nil, "feat/" + strings.Repeat("x", 35)},
+		{"collision gets a suffix", "Add caching", []string{"feat/add-caching"}, "feat/add-caching-2"},
+		{
+			"suffix skips taken numbers",
+			"Add caching",
+			[]string{"feat/add-caching", "feat/add-caching-2"},
+			"feat/add-caching-3",
+		},
+		{
+			"suffix still fits the length cap",
+			"Implement the distributed rate limiter for public endpoints",
+			[]string{"feat/implement-distributed-rate-limiter"},
+			"feat/implement-distributed-rate-2",
+		},
+		{
+			"suffix on a cut word",
+			strings.Repeat("x", 50),
+			[]string{"feat/" + strings.Repeat("x", 35)},
+			"feat/" + strings.Repeat("x", 33) + "-2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var taken func(string) bool
+			if tt.taken != nil {
+				taken = func(branch string) bool { return slices.Contains(tt.taken, branch) }
+			}
+			got := generateSimpleBranch(tt.description, taken)
+			if got != tt.want {
+				t.Errorf("generateSimpleBranch(%q) = %q, want %q", tt.description, got, tt.want)
+			}
+			if !isValidBranchName(got) {
+				t.Errorf("generateSimpleBranch(%q) = %q, not a valid branch name", tt.description, got)
+			}
+		})
+	}
+}
+
+func TestGenerateSimpleBranchWithoutUsableText(t *testing.T) {
+	for _, description := range []string{"", "!!! ??? ...", "the and a", "日本語"} {
+		got := generateSimpleBranch(description, nil)
+		if !strings.HasPrefix(got, "feat/task-") || !isValidBranchName(got) {
+			t.Errorf("generateSimpleBranch(%q) = %q, want a valid feat/task-N fallback", description, got)
+		}
+	}
+}
+
+func TestBuildAutoInputScript(t *testing.T) {
+	const prompt = "Fix the billing export for ACME Corp"
+
+	script := buildAutoInputScript(prompt, false)
+	if !strings.Contains(script, prompt) {
+		t.Errorf("script doesn't embed the prompt:\n%s", script)
+	}
+	if strings.Contains(script, "rm -f") {
+		t.Errorf("script deletes the prompt without private mode:\n%s", script)
+	}
+
+	private := buildAutoInputScript(prompt, true)
+	if strings.Contains(private, prompt) {
+		t.Errorf("private script contains the prompt:\n%s", private)
+	}
+	if !strings.Contains(private, "done < "+promptInputFile) {
+		t.Errorf("private script doesn't read %s:\n%s", promptInputFile, private)
+	}
+	if !strings.Contains(private, `rm -f `+promptInputFile+` "$0"`) {
+		t.Errorf("private script doesn't clean up:\n%s", private)
+	}
+}
+
+// stubClaudeProbe makes claudeProbe report Claude running from the given
+// check onwards (counting from 1; 0 never), polling without delay
+func stubClaudeProbe(t *testing.T, runningFrom int) *int {
+	t.Helper()
+	origProbe, origPoll := claudeProbe, claudeReadyPoll
+	t.Cleanup(func() { claudeProbe, claudeReadyPoll = origProbe, origPoll })
+	claudeReadyPoll = time.Millisecond
+
+	checks := 0
+	claudeProbe = func(string) bool {
+		checks++
+		return runningFrom > 0 && checks >= runningFrom
+	}
+	return &checks
+}
+
+func TestWaitForClaude(t *testing.T) {
+	origQuiet := quiet
+	t.Cleanup(func() { quiet = origQuiet })
+	quiet = true
+
+	tests := []struct {
+		name        string
+		runningFrom int
+		want        bool
+	}{
+		{"already running", 1, true},
+		{"starts after a few checks", 4, true},
+		{"never starts", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checks := stubClaudeProbe(t, tt.runningFrom)
+			if got := waitForClaude("maestro-x-1", 50*time.Millisecond); got != tt.want {
+				t.Errorf("waitForClaude() = %v, want %v", got, tt.want)
+			}
+			if tt.want && *checks != tt.runningFrom {
+				t.Errorf("probed %d times, want %d (stop once Claude is up)", *checks, tt.runningFrom)
+			}
+		})
+	}
+}
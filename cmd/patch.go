@@ -0,0 +1,156 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/uprockcom/maestro/pkg/system"
+)
+
+var (
+	patchBase   string
+	patchBinary bool
+)
+
+var patchCmd = &cobra.Command{
+	Use:   "patch <name> [file]",
+	Short: "Export a container's changes as a git patch",
+	Long: `Write the changes in a container's /workspace as a patch you can apply to
+your host checkout with 'git apply', without pushing anything.
+
+By default the patch holds the uncommitted changes (git diff HEAD). With
+--base it holds everything since the branch left base, committed or not.
+Untracked files aren't included; 'git add' them in the container first.
+
+Binary files can't be expressed in a plain diff and are left out with a
+warning; use --binary to include them (git apply understands the result).
+
+The patch goes to file if given, otherwise to stdout.
+
+Examples:
+  maestro patch feat-auth-1 auth.patch
+  maestro patch feat-auth-1 --base main > auth.patch
+  maestro patch feat-auth-1 --binary | git apply`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runPatch,
+}
+
+func init() {
+	rootCmd.AddCommand(patchCmd)
+	patchCmd.Flags().StringVar(&patchBase, "base", "", "Diff against the point where the branch left this branch (e.g. main)")
+	patchCmd.Flags().BoolVar(&patchBinary, "binary", false, "Include binary files in the patch")
+}
+
+func runPatch(cmd *cobra.Command, args []string) error {
+	containerName := resolveContainerName(args[0])
+	if !isContainerRunning(containerName) {
+		return fmt.Errorf("container %s is not running", args[0])
+	}
+
+	from := "HEAD"
+	if patchBase != "" {
+		output, err := containerGit(containerName, "merge-base", patchBase, "HEAD")
+		if err != nil {
+			return fmt.Errorf("failed to find where the branch left %s: %w", patchBase, err)
+		}
+		from = strings.TrimSpace(string(output))
+	}
+
+	patch, err := containerGit(containerName, patchDiffArgs(from, patchBinary)...)
+	if err != nil {
+		return fmt.Errorf("failed to create patch: %w", err)
+	}
+
+	if untracked, err := containerGit(containerName, "ls-files", "--others", "--exclude-standard"); err == nil {
+		if n := len(strings.Fields(string(untracked))); n > 0 {
+			fmt.Fprintf(os.Stderr, "Warning: %d untracked file(s) not included; 'git add' them in the container to export them\n", n)
+		}
+	}
+	if omitted := binaryFilesOmitted(string(patch)); len(omitted) > 0 {
+		fmt.Fprintf(os.Stderr, "Warning: binary file(s) left out (use --binary to include them): %s\n", strings.Join(omitted, ", "))
+	}
+
+	if len(patch) == 0 {
+		fmt.Fprintln(os.Stderr, "No changes to export")
+		return nil
+	}
+
+	if len(args) < 2 {
+		_, err := os.Stdout.Write(patch)
+		return err
+	}
+	if err := os.WriteFile(args[1], patch, 0644); err != nil {
+		return fmt.Errorf("failed to write patch: %w", err)
+	}
+	progressf("✅ Wrote %d changed file(s) to %s\n", strings.Count("\n"+string(patch), "\ndiff --git "), args[1])
+	progressf("Apply with: git apply %s\n", args[1])
+	return nil
+}
+
+// containerGit runs git in a container's /workspace and returns its output.
+// On failure the error includes git's stderr.
+func containerGit(containerName string, args ...string) ([]byte, error) {
+	gitCmd := system.DockerCommand(append([]string{"exec", containerName, "git", "-C", "/workspace"}, args...)...)
+	var stderr bytes.Buffer
+	gitCmd.Stderr = &stderr
+	output, err := gitCmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%w: %s", err, msg)
+		}
+		return nil, err
+	}
+	return output, nil
+}
+
+// patchDiffArgs returns the git arguments for a patch of the working tree
+// against from
+func patchDiffArgs(from string, binary bool) []string {
+	args := []string{"diff", "--no-color", "--no-ext-diff"}
+	if binary {
+		args = append(args, "--binary")
+	}
+	return append(args, from)
+}
+
+// binaryFilesOmitted returns the paths git diff reported as "Binary files
+// ... differ", which a plain patch can't carry
+func binaryFilesOmitted(patch string) []string {
+	var files []string
+	for _, line := range strings.Split(patch, "\n") {
+		rest, ok := strings.CutPrefix(line, "Binary files ")
+		if !ok {
+			continue
+		}
+		rest, ok = strings.CutSuffix(rest, " differ")
+		if !ok {
+			continue
+		}
+		// "a/old and b/new", either side may be /dev/null
+		oldPath, newPath, _ := strings.Cut(rest, " and ")
+		path := strings.TrimPrefix(newPath, "b/")
+		if newPath == "/dev/null" {
+			path = strings.TrimPrefix(oldPath, "a/")
+		}
+		files = append(files, path)
+	}
+	return files
+}
@@ -0,0 +1,56 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBinaryFilesOmitted(t *testing.T) {
+	patch := `diff --git a/main.go b/main.go
+index 1111111..2222222 100644
+--- a/main.go
++++ b/main.go
+@@ -1 +1 @@
+-package old
++package main
+diff --git a/logo.png b/logo.png
+index 3333333..4444444 100644
+Binary files a/logo.png and b/logo.png differ
+diff --git a/new.bin b/new.bin
+new file mode 100644
+Binary files /dev/null and b/new.bin differ
+diff --git a/gone.bin b/gone.bin
+deleted file mode 100644
+Binary files a/gone.bin and /dev/null differ
+`
+	want := []string{"logo.png", "new.bin", "gone.bin"}
+	if got := binaryFilesOmitted(patch); !reflect.DeepEqual(got, want) {
+		t.Errorf("binaryFilesOmitted() = %q, want %q", got, want)
+	}
+	if got := binaryFilesOmitted("GIT binary patch\nliteral 4\n"); got != nil {
+		t.Errorf("binaryFilesOmitted() = %q for a --binary patch, want none", got)
+	}
+}
+
+func TestPatchDiffArgs(t *testing.T) {
+	if got, want := patchDiffArgs("HEAD", false), []string{"diff", "--no-color", "--no-ext-diff", "HEAD"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("patchDiffArgs() = %q, want %q", got, want)
+	}
+	if got, want := patchDiffArgs("abc123", true), []string{"diff", "--no-color", "--no-ext-diff", "--binary", "abc123"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("patchDiffArgs() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/uprockcom/maestro/pkg/container"
+	"github.com/uprockcom/maestro/pkg/system"
+)
+
+var pauseCmd = &cobra.Command{
+	Use:   "pause <name>",
+	Short: "Freeze a running container without stopping it",
+	Long: `Freeze every process in a container with 'docker pause'. The container
+keeps its memory and state but uses no CPU until it is unpaused.
+
+Paused containers are never treated as dormant, so 'maestro stop' without a
+name leaves them alone.
+
+Examples:
+  maestro pause feat-auth-1
+  maestro unpause feat-auth-1`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPause,
+}
+
+var unpauseCmd = &cobra.Command{
+	Use:   "unpause <name>",
+	Short: "Resume a paused container",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runUnpause,
+}
+
+func init() {
+	rootCmd.AddCommand(pauseCmd)
+	rootCmd.AddCommand(unpauseCmd)
+}
+
+func runPause(cmd *cobra.Command, args []string) error {
+	containerName := resolveContainerName(args[0])
+	switch state := containerState(containerName); state {
+	case "running":
+	case "paused":
+		progressf("Container %s is already paused\n", args[0])
+		return nil
+	case "":
+		return fmt.Errorf("container %s not found", args[0])
+	default:
+		return fmt.Errorf("container %s is not running (status: %s)", args[0], state)
+	}
+
+	if err := container.PauseContainer(containerName); err != nil {
+		return err
+	}
+
+	progressf("⏸️  Container %s paused\n", args[0])
+	progressf("Resume it with: maestro unpause %s\n", args[0])
+	return nil
+}
+
+func runUnpause(cmd *cobra.Command, args []string) error {
+	containerName := resolveContainerName(args[0])
+	switch state := containerState(containerName); state {
+	case "paused":
+	case "running":
+		progressf("Container %s is not paused\n", args[0])
+		return nil
+	case "":
+		return fmt.Errorf("container %s not found", args[0])
+	default:
+		return fmt.Errorf("container %s is not paused (status: %s)", args[0], state)
+	}
+
+	if err := container.UnpauseContainer(containerName); err != nil {
+		return err
+	}
+
+	progressf("▶️  Container %s resumed\n", args[0])
+	return nil
+}
+
+// containerState returns docker's state for a container ("running",
+// "paused", "exited", ...), or "" if it doesn't exist
+func containerState(containerName string) string {
+	output, err := system.DockerCommand("inspect", "-f", "{{.State.Status}}", containerName).Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
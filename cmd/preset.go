@@ -0,0 +1,158 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+// Preset is a named set of container defaults from the presets config section
+type Preset struct {
+	Image   string   `mapstructure:"image"`
+	Memory  string   `mapstructure:"memory"`
+	CPUs    string   `mapstructure:"cpus"`
+	Domains []string `mapstructure:"domains"` // Added to firewall.allowed_domains
+	Mounts  []string `mapstructure:"mounts"`  // host:container[:ro], like --mount
+	Env     []string `mapstructure:"env"`     // KEY=VALUE
+}
+
+// clone returns a copy of p that shares no slices with it
+func (p Preset) clone() Preset {
+	p.Domains = append([]string(nil), p.Domains...)
+	p.Mounts = append([]string(nil), p.Mounts...)
+	p.Env = append([]string(nil), p.Env...)
+	return p
+}
+
+var (
+	presetName string
+	presetEnv  []string // Extra KEY=VALUE variables from the applied preset
+)
+
+var presetCmd = &cobra.Command{
+	Use:   "preset",
+	Short: "Manage container presets",
+	Long: `Presets are named container shapes defined under 'presets' in the config
+file. Apply one with 'maestro new --preset <name>' or 'maestro batch --preset <name>'.`,
+}
+
+var presetListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List configured presets",
+	Args:  cobra.NoArgs,
+	RunE:  runPresetList,
+}
+
+func init() {
+	rootCmd.AddCommand(presetCmd)
+	presetCmd.AddCommand(presetListCmd)
+}
+
+func runPresetList(cmd *cobra.Command, args []string) error {
+	if len(currentConfig().Presets) == 0 {
+		fmt.Println("No presets configured. Add them under 'presets' in your config file.")
+		return nil
+	}
+
+	names := make([]string, 0, len(currentConfig().Presets))
+	for name := range currentConfig().Presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tMEMORY\tCPUS\tIMAGE\tDOMAINS\tMOUNTS\tENV")
+	for _, name := range names {
+		p := currentConfig().Presets[name]
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%d\t%d\n", name,
+			orDash(p.Memory), orDash(p.CPUs), orDash(p.Image), len(p.Domains), len(p.Mounts), len(p.Env))
+	}
+	return w.Flush()
+}
+
+// orDash returns s, or "-" if it is empty
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// applyPreset overlays the named preset onto the loaded config for this run.
+// Explicit --mount flags win over preset mounts with the same container path.
+func applyPreset(name string) error {
+	if name == "" {
+		return nil
+	}
+
+	// Viper lowercases map keys
+	preset, ok := currentConfig().Presets[strings.ToLower(name)]
+	if !ok {
+		return fmt.Errorf("unknown preset %q (see 'maestro preset list')", name)
+	}
+
+	for _, kv := range preset.Env {
+		if key, _, ok := strings.Cut(kv, "="); !ok || key == "" {
+			return fmt.Errorf("invalid env %q in preset %s: expected KEY=VALUE", kv, name)
+		}
+	}
+
+	updateConfig(func(c *Config) {
+		if preset.Image != "" {
+			c.Containers.Image = preset.Image
+		}
+		if preset.Memory != "" {
+			c.Containers.Resources.Memory = preset.Memory
+		}
+		if preset.CPUs != "" {
+			c.Containers.Resources.CPUs = preset.CPUs
+		}
+		c.Firewall.AllowedDomains = append(c.Firewall.AllowedDomains, preset.Domains...)
+	})
+	extraMounts = mergeMounts(preset.Mounts, extraMounts)
+	presetEnv = preset.Env
+	return nil
+}
+
+// mergeMounts combines preset and explicit mount specs, dropping preset
+// mounts whose container path is also given explicitly
+func mergeMounts(preset, explicit []string) []string {
+	target := func(spec string) string {
+		parts := strings.Split(spec, ":")
+		if len(parts) < 2 {
+			return ""
+		}
+		return parts[1]
+	}
+
+	overridden := make(map[string]bool)
+	for _, spec := range explicit {
+		overridden[target(spec)] = true
+	}
+
+	var merged []string
+	for _, spec := range preset {
+		if !overridden[target(spec)] {
+			merged = append(merged, spec)
+		}
+	}
+	return append(merged, explicit...)
+}
@@ -0,0 +1,222 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/uprockcom/maestro/pkg/configfile"
+	"github.com/uprockcom/maestro/pkg/container"
+	"github.com/uprockcom/maestro/pkg/paths"
+)
+
+// Profile is a named setup from the profiles config section. While it is
+// active its settings replace the top-level ones for every command.
+type Profile struct {
+	Prefix  string   `mapstructure:"prefix"`
+	Image   string   `mapstructure:"image"`
+	Memory  string   `mapstructure:"memory"`
+	CPUs    string   `mapstructure:"cpus"`
+	Domains []string `mapstructure:"domains"` // Added to firewall.allowed_domains
+}
+
+// clone returns a copy of p that shares no slices with it
+func (p Profile) clone() Profile {
+	p.Domains = append([]string(nil), p.Domains...)
+	return p
+}
+
+// profileFlag is the --profile flag; it wins over the persisted active_profile
+var profileFlag string
+
+var profileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "List and switch config profiles",
+	Long: `Profiles are named setups defined under 'profiles' in the config file. The
+active profile's prefix, image, memory, and cpus replace the top-level
+settings, and its domains are added to the firewall allowlist.
+
+'maestro profile use <name>' makes a profile active for every command until
+changed; --profile <name> picks one for a single command.
+
+Examples:
+  maestro profile list
+  maestro profile use client-a
+  maestro list --profile client-b
+  maestro profile clear`,
+}
+
+var profileListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List configured profiles",
+	Args:  cobra.NoArgs,
+	RunE:  runProfileList,
+}
+
+var profileUseCmd = &cobra.Command{
+	Use:   "use <name>",
+	Short: "Make a profile active for every command",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runProfileUse,
+}
+
+var profileClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Go back to the top-level settings",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setActiveProfile("")
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(profileCmd)
+	profileCmd.AddCommand(profileListCmd)
+	profileCmd.AddCommand(profileUseCmd)
+	profileCmd.AddCommand(profileClearCmd)
+}
+
+func runProfileList(cmd *cobra.Command, args []string) error {
+	cfg := currentConfig()
+	if len(cfg.Profiles) == 0 {
+		fmt.Println("No profiles configured. Add them under 'profiles' in your config file.")
+		return nil
+	}
+
+	names := make([]string, 0, len(cfg.Profiles))
+	for name := range cfg.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	active := strings.ToLower(activeProfileName(cfg))
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "\tNAME\tPREFIX\tIMAGE\tMEMORY\tCPUS\tDOMAINS")
+	for _, name := range names {
+		p := cfg.Profiles[name]
+		marker := ""
+		if name == active {
+			marker = "*"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%d\n", marker, name,
+			orDash(p.Prefix), orDash(p.Image), orDash(p.Memory), orDash(p.CPUs), len(p.Domains))
+	}
+	return w.Flush()
+}
+
+func runProfileUse(cmd *cobra.Command, args []string) error {
+	// Viper lowercases map keys
+	name := strings.ToLower(args[0])
+	if _, ok := currentConfig().Profiles[name]; !ok {
+		return fmt.Errorf("unknown profile %q (see 'maestro profile list')", args[0])
+	}
+	return setActiveProfile(name)
+}
+
+// setActiveProfile persists name as the active profile ("" for none) and
+// reloads the config with it applied
+func setActiveProfile(name string) error {
+	// Check the profile against the top-level settings before persisting it:
+	// a bad prefix would otherwise stop every later command from loading
+	base := &Config{}
+	if err := viper.Unmarshal(base); err != nil {
+		return fmt.Errorf("failed to parse config: %w", err)
+	}
+	if _, err := withProfile(base, name); err != nil {
+		return err
+	}
+
+	if err := paths.EnsureConfigDir(); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	err := configfile.Update(configFilePath(), func(doc *configfile.Document) error {
+		return doc.Set("active_profile", name)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update config: %w", err)
+	}
+
+	// An explicit --profile would otherwise mask the change being made
+	profileFlag = ""
+	if err := ReloadConfig(); err != nil {
+		return err
+	}
+
+	if name == "" {
+		progressln("✓ No profile active; using the top-level settings")
+	} else {
+		progressf("✓ Profile '%s' is now active (prefix %s)\n", name, currentConfig().Containers.Prefix)
+	}
+	return nil
+}
+
+// activeProfileName returns the profile to apply: --profile if given,
+// otherwise the persisted active_profile
+func activeProfileName(c *Config) string {
+	if profileFlag != "" {
+		return profileFlag
+	}
+	return c.ActiveProfile
+}
+
+// applyProfile overlays the named profile onto c. An empty name leaves c as is.
+func applyProfile(c *Config, name string) error {
+	if name == "" {
+		return nil
+	}
+
+	// Viper lowercases map keys
+	profile, ok := c.Profiles[strings.ToLower(name)]
+	if !ok {
+		return fmt.Errorf("unknown profile %q (see 'maestro profile list')", name)
+	}
+
+	if profile.Prefix != "" {
+		c.Containers.Prefix = profile.Prefix
+	}
+	if profile.Image != "" {
+		c.Containers.Image = profile.Image
+	}
+	if profile.Memory != "" {
+		c.Containers.Resources.Memory = profile.Memory
+	}
+	if profile.CPUs != "" {
+		c.Containers.Resources.CPUs = profile.CPUs
+	}
+	c.Firewall.AllowedDomains = append(c.Firewall.AllowedDomains, profile.Domains...)
+	return nil
+}
+
+// withProfile returns a copy of c with the named profile applied, after
+// checking that the resulting prefix is usable. c is left unchanged.
+func withProfile(c *Config, name string) (*Config, error) {
+	next := c.clone()
+	if err := applyProfile(next, name); err != nil {
+		return nil, err
+	}
+	if err := container.ValidatePrefix(next.Containers.Prefix); err != nil {
+		if name == "" {
+			return nil, fmt.Errorf("invalid containers.prefix: %w", err)
+		}
+		return nil, fmt.Errorf("profile %q: invalid prefix: %w", name, err)
+	}
+	return next, nil
+}
@@ -0,0 +1,147 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestApplyProfile(t *testing.T) {
+	base := func() *Config {
+		c := &Config{}
+		c.Containers.Prefix = "maestro-"
+		c.Containers.Image = "maestro:latest"
+		c.Containers.Resources.Memory = "4g"
+		c.Containers.Resources.CPUs = "2"
+		c.Firewall.AllowedDomains = []string{"github.com"}
+		c.Profiles = map[string]Profile{
+			"client-a": {Prefix: "clienta-", Memory: "8g", Domains: []string{"git.client-a.com"}},
+			"empty":    {},
+		}
+		return c
+	}
+
+	t.Run("overlays set fields", func(t *testing.T) {
+		c := base()
+		if err := applyProfile(c, "Client-A"); err != nil {
+			t.Fatalf("applyProfile() error = %v", err)
+		}
+		if c.Containers.Prefix != "clienta-" || c.Containers.Resources.Memory != "8g" {
+			t.Errorf("prefix, memory = %q, %q, want clienta-, 8g", c.Containers.Prefix, c.Containers.Resources.Memory)
+		}
+		if c.Containers.Image != "maestro:latest" || c.Containers.Resources.CPUs != "2" {
+			t.Errorf("unset profile fields changed image, cpus to %q, %q", c.Containers.Image, c.Containers.Resources.CPUs)
+		}
+		if want := []string{"github.com", "git.client-a.com"}; !reflect.DeepEqual(c.Firewall.AllowedDomains, want) {
+			t.Errorf("allowed domains = %q, want %q", c.Firewall.AllowedDomains, want)
+		}
+	})
+
+	t.Run("no profile and empty profile change nothing", func(t *testing.T) {
+		for _, name := range []string{"", "empty"} {
+			c := base()
+			if err := applyProfile(c, name); err != nil {
+				t.Fatalf("applyProfile(%q) error = %v", name, err)
+			}
+			if !reflect.DeepEqual(c, base()) {
+				t.Errorf("applyProfile(%q) changed the config", name)
+			}
+		}
+	})
+
+	t.Run("unknown profile", func(t *testing.T) {
+		if err := applyProfile(base(), "nope"); err == nil {
+			t.Error("applyProfile() accepted an unknown profile")
+		}
+	})
+}
+
+func TestActiveProfileName(t *testing.T) {
+	defer func(orig string) { profileFlag = orig }(profileFlag)
+
+	c := &Config{ActiveProfile: "client-a"}
+	profileFlag = ""
+	if got := activeProfileName(c); got != "client-a" {
+		t.Errorf("activeProfileName() = %q, want the persisted client-a", got)
+	}
+	profileFlag = "client-b"
+	if got := activeProfileName(c); got != "client-b" {
+		t.Errorf("activeProfileName() = %q, want --profile client-b", got)
+	}
+}
+
+func TestWithProfile(t *testing.T) {
+	c := &Config{}
+	c.Containers.Prefix = "maestro-"
+	c.Profiles = map[string]Profile{
+		"client-a": {Prefix: "clienta-"},
+		"broken":   {Prefix: "-bad prefix"},
+	}
+
+	got, err := withProfile(c, "client-a")
+	if err != nil {
+		t.Fatalf("withProfile(client-a) error = %v", err)
+	}
+	if got.Containers.Prefix != "clienta-" {
+		t.Errorf("prefix = %q, want clienta-", got.Containers.Prefix)
+	}
+	if c.Containers.Prefix != "maestro-" {
+		t.Errorf("withProfile() changed the original prefix to %q", c.Containers.Prefix)
+	}
+
+	for _, name := range []string{"broken", "nope"} {
+		if _, err := withProfile(c, name); err == nil {
+			t.Errorf("withProfile(%q) accepted it", name)
+		}
+	}
+}
+
+func TestSetActiveProfileRejectsInvalidPrefix(t *testing.T) {
+	orig, origOverrides, origFile := config, configOverrides, cfgFile
+	t.Cleanup(func() {
+		config, configOverrides, cfgFile = orig, origOverrides, origFile
+		viper.Reset()
+	})
+
+	original := []byte("containers:\n  prefix: maestro-\nprofiles:\n  broken:\n    prefix: -bad prefix\n")
+	cfgFile = filepath.Join(t.TempDir(), "config.yml")
+	if err := os.WriteFile(cfgFile, original, 0644); err != nil {
+		t.Fatal(err)
+	}
+	config, configOverrides = &Config{}, nil
+	viper.Reset()
+	if err := ReloadConfig(); err != nil {
+		t.Fatalf("ReloadConfig() error = %v", err)
+	}
+
+	if err := setActiveProfile("broken"); err == nil {
+		t.Fatal("setActiveProfile() accepted a profile with an invalid prefix")
+	}
+	data, err := os.ReadFile(cfgFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != string(original) {
+		t.Errorf("config file changed to:\n%s", data)
+	}
+	if got := currentConfig().Containers.Prefix; got != "maestro-" {
+		t.Errorf("active prefix = %q, want maestro-", got)
+	}
+}
@@ -0,0 +1,159 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/uprockcom/maestro/pkg/container"
+	"github.com/uprockcom/maestro/pkg/version"
+)
+
+var (
+	rebuildNoCache bool
+	rebuildBuild   bool
+)
+
+var rebuildImageCmd = &cobra.Command{
+	Use:   "rebuild-image",
+	Short: "Pull or rebuild the container image",
+	Long: `Refresh the container image, regardless of whether it already exists.
+
+Registry images are pulled again; if the pull fails, or with --build, the image
+is built from the local docker/ directory instead.
+
+Existing containers keep running on the image they were created from. Recreate
+them to pick up the new image.
+
+Examples:
+  maestro rebuild-image
+  maestro rebuild-image --build --no-cache`,
+	Args: cobra.NoArgs,
+	RunE: runRebuildImage,
+}
+
+func init() {
+	rootCmd.AddCommand(rebuildImageCmd)
+	rebuildImageCmd.Flags().BoolVar(&rebuildBuild, "build", false, "Build from docker/ instead of pulling")
+	rebuildImageCmd.Flags().BoolVar(&rebuildNoCache, "no-cache", false, "Don't use the Docker build cache")
+}
+
+func runRebuildImage(cmd *cobra.Command, args []string) error {
+	imageName := getDockerImage()
+	isRegistryImage := strings.Contains(imageName, "ghcr.io") || strings.Contains(imageName, "docker.io")
+
+	pulled := false
+	if isRegistryImage && !rebuildBuild {
+		if err := pullDockerImage(imageName); err == nil {
+			pulled = true
+		} else {
+			fmt.Println("Warning: Failed to pull from registry, will try to build locally...")
+		}
+	}
+	if !pulled {
+		if err := buildDockerImage(imageName, rebuildNoCache); err != nil {
+			return fmt.Errorf("failed to rebuild image: %w", err)
+		}
+	}
+	fmt.Printf("✓ Image %s refreshed\n", imageName)
+
+	if containers, err := container.GetAllContainers(currentConfig().Containers.Prefix); err == nil {
+		if freshness, err := checkImageFreshness(containers); err == nil {
+			if freshness.Outdated {
+				fmt.Printf("⚠️  The image is still at revision %d (expected %d); the registry may not have the new image yet.\n",
+					freshness.Revision, version.ImageRevision)
+			}
+			if len(freshness.Stale) > 0 {
+				fmt.Printf("%d container(s) still use the previous image: %s\n", len(freshness.Stale), strings.Join(freshness.Stale, ", "))
+				fmt.Println("Recreate them to pick up the new image.")
+			}
+		}
+	}
+
+	return nil
+}
+
+// imageFreshness describes how the local container image and the containers
+// created from it compare to what this binary expects
+type imageFreshness struct {
+	Image    string
+	Revision int      // Revision label of the local image; 0 if unknown
+	Outdated bool     // Local image is labelled older than version.ImageRevision
+	Stale    []string // Short names of containers created from an older build of their image
+}
+
+// checkImageFreshness inspects the local container image and the given
+// containers. An image without a revision label has an unknown revision and
+// is never reported as outdated. Each container is compared with the current
+// local build of the image it was created from; every distinct image is
+// inspected once.
+func checkImageFreshness(containers []container.Info) (*imageFreshness, error) {
+	imageName := getDockerImage()
+	image, err := container.InspectImage(imageName)
+	if err != nil {
+		return nil, err
+	}
+
+	freshness := &imageFreshness{Image: imageName, Revision: image.Revision}
+	freshness.Outdated = image.Revision > 0 && image.Revision < version.ImageRevision
+
+	names := make([]string, len(containers))
+	for i, c := range containers {
+		names[i] = c.Name
+	}
+	images := container.ContainerImages(names)
+
+	// Local image ID by image name; "" if the image isn't present locally
+	localIDs := map[string]string{imageName: image.ID}
+	for _, c := range containers {
+		ci, ok := images[c.Name]
+		if !ok {
+			continue
+		}
+		localID, inspected := localIDs[ci.Ref]
+		if !inspected {
+			if info, err := container.InspectImage(ci.Ref); err == nil {
+				localID = info.ID
+			}
+			localIDs[ci.Ref] = localID
+		}
+		if localID != "" && ci.ID != localID {
+			freshness.Stale = append(freshness.Stale, c.ShortName)
+		}
+	}
+
+	return freshness, nil
+}
+
+// printImageFreshnessWarning prints a short warning if the local image or any
+// container is behind; used by list. Problems inspecting the image are ignored.
+func printImageFreshnessWarning(containers []container.Info) {
+	freshness, err := checkImageFreshness(containers)
+	if err != nil {
+		return
+	}
+	if freshness.Outdated {
+		fmt.Printf("\n⚠️  Container image %s is outdated (revision %d, expected %d).\n",
+			freshness.Image, freshness.Revision, version.ImageRevision)
+		fmt.Println("   Run 'maestro rebuild-image' to update it.")
+	}
+	if len(freshness.Stale) > 0 {
+		fmt.Printf("\n⚠️  %d container(s) run an older build of the image: %s\n",
+			len(freshness.Stale), strings.Join(freshness.Stale, ", "))
+		fmt.Println("   Recreate them to pick up the current image.")
+	}
+}
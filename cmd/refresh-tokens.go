@@ -15,31 +15,61 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
-	"os/exec"
-	"path/filepath"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/uprockcom/maestro/pkg/container"
 	"github.com/uprockcom/maestro/pkg/paths"
+	"github.com/uprockcom/maestro/pkg/system"
 	"github.com/spf13/cobra"
 )
 
 var refreshTokensCmd = &cobra.Command{
-	Use:   "refresh-tokens",
+	Use:   "refresh-tokens [name...]",
 	Short: "Find and propagate the freshest authentication token",
 	Long: `Scans all running containers and the host for credentials,
 finds the one with the latest expiration time, and syncs it
 to all containers and the host.
 
 This is useful when tokens have been automatically refreshed
-in one container but not synchronized to others.`,
+in one container but not synchronized to others.
+
+After syncing, each container's credentials are read back and checked against
+the chosen token and its auth status. Containers where the sync didn't take
+effect are reported and the command exits with an error.
+
+Pass container names (as arguments or with --containers) to limit both
+the scan and the sync to those containers plus the host.
+
+With --only-stale, containers whose own token is still valid for longer than
+--stale-threshold are left alone, so a container that refreshed its token
+independently isn't overwritten. The host is always synced.
+
+Examples:
+  maestro refresh-tokens
+  maestro refresh-tokens feat-auth-1 feat-auth-2
+  maestro refresh-tokens --containers feat-auth-1,feat-auth-2
+  maestro refresh-tokens --only-stale --stale-threshold 6h`,
 	RunE: runRefreshTokens,
 }
 
+var (
+	refreshTokensJSON           bool
+	refreshTokensContainers     []string
+	refreshTokensOnlyStale      bool
+	refreshTokensStaleThreshold time.Duration
+)
+
 func init() {
 	rootCmd.AddCommand(refreshTokensCmd)
+	refreshTokensCmd.Flags().BoolVar(&refreshTokensJSON, "json", false, "Print a JSON summary instead of progress output")
+	refreshTokensCmd.Flags().StringSliceVar(&refreshTokensContainers, "containers", nil, "Only scan and sync these containers (comma-separated)")
+	refreshTokensCmd.Flags().BoolVar(&refreshTokensOnlyStale, "only-stale", false, "Only sync containers whose token expires within --stale-threshold")
+	refreshTokensCmd.Flags().DurationVar(&refreshTokensStaleThreshold, "stale-threshold", 24*time.Hour, "With --only-stale, containers with a token valid for longer than this are skipped")
 }
 
 type tokenSource struct {
@@ -49,57 +79,170 @@ type tokenSource struct {
 	expiresAt time.Time
 }
 
+// freshestSource returns the source whose token expires last, and how many
+// sources share that expiry. Sources synced together usually tie, so ties
+// go to the host, then to the lexically first container name, making the
+// choice the same on every run whatever order the scan finished in.
+func freshestSource(sources []tokenSource) (tokenSource, int) {
+	var freshest tokenSource
+	tied := 0
+	for _, src := range sources {
+		switch {
+		case tied == 0 || src.expiresAt.After(freshest.expiresAt):
+			freshest, tied = src, 1
+		case src.expiresAt.Equal(freshest.expiresAt):
+			tied++
+			if preferTokenSource(src.location, freshest.location) {
+				freshest = src
+			}
+		}
+	}
+	return freshest, tied
+}
+
+// preferTokenSource reports whether location a wins a tie against b
+func preferTokenSource(a, b string) bool {
+	if a == "host" || b == "host" {
+		return a == "host"
+	}
+	return a < b
+}
+
+// refreshSourceResult describes a location scanned for credentials
+type refreshSourceResult struct {
+	Location  string     `json:"location"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Expired   bool       `json:"expired"`
+	Error     string     `json:"error,omitempty"`
+}
+
+// refreshTargetResult describes the outcome of syncing to one location
+type refreshTargetResult struct {
+	Location    string `json:"location"`
+	Status      string `json:"status"` // "synced", "warning", "failed", or "skipped"
+	Error       string `json:"error,omitempty"`
+	Reason      string `json:"reason,omitempty"`       // Why the location was skipped
+	Verified    bool   `json:"verified"`               // Credentials read back match the chosen token
+	AuthStatus  string `json:"auth_status,omitempty"`  // Auth status after the sync, as in 'maestro list'
+	VerifyError string `json:"verify_error,omitempty"` // Why the sync didn't take effect
+}
+
+// refreshResult is the structured summary printed with --json
+type refreshResult struct {
+	Sources    []refreshSourceResult `json:"sources"`
+	Chosen     *refreshSourceResult  `json:"chosen,omitempty"`
+	AllExpired bool                  `json:"all_expired"`
+	Targets    []refreshTargetResult `json:"targets"`
+	Synced     int                   `json:"synced"`
+	Skipped    int                   `json:"skipped"`
+}
+
 func runRefreshTokens(cmd *cobra.Command, args []string) error {
-	fmt.Println("Scanning for credentials...")
+	result := &refreshResult{
+		Sources: []refreshSourceResult{},
+		Targets: []refreshTargetResult{},
+	}
+
+	// Progress output is suppressed in JSON mode
+	say := func(format string, a ...any) {
+		if !refreshTokensJSON {
+			progressf(format, a...)
+		}
+	}
+
+	scope := append(append([]string{}, args...), refreshTokensContainers...)
+	err := refreshTokens(result, scope, say)
+
+	if refreshTokensJSON {
+		out, jsonErr := json.MarshalIndent(result, "", "  ")
+		if jsonErr != nil {
+			return fmt.Errorf("failed to encode result: %w", jsonErr)
+		}
+		fmt.Println(string(out))
+	}
+
+	return err
+}
+
+// refreshTokens finds the freshest token and syncs it everywhere, recording each step in result.
+// If scope is non-empty, only those containers (plus the host) are scanned and synced.
+func refreshTokens(result *refreshResult, scope []string, say func(format string, a ...any)) error {
+	unlock, err := container.LockFleet("refresh-tokens", func(holder string) {
+		say("⏳ Waiting for another maestro operation to finish: %s\n", holder)
+	})
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	say("Scanning for credentials...\n")
 
 	var sources []tokenSource
+	current := make(map[string]*container.Credentials)
+
+	addSource := func(src tokenSource) {
+		expiresAt := src.expiresAt
+		sources = append(sources, src)
+		current[src.location] = src.creds
+		result.Sources = append(result.Sources, refreshSourceResult{
+			Location:  src.location,
+			ExpiresAt: &expiresAt,
+			Expired:   container.IsTokenExpired(src.creds),
+		})
+	}
 
 	// 1. Check host credentials
-	hostCredPath := filepath.Join(paths.AuthDir(), ".credentials.json")
+	hostCredPath := paths.FindCredentials(paths.AuthDir())
 	if hostCreds, err := container.ReadCredentials(hostCredPath); err == nil {
-		sources = append(sources, tokenSource{
+		addSource(tokenSource{
 			location:  "host",
 			path:      hostCredPath,
 			creds:     hostCreds,
 			expiresAt: time.UnixMilli(hostCreds.ClaudeAiOauth.ExpiresAt),
 		})
-		fmt.Printf("  ✓ Host: %s\n", container.FormatExpiration(hostCreds))
+		say("  ✓ Host: %s\n", container.FormatExpiration(hostCreds))
 	} else {
-		fmt.Printf("  ✗ Host: Could not read credentials (%v)\n", err)
+		result.Sources = append(result.Sources, refreshSourceResult{Location: "host", Error: err.Error()})
+		say("  ✗ Host: Could not read credentials (%v)\n", err)
+	}
+
+	// Listing probes every container, so show a spinner unless printing JSON
+	var steps *stepSpinner
+	if !refreshTokensJSON {
+		steps = newStepSpinner()
 	}
 
-	// 2. Check all running containers (including legacy "mcl-" prefix for backward compatibility)
-	containers, err := container.GetRunningContainers(config.Containers.Prefix)
+	// 2. Check all running containers (including the legacy "mcl-" prefix, per scan_legacy_prefix)
+	steps.Step("Listing running containers")
+	containers, err := container.GetRunningContainersWithLegacy(currentConfig().Containers.Prefix)
 	if err != nil {
+		steps.Fail()
 		return fmt.Errorf("failed to list containers: %w", err)
 	}
+	steps.Done()
 
-	// Also check legacy prefix if different from configured
-	if config.Containers.Prefix != "mcl-" {
-		legacyContainers, _ := container.GetRunningContainers("mcl-")
-		containers = append(containers, legacyContainers...)
+	if len(scope) > 0 {
+		containers = scopeContainers(containers, scope, result, say)
 	}
 
-	for _, c := range containers {
-		// Extract credentials from container to temp file
-		tmpFile := filepath.Join(os.TempDir(), fmt.Sprintf("maestro-creds-%s.json", c.Name))
-		copyCmd := exec.Command("docker", "cp",
-			fmt.Sprintf("%s:/home/node/.claude/.credentials.json", c.Name),
-			tmpFile)
-		if err := copyCmd.Run(); err != nil {
-			fmt.Printf("  ✗ %s: Could not read credentials\n", c.Name)
-			continue
-		}
-		defer os.Remove(tmpFile)
+	scans := scanContainerCredentials(containers)
+	defer removeCredentialScans(scans)
 
-		if creds, err := container.ReadCredentials(tmpFile); err == nil {
-			sources = append(sources, tokenSource{
-				location:  c.Name,
-				path:      tmpFile,
-				creds:     creds,
-				expiresAt: time.UnixMilli(creds.ClaudeAiOauth.ExpiresAt),
+	for _, scan := range scans {
+		switch {
+		case scan.copyErr != nil:
+			result.Sources = append(result.Sources, refreshSourceResult{Location: scan.name, Error: "could not read credentials"})
+			say("  ✗ %s: Could not read credentials\n", scan.name)
+		case scan.err != nil:
+			result.Sources = append(result.Sources, refreshSourceResult{Location: scan.name, Error: scan.err.Error()})
+		default:
+			addSource(tokenSource{
+				location:  scan.name,
+				path:      scan.path,
+				creds:     scan.creds,
+				expiresAt: time.UnixMilli(scan.creds.ClaudeAiOauth.ExpiresAt),
 			})
-			fmt.Printf("  ✓ %s: %s\n", c.Name, container.FormatExpiration(creds))
+			say("  ✓ %s: %s\n", scan.name, container.FormatExpiration(scan.creds))
 		}
 	}
 
@@ -108,81 +251,273 @@ func runRefreshTokens(cmd *cobra.Command, args []string) error {
 	}
 
 	// 3. Find freshest token
-	var freshest tokenSource
-	for _, src := range sources {
-		if src.expiresAt.After(freshest.expiresAt) {
-			freshest = src
-		}
+	freshest, tied := freshestSource(sources)
+	if tied > 1 {
+		say("\n%d sources share the latest expiry; using %s\n", tied, freshest.location)
+	}
+
+	expiresAt := freshest.expiresAt
+	result.Chosen = &refreshSourceResult{
+		Location:  freshest.location,
+		ExpiresAt: &expiresAt,
+		Expired:   container.IsTokenExpired(freshest.creds),
 	}
 
 	// 4. Check if freshest is still valid
 	if container.IsTokenExpired(freshest.creds) {
-		fmt.Println("\n❌ All tokens are expired!")
-		fmt.Printf("   Latest token: %s\n", container.FormatExpiration(freshest.creds))
-		fmt.Println("\nPlease run 'maestro auth' to re-authenticate.")
+		result.AllExpired = true
+		say("\n❌ All tokens are expired!\n")
+		say("   Latest token: %s\n", container.FormatExpiration(freshest.creds))
+		say("\nPlease run 'maestro auth' to re-authenticate.\n")
 		return fmt.Errorf("all tokens expired")
 	}
 
-	fmt.Printf("\n✓ Found fresh token in %s\n", freshest.location)
-	fmt.Printf("  Expires: %s\n", freshest.expiresAt.Format(time.RFC1123))
-	fmt.Printf("  Status: %s\n", container.FormatExpiration(freshest.creds))
+	say("\n✓ Found fresh token in %s\n", freshest.location)
+	say("  Expires: %s\n", freshest.expiresAt.Format(time.RFC1123))
+	say("  Status: %s\n", container.FormatExpiration(freshest.creds))
 
 	// 5. Warn if expiring soon
 	timeUntilExp := container.TimeUntilExpiration(freshest.creds)
 	if timeUntilExp < 24*time.Hour {
-		fmt.Printf("\n⚠️  Token expires in less than 24 hours!\n")
-		fmt.Printf("   Consider running 'maestro auth' soon.\n")
+		say("\n⚠️  Token expires in less than 24 hours!\n")
+		say("   Consider running 'maestro auth' soon.\n")
 	}
 
 	// 6. Sync to all locations
-	fmt.Println("\nSyncing credentials...")
+	say("\nSyncing credentials...\n")
 
 	syncCount := 0
 
 	// Sync to host (if not already source)
 	if freshest.location != "host" {
 		if err := copyCredentials(freshest.path, hostCredPath); err != nil {
-			fmt.Printf("  ✗ Failed to sync to host: %v\n", err)
+			result.Targets = append(result.Targets, refreshTargetResult{Location: "host", Status: "failed", Error: err.Error()})
+			say("  ✗ Failed to sync to host: %v\n", err)
 		} else {
-			fmt.Println("  ✓ Synced to host")
+			result.Targets = append(result.Targets, refreshTargetResult{Location: "host", Status: "synced"})
+			say("  ✓ Synced to host\n")
 			syncCount++
 		}
 	}
 
 	// Sync to containers (skip source container)
+	credPath := container.CredentialsPath()
 	for _, container := range containers {
 		if container.Name == freshest.location {
 			continue
 		}
 
+		if reason, fresh := freshTokenReason(current[container.Name], refreshTokensStaleThreshold); refreshTokensOnlyStale && fresh {
+			result.Targets = append(result.Targets, refreshTargetResult{Location: container.Name, Status: "skipped", Reason: reason})
+			result.Skipped++
+			say("  - Skipped %s (%s)\n", container.Name, reason)
+			continue
+		}
+
 		// Copy to container
 		tmpFile := freshest.path
 		if freshest.location == "host" {
 			tmpFile = hostCredPath
 		}
 
-		copyCmd := exec.Command("docker", "cp", tmpFile,
-			fmt.Sprintf("%s:/home/node/.claude/.credentials.json", container.Name))
+		copyCmd := system.DockerCommand("cp", tmpFile,
+			fmt.Sprintf("%s:%s", container.Name, credPath))
 		if err := copyCmd.Run(); err != nil {
-			fmt.Printf("  ✗ Failed to sync to %s: %v\n", container.Name, err)
+			result.Targets = append(result.Targets, refreshTargetResult{Location: container.Name, Status: "failed", Error: err.Error()})
+			say("  ✗ Failed to sync to %s: %v\n", container.Name, err)
 			continue
 		}
 
 		// Fix ownership
-		chownCmd := exec.Command("docker", "exec", "-u", "root", container.Name,
-			"chown", "node:node", "/home/node/.claude/.credentials.json")
+		chownCmd := system.DockerCommand("exec", "-u", "root", container.Name,
+			"chown", "node:node", credPath)
 		if err := chownCmd.Run(); err != nil {
-			fmt.Printf("  ⚠  Synced to %s but failed to fix ownership\n", container.Name)
+			result.Targets = append(result.Targets, refreshTargetResult{Location: container.Name, Status: "warning", Error: "failed to fix ownership"})
+			say("  ⚠  Synced to %s but failed to fix ownership\n", container.Name)
 		} else {
-			fmt.Printf("  ✓ Synced to %s\n", container.Name)
+			result.Targets = append(result.Targets, refreshTargetResult{Location: container.Name, Status: "synced"})
+			say("  ✓ Synced to %s\n", container.Name)
 		}
 		syncCount++
 	}
 
-	fmt.Printf("\n✅ Refresh complete! Synced to %d location(s).\n", syncCount)
+	result.Synced = syncCount
+
+	// 7. Read the credentials back to catch syncs that didn't take effect
+	failed := verifySyncedContainers(result, freshest.creds, say)
+
+	say("\n✅ Refresh complete! Synced to %d location(s).\n", syncCount)
+	if result.Skipped > 0 {
+		say("   Skipped %d container(s) with a token valid for more than %s.\n", result.Skipped, refreshTokensStaleThreshold)
+	}
+	if failed > 0 {
+		say("\n⚠️  The new token isn't in place in %d container(s); retry with 'maestro refresh-tokens <name>' or restart them.\n", failed)
+		return fmt.Errorf("credentials not updated in %d container(s)", failed)
+	}
+	return nil
+}
+
+// verifySyncedContainers reads the credentials back out of every container
+// synced in result and checks they are the chosen token and usable, recording
+// the outcome on each target. It returns how many failed the check.
+func verifySyncedContainers(result *refreshResult, want *container.Credentials, say func(format string, a ...any)) int {
+	var synced []container.Info
+	var targets []*refreshTargetResult
+	for i := range result.Targets {
+		target := &result.Targets[i]
+		if target.Location == "host" || target.Status == "failed" || target.Status == "skipped" {
+			continue
+		}
+		synced = append(synced, container.Info{Name: target.Location})
+		targets = append(targets, target)
+	}
+	if len(targets) == 0 {
+		return 0
+	}
+
+	say("\nVerifying credentials...\n")
+	scans := scanContainerCredentials(synced)
+	defer removeCredentialScans(scans)
+
+	failed := 0
+	for i, scan := range scans {
+		target := targets[i]
+		err := scan.copyErr
+		if err == nil {
+			err = scan.err
+		}
+		if err == nil {
+			err = compareCredentials(scan.creds, want)
+		}
+		if err == nil {
+			target.AuthStatus = container.GetAuthStatus(scan.name)
+			if !strings.HasPrefix(target.AuthStatus, "✓") && !strings.HasPrefix(target.AuthStatus, "⚠") {
+				err = fmt.Errorf("auth status is %s", target.AuthStatus)
+			}
+		}
+
+		if err != nil {
+			failed++
+			target.VerifyError = err.Error()
+			say("  ✗ %s: sync didn't take effect: %v\n", scan.name, err)
+			continue
+		}
+		target.Verified = true
+		say("  ✓ %s: token in place (%s)\n", scan.name, target.AuthStatus)
+	}
+	return failed
+}
+
+// freshTokenReason reports whether --only-stale should leave a location with
+// creds alone (they exist and are valid for longer than threshold), and why
+func freshTokenReason(creds *container.Credentials, threshold time.Duration) (string, bool) {
+	if creds == nil || container.TimeUntilExpiration(creds) <= threshold {
+		return "", false
+	}
+	return "token " + strings.ToLower(container.FormatExpiration(creds)), true
+}
+
+// compareCredentials reports how got differs from the token that was synced
+func compareCredentials(got, want *container.Credentials) error {
+	switch {
+	case got.ClaudeAiOauth.AccessToken != want.ClaudeAiOauth.AccessToken,
+		got.ClaudeAiOauth.RefreshToken != want.ClaudeAiOauth.RefreshToken:
+		return fmt.Errorf("token differs from the one synced (%s)", container.FormatExpiration(got))
+	case got.ClaudeAiOauth.ExpiresAt != want.ClaudeAiOauth.ExpiresAt:
+		return fmt.Errorf("expiry differs from the token synced (%s)", container.FormatExpiration(got))
+	}
 	return nil
 }
 
+// scopeContainers keeps only the named containers, recording any that aren't running
+func scopeContainers(containers []container.Info, scope []string, result *refreshResult, say func(format string, a ...any)) []container.Info {
+	running := make(map[string]container.Info)
+	for _, c := range containers {
+		running[c.Name] = c
+	}
+
+	var scoped []container.Info
+	seen := make(map[string]bool)
+	for _, name := range scope {
+		containerName := resolveContainerName(name)
+		if seen[containerName] {
+			continue
+		}
+		seen[containerName] = true
+
+		c, ok := running[containerName]
+		if !ok {
+			result.Sources = append(result.Sources, refreshSourceResult{Location: containerName, Error: "not running"})
+			say("  ✗ %s: Not running\n", containerName)
+			continue
+		}
+		scoped = append(scoped, c)
+	}
+	return scoped
+}
+
+// credentialScan is the result of reading one container's credentials
+type credentialScan struct {
+	name    string
+	path    string // Temp file holding the copied credentials
+	creds   *container.Credentials
+	copyErr error // Set if the file couldn't be copied out of the container
+	err     error // Set if the copied file couldn't be parsed
+}
+
+// scanContainerCredentials copies each container's credentials to a temp file
+// and parses them, probing several containers at once. Results are in the same
+// order as containers; callers must clean up with removeCredentialScans.
+func scanContainerCredentials(containers []container.Info) []credentialScan {
+	scans := make([]credentialScan, len(containers))
+
+	limit := currentConfig().Containers.ProbeConcurrency
+	if limit < 1 {
+		limit = 8
+	}
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, limit)
+
+	for i, c := range containers {
+		wg.Add(1)
+		go func(idx int, name string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			scan := credentialScan{name: name}
+			// A unique file, so concurrent runs can't overwrite each other's copies
+			tmp, err := os.CreateTemp("", "maestro-creds-*.json")
+			if err != nil {
+				scan.copyErr = err
+				scans[idx] = scan
+				return
+			}
+			tmp.Close()
+			scan.path = tmp.Name()
+
+			if _, err := container.CopyCredentialsFromContainer(name, scan.path); err != nil {
+				scan.copyErr = err
+			} else {
+				scan.creds, scan.err = container.ReadCredentials(scan.path)
+			}
+			scans[idx] = scan
+		}(i, c.Name)
+	}
+	wg.Wait()
+
+	return scans
+}
+
+// removeCredentialScans deletes the temp files created by scanContainerCredentials
+func removeCredentialScans(scans []credentialScan) {
+	for _, scan := range scans {
+		if scan.path != "" {
+			os.Remove(scan.path)
+		}
+	}
+}
+
 func copyCredentials(src, dst string) error {
 	data, err := os.ReadFile(src)
 	if err != nil {
@@ -0,0 +1,116 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/uprockcom/maestro/pkg/container"
+)
+
+func TestCompareCredentials(t *testing.T) {
+	newCreds := func(access, refresh string, expiresAt int64) *container.Credentials {
+		creds := &container.Credentials{}
+		creds.ClaudeAiOauth.AccessToken = access
+		creds.ClaudeAiOauth.RefreshToken = refresh
+		creds.ClaudeAiOauth.ExpiresAt = expiresAt
+		return creds
+	}
+	expires := time.Now().Add(6 * time.Hour).UnixMilli()
+	want := newCreds("access-new", "refresh-new", expires)
+
+	tests := []struct {
+		name    string
+		got     *container.Credentials
+		wantErr bool
+	}{
+		{"same token", newCreds("access-new", "refresh-new", expires), false},
+		{"old access token", newCreds("access-old", "refresh-new", expires), true},
+		{"old refresh token", newCreds("access-new", "refresh-old", expires), true},
+		{"different expiry", newCreds("access-new", "refresh-new", expires-1000), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := compareCredentials(tt.got, want); (err != nil) != tt.wantErr {
+				t.Errorf("compareCredentials() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFreshTokenReason(t *testing.T) {
+	expiringIn := func(d time.Duration) *container.Credentials {
+		creds := &container.Credentials{}
+		creds.ClaudeAiOauth.ExpiresAt = time.Now().Add(d).UnixMilli()
+		return creds
+	}
+
+	tests := []struct {
+		name      string
+		creds     *container.Credentials
+		wantFresh bool
+	}{
+		{"no credentials", nil, false},
+		{"expired", expiringIn(-time.Hour), false},
+		{"expires within the threshold", expiringIn(3 * time.Hour), false},
+		{"valid past the threshold", expiringIn(48 * time.Hour), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason, fresh := freshTokenReason(tt.creds, 6*time.Hour)
+			if fresh != tt.wantFresh {
+				t.Errorf("freshTokenReason() fresh = %v, want %v", fresh, tt.wantFresh)
+			}
+			if fresh && reason != "token valid for 2.0d" {
+				t.Errorf("freshTokenReason() reason = %q", reason)
+			}
+		})
+	}
+}
+
+func TestFreshestSource(t *testing.T) {
+	base := time.Now().Add(6 * time.Hour)
+	later := base.Add(time.Hour)
+	src := func(location string, expiresAt time.Time) tokenSource {
+		return tokenSource{location: location, expiresAt: expiresAt}
+	}
+
+	tests := []struct {
+		name     string
+		sources  []tokenSource
+		want     string
+		wantTied int
+	}{
+		{"latest wins", []tokenSource{src("host", base), src("maestro-b-1", later), src("maestro-a-1", base)}, "maestro-b-1", 1},
+		{"host wins a tie", []tokenSource{src("maestro-a-1", later), src("host", later), src("maestro-b-1", later)}, "host", 3},
+		{"first name wins a tie", []tokenSource{src("maestro-c-1", later), src("maestro-a-1", later), src("host", base)}, "maestro-a-1", 2},
+		{"single source", []tokenSource{src("maestro-a-1", base)}, "maestro-a-1", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Every order the scan could finish in must pick the same source
+			for i := range tt.sources {
+				rotated := append(append([]tokenSource{}, tt.sources[i:]...), tt.sources[:i]...)
+				got, tied := freshestSource(rotated)
+				if got.location != tt.want || tied != tt.wantTied {
+					t.Errorf("freshestSource(%v) = %s, %d tied; want %s, %d", rotated, got.location, tied, tt.want, tt.wantTied)
+				}
+			}
+		})
+	}
+}
@@ -18,17 +18,20 @@ import (
 	"bufio"
 	"fmt"
 	"os"
-	"os/exec"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/uprockcom/maestro/pkg/container"
+	"github.com/uprockcom/maestro/pkg/system"
 	"github.com/spf13/cobra"
 )
 
 var (
-	fullRestart bool
+	fullRestart              bool
+	restartSession           string
+	restartClaudeAndFirewall bool
 )
 
 var restartCmd = &cobra.Command{
@@ -39,12 +42,25 @@ var restartCmd = &cobra.Command{
 This is useful when Claude has crashed (zombie process) or is unresponsive.
 The restart preserves the container, git state, and shell window.
 
+Claude runs in window 0 of the "main" tmux session; use --session to restart
+it in another session.
+
+--claude-and-firewall first re-applies the firewall, keeping domains added at
+runtime with add-domain, then restarts Claude. Use it when both have died,
+e.g. after the host slept.
+
+--full stops and starts the whole container. The session's windows are
+recreated with their names and working directories (not their panes or
+running programs), and Claude is restarted in window 0.
+
 If no name is provided, you'll be prompted to select from a list.
 
 Examples:
   maestro restart                    # Show list to select from
   maestro restart feat-auth-1        # Restart Claude process only
-  maestro restart feat-auth-1 --full # Full container restart`,
+  maestro restart feat-auth-1 --full # Full container restart
+  maestro restart feat-auth-1 --session scratch # Restart Claude in another tmux session
+  maestro restart feat-auth-1 --claude-and-firewall # Recover Claude and the firewall`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runRestart,
 }
@@ -52,6 +68,9 @@ Examples:
 func init() {
 	rootCmd.AddCommand(restartCmd)
 	restartCmd.Flags().BoolVar(&fullRestart, "full", false, "Perform full container restart instead of just Claude")
+	restartCmd.Flags().StringVar(&restartSession, "session", "main", "tmux session whose Claude window to restart")
+	restartCmd.Flags().BoolVar(&restartClaudeAndFirewall, "claude-and-firewall", false, "Re-apply the firewall, then restart Claude")
+	restartCmd.MarkFlagsMutuallyExclusive("full", "claude-and-firewall")
 }
 
 func runRestart(cmd *cobra.Command, args []string) error {
@@ -65,14 +84,14 @@ func runRestart(cmd *cobra.Command, args []string) error {
 
 	// If no args provided, show interactive selection
 	if len(args) == 0 {
-		containers, err := container.GetRunningContainers(config.Containers.Prefix)
+		containers, err := container.GetRunningContainers(currentConfig().Containers.Prefix)
 		if err != nil {
 			return fmt.Errorf("failed to get containers: %w", err)
 		}
 
 		if len(containers) == 0 {
-			fmt.Println("No containers found to restart.")
-			fmt.Println("\nCreate a new container with: maestro new <description>")
+			progressln("No containers found to restart.")
+			progressln("\nCreate a new container with: maestro new <description>")
 			return nil
 		}
 
@@ -89,15 +108,46 @@ func runRestart(cmd *cobra.Command, args []string) error {
 	}
 
 	if fullRestart {
-		return performFullRestart(containerName, shortName)
+		return performFullRestart(containerName, shortName, restartSession)
 	}
 
-	return performClaudeRestart(containerName, shortName)
+	if restartClaudeAndFirewall {
+		if err := reapplyFirewall(containerName, shortName); err != nil {
+			return err
+		}
+	}
+
+	return performClaudeRestart(containerName, shortName, restartSession)
+}
+
+// reapplyFirewall re-runs the firewall script with the configured domains plus
+// those the container's firewall already allowed, so runtime additions survive
+func reapplyFirewall(containerName, shortName string) error {
+	if !isContainerRunning(containerName) {
+		return fmt.Errorf("container %s is not running", shortName)
+	}
+	if container.IsFirewallDisabled(containerName) {
+		progressf("⚠️  Firewall is disabled for %s; leaving it off (re-enable with: maestro firewall enable %s)\n", shortName, shortName)
+		return nil
+	}
+
+	steps := newStepSpinner()
+	steps.Step("Re-applying firewall")
+	domains, err := container.FirewallDomains(containerName)
+	if err != nil {
+		steps.Warnf("Runtime domains not found, using config only: %v", err)
+	}
+	if err := initializeFirewall(containerName, domains...); err != nil {
+		steps.Fail()
+		return fmt.Errorf("failed to re-apply firewall: %w", err)
+	}
+	steps.Done()
+	return nil
 }
 
 // checkDockerRunning verifies that Docker is running
 func checkDockerRunning() error {
-	cmd := exec.Command("docker", "info")
+	cmd := system.DockerCommand("info")
 	err := cmd.Run()
 	if err != nil {
 		// Check if it's a connection error (Docker not running)
@@ -140,134 +190,107 @@ func selectContainerForRestart(containers []container.Info) (container.Info, err
 	return sorted[choice-1], nil
 }
 
-func performClaudeRestart(containerName, shortName string) error {
-	fmt.Printf("Restarting Claude process in %s...\n", shortName)
+// performClaudeRestart kills Claude and recreates its window (window 0) in the
+// given tmux session
+func performClaudeRestart(containerName, shortName, session string) error {
+	progressf("Restarting Claude process in %s...\n", shortName)
+
+	steps := newStepSpinner()
 
 	// Step 1: Kill any existing Claude processes (including zombies)
-	fmt.Println("  Stopping Claude process...")
-	killCmd := exec.Command("docker", "exec", containerName, "sh", "-c",
+	steps.Step("Stopping Claude process")
+	killCmd := system.DockerCommand("exec", containerName, "sh", "-c",
 		"pkill -9 claude || true")
 	if err := killCmd.Run(); err != nil {
-		fmt.Printf("  Warning: Failed to kill Claude: %v\n", err)
+		steps.Warnf("Failed to kill Claude: %v", err)
 	}
 
 	// Wait a moment for cleanup
 	time.Sleep(500 * time.Millisecond)
 
 	// Step 2: Kill the tmux window 0 (Claude window)
-	fmt.Println("  Recreating Claude window...")
-	killWindowCmd := exec.Command("docker", "exec", containerName,
-		"tmux", "kill-window", "-t", "main:0")
-	if err := killWindowCmd.Run(); err != nil {
-		// Window might already be dead, that's OK
-		fmt.Printf("  Window already closed\n")
-	}
+	steps.Step("Recreating Claude window")
+	killWindowCmd := system.DockerCommand("exec", containerName,
+		"tmux", "kill-window", "-t", session+":0")
+	// Window might already be dead, that's OK
+	killWindowCmd.Run()
 
 	// Step 3: Create new window 0 with Claude
-	createWindowCmd := exec.Command("docker", "exec", "-u", "node", containerName, "sh", "-c",
-		"cd /workspace && HOME=/home/node tmux new-window -t main:0 -n claude 'claude --dangerously-skip-permissions'")
+	createWindowCmd := system.DockerCommand("exec", "-u", "node", containerName, "sh", "-c",
+		"cd /workspace && HOME=/home/node tmux new-window -t "+shellCommand(session+":0")+" -n claude '"+claudeLaunchCommand+"'")
 	if err := createWindowCmd.Run(); err != nil {
+		steps.Fail()
 		return fmt.Errorf("failed to create new Claude window: %w", err)
 	}
 
 	// Step 4: Enable monitoring on the new window
 	time.Sleep(500 * time.Millisecond)
 
-	monitorCmd := exec.Command("docker", "exec", containerName,
-		"tmux", "set-window-option", "-t", "main:0", "monitor-bell", "on")
+	monitorCmd := system.DockerCommand("exec", containerName,
+		"tmux", "set-window-option", "-t", session+":0", "monitor-bell", "on")
 	if err := monitorCmd.Run(); err != nil {
-		fmt.Printf("  Warning: Failed to enable bell monitoring: %v\n", err)
+		steps.Warnf("Failed to enable bell monitoring: %v", err)
 	}
 
-	silenceCmd := exec.Command("docker", "exec", containerName,
-		"tmux", "set-window-option", "-t", "main:0", "monitor-silence", "10")
+	silenceCmd := system.DockerCommand("exec", containerName,
+		"tmux", "set-window-option", "-t", session+":0", "monitor-silence", "10")
 	if err := silenceCmd.Run(); err != nil {
-		fmt.Printf("  Warning: Failed to enable silence monitoring: %v\n", err)
+		steps.Warnf("Failed to enable silence monitoring: %v", err)
 	}
 
 	// Step 5: Make window 0 active
-	selectCmd := exec.Command("docker", "exec", containerName,
-		"tmux", "select-window", "-t", "main:0")
+	selectCmd := system.DockerCommand("exec", containerName,
+		"tmux", "select-window", "-t", session+":0")
 	if err := selectCmd.Run(); err != nil {
-		fmt.Printf("  Warning: Failed to select window: %v\n", err)
+		steps.Warnf("Failed to select window: %v", err)
 	}
+	steps.Done()
 
-	fmt.Printf("\n✅ Claude restarted successfully in %s\n", shortName)
-	fmt.Printf("Connect with: maestro connect %s\n", shortName)
+	progressf("\n✅ Claude restarted successfully in %s\n", shortName)
+	progressf("Connect with: maestro connect %s\n", shortName)
 
 	return nil
 }
 
-func performFullRestart(containerName, shortName string) error {
-	fmt.Printf("Performing full restart of %s...\n", shortName)
+func performFullRestart(containerName, shortName, session string) error {
+	progressf("Performing full restart of %s...\n", shortName)
+
+	steps := newStepSpinner()
+
+	// Remember the session's windows so the user's layout can be rebuilt
+	var savedWindows []container.TmuxWindow
+	if windows, err := container.ListTmuxWindows(containerName); err == nil {
+		savedWindows = windowsToRestore(windows, session)
+	}
 
 	// Step 1: Stop container
-	fmt.Println("  Stopping container...")
-	stopCmd := exec.Command("docker", "stop", containerName)
+	steps.Step("Stopping container")
+	stopCmd := system.DockerCommand("stop", containerName)
 	if err := stopCmd.Run(); err != nil {
+		steps.Fail()
 		return fmt.Errorf("failed to stop container: %w", err)
 	}
 
 	// Step 2: Start container
-	fmt.Println("  Starting container...")
-	startCmd := exec.Command("docker", "start", containerName)
+	steps.Step("Starting container")
+	startCmd := system.DockerCommand("start", containerName)
 	if err := startCmd.Run(); err != nil {
+		steps.Fail()
 		return fmt.Errorf("failed to start container: %w", err)
 	}
 
 	// Step 3: Wait for container to be ready
-	fmt.Println("  Waiting for container to be ready...")
+	steps.Step("Waiting for container to be ready")
 	time.Sleep(2 * time.Second)
 
 	// Step 3.5: Fix shell config for better terminal experience
-	checkPromptCmd := exec.Command("docker", "exec", containerName, "sh", "-c",
-		"grep -q 'Custom MCL prompt' /home/node/.zshrc")
-	if err := checkPromptCmd.Run(); err != nil {
-		// Prompt not found, apply all shell fixes
-		shellFixCmd := exec.Command("docker", "exec", containerName, "sh", "-c",
-			`# Remove TERM override
-sed -i '/^export TERM=xterm$/d' /home/node/.zshrc
-
-# Disable powerlevel10k theme (causes missing font glyphs)
-sed -i 's/^ZSH_THEME=.*/ZSH_THEME=""/' /home/node/.zshrc
-
-# Add custom prompt with readable symbols and colors
-cat >> /home/node/.zshrc << 'PROMPT_EOF'
-
-# Custom MCL prompt with colors and git status
-autoload -Uz vcs_info
-precmd_vcs_info() { vcs_info }
-precmd_functions+=( precmd_vcs_info )
-setopt prompt_subst
-zstyle ':vcs_info:git:*' formats '%b'
-zstyle ':vcs_info:*' enable git
-
-# Git status indicators (matching maestro list command)
-git_status_symbols() {
-    if [[ -n ${vcs_info_msg_0_} ]]; then
-        local git_status=""
-        local changes=$(git status --porcelain 2>/dev/null | wc -l | tr -d ' ')
-        local ahead=$(git rev-list --count @{u}..HEAD 2>/dev/null || echo "0")
-        local behind=$(git rev-list --count HEAD..@{u} 2>/dev/null || echo "0")
-
-        [[ $changes -gt 0 ]] && git_status+="Δ$changes "
-        [[ $ahead -gt 0 ]] && git_status+="↑$ahead "
-        [[ $behind -gt 0 ]] && git_status+="↓$behind "
-        [[ -z $git_status ]] && git_status="✓ "
-
-        echo "$git_status"
-    fi
-}
-
-PROMPT='%F{green}%n%f  %F{blue}%~%f  %F{magenta}${vcs_info_msg_0_}%f %F{yellow}$(git_status_symbols)%f'
-PROMPT_EOF`)
-		if err := shellFixCmd.Run(); err != nil {
-			fmt.Printf("  Warning: Failed to configure shell: %v\n", err)
-		}
+	steps.Step("Configuring shell and tmux")
+	if err := container.EnsureShellConfig(containerName); err != nil {
+		steps.Warnf("Failed to configure shell: %v", err)
 	}
 
 	// Step 4: Get branch name for tmux config
-	branchCmd := exec.Command("docker", "exec", containerName, "git", "-C", "/workspace", "branch", "--show-current")
+	branchCmd := system.DockerCommand("exec", containerName, "git", "-C", "/workspace", "branch", "--show-current")
 	branchOutput, err := branchCmd.Output()
 	branchName := "main"
 	if err == nil {
@@ -276,40 +299,96 @@ PROMPT_EOF`)
 
 	// Step 5: Always write tmux config with true color support
 	tmuxConfig := generateTmuxConfig(containerName, branchName)
-	writeCmd := exec.Command("docker", "exec", containerName, "sh", "-c",
+	writeCmd := system.DockerCommand("exec", containerName, "sh", "-c",
 		fmt.Sprintf("cat > /home/node/.tmux.conf << 'EOF'\n%s\nEOF", tmuxConfig))
 	if err := writeCmd.Run(); err != nil {
-		fmt.Printf("  Warning: Failed to write tmux config: %v\n", err)
+		steps.Warnf("Failed to write tmux config: %v", err)
 	}
 
-	// Step 6: Check if tmux session exists
-	checkCmd := exec.Command("docker", "exec", containerName, "tmux", "has-session", "-t", "main")
-	if err := checkCmd.Run(); err != nil {
-		fmt.Println("  Recreating tmux session...")
+	// Step 6: Recreate tmux session if it doesn't exist
+	steps.Step("Starting tmux session")
+	if err := ensureTmuxSession(containerName, session); err != nil {
+		steps.Fail()
+		return err
+	}
+	if len(savedWindows) > 0 {
+		steps.Step("Restoring tmux windows")
+		if failed := restoreTmuxWindows(containerName, session, savedWindows); len(failed) > 0 {
+			steps.Warnf("Could not restore window(s): %s", strings.Join(failed, ", "))
+		}
+	}
+	steps.Done()
+
+	progressf("\n✅ Container %s restarted successfully\n", shortName)
+	progressf("Connect with: maestro connect %s\n", shortName)
+
+	return nil
+}
 
-		// Start tmux with Claude
-		tmuxStartCmd := exec.Command("docker", "exec", "-u", "node", containerName, "sh", "-c",
-			"cd /workspace && HOME=/home/node tmux new-session -d -s main 'claude --dangerously-skip-permissions'")
-		if err := tmuxStartCmd.Run(); err != nil {
-			return fmt.Errorf("failed to start tmux session: %w", err)
+
+// windowsToRestore returns the windows of session that a full restart should
+// recreate: all but the Claude window (0), which ensureTmuxSession always
+// rebuilds with its monitoring, in index order
+func windowsToRestore(windows []container.TmuxWindow, session string) []container.TmuxWindow {
+	var restore []container.TmuxWindow
+	for _, w := range windows {
+		if w.Session == session && w.Index != 0 {
+			restore = append(restore, w)
 		}
+	}
+	sort.Slice(restore, func(i, j int) bool { return restore[i].Index < restore[j].Index })
+	return restore
+}
 
-		time.Sleep(1 * time.Second)
+// restoreTmuxWindows recreates saved windows in a fresh session, at their old
+// indexes with their old names and working directories. The default shell
+// window is replaced by the saved layout; panes within a window are not
+// restored. It returns the names of windows that couldn't be created.
+func restoreTmuxWindows(containerName, session string, windows []container.TmuxWindow) []string {
+	system.DockerCommand("exec", "-u", "node", containerName, "tmux", "kill-window", "-t", session+":1").Run()
+
+	var failed []string
+	for _, w := range windows {
+		args := []string{"exec", "-u", "node", containerName,
+			"tmux", "new-window", "-d", "-t", fmt.Sprintf("%s:%d", session, w.Index), "-n", w.Name}
+		if w.Path != "" {
+			args = append(args, "-c", w.Path)
+		}
+		if err := system.DockerCommand(args...).Run(); err != nil {
+			failed = append(failed, w.Name)
+		}
+	}
+	system.DockerCommand("exec", containerName, "tmux", "select-window", "-t", session+":0").Run()
+	return failed
+}
 
-		// Add shell window
-		shellCmd := exec.Command("docker", "exec", containerName,
-			"tmux", "new-window", "-t", "main:1", "-n", "shell", "-c", "cd /workspace && exec zsh")
-		shellCmd.Run()
+// ensureTmuxSession recreates a tmux session with Claude and a shell window if
+// it isn't already running
+func ensureTmuxSession(containerName, session string) error {
+	checkCmd := system.DockerCommand("exec", containerName, "tmux", "has-session", "-t", session)
+	if err := checkCmd.Run(); err == nil {
+		return nil
+	}
 
-		// Rename and configure windows
-		exec.Command("docker", "exec", containerName, "tmux", "rename-window", "-t", "main:0", "claude").Run()
-		exec.Command("docker", "exec", containerName, "tmux", "set-window-option", "-t", "main:0", "monitor-bell", "on").Run()
-		exec.Command("docker", "exec", containerName, "tmux", "set-window-option", "-t", "main:0", "monitor-silence", "10").Run()
-		exec.Command("docker", "exec", containerName, "tmux", "select-window", "-t", "main:0").Run()
+	// Start tmux with Claude
+	tmuxStartCmd := system.DockerCommand("exec", "-u", "node", containerName, "sh", "-c",
+		"cd /workspace && HOME=/home/node tmux new-session -d -s "+shellCommand(session)+" '"+claudeLaunchCommand+"'")
+	if err := tmuxStartCmd.Run(); err != nil {
+		return fmt.Errorf("failed to start tmux session: %w", err)
 	}
 
-	fmt.Printf("\n✅ Container %s restarted successfully\n", shortName)
-	fmt.Printf("Connect with: maestro connect %s\n", shortName)
+	time.Sleep(1 * time.Second)
+
+	// Add shell window
+	shellCmd := system.DockerCommand("exec", containerName,
+		"tmux", "new-window", "-t", session+":1", "-n", "shell", "-c", "cd /workspace && exec zsh")
+	shellCmd.Run()
+
+	// Rename and configure windows
+	system.DockerCommand("exec", containerName, "tmux", "rename-window", "-t", session+":0", "claude").Run()
+	system.DockerCommand("exec", containerName, "tmux", "set-window-option", "-t", session+":0", "monitor-bell", "on").Run()
+	system.DockerCommand("exec", containerName, "tmux", "set-window-option", "-t", session+":0", "monitor-silence", "10").Run()
+	system.DockerCommand("exec", containerName, "tmux", "select-window", "-t", session+":0").Run()
 
 	return nil
 }
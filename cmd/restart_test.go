@@ -0,0 +1,42 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/uprockcom/maestro/pkg/container"
+)
+
+func TestWindowsToRestore(t *testing.T) {
+	windows := []container.TmuxWindow{
+		{Session: "main", Index: 0, Name: "claude", Path: "/workspace"},
+		{Session: "main", Index: 3, Name: "logs", Path: "/var/log"},
+		{Session: "scratch", Index: 1, Name: "notes"},
+		{Session: "main", Index: 1, Name: "editor", Path: "/workspace/src"},
+	}
+
+	want := []container.TmuxWindow{
+		{Session: "main", Index: 1, Name: "editor", Path: "/workspace/src"},
+		{Session: "main", Index: 3, Name: "logs", Path: "/var/log"},
+	}
+	if got := windowsToRestore(windows, "main"); !reflect.DeepEqual(got, want) {
+		t.Errorf("windowsToRestore() = %+v, want %+v", got, want)
+	}
+	if got := windowsToRestore(windows[:1], "main"); got != nil {
+		t.Errorf("windowsToRestore() with only the Claude window = %+v, want nil", got)
+	}
+}
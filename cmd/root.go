@@ -19,8 +19,11 @@ import (
 	"os"
 	"os/exec"
 	"strings"
+	"sync"
 
+	"github.com/uprockcom/maestro/pkg/container"
 	"github.com/uprockcom/maestro/pkg/paths"
+	"github.com/uprockcom/maestro/pkg/system"
 	"github.com/uprockcom/maestro/pkg/tui"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -29,8 +32,67 @@ import (
 var (
 	cfgFile string
 	config  *Config
+	quiet   bool // --quiet: suppress progress output; see progressf
+	timing  bool // --timing: report how long each phase took; see reportTiming
 )
 
+// configMu guards the config pointer. The Config it points to is never
+// modified in place: updateConfig swaps in an edited copy, so goroutines can
+// read a snapshot from currentConfig without locking while commands update it.
+var configMu sync.RWMutex
+
+// currentConfig returns the active config. Treat the result as read-only.
+func currentConfig() *Config {
+	configMu.RLock()
+	defer configMu.RUnlock()
+	return config
+}
+
+// configOverrides are the edits made with updateConfig during this run.
+// ReloadConfig replays them so a reload doesn't drop them. Guarded by configMu.
+var configOverrides []func(c *Config)
+
+// updateConfig applies edit to a copy of the active config and makes the copy active
+func updateConfig(edit func(c *Config)) {
+	configMu.Lock()
+	defer configMu.Unlock()
+	next := config.clone()
+	edit(next)
+	config = next
+	configOverrides = append(configOverrides, edit)
+}
+
+// clone returns a copy of c that shares no maps or slices with it
+func (c *Config) clone() *Config {
+	next := *c
+	next.Firewall.AllowedDomains = append([]string(nil), c.Firewall.AllowedDomains...)
+	next.Firewall.InternalDomains = append([]string(nil), c.Firewall.InternalDomains...)
+	next.Sync.AdditionalFolders = append([]string(nil), c.Sync.AdditionalFolders...)
+	next.Daemon.Notifications.NotifyOn = append([]string(nil), c.Daemon.Notifications.NotifyOn...)
+	next.Hooks = cloneMap(c.Hooks)
+	next.Apps = cloneMap(c.Apps)
+	next.AppDestinations = cloneMap(c.AppDestinations)
+	next.AppBuilds = cloneMap(c.AppBuilds)
+	next.Presets = make(map[string]Preset, len(c.Presets))
+	for name, preset := range c.Presets {
+		next.Presets[name] = preset.clone()
+	}
+	next.Profiles = make(map[string]Profile, len(c.Profiles))
+	for name, profile := range c.Profiles {
+		next.Profiles[name] = profile.clone()
+	}
+	return &next
+}
+
+// cloneMap returns a shallow copy of m, or an empty map if m is nil
+func cloneMap[V any](m map[string]V) map[string]V {
+	out := make(map[string]V, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
+
 // Config represents the maestro configuration
 type Config struct {
 	Claude struct {
@@ -39,6 +101,10 @@ type Config struct {
 		DefaultMode string `mapstructure:"default_mode"`
 	} `mapstructure:"claude"`
 
+	Auth struct {
+		CredentialsFile string `mapstructure:"credentials_file"` // Credentials filename in the auth dir and containers
+	} `mapstructure:"auth"`
+
 	Containers struct {
 		Prefix string `mapstructure:"prefix"`
 		Image  string `mapstructure:"image"`
@@ -46,9 +112,26 @@ type Config struct {
 			Memory string `mapstructure:"memory"`
 			CPUs   string `mapstructure:"cpus"`
 		} `mapstructure:"resources"`
-		DefaultReturnToTUI bool `mapstructure:"default_return_to_tui"`
+		DefaultReturnToTUI bool   `mapstructure:"default_return_to_tui"`
+		ProbeConcurrency   int    `mapstructure:"probe_concurrency"`
+		GitEnabled         bool   `mapstructure:"git_enabled"`
+		ConnectCommand     string `mapstructure:"connect_command"`
+		ImagePullPolicy    string `mapstructure:"image_pull_policy"`
+		DiskSpace          struct {
+			WarnBelow  string `mapstructure:"warn_below"`  // Warn before creating when docker's disk has less free
+			BlockBelow string `mapstructure:"block_below"` // Refuse to create when docker's disk has less free
+		} `mapstructure:"disk_space"`
+		EnvRedact        []string `mapstructure:"env_redact"`         // Env var name substrings whose values are hidden
+		RecentLogsLimit  string   `mapstructure:"recent_logs_limit"`  // Max size of the recent logs in the details view
+		PrivatePrompt    bool     `mapstructure:"private_prompt"`     // Keep the task prompt off docker command lines and out of the container after sending
+		ScanLegacyPrefix bool     `mapstructure:"scan_legacy_prefix"` // Also look for containers under the old "mcl-" prefix
 	} `mapstructure:"containers"`
 
+	Docker struct {
+		Binary  string `mapstructure:"binary"`  // Docker-compatible CLI to run; MAESTRO_DOCKER overrides it
+		Runtime string `mapstructure:"runtime"` // docker or podman; empty detects it from the binary name
+	} `mapstructure:"docker"`
+
 	Tmux struct {
 		DefaultSession string `mapstructure:"default_session"`
 		Prefix         string `mapstructure:"prefix"`
@@ -111,6 +194,8 @@ type Config struct {
 			Enabled            bool     `mapstructure:"enabled"`
 			AttentionThreshold string   `mapstructure:"attention_threshold"`
 			NotifyOn           []string `mapstructure:"notify_on"`
+			WebhookURL         string   `mapstructure:"webhook_url"`
+			WebhookSecret      string   `mapstructure:"webhook_secret"`
 			QuietHours         struct {
 				Start string `mapstructure:"start"`
 				End   string `mapstructure:"end"`
@@ -118,7 +203,22 @@ type Config struct {
 		} `mapstructure:"notifications"`
 	} `mapstructure:"daemon"`
 
-	Apps map[string]string `mapstructure:"apps"` // name -> source path
+	Batch struct {
+		Analyzer        string `mapstructure:"analyzer"`          // "claude-cli" or "command"
+		AnalyzerCommand string `mapstructure:"analyzer_command"`  // Shell command for "command"; prompt on stdin, JSON on stdout
+		MaxDocumentSize int    `mapstructure:"max_document_size"` // Bytes of markdown sent for analysis (0 = unlimited)
+	} `mapstructure:"batch"`
+
+	Hooks map[string]string `mapstructure:"hooks"` // event -> shell command template
+
+	Presets map[string]Preset `mapstructure:"presets"` // name -> container defaults for --preset
+
+	Profiles      map[string]Profile `mapstructure:"profiles"`       // name -> settings for --profile / 'profile use'
+	ActiveProfile string             `mapstructure:"active_profile"` // Profile applied when --profile isn't given
+
+	Apps            map[string]string `mapstructure:"apps"`             // name -> source path
+	AppDestinations map[string]string `mapstructure:"app_destinations"` // name -> install path (optional)
+	AppBuilds       map[string]string `mapstructure:"app_builds"`       // name -> build command run before syncing (optional)
 }
 
 var rootCmd = &cobra.Command{
@@ -135,7 +235,7 @@ parallel, each in their own isolated environment with proper branch management.`
 		// Maintain cached state for seamless return from containers
 		var cachedState *tui.CachedState
 		for {
-			result, newState, err := tui.Run(config.Containers.Prefix, cachedState)
+			result, newState, err := tui.Run(currentConfig().Containers.Prefix, cachedState)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error running TUI: %v\n", err)
 				os.Exit(1)
@@ -169,10 +269,13 @@ parallel, each in their own isolated environment with proper branch management.`
 				}
 				// Loop continues, TUI will restart with cached state
 			case tui.ActionEditConfig:
-				// TODO: Open config file in editor
-				fmt.Printf("Edit config: %s\n", result.FilePath)
-				fmt.Println("Press Enter to continue...")
-				fmt.Scanln()
+				// Open config file in editor; the reload below picks up the changes
+				if err := editFile(result.FilePath); err != nil {
+					fmt.Fprintf(os.Stderr, "Error editing config: %v\n", err)
+					fmt.Println("Press Enter to continue...")
+					fmt.Scanln()
+				}
+				// Loop continues, TUI will restart with cached state
 			case tui.ActionRunCommand:
 				// TODO: Execute the command
 				fmt.Println("Run command (not yet implemented)")
@@ -195,6 +298,13 @@ parallel, each in their own isolated environment with proper branch management.`
 				// Exit the loop
 				return
 			}
+
+			// Pick up config changes made from the TUI or the editor
+			if err := ReloadConfig(); err != nil {
+				fmt.Fprintf(os.Stderr, "Error reloading config, keeping previous settings: %v\n", err)
+				fmt.Println("Press Enter to continue...")
+				fmt.Scanln()
+			}
 		}
 	},
 }
@@ -203,6 +313,11 @@ parallel, each in their own isolated environment with proper branch management.`
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		if system.IsDockerPermissionError(err) {
+			for _, line := range system.DockerGuidance(system.DockerNoAccess) {
+				fmt.Fprintf(os.Stderr, "  %s\n", line)
+			}
+		}
 		os.Exit(1)
 	}
 }
@@ -212,19 +327,25 @@ func init() {
 
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "",
 		"config file (default is $HOME/.maestro/config.yml)")
+	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false,
+		"suppress progress output (errors and --json data are still printed)")
+	rootCmd.PersistentFlags().StringVar(&profileFlag, "profile", "",
+		"config profile to use for this command (overrides 'maestro profile use')")
+	rootCmd.PersistentFlags().BoolVar(&timing, "timing", false,
+		"print how long each phase of the command took to stderr (for diagnosing slowness)")
 }
 
 // performConnect connects to a container's tmux session
 func performConnect(containerName string) error {
 	// Verify container is running
-	checkCmd := exec.Command("docker", "inspect", "-f", "{{.State.Status}}", containerName)
+	checkCmd := system.DockerCommand("inspect", "-f", "{{.State.Status}}", containerName)
 	output, err := checkCmd.Output()
 	if err != nil {
 		// Try as short name
 		shortName := containerName
-		if !strings.HasPrefix(shortName, config.Containers.Prefix) {
-			containerName = config.Containers.Prefix + shortName
-			checkCmd = exec.Command("docker", "inspect", "-f", "{{.State.Status}}", containerName)
+		if !strings.HasPrefix(shortName, currentConfig().Containers.Prefix) {
+			containerName = currentConfig().Containers.Prefix + shortName
+			checkCmd = system.DockerCommand("inspect", "-f", "{{.State.Status}}", containerName)
 			output, err = checkCmd.Output()
 			if err != nil {
 				return fmt.Errorf("container %s not found", shortName)
@@ -242,17 +363,89 @@ func performConnect(containerName string) error {
 		return fmt.Errorf("container %s is not running (status: %s)", containerName, state)
 	}
 
-	fmt.Printf("Connecting to %s...\n", containerName)
-	fmt.Println("Detach with: Ctrl+b d")
-	fmt.Println("Switch windows: Ctrl+b 0 (Claude), Ctrl+b 1 (shell)")
+	return attachToContainer(containerName)
+}
+
+// editFile opens path in $VISUAL or $EDITOR (vi if neither is set) and
+// waits for the editor to exit
+func editFile(path string) error {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
 
-	// Connect to tmux session
-	connectCmd := exec.Command("docker", "exec", "-it", containerName, "tmux", "attach", "-t", "main")
-	connectCmd.Stdin = os.Stdin
-	connectCmd.Stdout = os.Stdout
-	connectCmd.Stderr = os.Stderr
+	// The editor variable may carry arguments, e.g. "code --wait"
+	editorArgs := strings.Fields(editor)
+	editCmd := exec.Command(editorArgs[0], append(editorArgs[1:], path)...)
+	editCmd.Stdin = os.Stdin
+	editCmd.Stdout = os.Stdout
+	editCmd.Stderr = os.Stderr
+	if err := editCmd.Run(); err != nil {
+		return fmt.Errorf("failed to run editor %s: %w", editorArgs[0], err)
+	}
+	return nil
+}
+
+// ReloadConfig re-reads the config file and makes it the active config, so
+// changes written by other commands or an editor take effect without a
+// restart. Readers holding an earlier currentConfig snapshot keep seeing it
+// unchanged. Overrides applied with updateConfig for this run are re-applied.
+func ReloadConfig() error {
+	// Read the file config writers update, which is the one --config names
+	viper.SetConfigFile(configFilePath())
+	if err := viper.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return fmt.Errorf("failed to read config: %w", err)
+		}
+	}
+
+	loaded := &Config{}
+	if err := viper.Unmarshal(loaded); err != nil {
+		return fmt.Errorf("failed to parse config: %w", err)
+	}
+	loaded, err := withProfile(loaded, activeProfileName(loaded))
+	if err != nil {
+		return err
+	}
+
+	configMu.Lock()
+	for _, edit := range configOverrides {
+		edit(loaded)
+	}
+	config = loaded
+	configMu.Unlock()
 
-	return connectCmd.Run()
+	applyContainerSettings(loaded)
+	return nil
+}
+
+// applyContainerSettings passes the config's container settings to pkg/container
+func applyContainerSettings(c *Config) {
+	container.SetProbeConcurrency(c.Containers.ProbeConcurrency)
+	container.SetGitEnabled(c.Containers.GitEnabled)
+	container.SetScanLegacyPrefix(c.Containers.ScanLegacyPrefix)
+	container.SetDefaultConnectCommand(c.Containers.ConnectCommand)
+	container.SetEnvRedactPatterns(c.Containers.EnvRedact)
+	if c.Containers.RecentLogsLimit != "" {
+		if limit, err := container.ParseByteSize(c.Containers.RecentLogsLimit); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: invalid containers.recent_logs_limit: %v\n", err)
+		} else {
+			container.SetRecentLogsLimit(int(limit))
+		}
+	}
+	paths.SetCredentialsFile(c.Auth.CredentialsFile)
+	system.SetDockerBinary(c.Docker.Binary)
+	if err := system.SetDockerRuntime(c.Docker.Runtime); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: invalid docker.runtime: %v\n", err)
+	}
+	if len(c.Hooks) > 0 {
+		container.SetLifecycleHook(runLifecycleHook)
+	} else {
+		container.SetLifecycleHook(nil)
+	}
 }
 
 // performCreate creates a new container from TUI form data
@@ -295,58 +488,7 @@ func initConfig() {
 		}
 	}
 
-	// Set defaults - use paths package for directory defaults
-	viper.SetDefault("claude.config_path", "~/.claude")
-	viper.SetDefault("claude.auth_path", paths.AuthDir())
-	viper.SetDefault("claude.default_mode", "yolo")
-	viper.SetDefault("containers.prefix", "maestro-")
-	viper.SetDefault("containers.image", "ghcr.io/uprockcom/maestro:latest")
-	viper.SetDefault("containers.resources.memory", "4g")
-	viper.SetDefault("containers.resources.cpus", "2")
-	viper.SetDefault("containers.default_return_to_tui", false)
-	viper.SetDefault("tmux.default_session", "main")
-	viper.SetDefault("tmux.prefix", "C-b")
-	viper.SetDefault("firewall.allowed_domains", []string{
-		"registry.npmjs.org",
-		"api.anthropic.com",
-		"github.com",
-		"pypi.org",
-		"files.pythonhosted.org",
-		"sentry.io",
-		"statsig.anthropic.com",
-		"statsig.com",
-		// AWS Bedrock domains
-		"sts.amazonaws.com",
-		"bedrock.amazonaws.com",
-		"bedrock-runtime.amazonaws.com",
-	})
-	viper.SetDefault("firewall.internal_dns", "")
-	viper.SetDefault("firewall.internal_domains", []string{})
-	viper.SetDefault("ssh.enabled", false)
-	viper.SetDefault("ssh.known_hosts_path", "~/.ssh/known_hosts")
-	viper.SetDefault("ssl.certificates_path", paths.CertificatesDir())
-	viper.SetDefault("android.sdk_path", "")
-	viper.SetDefault("git.user_name", "")
-	viper.SetDefault("git.user_email", "")
-	viper.SetDefault("github.enabled", false)
-	viper.SetDefault("github.config_path", paths.GitHubAuthDir())
-	viper.SetDefault("aws.enabled", false)
-	viper.SetDefault("aws.profile", "")
-	viper.SetDefault("aws.region", "")
-	viper.SetDefault("bedrock.enabled", false)
-	viper.SetDefault("bedrock.model", "")
-	viper.SetDefault("daemon.check_interval", "30m")
-	viper.SetDefault("daemon.show_nag", true)
-	viper.SetDefault("daemon.token_refresh.enabled", true)
-	viper.SetDefault("daemon.token_refresh.threshold", "6h")
-	viper.SetDefault("daemon.notifications.enabled", true)
-	viper.SetDefault("daemon.notifications.attention_threshold", "5m")
-	viper.SetDefault("daemon.notifications.notify_on", []string{"attention_needed", "token_expiring"})
-	viper.SetDefault("daemon.notifications.quiet_hours.start", "")
-	viper.SetDefault("daemon.notifications.quiet_hours.end", "")
-	viper.SetDefault("apps", map[string]string{})
-	viper.SetDefault("wizard.always_run", false)
-	viper.SetDefault("wizard.resume_after_auth", false)
+	setConfigDefaults(viper.GetViper())
 
 	// Read config
 	if err := viper.ReadInConfig(); err != nil {
@@ -357,9 +499,111 @@ func initConfig() {
 	}
 
 	// Unmarshal config
-	config = &Config{}
-	if err := viper.Unmarshal(config); err != nil {
+	loaded := &Config{}
+	if err := viper.Unmarshal(loaded); err != nil {
 		fmt.Fprintf(os.Stderr, "Error parsing config: %v\n", err)
 		os.Exit(1)
 	}
-}
\ No newline at end of file
+	if name := activeProfileName(loaded); name != "" {
+		profiled, err := withProfile(loaded, name)
+		if err != nil {
+			if profileFlag != "" {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			// A stale or broken active_profile mustn't lock out 'maestro profile use'
+			fmt.Fprintf(os.Stderr, "Warning: active_profile: %v; using the top-level settings\n", err)
+		} else {
+			loaded = profiled
+		}
+	}
+	configMu.Lock()
+	config = loaded
+	configMu.Unlock()
+
+	if err := container.ValidatePrefix(currentConfig().Containers.Prefix); err != nil {
+		fmt.Fprintf(os.Stderr, "Error in config: containers.prefix: %v\n", err)
+		os.Exit(1)
+	}
+
+	applyContainerSettings(config)
+}
+
+// setConfigDefaults registers the default of every config setting on v
+func setConfigDefaults(v *viper.Viper) {
+	// Use paths package for directory defaults
+	v.SetDefault("claude.config_path", "~/.claude")
+	v.SetDefault("claude.auth_path", paths.AuthDir())
+	v.SetDefault("claude.default_mode", "yolo")
+	v.SetDefault("auth.credentials_file", paths.DefaultCredentialsFile)
+	v.SetDefault("containers.prefix", "maestro-")
+	v.SetDefault("containers.image", "ghcr.io/uprockcom/maestro:latest")
+	v.SetDefault("containers.resources.memory", "4g")
+	v.SetDefault("containers.resources.cpus", "2")
+	v.SetDefault("containers.default_return_to_tui", false)
+	v.SetDefault("containers.probe_concurrency", 8)
+	v.SetDefault("containers.git_enabled", true)
+	v.SetDefault("containers.connect_command", "")
+	v.SetDefault("containers.image_pull_policy", "missing")
+	v.SetDefault("containers.env_redact", []string{"TOKEN", "SECRET", "PASSWORD"})
+	v.SetDefault("containers.disk_space.warn_below", "5g")
+	v.SetDefault("containers.disk_space.block_below", "")
+	v.SetDefault("containers.recent_logs_limit", "16k")
+	v.SetDefault("containers.private_prompt", false)
+	v.SetDefault("containers.scan_legacy_prefix", true)
+	v.SetDefault("docker.binary", "docker")
+	v.SetDefault("docker.runtime", "")
+	v.SetDefault("tmux.default_session", "main")
+	v.SetDefault("tmux.prefix", "C-b")
+	v.SetDefault("firewall.allowed_domains", []string{
+		"registry.npmjs.org",
+		"api.anthropic.com",
+		"github.com",
+		"pypi.org",
+		"files.pythonhosted.org",
+		"sentry.io",
+		"statsig.anthropic.com",
+		"statsig.com",
+		// AWS Bedrock domains
+		"sts.amazonaws.com",
+		"bedrock.amazonaws.com",
+		"bedrock-runtime.amazonaws.com",
+	})
+	v.SetDefault("firewall.internal_dns", "")
+	v.SetDefault("firewall.internal_domains", []string{})
+	v.SetDefault("ssh.enabled", false)
+	v.SetDefault("ssh.known_hosts_path", "~/.ssh/known_hosts")
+	v.SetDefault("ssl.certificates_path", paths.CertificatesDir())
+	v.SetDefault("android.sdk_path", "")
+	v.SetDefault("git.user_name", "")
+	v.SetDefault("git.user_email", "")
+	v.SetDefault("github.enabled", false)
+	v.SetDefault("github.config_path", paths.GitHubAuthDir())
+	v.SetDefault("aws.enabled", false)
+	v.SetDefault("aws.profile", "")
+	v.SetDefault("aws.region", "")
+	v.SetDefault("bedrock.enabled", false)
+	v.SetDefault("bedrock.model", "")
+	v.SetDefault("daemon.check_interval", "30m")
+	v.SetDefault("daemon.show_nag", true)
+	v.SetDefault("daemon.token_refresh.enabled", true)
+	v.SetDefault("daemon.token_refresh.threshold", "6h")
+	v.SetDefault("daemon.notifications.enabled", true)
+	v.SetDefault("daemon.notifications.attention_threshold", "5m")
+	v.SetDefault("daemon.notifications.notify_on", []string{"attention_needed", "token_expiring"})
+	v.SetDefault("daemon.notifications.webhook_url", "")
+	v.SetDefault("daemon.notifications.webhook_secret", "")
+	v.SetDefault("daemon.notifications.quiet_hours.start", "")
+	v.SetDefault("daemon.notifications.quiet_hours.end", "")
+	v.SetDefault("batch.analyzer", "claude-cli")
+	v.SetDefault("batch.analyzer_command", "")
+	v.SetDefault("batch.max_document_size", 100000)
+	v.SetDefault("hooks", map[string]string{})
+	v.SetDefault("apps", map[string]string{})
+	v.SetDefault("app_destinations", map[string]string{})
+	v.SetDefault("app_builds", map[string]string{})
+	v.SetDefault("tui.refresh_interval", "30s")
+	v.SetDefault("tui.delete_grace_period", "10s")
+	v.SetDefault("wizard.always_run", false)
+	v.SetDefault("wizard.resume_after_auth", false)
+}
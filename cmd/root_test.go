@@ -0,0 +1,113 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+// TestUpdateConfigConcurrent is meant to be run with -race (make test does):
+// readers holding a snapshot must never observe writes from updateConfig.
+func TestUpdateConfigConcurrent(t *testing.T) {
+	orig, origOverrides := config, configOverrides
+	t.Cleanup(func() { config, configOverrides = orig, origOverrides })
+
+	config = &Config{Apps: map[string]string{"base": "/bin/base"}}
+	config.Containers.Prefix = "maestro-"
+	config.Firewall.AllowedDomains = []string{"github.com"}
+	snapshot := currentConfig()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			updateConfig(func(c *Config) {
+				c.Apps[fmt.Sprintf("app%d", i)] = "/bin/app"
+				c.Firewall.AllowedDomains = append(c.Firewall.AllowedDomains, "example.com")
+			})
+		}(i)
+		go func() {
+			defer wg.Done()
+			cfg := currentConfig()
+			_ = cfg.Containers.Prefix
+			for range cfg.Apps {
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(snapshot.Apps) != 1 || len(snapshot.Firewall.AllowedDomains) != 1 {
+		t.Errorf("snapshot changed: apps=%v domains=%v", snapshot.Apps, snapshot.Firewall.AllowedDomains)
+	}
+	if got := currentConfig(); len(got.Apps) != 21 || len(got.Firewall.AllowedDomains) != 21 {
+		t.Errorf("after updates: %d apps, %d domains; want 21 each", len(got.Apps), len(got.Firewall.AllowedDomains))
+	}
+}
+
+func TestReloadConfigKeepsOverrides(t *testing.T) {
+	orig, origOverrides, origFile := config, configOverrides, cfgFile
+	t.Cleanup(func() {
+		config, configOverrides, cfgFile = orig, origOverrides, origFile
+		viper.Reset()
+	})
+
+	cfgFile = filepath.Join(t.TempDir(), "config.yml")
+	if err := os.WriteFile(cfgFile, []byte("containers:\n  prefix: maestro-\n  image: from-file\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	config, configOverrides = &Config{}, nil
+	viper.Reset()
+
+	updateConfig(func(c *Config) { c.Containers.Image = "from-preset" })
+	if err := os.WriteFile(cfgFile, []byte("containers:\n  prefix: edited-\n  image: from-file\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ReloadConfig(); err != nil {
+		t.Fatalf("ReloadConfig() error = %v", err)
+	}
+
+	got := currentConfig()
+	if got.Containers.Prefix != "edited-" {
+		t.Errorf("prefix = %q, want the edited file's %q", got.Containers.Prefix, "edited-")
+	}
+	if got.Containers.Image != "from-preset" {
+		t.Errorf("image = %q, want the override %q", got.Containers.Image, "from-preset")
+	}
+}
+
+func TestConfigCloneCopiesPresets(t *testing.T) {
+	c := &Config{
+		Presets:  map[string]Preset{"gpu": {Domains: []string{"pypi.org"}, Env: []string{"A=1"}}},
+		Profiles: map[string]Profile{"work": {Domains: []string{"corp.example"}}},
+	}
+	next := c.clone()
+	next.Presets["gpu"].Domains[0] = "changed"
+	next.Presets["gpu"].Env[0] = "changed"
+	next.Profiles["work"].Domains[0] = "changed"
+
+	if c.Presets["gpu"].Domains[0] != "pypi.org" || c.Presets["gpu"].Env[0] != "A=1" {
+		t.Errorf("clone shares preset slices: %+v", c.Presets["gpu"])
+	}
+	if c.Profiles["work"].Domains[0] != "corp.example" {
+		t.Errorf("clone shares profile slices: %+v", c.Profiles["work"])
+	}
+}
@@ -0,0 +1,199 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/spf13/cobra"
+	"github.com/uprockcom/maestro/pkg/container"
+)
+
+var (
+	metricsAddr     string
+	metricsInterval time.Duration
+)
+
+var serveMetricsCmd = &cobra.Command{
+	Use:   "serve-metrics",
+	Short: "Expose container metrics for Prometheus",
+	Long: `Serve Prometheus metrics about maestro containers on /metrics.
+
+Container info is gathered in the background on a fixed interval, so scrapes
+are cheap and never block on docker.
+
+Metrics:
+  maestro_containers{state}                      Containers by docker state
+  maestro_container_needs_attention{container}   1 if the container needs attention
+  maestro_container_dormant{container}           1 if Claude is not running
+  maestro_container_git_changes{container}       Uncommitted changes
+  maestro_container_git_ahead{container}         Commits ahead of upstream
+  maestro_container_git_behind{container}        Commits behind upstream
+  maestro_token_expiry_seconds{container}        Seconds until the Claude token expires
+  maestro_scrape_duration_seconds                Time taken to gather container info
+
+Example:
+  maestro serve-metrics --addr :9100 --interval 1m`,
+	Args: cobra.NoArgs,
+	RunE: runServeMetrics,
+}
+
+func init() {
+	rootCmd.AddCommand(serveMetricsCmd)
+	serveMetricsCmd.Flags().StringVar(&metricsAddr, "addr", ":9100", "Address to listen on")
+	serveMetricsCmd.Flags().DurationVar(&metricsInterval, "interval", 30*time.Second, "How often to gather container info")
+}
+
+// fleetMetrics holds the Prometheus collectors for the container fleet
+type fleetMetrics struct {
+	containers     *prometheus.GaugeVec
+	needsAttention *prometheus.GaugeVec
+	dormant        *prometheus.GaugeVec
+	gitChanges     *prometheus.GaugeVec
+	gitAhead       *prometheus.GaugeVec
+	gitBehind      *prometheus.GaugeVec
+	tokenExpiry    *prometheus.GaugeVec
+	scrapeDuration prometheus.Gauge
+}
+
+func newFleetMetrics(reg prometheus.Registerer) *fleetMetrics {
+	perContainer := func(name, help string) *prometheus.GaugeVec {
+		return prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: name, Help: help}, []string{"container"})
+	}
+
+	m := &fleetMetrics{
+		containers: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "maestro_containers",
+			Help: "Number of maestro containers by docker state.",
+		}, []string{"state"}),
+		needsAttention: perContainer("maestro_container_needs_attention", "Whether the container needs attention (1) or not (0)."),
+		dormant:        perContainer("maestro_container_dormant", "Whether Claude has exited in the container (1) or is running (0)."),
+		gitChanges:     perContainer("maestro_container_git_changes", "Number of uncommitted changes in /workspace."),
+		gitAhead:       perContainer("maestro_container_git_ahead", "Commits ahead of the upstream branch."),
+		gitBehind:      perContainer("maestro_container_git_behind", "Commits behind the upstream branch."),
+		tokenExpiry:    perContainer("maestro_token_expiry_seconds", "Seconds until the container's Claude token expires (negative if expired)."),
+		scrapeDuration: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "maestro_scrape_duration_seconds",
+			Help: "Time taken to gather container info.",
+		}),
+	}
+
+	reg.MustRegister(m.containers, m.needsAttention, m.dormant, m.gitChanges,
+		m.gitAhead, m.gitBehind, m.tokenExpiry, m.scrapeDuration)
+	return m
+}
+
+// update gathers container info and replaces all metric values
+func (m *fleetMetrics) update(prefix string) error {
+	start := time.Now()
+
+	containers, err := container.GetAllContainers(prefix)
+	if err != nil {
+		return err
+	}
+
+	// Token expiry isn't part of Info, so read credentials separately
+	expiry := make(map[string]float64)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, c := range containers {
+		if c.Status != "running" {
+			continue
+		}
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			creds, err := container.ReadContainerCredentials(name)
+			if err != nil {
+				return
+			}
+			mu.Lock()
+			expiry[name] = container.TimeUntilExpiration(creds).Seconds()
+			mu.Unlock()
+		}(c.Name)
+	}
+	wg.Wait()
+
+	// Reset so removed containers disappear from the output
+	for _, g := range []*prometheus.GaugeVec{m.containers, m.needsAttention, m.dormant,
+		m.gitChanges, m.gitAhead, m.gitBehind, m.tokenExpiry} {
+		g.Reset()
+	}
+
+	for _, c := range containers {
+		m.containers.WithLabelValues(c.Status).Inc()
+
+		if c.Status != "running" {
+			continue
+		}
+
+		m.needsAttention.WithLabelValues(c.ShortName).Set(boolToFloat(c.NeedsAttention))
+		m.dormant.WithLabelValues(c.ShortName).Set(boolToFloat(c.IsDormant))
+
+		changes, ahead, behind := container.ParseGitStatus(c.GitStatus)
+		m.gitChanges.WithLabelValues(c.ShortName).Set(float64(changes))
+		m.gitAhead.WithLabelValues(c.ShortName).Set(float64(ahead))
+		m.gitBehind.WithLabelValues(c.ShortName).Set(float64(behind))
+
+		if secs, ok := expiry[c.Name]; ok {
+			m.tokenExpiry.WithLabelValues(c.ShortName).Set(secs)
+		}
+	}
+
+	m.scrapeDuration.Set(time.Since(start).Seconds())
+	return nil
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func runServeMetrics(cmd *cobra.Command, args []string) error {
+	if metricsInterval < time.Second {
+		return fmt.Errorf("--interval must be at least 1s")
+	}
+
+	reg := prometheus.NewRegistry()
+	metrics := newFleetMetrics(reg)
+
+	// Gather once up front so the first scrape has data
+	if err := metrics.update(currentConfig().Containers.Prefix); err != nil {
+		fmt.Printf("Warning: failed to gather container info: %v\n", err)
+	}
+
+	go func() {
+		ticker := time.NewTicker(metricsInterval)
+		defer ticker.Stop()
+		for range ticker.C {
+			if err := metrics.update(currentConfig().Containers.Prefix); err != nil {
+				fmt.Printf("Warning: failed to gather container info: %v\n", err)
+			}
+		}
+	}()
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(reg, promhttp.HandlerOpts{}))
+
+	fmt.Printf("Serving metrics on %s/metrics (refresh every %s)\n", metricsAddr, metricsInterval)
+	return http.ListenAndServe(metricsAddr, mux)
+}
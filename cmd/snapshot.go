@@ -0,0 +1,171 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/uprockcom/maestro/pkg/container"
+	"github.com/uprockcom/maestro/pkg/system"
+)
+
+// snapshotRepository is the image repository prefix used for container snapshots
+const snapshotRepository = "maestro-snapshot"
+
+// Image labels recorded on snapshots
+const (
+	snapshotLabelSource  = "maestro.snapshot.source"
+	snapshotLabelBranch  = "maestro.snapshot.branch"
+	snapshotLabelTask    = "maestro.snapshot.task"
+	snapshotLabelCreated = "maestro.snapshot.created"
+)
+
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot <name> [tag]",
+	Short: "Save a container's filesystem as an image",
+	Long: `Commit a container's current filesystem to a snapshot image.
+
+The image is named maestro-snapshot/<name>:<tag>. If no tag is given, a
+timestamp is used. The source container, branch, and task are recorded as
+image labels.
+
+Note: named volumes (npm cache, uv cache, shell history) are not included.
+
+Examples:
+  maestro snapshot feat-auth-1
+  maestro snapshot feat-auth-1 before-refactor
+  maestro restore feat-auth-1:before-refactor`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runSnapshot,
+}
+
+var restoreCmd = &cobra.Command{
+	Use:   "restore <snapshot>",
+	Short: "Create a new container from a snapshot",
+	Long: `Create a new container from an image made with 'maestro snapshot'.
+
+The snapshot can be given as <name>:<tag> or as the full image reference
+(maestro-snapshot/<name>:<tag>). The new container is named after the
+snapshot's branch and gets a fresh Claude session.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runRestore,
+}
+
+func init() {
+	rootCmd.AddCommand(snapshotCmd)
+	rootCmd.AddCommand(restoreCmd)
+}
+
+func runSnapshot(cmd *cobra.Command, args []string) error {
+	shortName := args[0]
+	containerName := resolveContainerName(shortName)
+
+	tag := time.Now().Format("20060102-150405")
+	if len(args) > 1 {
+		tag = args[1]
+	}
+
+	imageName := fmt.Sprintf("%s/%s:%s", snapshotRepository,
+		container.GetShortName(containerName, currentConfig().Containers.Prefix), tag)
+
+	// Gather metadata to record with the snapshot
+	branch := container.GetBranchName(containerName)
+	task := truncateString(container.GetTaskDescription(containerName), 200)
+
+	fmt.Printf("Snapshotting %s to %s...\n", containerName, imageName)
+
+	commitArgs := []string{"commit",
+		"--change", labelInstruction(snapshotLabelSource, containerName),
+		"--change", labelInstruction(snapshotLabelBranch, branch),
+		"--change", labelInstruction(snapshotLabelTask, task),
+		"--change", labelInstruction(snapshotLabelCreated, time.Now().Format(time.RFC3339)),
+		containerName, imageName,
+	}
+	commitCmd := system.DockerCommand(commitArgs...)
+	if output, err := commitCmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to commit container: %w\n%s", err, strings.TrimSpace(string(output)))
+	}
+
+	fmt.Printf("✅ Snapshot saved: %s\n", imageName)
+	fmt.Printf("Restore with: maestro restore %s\n", strings.TrimPrefix(imageName, snapshotRepository+"/"))
+
+	return nil
+}
+
+// labelInstruction returns a Dockerfile LABEL instruction setting key to
+// value. Go's %q escapes (\t, \u2019, ...) mean nothing to the Dockerfile
+// parser, so only what a double-quoted Dockerfile word needs is escaped, and
+// line breaks, which can't appear in a --change instruction, become spaces.
+func labelInstruction(key, value string) string {
+	value = strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ").Replace(value)
+	value = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "$", `\$`).Replace(value)
+	return fmt.Sprintf(`LABEL %s="%s"`, key, value)
+}
+
+func runRestore(cmd *cobra.Command, args []string) error {
+	imageName := args[0]
+	if !strings.Contains(imageName, "/") {
+		imageName = snapshotRepository + "/" + imageName
+	}
+
+	// Read branch label to name the new container
+	inspectCmd := system.DockerCommand("image", "inspect", "--format",
+		fmt.Sprintf("{{index .Config.Labels %q}}", snapshotLabelBranch), imageName)
+	output, err := inspectCmd.Output()
+	if err != nil {
+		return fmt.Errorf("snapshot %s not found", imageName)
+	}
+
+	branchName := strings.TrimSpace(string(output))
+	if !isValidBranchName(branchName) {
+		branchName = "restored"
+	}
+
+	containerName, err := getNextContainerName(branchName)
+	if err != nil {
+		return fmt.Errorf("failed to generate container name: %w", err)
+	}
+
+	fmt.Printf("Restoring %s as %s...\n", imageName, containerName)
+
+	if err := startContainerFromImage(containerName, imageName); err != nil {
+		return fmt.Errorf("failed to start container: %w", err)
+	}
+
+	// Refresh tmux config for the new container name, then start a Claude session
+	tmuxConfig := generateTmuxConfig(containerName, branchName)
+	writeCmd := system.DockerCommand("exec", containerName, "sh", "-c",
+		fmt.Sprintf("cat > /home/node/.tmux.conf << 'EOF'\n%s\nEOF", tmuxConfig))
+	if err := writeCmd.Run(); err != nil {
+		fmt.Printf("Warning: Failed to write tmux config: %v\n", err)
+	}
+
+	if err := ensureTmuxSession(containerName, "main"); err != nil {
+		return err
+	}
+
+	task, _ := system.DockerCommand("image", "inspect", "--format",
+		fmt.Sprintf("{{index .Config.Labels %q}}", snapshotLabelTask), imageName).Output()
+	firePostCreateHook(containerName, branchName, strings.TrimSpace(string(task)))
+
+	shortName := container.GetShortName(containerName, currentConfig().Containers.Prefix)
+	fmt.Printf("\n✅ Container %s restored from %s\n", containerName, imageName)
+	fmt.Printf("Connect with: maestro connect %s\n", shortName)
+
+	return nil
+}
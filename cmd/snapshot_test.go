@@ -0,0 +1,37 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import "testing"
+
+func TestLabelInstruction(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"feat/auth", `LABEL k="feat/auth"`},
+		{`say "hi"`, `LABEL k="say \"hi\""`},
+		{`C:\path`, `LABEL k="C:\\path"`},
+		{"costs $HOME", `LABEL k="costs \$HOME"`},
+		{"line one\nline two\r\nthree", `LABEL k="line one line two three"`},
+		// Non-ASCII and tabs pass through instead of becoming Go escapes
+		{"it’s\tdone", "LABEL k=\"it’s\tdone\""},
+	}
+	for _, tt := range tests {
+		if got := labelInstruction("k", tt.value); got != tt.want {
+			t.Errorf("labelInstruction(%q) = %s, want %s", tt.value, got, tt.want)
+		}
+	}
+}
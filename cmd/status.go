@@ -0,0 +1,144 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/uprockcom/maestro/pkg/daemon"
+	"github.com/uprockcom/maestro/pkg/system"
+)
+
+var statusCompact bool
+
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show a quick summary of container states",
+	Long: `Show how many maestro containers are running, need attention, or are stopped.
+
+Only a single 'docker ps' call is made, so this is cheap enough to run from a
+shell prompt or tmux status bar. Attention counts come from the daemon's last
+check and are omitted when the daemon isn't running.
+
+With --compact, prints a single line with no trailing newline and always exits 0:
+  maestro: 3▶ 1🔔 2■
+
+Examples:
+  maestro status
+  maestro status --compact
+  set -g status-right '#(maestro status --compact)'`,
+	Args: cobra.NoArgs,
+	RunE: runStatus,
+}
+
+func init() {
+	rootCmd.AddCommand(statusCmd)
+	statusCmd.Flags().BoolVar(&statusCompact, "compact", false, "Print a terse one-liner for shell prompts and status bars")
+}
+
+// statusCounts summarizes container states
+type statusCounts struct {
+	Running   int
+	Attention int // -1 when unknown (daemon not running)
+	Stopped   int
+}
+
+func runStatus(cmd *cobra.Command, args []string) error {
+	counts, err := gatherStatusCounts()
+	if statusCompact {
+		// Prompt integrations must never fail or print errors
+		if err != nil {
+			fmt.Print("maestro: ?")
+			return nil
+		}
+		fmt.Print(formatCompactStatus(counts))
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Running:          %d\n", counts.Running)
+	if counts.Attention >= 0 {
+		fmt.Printf("Needs attention:  %d\n", counts.Attention)
+	} else {
+		fmt.Println("Needs attention:  unknown (daemon not running)")
+	}
+	fmt.Printf("Stopped:          %d\n", counts.Stopped)
+	return nil
+}
+
+// gatherStatusCounts counts containers by state using a single docker ps call
+func gatherStatusCounts() (statusCounts, error) {
+	counts := statusCounts{Attention: -1}
+
+	// Bound the docker call so a hung daemon can't freeze a shell prompt
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	psCmd := system.DockerCommandContext(ctx, "ps", "-a", "--format", "{{.Names}}\t{{.State}}")
+	output, err := psCmd.Output()
+	if err != nil {
+		return counts, fmt.Errorf("failed to list containers: %w", err)
+	}
+
+	// Attention state is published by the daemon; only trust it while the daemon is alive
+	authDir := expandPath(currentConfig().Claude.AuthPath)
+	var attention map[string]bool
+	if _, running := daemon.IsRunning(filepath.Join(authDir, daemon.PIDFile)); running {
+		if names, err := daemon.ReadAttentionState(authDir); err == nil {
+			attention = names
+			counts.Attention = 0
+		}
+	}
+
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		parts := strings.SplitN(line, "\t", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		name, state := parts[0], parts[1]
+		if !strings.HasPrefix(name, currentConfig().Containers.Prefix) && !(scanLegacyPrefix() && strings.HasPrefix(name, legacyPrefix)) {
+			continue
+		}
+
+		if state == "running" {
+			counts.Running++
+			if attention[name] {
+				counts.Attention++
+			}
+		} else {
+			counts.Stopped++
+		}
+	}
+
+	return counts, nil
+}
+
+// formatCompactStatus renders counts as "maestro: 3▶ 1🔔 2■"
+func formatCompactStatus(c statusCounts) string {
+	parts := []string{fmt.Sprintf("%d▶", c.Running)}
+	if c.Attention >= 0 {
+		parts = append(parts, fmt.Sprintf("%d🔔", c.Attention))
+	}
+	parts = append(parts, fmt.Sprintf("%d■", c.Stopped))
+	return "maestro: " + strings.Join(parts, " ")
+}
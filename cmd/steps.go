@@ -0,0 +1,191 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// stepSpinner shows progress through a sequence of CLI steps. On a terminal
+// the current step gets an animated spinner that turns into ✓ or ✗ when it
+// finishes; otherwise each step is printed once as a plain line.
+//
+// A nil *stepSpinner is valid and prints nothing, which is what newStepSpinner
+// returns in --quiet mode.
+type stepSpinner struct {
+	mu       sync.Mutex
+	animated bool
+	step     string // Current step, "" when idle
+	frame    int
+	stop     chan struct{}
+	stopped  chan struct{}
+}
+
+// newStepSpinner creates a spinner. Animation is disabled when stdout isn't a
+// terminal or a batch progress display owns the screen.
+func newStepSpinner() *stepSpinner {
+	if quiet {
+		return nil
+	}
+	return &stepSpinner{
+		animated: isTerminal(os.Stdout) && GetMultiProgress() == nil,
+	}
+}
+
+// isTerminal reports whether f is an interactive terminal
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// Step finishes the current step (if any) as successful and starts a new one
+func (s *stepSpinner) Step(step string) {
+	if s == nil {
+		return
+	}
+	s.finish("✓")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.step = step
+	if !s.animated {
+		fmt.Printf("  %s...\n", step)
+		return
+	}
+
+	s.frame = 0
+	s.render()
+	s.stop = make(chan struct{})
+	s.stopped = make(chan struct{})
+	go s.animate(s.stop, s.stopped)
+}
+
+// Update replaces the current step's text without finishing it
+func (s *stepSpinner) Update(step string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.step == "" || !s.animated {
+		return
+	}
+	s.step = step
+	s.render()
+}
+
+// Clear stops the current step and erases its line, leaving no trace
+func (s *stepSpinner) Clear() {
+	if s == nil {
+		return
+	}
+	s.stopAnimation()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.animated && s.step != "" {
+		fmt.Print("\r\033[K")
+	}
+	s.step = ""
+}
+
+// Done finishes the current step as successful
+func (s *stepSpinner) Done() {
+	if s != nil {
+		s.finish("✓")
+	}
+}
+
+// Fail finishes the current step as failed
+func (s *stepSpinner) Fail() {
+	if s != nil {
+		s.finish("✗")
+	}
+}
+
+// Warnf prints a warning line without corrupting the spinner. Warnings are
+// printed even in --quiet mode.
+func (s *stepSpinner) Warnf(format string, a ...any) {
+	if s == nil {
+		fmt.Printf("  Warning: "+format+"\n", a...)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.animated && s.step != "" {
+		fmt.Print("\r\033[K")
+	}
+	fmt.Printf("  Warning: "+format+"\n", a...)
+	if s.animated && s.step != "" {
+		s.render()
+	}
+}
+
+// finish stops the animation and marks the current step with mark
+func (s *stepSpinner) finish(mark string) {
+	s.stopAnimation()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.step == "" {
+		return
+	}
+	if s.animated {
+		fmt.Printf("\r\033[K  %s %s\n", mark, s.step)
+	}
+	s.step = ""
+}
+
+// stopAnimation stops the animation goroutine, if running, and waits for it
+func (s *stepSpinner) stopAnimation() {
+	s.mu.Lock()
+	stop, stopped := s.stop, s.stopped
+	s.stop, s.stopped = nil, nil
+	s.mu.Unlock()
+
+	if stop != nil {
+		close(stop)
+		<-stopped
+	}
+}
+
+func (s *stepSpinner) animate(stop, stopped chan struct{}) {
+	defer close(stopped)
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			s.mu.Lock()
+			s.frame = (s.frame + 1) % len(spinnerFrames)
+			s.render()
+			s.mu.Unlock()
+		}
+	}
+}
+
+// render draws the current step; callers must hold s.mu
+func (s *stepSpinner) render() {
+	fmt.Printf("\r\033[K  %s %s...", spinnerFrames[s.frame], s.step)
+}
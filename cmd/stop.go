@@ -18,28 +18,59 @@ import (
 	"bufio"
 	"fmt"
 	"os"
-	"os/exec"
+	"path"
 	"strings"
 
 	"github.com/uprockcom/maestro/pkg/container"
+	"github.com/uprockcom/maestro/pkg/system"
 	"github.com/spf13/cobra"
 )
 
+var (
+	stopMatch       string
+	stopDormantOnly bool
+)
+
 var stopCmd = &cobra.Command{
 	Use:   "stop [name]",
 	Short: "Stop a running container",
 	Long: `Stop a running maestro container. The container can be restarted later.
 
-If no name is provided, will prompt to stop all dormant containers (where Claude is not running).`,
+If no name is provided, will prompt to stop all dormant containers (where Claude is not running).
+
+With --match, prompts to stop every running container whose short name matches
+a glob (* and ? wildcards, [a-z] classes). Add --dormant-only to leave the ones
+where Claude is still working alone.
+
+Examples:
+  maestro stop feat-auth-1
+  maestro stop
+  maestro stop --match 'feat-auth-*'
+  maestro stop --match 'feat-auth-*' --dormant-only`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runStop,
 }
 
 func init() {
 	rootCmd.AddCommand(stopCmd)
+	stopCmd.Flags().StringVar(&stopMatch, "match", "", "Stop the running containers whose short name matches this glob")
+	stopCmd.Flags().BoolVar(&stopDormantOnly, "dormant-only", false, "With --match, only stop containers where Claude is not running")
 }
 
 func runStop(cmd *cobra.Command, args []string) error {
+	if stopMatch != "" {
+		if len(args) > 0 {
+			return fmt.Errorf("give either a container name or --match, not both")
+		}
+		if _, err := path.Match(stopMatch, ""); err != nil {
+			return fmt.Errorf("invalid --match pattern %q: %w", stopMatch, err)
+		}
+		return stopMatchingContainers(stopMatch, stopDormantOnly)
+	}
+	if stopDormantOnly && len(args) > 0 {
+		return fmt.Errorf("--dormant-only applies to --match, not a named container")
+	}
+
 	// If no arguments, prompt to stop dormant containers
 	if len(args) == 0 {
 		return stopDormantContainers()
@@ -49,49 +80,90 @@ func runStop(cmd *cobra.Command, args []string) error {
 	shortName := args[0]
 	containerName := resolveContainerName(shortName)
 
-	fmt.Printf("Stopping %s...\n", containerName)
+	progressf("Stopping %s...\n", containerName)
 
-	stopCmd := exec.Command("docker", "stop", containerName)
+	container.FireLifecycleEvent(container.EventPreStop, containerName)
+	stopCmd := system.DockerCommand("stop", containerName)
 	if err := stopCmd.Run(); err != nil {
 		return fmt.Errorf("failed to stop container: %w", err)
 	}
 
-	fmt.Printf("✅ Container %s stopped\n", containerName)
-	fmt.Printf("To remove it completely, run: maestro cleanup\n")
-	fmt.Printf("To restart it, run: docker start %s && maestro connect %s\n", containerName, shortName)
+	progressf("✅ Container %s stopped\n", containerName)
+	progressf("To remove it completely, run: maestro cleanup\n")
+	progressf("To restart it, run: docker start %s && maestro connect %s\n", containerName, shortName)
 
 	return nil
 }
 
 func stopDormantContainers() error {
 	// Get all running containers
-	containers, err := container.GetRunningContainers(config.Containers.Prefix)
+	containers, err := container.GetRunningContainers(currentConfig().Containers.Prefix)
 	if err != nil {
 		return fmt.Errorf("failed to list containers: %w", err)
 	}
 
 	// Filter for dormant containers
-	var dormantContainers []container.Info
-	for _, c := range containers {
-		if c.IsDormant {
-			dormantContainers = append(dormantContainers, c)
-		}
-	}
+	dormantContainers := filterStopTargets(containers, "", true)
 
 	if len(dormantContainers) == 0 {
-		fmt.Println("No dormant containers found.")
-		fmt.Println("(Dormant = containers where Claude is not running)")
+		progressln("No dormant containers found.")
+		progressln("(Dormant = containers where Claude is not running)")
+		return nil
+	}
+
+	return confirmAndStop(dormantContainers, "dormant container(s)", "Stop all dormant containers?")
+}
+
+// stopMatchingContainers stops the running containers whose short name
+// matches pattern, after confirmation
+func stopMatchingContainers(pattern string, dormantOnly bool) error {
+	containers, err := container.GetRunningContainers(currentConfig().Containers.Prefix)
+	if err != nil {
+		return fmt.Errorf("failed to list containers: %w", err)
+	}
+
+	kind := "running"
+	if dormantOnly {
+		kind = "dormant"
+	}
+	matches := filterStopTargets(containers, pattern, dormantOnly)
+	if len(matches) == 0 {
+		progressf("No %s containers match %q.\n", kind, pattern)
 		return nil
 	}
 
-	// Display dormant containers
-	fmt.Printf("Found %d dormant container(s):\n", len(dormantContainers))
-	for _, c := range dormantContainers {
+	what := fmt.Sprintf("%s container(s) matching %s", kind, pattern)
+	return confirmAndStop(matches, what, fmt.Sprintf("Stop these %d container(s)?", len(matches)))
+}
+
+// filterStopTargets returns the containers whose short name matches pattern
+// (all of them if pattern is empty), keeping only dormant ones if dormantOnly
+func filterStopTargets(containers []container.Info, pattern string, dormantOnly bool) []container.Info {
+	var targets []container.Info
+	for _, c := range containers {
+		if dormantOnly && !c.IsDormant {
+			continue
+		}
+		if pattern != "" {
+			if ok, _ := path.Match(pattern, c.ShortName); !ok {
+				continue
+			}
+		}
+		targets = append(targets, c)
+	}
+	return targets
+}
+
+// confirmAndStop lists targets, asks question, and stops them if confirmed
+func confirmAndStop(targets []container.Info, what, question string) error {
+	// Display the containers
+	fmt.Printf("Found %d %s:\n", len(targets), what)
+	for _, c := range targets {
 		fmt.Printf("  - %s (branch: %s)\n", c.ShortName, c.Branch)
 	}
 
 	// Prompt for confirmation
-	fmt.Print("\nStop all dormant containers? (y/N): ")
+	fmt.Printf("\n%s (y/N): ", question)
 	reader := bufio.NewReader(os.Stdin)
 	response, err := reader.ReadString('\n')
 	if err != nil {
@@ -104,27 +176,32 @@ func stopDormantContainers() error {
 		return nil
 	}
 
-	// Stop all dormant containers
-	fmt.Println("\nStopping dormant containers...")
+	// Stop the containers
+	progressln("\nStopping containers...")
 	successCount := 0
-	for _, c := range dormantContainers {
-		fmt.Printf("  Stopping %s... ", c.ShortName)
-		stopCmd := exec.Command("docker", "stop", c.Name)
+	for _, c := range targets {
+		progressf("  Stopping %s... ", c.ShortName)
+		container.FireLifecycleEvent(container.EventPreStop, c.Name)
+		stopCmd := system.DockerCommand("stop", c.Name)
 		if err := stopCmd.Run(); err != nil {
-			fmt.Printf("FAILED: %v\n", err)
+			if quiet {
+				fmt.Printf("Failed to stop %s: %v\n", c.ShortName, err)
+			} else {
+				fmt.Printf("FAILED: %v\n", err)
+			}
 			continue
 		}
-		fmt.Println("✓")
+		progressln("✓")
 		successCount++
 	}
 
-	if successCount == len(dormantContainers) {
-		fmt.Printf("\n✅ Successfully stopped %d container(s)\n", successCount)
+	if successCount == len(targets) {
+		progressf("\n✅ Successfully stopped %d container(s)\n", successCount)
 	} else {
-		fmt.Printf("\n⚠️  Stopped %d/%d container(s)\n", successCount, len(dormantContainers))
+		fmt.Printf("\n⚠️  Stopped %d/%d container(s)\n", successCount, len(targets))
 	}
 
-	fmt.Println("\nTo remove stopped containers, run: maestro cleanup")
+	progressln("\nTo remove stopped containers, run: maestro cleanup")
 
 	return nil
-}
\ No newline at end of file
+}
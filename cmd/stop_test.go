@@ -0,0 +1,57 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/uprockcom/maestro/pkg/container"
+)
+
+func TestFilterStopTargets(t *testing.T) {
+	containers := []container.Info{
+		{ShortName: "feat-auth-1", IsDormant: true},
+		{ShortName: "feat-auth-2"},
+		{ShortName: "feat-authz-1", IsDormant: true},
+		{ShortName: "fix-auth-1", IsDormant: true},
+	}
+
+	tests := []struct {
+		name        string
+		pattern     string
+		dormantOnly bool
+		want        []string
+	}{
+		{"glob", "feat-auth-*", false, []string{"feat-auth-1", "feat-auth-2"}},
+		{"glob and dormant", "feat-auth-*", true, []string{"feat-auth-1"}},
+		{"wider glob", "feat-auth*", false, []string{"feat-auth-1", "feat-auth-2", "feat-authz-1"}},
+		{"single character", "f??-auth-1", false, []string{"fix-auth-1"}},
+		{"exact name", "feat-auth-2", false, []string{"feat-auth-2"}},
+		{"no pattern is every dormant container", "", true, []string{"feat-auth-1", "feat-authz-1", "fix-auth-1"}},
+		{"no matches", "docs-*", false, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, c := range filterStopTargets(containers, tt.pattern, tt.dormantOnly) {
+				got = append(got, c.ShortName)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("filterStopTargets(%q, %v) = %q, want %q", tt.pattern, tt.dormantOnly, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,238 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/uprockcom/maestro/pkg/system"
+)
+
+var syncCmd = &cobra.Command{
+	Use:   "sync <name>",
+	Short: "Copy changed project files into a running container",
+	Long: `Re-copy project files from the current directory into the container's
+/workspace. Only files whose contents differ (by SHA-256 checksum) or that are
+missing in the container are copied, so repeated syncs are cheap.
+
+The same rules as 'maestro new' apply: .maestroignore (or .gitignore as a
+fallback) is respected and node_modules and .git are skipped. Files are never
+deleted from the container.
+
+Examples:
+  maestro sync feat-auth-1`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSync,
+}
+
+func init() {
+	rootCmd.AddCommand(syncCmd)
+}
+
+func runSync(cmd *cobra.Command, args []string) error {
+	containerName := resolveContainerName(args[0])
+	if !isContainerRunning(containerName) {
+		return fmt.Errorf("container %s is not running", args[0])
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+
+	entries, ignoreSource, err := listProjectFiles(cwd)
+	if err != nil {
+		return err
+	}
+	if ignoreSource != "" {
+		fmt.Printf("Excluding paths from %s\n", ignoreSource)
+	}
+
+	// Only regular files carry content worth comparing
+	var files []string
+	for _, rel := range entries {
+		info, err := os.Lstat(filepath.Join(cwd, rel))
+		if err == nil && info.Mode().IsRegular() {
+			files = append(files, rel)
+		}
+	}
+
+	fmt.Printf("Comparing %d file(s) with %s...\n", len(files), containerName)
+	local, err := hashLocalFiles(cwd, files)
+	if err != nil {
+		return err
+	}
+	remote, err := hashContainerFiles(containerName, files)
+	if err != nil {
+		return err
+	}
+
+	changed := changedFiles(files, local, remote)
+	if len(changed) == 0 {
+		fmt.Println("✓ Container is already up to date")
+		return nil
+	}
+
+	if err := copyFilesToContainer(containerName, cwd, changed); err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Updated %d file(s) in %s\n", len(changed), containerName)
+	return nil
+}
+
+// hashLocalFiles returns the hex SHA-256 of each file, keyed by relative path
+func hashLocalFiles(root string, files []string) (map[string]string, error) {
+	sums := make(map[string]string, len(files))
+	for _, rel := range files {
+		f, err := os.Open(filepath.Join(root, rel))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", rel, err)
+		}
+		h := sha256.New()
+		_, err = io.Copy(h, f)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", rel, err)
+		}
+		sums[rel] = hex.EncodeToString(h.Sum(nil))
+	}
+	return sums, nil
+}
+
+// hashContainerFiles checksums the given files inside the container's /workspace.
+// Files missing in the container are absent from the result.
+func hashContainerFiles(containerName string, files []string) (map[string]string, error) {
+	// sha256sum exits non-zero for missing files; those are expected, so ignore the status
+	hashCmd := system.DockerCommand("exec", "-i", containerName, "sh", "-c",
+		"cd /workspace && xargs -0 -r sha256sum 2>/dev/null; true")
+	hashCmd.Stdin = strings.NewReader(strings.Join(files, "\x00"))
+	output, err := hashCmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to checksum container files: %w", err)
+	}
+
+	return parseSha256sums(string(output)), nil
+}
+
+// parseSha256sums parses sha256sum output into checksums keyed by path.
+// Lines are "<hash>  <path>" ("<hash> *<path>" in binary mode); a leading
+// backslash marks a path with escaped backslashes and newlines.
+func parseSha256sums(output string) map[string]string {
+	sums := make(map[string]string)
+	for _, line := range strings.Split(output, "\n") {
+		escaped := strings.HasPrefix(line, "\\")
+		if escaped {
+			line = line[1:]
+		}
+
+		hash, path, ok := strings.Cut(line, " ")
+		if !ok || len(hash) != sha256.Size*2 || path == "" || (path[0] != ' ' && path[0] != '*') {
+			continue
+		}
+		path = path[1:]
+		if escaped {
+			path = unescapeSha256sumPath(path)
+		}
+		sums[path] = hash
+	}
+	return sums
+}
+
+// unescapeSha256sumPath undoes sha256sum's escaping of backslashes, newlines,
+// and carriage returns in paths
+func unescapeSha256sumPath(path string) string {
+	var b strings.Builder
+	for i := 0; i < len(path); i++ {
+		if path[i] == '\\' && i+1 < len(path) {
+			switch path[i+1] {
+			case '\\':
+				b.WriteByte('\\')
+				i++
+				continue
+			case 'n':
+				b.WriteByte('\n')
+				i++
+				continue
+			case 'r':
+				b.WriteByte('\r')
+				i++
+				continue
+			}
+		}
+		b.WriteByte(path[i])
+	}
+	return b.String()
+}
+
+// changedFiles returns the files whose local checksum doesn't match the container's
+func changedFiles(files []string, local, remote map[string]string) []string {
+	var changed []string
+	for _, rel := range files {
+		if local[rel] != remote[rel] {
+			changed = append(changed, rel)
+		}
+	}
+	return changed
+}
+
+// copyFilesToContainer tars the given files from root into /workspace
+func copyFilesToContainer(containerName, root string, files []string) error {
+	list := strings.Join(files, "\x00")
+
+	tarCmd := exec.Command("tar", "-cf", "-", "--no-recursion", "--null", "-T", "-")
+	tarCmd.Dir = root
+	tarCmd.Stdin = strings.NewReader(list)
+
+	dockerCmd := system.DockerCommand("exec", "-i", containerName, "tar", "-xf", "-", "-C", "/workspace")
+	pipe, err := tarCmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	dockerCmd.Stdin = pipe
+	var stderr bytes.Buffer
+	dockerCmd.Stderr = &stderr
+
+	if err := dockerCmd.Start(); err != nil {
+		return fmt.Errorf("failed to start copy: %w", err)
+	}
+	if err := tarCmd.Run(); err != nil {
+		dockerCmd.Wait()
+		return fmt.Errorf("failed to archive files: %w", err)
+	}
+	if err := dockerCmd.Wait(); err != nil {
+		return fmt.Errorf("failed to copy files: %s", strings.TrimSpace(stderr.String()))
+	}
+
+	// Only chown what we touched; a recursive chown of /workspace can be slow
+	chownCmd := system.DockerCommand("exec", "-i", containerName, "sh", "-c",
+		"cd /workspace && xargs -0 -r sudo chown node:node")
+	chownCmd.Stdin = strings.NewReader(list)
+	if err := chownCmd.Run(); err != nil {
+		fmt.Printf("Warning: Failed to fix ownership: %v\n", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseSha256sums(t *testing.T) {
+	hashA := strings.Repeat("a", 64)
+	hashB := strings.Repeat("b", 64)
+
+	tests := []struct {
+		name   string
+		output string
+		want   map[string]string
+	}{
+		{"text mode", hashA + "  main.go\n", map[string]string{"main.go": hashA}},
+		{"binary mode", hashA + " *main.go\n", map[string]string{"main.go": hashA}},
+		{"several files", hashA + "  a.go\n" + hashB + "  dir/b.go\n", map[string]string{"a.go": hashA, "dir/b.go": hashB}},
+		{"spaces in path", hashA + "  my file  two.txt\n", map[string]string{"my file  two.txt": hashA}},
+		{"path starting with a star", hashA + "  *star\n", map[string]string{"*star": hashA}},
+		{"escaped newline", `\` + hashA + `  line\nbreak` + "\n", map[string]string{"line\nbreak": hashA}},
+		{"escaped backslash", `\` + hashA + `  back\\slash` + "\n", map[string]string{`back\slash`: hashA}},
+		{"short hash", "abc  main.go\n", map[string]string{}},
+		{"no separator", hashA + "main.go\n", map[string]string{}},
+		{"bad separator", hashA + " -main.go\n", map[string]string{}},
+		{"empty", "", map[string]string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseSha256sums(tt.output); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseSha256sums() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
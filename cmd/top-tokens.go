@@ -0,0 +1,154 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/uprockcom/maestro/pkg/container"
+	"github.com/uprockcom/maestro/pkg/paths"
+)
+
+var topTokensCmd = &cobra.Command{
+	Use:   "top-tokens",
+	Short: "Show token expiry for the host and every running container",
+	Long: `Scans the host and all running containers for credentials and prints
+a table of where each token expires, stalest first. Nothing is synced; use
+'maestro refresh-tokens' to propagate the freshest token.
+
+Status colors: red for expired tokens, yellow for tokens expiring within
+24 hours, green otherwise.`,
+	Args: cobra.NoArgs,
+	RunE: runTopTokens,
+}
+
+func init() {
+	rootCmd.AddCommand(topTokensCmd)
+}
+
+// tokenRow is one location in the top-tokens table
+type tokenRow struct {
+	location string
+	creds    *container.Credentials // nil if unreadable
+	err      string
+}
+
+const (
+	ansiRed    = "\033[31m"
+	ansiYellow = "\033[33m"
+	ansiGreen  = "\033[32m"
+	ansiReset  = "\033[0m"
+)
+
+func runTopTokens(cmd *cobra.Command, args []string) error {
+	var rows []tokenRow
+
+	hostCredPath := paths.FindCredentials(paths.AuthDir())
+	if hostCreds, err := container.ReadCredentials(hostCredPath); err == nil {
+		rows = append(rows, tokenRow{location: "host", creds: hostCreds})
+	} else {
+		rows = append(rows, tokenRow{location: "host", err: "could not read credentials"})
+	}
+
+	steps := newStepSpinner()
+	steps.Step("Scanning running containers")
+	// Includes the legacy prefix, per scan_legacy_prefix
+	containers, err := container.GetRunningContainersWithLegacy(currentConfig().Containers.Prefix)
+	if err != nil {
+		steps.Fail()
+		return fmt.Errorf("failed to list containers: %w", err)
+	}
+
+	scans := scanContainerCredentials(containers)
+	defer removeCredentialScans(scans)
+	steps.Done()
+
+	for i, scan := range scans {
+		row := tokenRow{location: containers[i].ShortName, creds: scan.creds}
+		switch {
+		case scan.copyErr != nil:
+			row.err = "could not read credentials"
+		case scan.err != nil:
+			row.err = "invalid credentials file"
+		}
+		rows = append(rows, row)
+	}
+
+	sortTokenRows(rows)
+
+	color := isTerminal(os.Stdout)
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "LOCATION\tEXPIRES\tSTATUS")
+	var freshest time.Time
+	for _, row := range rows {
+		if row.creds == nil {
+			fmt.Fprintf(w, "%s\t-\t%s\n", row.location, colorize(color, ansiRed, "✗ "+row.err))
+			continue
+		}
+
+		expiresAt := time.UnixMilli(row.creds.ClaudeAiOauth.ExpiresAt)
+		if expiresAt.After(freshest) {
+			freshest = expiresAt
+		}
+
+		statusColor := ansiGreen
+		switch remaining := container.TimeUntilExpiration(row.creds); {
+		case remaining < 0:
+			statusColor = ansiRed
+		case remaining < 24*time.Hour:
+			statusColor = ansiYellow
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", row.location, expiresAt.Local().Format("2006-01-02 15:04"),
+			colorize(color, statusColor, container.FormatExpiration(row.creds)))
+	}
+	w.Flush()
+
+	stale := 0
+	for _, row := range rows {
+		if row.creds != nil && time.UnixMilli(row.creds.ClaudeAiOauth.ExpiresAt).Before(freshest) {
+			stale++
+		}
+	}
+	if stale > 0 {
+		fmt.Printf("\n%d location(s) have an older token than the freshest one.\n", stale)
+		fmt.Println("Run 'maestro refresh-tokens' to sync it everywhere.")
+	}
+
+	return nil
+}
+
+// sortTokenRows orders rows by expiry, stalest first, with unreadable locations last
+func sortTokenRows(rows []tokenRow) {
+	sort.SliceStable(rows, func(i, j int) bool {
+		a, b := rows[i].creds, rows[j].creds
+		if a == nil || b == nil {
+			return b == nil && a != nil
+		}
+		return a.ClaudeAiOauth.ExpiresAt < b.ClaudeAiOauth.ExpiresAt
+	})
+}
+
+// colorize wraps s in an ANSI color when enabled
+func colorize(enabled bool, color, s string) string {
+	if !enabled {
+		return s
+	}
+	return color + s + ansiReset
+}
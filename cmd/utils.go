@@ -15,12 +15,18 @@
 package cmd
 
 import (
+	"bufio"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
+
+	"github.com/uprockcom/maestro/pkg/container"
+	"github.com/uprockcom/maestro/pkg/daemon"
+	"github.com/uprockcom/maestro/pkg/system"
 )
 
 // parseInt parses a string to int64
@@ -29,45 +35,44 @@ func parseInt(s string) int64 {
 	return i
 }
 
-// showDaemonNag shows a reminder to start the daemon if it's not running
-func showDaemonNag() {
-	if !config.Daemon.ShowNag {
-		return
+// progressf prints progress or decorative output, unless --quiet is set.
+// Errors, warnings, prompts, and --json data should use fmt directly.
+func progressf(format string, a ...any) {
+	if !quiet {
+		fmt.Printf(format, a...)
 	}
+}
 
-	authDir := expandPath(config.Claude.AuthPath)
-	pidFile := filepath.Join(authDir, "daemon.pid")
+// progressln is the fmt.Println counterpart of progressf
+func progressln(a ...any) {
+	if !quiet {
+		fmt.Println(a...)
+	}
+}
 
-	// Check if daemon is running
-	data, err := os.ReadFile(pidFile)
-	if err != nil {
-		// PID file doesn't exist - daemon not running
-		fmt.Println("\n💡 Tip: Start the daemon for automatic token refresh and notifications:")
-		fmt.Println("   maestro daemon start")
-		fmt.Println("   (Disable this message: add 'show_nag: false' to daemon config)")
-		return
+// reportTiming prints how long a phase took to stderr when --timing is set.
+// It goes to stderr so it can be captured without disturbing the output.
+func reportTiming(phase string, d time.Duration) {
+	if timing {
+		fmt.Fprintf(os.Stderr, "⏱  %-28s %v\n", phase, d.Round(time.Millisecond))
 	}
+}
 
-	// Check if process is actually alive
-	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
-	if err != nil {
-		// Invalid PID file - show nag
-		fmt.Println("\n💡 Tip: Start the daemon for automatic token refresh and notifications:")
-		fmt.Println("   maestro daemon start")
-		fmt.Println("   (Disable this message: add 'show_nag: false' to daemon config)")
+// showDaemonNag shows a reminder to start the daemon if it's not running,
+// unless daemon.show_nag is off
+func showDaemonNag() {
+	if !currentConfig().Daemon.ShowNag {
 		return
 	}
 
-	// On Unix, check if process exists by sending signal 0
-	// This is more reliable than FindProcess which always succeeds on some platforms
-	cmd := exec.Command("ps", "-p", strconv.Itoa(pid))
-	if err := cmd.Run(); err != nil {
-		// Process doesn't exist
-		fmt.Println("\n💡 Tip: Start the daemon for automatic token refresh and notifications:")
-		fmt.Println("   maestro daemon start")
-		fmt.Println("   (Disable this message: add 'show_nag: false' to daemon config)")
+	authDir := expandPath(currentConfig().Claude.AuthPath)
+	if _, running := daemon.IsRunning(filepath.Join(authDir, daemon.PIDFile)); running {
+		return
 	}
-	// If ps succeeds, daemon is running - don't show nag
+
+	fmt.Println("\n💡 Tip: Start the daemon for automatic token refresh and notifications:")
+	fmt.Println("   maestro daemon start")
+	fmt.Println("   (Don't show this again: maestro daemon nag off)")
 }
 
 // generateTmuxConfig creates a tmux configuration string with true color support
@@ -85,61 +90,178 @@ set -g status-right '#{?window_bell_flag,🔔 ,} %%%%H:%%%%M'`, containerName, b
 }
 
 
-// resolveContainerName resolves a short name or full name to the actual container name
+// legacyPrefix is the container prefix maestro used before it was configurable
+const legacyPrefix = container.LegacyPrefix
+
+// scanLegacyPrefix reports whether containers under legacyPrefix are looked
+// up too: containers.scan_legacy_prefix is on and the configured prefix differs
+func scanLegacyPrefix() bool {
+	return currentConfig().Containers.ScanLegacyPrefix && currentConfig().Containers.Prefix != legacyPrefix
+}
+
+// resolveContainerName resolves a short name or full name to the actual container name.
+// The short name is tried with the configured prefix, the legacy "mcl-"
+// prefix (see scanLegacyPrefix), and against the short-name label, so
+// containers created before a prefix change still resolve.
 func resolveContainerName(shortName string) string {
 	// If already has configured prefix, return as-is
-	if strings.HasPrefix(shortName, config.Containers.Prefix) {
+	if strings.HasPrefix(shortName, currentConfig().Containers.Prefix) {
 		return shortName
 	}
 
 	// If already has legacy prefix, return as-is (for backward compatibility)
-	if strings.HasPrefix(shortName, "mcl-") {
+	if scanLegacyPrefix() && strings.HasPrefix(shortName, legacyPrefix) {
 		return shortName
 	}
 
 	// Try to find exact match with configured prefix
-	fullName := config.Containers.Prefix + shortName
-
-	// Check if this exact name exists
-	checkCmd := exec.Command("docker", "ps", "-a", "--filter", fmt.Sprintf("name=^%s$", fullName), "--format", "{{.Names}}")
-	output, err := checkCmd.Output()
-	if err == nil && len(output) > 0 {
-		return strings.TrimSpace(string(output))
+	fullName := currentConfig().Containers.Prefix + shortName
+	if name := findContainer("name=^" + fullName + "$"); name != "" {
+		return name
 	}
 
 	// Try pattern match (for cases where user omits the number)
-	checkCmd = exec.Command("docker", "ps", "-a", "--filter", fmt.Sprintf("name=%s", fullName), "--format", "{{.Names}}")
-	output, err = checkCmd.Output()
-	if err == nil && len(output) > 0 {
-		names := strings.Split(string(output), "\n")
-		if len(names) > 0 && names[0] != "" {
-			// Return the most recent (highest numbered) match
-			return strings.TrimSpace(names[0])
-		}
+	if name := findNewestContainer("name=" + fullName); name != "" {
+		return name
 	}
 
 	// Try legacy "mcl-" prefix as fallback (for backward compatibility)
-	if config.Containers.Prefix != "mcl-" {
-		legacyFullName := "mcl-" + shortName
-
-		// Check exact match with legacy prefix
-		checkCmd = exec.Command("docker", "ps", "-a", "--filter", fmt.Sprintf("name=^%s$", legacyFullName), "--format", "{{.Names}}")
-		output, err = checkCmd.Output()
-		if err == nil && len(output) > 0 {
-			return strings.TrimSpace(string(output))
+	if scanLegacyPrefix() {
+		legacyFullName := legacyPrefix + shortName
+		if name := findContainer("name=^" + legacyFullName + "$"); name != "" {
+			return name
 		}
-
-		// Try pattern match with legacy prefix
-		checkCmd = exec.Command("docker", "ps", "-a", "--filter", fmt.Sprintf("name=%s", legacyFullName), "--format", "{{.Names}}")
-		output, err = checkCmd.Output()
-		if err == nil && len(output) > 0 {
-			names := strings.Split(string(output), "\n")
-			if len(names) > 0 && names[0] != "" {
-				return strings.TrimSpace(names[0])
-			}
+		if name := findNewestContainer("name=" + legacyFullName); name != "" {
+			return name
 		}
 	}
 
+	// Containers created under an earlier custom prefix carry their short name as a label
+	if name := findContainer(fmt.Sprintf("label=%s=%s", container.ShortNameLabel, shortName)); name != "" {
+		return name
+	}
+
 	// Return the fullName as last resort
 	return fullName
 }
+
+// resolveContainerNameFuzzy is resolveContainerName for connect: when nothing
+// resolves, the name is matched as a substring of every container's short
+// name, so "auth" finds "feat-auth-login-1". Commands that change a
+// container's state don't use it, so a fragment can't stop the wrong one.
+func resolveContainerNameFuzzy(shortName string) string {
+	name := resolveContainerName(shortName)
+	if findContainer("name=^"+name+"$") != "" {
+		return name
+	}
+	if match, ok := fuzzyMatchContainer(shortName); ok {
+		return match
+	}
+	return name
+}
+
+// findContainer returns the name of the only container matching a docker ps
+// filter, or "" if none or several match
+func findContainer(filter string) string {
+	output, err := system.DockerCommand("ps", "-a", "--filter", filter, "--format", "{{.Names}}").Output()
+	if err != nil {
+		return ""
+	}
+	names := strings.Fields(string(output))
+	if len(names) != 1 {
+		return ""
+	}
+	return names[0]
+}
+
+// findNewestContainer returns the most recently created container matching a
+// docker ps filter (the highest numbered, for a name without its number), or ""
+func findNewestContainer(filter string) string {
+	output, err := system.DockerCommand("ps", "-a", "--filter", filter, "--format", "{{.Names}}").Output()
+	if err != nil {
+		return ""
+	}
+	names := strings.Fields(string(output))
+	if len(names) == 0 {
+		return ""
+	}
+	return names[0]
+}
+
+// fuzzyMatchContainer finds the container whose short name contains fragment
+// (case-insensitively). With several matches, a terminal user picks one; in
+// scripts the matches are listed and no container is chosen.
+func fuzzyMatchContainer(fragment string) (string, bool) {
+	output, err := system.DockerCommand("ps", "-a", "--format", "{{.Names}}").Output()
+	if err != nil {
+		return "", false
+	}
+
+	prefixes := []string{currentConfig().Containers.Prefix}
+	if scanLegacyPrefix() {
+		prefixes = append(prefixes, legacyPrefix)
+	}
+
+	matches := matchContainerNames(strings.Split(string(output), "\n"), prefixes, fragment)
+	switch {
+	case len(matches) == 0:
+		return "", false
+	case len(matches) == 1:
+		progressf("Using %s (matched %q)\n", container.GetShortName(matches[0], currentConfig().Containers.Prefix), fragment)
+		return matches[0], true
+	}
+
+	if !isTerminal(os.Stdin) {
+		fmt.Fprintf(os.Stderr, "%q matches %d containers:\n", fragment, len(matches))
+		for _, name := range matches {
+			fmt.Fprintf(os.Stderr, "  %s\n", container.GetShortName(name, currentConfig().Containers.Prefix))
+		}
+		return "", false
+	}
+
+	fmt.Printf("%q matches %d containers:\n", fragment, len(matches))
+	for i, name := range matches {
+		fmt.Printf("  %d) %s\n", i+1, container.GetShortName(name, currentConfig().Containers.Prefix))
+	}
+	fmt.Printf("Enter number (1-%d): ", len(matches))
+	reader := bufio.NewReader(os.Stdin)
+	input, _ := reader.ReadString('\n')
+	choice, err := strconv.Atoi(strings.TrimSpace(input))
+	if err != nil || choice < 1 || choice > len(matches) {
+		return "", false
+	}
+	return matches[choice-1], true
+}
+
+// matchContainerNames returns, sorted, the names with one of prefixes whose
+// short name contains fragment, ignoring case
+func matchContainerNames(names, prefixes []string, fragment string) []string {
+	fragment = strings.ToLower(fragment)
+	var matches []string
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(name, prefix) &&
+				strings.Contains(strings.ToLower(strings.TrimPrefix(name, prefix)), fragment) {
+				matches = append(matches, name)
+				break
+			}
+		}
+	}
+	sort.Strings(matches)
+	return matches
+}
+
+// shellCommand joins args into a command line that can be pasted into a POSIX
+// shell, single-quoting any argument that isn't made of safe characters
+func shellCommand(args ...string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		if arg != "" && strings.Trim(arg, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_./:=@%+,") == "" {
+			quoted[i] = arg
+			continue
+		}
+		quoted[i] = "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+	}
+	return strings.Join(quoted, " ")
+}
@@ -0,0 +1,92 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestShellCommand(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"docker", "exec", "-it", "maestro-feat-1", "tmux", "attach", "-t", "main"},
+			"docker exec -it maestro-feat-1 tmux attach -t main"},
+		{[]string{"docker", "exec", "c", "sh", "-c", "cd /workspace && zsh"},
+			"docker exec c sh -c 'cd /workspace && zsh'"},
+		{[]string{"echo", "it's"}, `echo 'it'\''s'`},
+		{[]string{"echo", ""}, "echo ''"},
+		{[]string{"echo", "$HOME"}, "echo '$HOME'"},
+	}
+	for _, tt := range tests {
+		if got := shellCommand(tt.args...); got != tt.want {
+			t.Errorf("shellCommand(%q) = %s, want %s", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestMatchContainerNames(t *testing.T) {
+	names := []string{
+		"maestro-feat-auth-login-1",
+		"maestro-fix-oauth-2",
+		"maestro-docs-readme-1",
+		"mcl-feat-auth-old-1",
+		"postgres",
+		"",
+	}
+	prefixes := []string{"maestro-", "mcl-"}
+
+	tests := []struct {
+		fragment string
+		want     []string
+	}{
+		{"login", []string{"maestro-feat-auth-login-1"}},
+		{"AUTH", []string{"maestro-feat-auth-login-1", "maestro-fix-oauth-2", "mcl-feat-auth-old-1"}},
+		{"readme", []string{"maestro-docs-readme-1"}},
+		{"postgres", nil}, // Not a maestro container
+		{"maestro", nil},  // Prefixes aren't part of the short name
+		{"nothing", nil},
+	}
+	for _, tt := range tests {
+		if got := matchContainerNames(names, prefixes, tt.fragment); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("matchContainerNames(%q) = %v, want %v", tt.fragment, got, tt.want)
+		}
+	}
+}
+
+func TestScanLegacyPrefix(t *testing.T) {
+	orig := config
+	t.Cleanup(func() { config = orig })
+	config = &Config{}
+
+	tests := []struct {
+		prefix string
+		scan   bool
+		want   bool
+	}{
+		{"maestro-", true, true},
+		{"maestro-", false, false},
+		{legacyPrefix, true, false}, // Already covered by the configured prefix
+	}
+	for _, tt := range tests {
+		config.Containers.Prefix = tt.prefix
+		config.Containers.ScanLegacyPrefix = tt.scan
+		if got := scanLegacyPrefix(); got != tt.want {
+			t.Errorf("scanLegacyPrefix() with prefix %q, scan %v = %v, want %v", tt.prefix, tt.scan, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/uprockcom/maestro/pkg/container"
+	"github.com/uprockcom/maestro/pkg/system"
+)
+
+var (
+	waitUntil    string
+	waitTimeout  time.Duration
+	waitInterval time.Duration
+)
+
+var waitCmd = &cobra.Command{
+	Use:   "wait <name>",
+	Short: "Block until a container reaches a state",
+	Long: `Wait until a container's Claude session reaches a condition, then exit 0.
+Exits non-zero if --timeout elapses or the container stops first.
+
+Conditions (--until):
+  idle       Claude needs attention or has exited (default)
+  attention  Claude rang the bell or has been silent (waiting for input)
+  exit       The Claude process is no longer running
+
+Examples:
+  maestro wait feat-auth-1
+  maestro wait feat-auth-1 --until exit --timeout 2h
+  maestro new -n "fix flaky test" && maestro wait fix-flaky-test-1 && maestro exec fix-flaky-test-1 -- git diff`,
+	Args: cobra.ExactArgs(1),
+	RunE: runWait,
+}
+
+func init() {
+	rootCmd.AddCommand(waitCmd)
+	waitCmd.Flags().StringVar(&waitUntil, "until", "idle", "Condition to wait for: idle, attention, or exit")
+	waitCmd.Flags().DurationVar(&waitTimeout, "timeout", 0, "Give up after this long (0 waits forever)")
+	waitCmd.Flags().DurationVar(&waitInterval, "interval", 5*time.Second, "How often to check the container")
+}
+
+func runWait(cmd *cobra.Command, args []string) error {
+	switch waitUntil {
+	case "idle", "attention", "exit":
+	default:
+		return fmt.Errorf("invalid --until %q (expected idle, attention, or exit)", waitUntil)
+	}
+	if waitInterval <= 0 {
+		return fmt.Errorf("--interval must be positive")
+	}
+
+	containerName := resolveContainerName(args[0])
+
+	var deadline time.Time
+	if waitTimeout > 0 {
+		deadline = time.Now().Add(waitTimeout)
+	}
+
+	// Right after 'maestro new' Claude may not have started yet, which would
+	// otherwise read as already exited
+	awaitClaudeStart(containerName, waitUntil)
+
+	for {
+		// Stop waiting if the container itself is gone or stopped
+		stateCmd := system.DockerCommand("inspect", "-f", "{{.State.Status}}", containerName)
+		output, err := stateCmd.Output()
+		if err != nil {
+			return fmt.Errorf("container %s not found", args[0])
+		}
+		if state := strings.TrimSpace(string(output)); state != "running" {
+			return fmt.Errorf("container %s is not running (status: %s)", args[0], state)
+		}
+
+		if waitConditionMet(containerName, waitUntil) {
+			return nil
+		}
+
+		if !deadline.IsZero() && time.Now().After(deadline) {
+			return fmt.Errorf("timed out after %s waiting for %s to be %s", waitTimeout, args[0], waitUntil)
+		}
+
+		time.Sleep(waitInterval)
+	}
+}
+
+// bellProbe reports whether Claude in a container rang the bell (replaced in tests)
+var bellProbe = container.CheckBellStatus
+
+// awaitClaudeStart gives Claude up to claudeStartGrace to start when the
+// condition counts a stopped Claude as met. If it never starts, waiting
+// proceeds and sees it as exited.
+func awaitClaudeStart(containerName, until string) {
+	if until == "attention" {
+		return
+	}
+	claudeStarted(containerName, claudeStartGrace)
+}
+
+// waitConditionMet reports whether the container currently satisfies the condition
+func waitConditionMet(containerName, until string) bool {
+	switch until {
+	case "attention":
+		return bellProbe(containerName)
+	case "exit":
+		return !claudeProbe(containerName)
+	default: // idle
+		return !claudeProbe(containerName) || bellProbe(containerName)
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWaitConditionAfterStartupGrace(t *testing.T) {
+	origBell, origGrace := bellProbe, claudeStartGrace
+	t.Cleanup(func() { bellProbe, claudeStartGrace = origBell, origGrace })
+	bellProbe = func(string) bool { return false }
+
+	tests := []struct {
+		name        string
+		until       string
+		runningFrom int
+		want        bool
+	}{
+		// Claude still launching must not read as exited
+		{"idle while Claude starts", "idle", 3, false},
+		{"exit while Claude starts", "exit", 3, false},
+		{"idle once running", "idle", 1, false},
+		// Past the grace period a Claude that never started counts as exited
+		{"idle when Claude never starts", "idle", 0, true},
+		{"exit when Claude never starts", "exit", 0, true},
+		{"attention skips the grace", "attention", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checks := stubClaudeProbe(t, tt.runningFrom)
+			claudeStartGrace = 20 * time.Millisecond
+
+			awaitClaudeStart("maestro-test-1", tt.until)
+			if got := waitConditionMet("maestro-test-1", tt.until); got != tt.want {
+				t.Errorf("waitConditionMet(%q) = %v after %d checks, want %v", tt.until, got, *checks, tt.want)
+			}
+			if tt.until == "attention" && *checks != 0 {
+				t.Errorf("--until attention checked for Claude %d times, want 0", *checks)
+			}
+		})
+	}
+}
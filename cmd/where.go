@@ -0,0 +1,101 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/uprockcom/maestro/pkg/container"
+)
+
+var whereVolume string
+
+var whereCmd = &cobra.Command{
+	Use:   "where <name>",
+	Short: "Print the host paths behind a container's mounts and volumes",
+	Long: `Print where a container's files live on the host: the source of each mount,
+and the docker data directory of its npm, uv, and history volumes.
+
+Use --volume to print just one volume's path, e.g. to cd into it. With Docker
+Desktop, volumes live inside its VM and their paths aren't reachable from the
+host directly.
+
+Examples:
+  maestro where feat-auth-1
+  maestro where feat-auth-1 --volume history
+  cd "$(maestro where feat-auth-1 --volume npm)"`,
+	Args: cobra.ExactArgs(1),
+	RunE: runWhere,
+}
+
+func init() {
+	rootCmd.AddCommand(whereCmd)
+	whereCmd.Flags().StringVar(&whereVolume, "volume", "", "Print only this volume's path: "+strings.Join(container.VolumeKinds, ", "))
+}
+
+func runWhere(cmd *cobra.Command, args []string) error {
+	containerName := resolveContainerName(args[0])
+
+	if whereVolume != "" {
+		if !slices.Contains(container.VolumeKinds, whereVolume) {
+			return fmt.Errorf("unknown volume %q (expected one of: %s)", whereVolume, strings.Join(container.VolumeKinds, ", "))
+		}
+		mountpoint, err := container.VolumeMountpoint(containerName + "-" + whereVolume)
+		if err != nil {
+			return err
+		}
+		fmt.Println(mountpoint)
+		return nil
+	}
+
+	details, err := container.GetContainerDetails(containerName, currentConfig().Containers.Prefix)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Mounts (host -> container):")
+	if len(details.Volumes) == 0 {
+		fmt.Println("  (none)")
+	}
+	for _, mount := range details.Volumes {
+		fmt.Println("  " + mount)
+	}
+
+	fmt.Println("\nVolumes:")
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	inVM := false
+	for i, volume := range container.ContainerVolumes(containerName) {
+		mountpoint, err := container.VolumeMountpoint(volume)
+		if err != nil {
+			mountpoint = "(not found)"
+		} else if _, statErr := os.Stat(mountpoint); os.IsNotExist(statErr) {
+			inVM = true
+		}
+		fmt.Fprintf(w, "  %s\t%s\t%s\n", container.VolumeKinds[i], volume, mountpoint)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if inVM {
+		fmt.Println("\nSome volume paths don't exist on this host; Docker Desktop keeps volumes inside its VM.")
+		fmt.Println("Their contents are visible inside the container at the destinations listed under Mounts.")
+	}
+	return nil
+}
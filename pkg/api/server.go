@@ -0,0 +1,324 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package api implements maestro's local control API: newline-delimited JSON
+// requests and responses over a Unix socket. See docs/API.md for the protocol.
+package api
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/uprockcom/maestro/pkg/container"
+	"github.com/uprockcom/maestro/pkg/system"
+)
+
+// Request is a single API call
+type Request struct {
+	ID     any             `json:"id,omitempty"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params,omitempty"`
+}
+
+// Response answers a Request. Exactly one of Result or Error is set.
+type Response struct {
+	ID     any    `json:"id,omitempty"`
+	Result any    `json:"result,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// ContainerParams identifies the target container for per-container methods
+type ContainerParams struct {
+	Name string `json:"name"`
+}
+
+// AddDomainParams are the parameters for add_domain
+type AddDomainParams struct {
+	Domain string `json:"domain"`
+	Name   string `json:"name,omitempty"` // Empty applies to all running containers
+}
+
+// ContainerSummary is a list entry returned by the list method
+type ContainerSummary struct {
+	Name           string    `json:"name"`
+	ShortName      string    `json:"short_name"`
+	Status         string    `json:"status"`
+	Branch         string    `json:"branch"`
+	NeedsAttention bool      `json:"needs_attention"`
+	IsDormant      bool      `json:"is_dormant"`
+	AuthStatus     string    `json:"auth_status,omitempty"`
+	LastActivity   string    `json:"last_activity,omitempty"`
+	GitStatus      string    `json:"git_status,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// ConnectInfo describes how to attach to a container's tmux session
+type ConnectInfo struct {
+	Name    string   `json:"name"`
+	Session string   `json:"session,omitempty"` // Empty when a custom connect command is used or tmux is missing
+	Command []string `json:"command"`
+}
+
+// Server serves the control API on a Unix socket
+type Server struct {
+	socketPath string
+	prefix     string
+
+	mu       sync.Mutex // Guards listener, set by ListenAndServe and read by Close
+	listener net.Listener
+}
+
+// NewServer creates a server for containers with the given prefix
+func NewServer(socketPath, prefix string) *Server {
+	return &Server{socketPath: socketPath, prefix: prefix}
+}
+
+// ListenAndServe listens on the socket and handles connections until Close is called
+func (s *Server) ListenAndServe() error {
+	// Remove a stale socket left behind by a previous run
+	if _, err := os.Stat(s.socketPath); err == nil {
+		if conn, err := net.Dial("unix", s.socketPath); err == nil {
+			conn.Close()
+			return fmt.Errorf("socket %s is already in use", s.socketPath)
+		}
+		os.Remove(s.socketPath)
+	}
+
+	listener, err := net.Listen("unix", s.socketPath)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.socketPath, err)
+	}
+	s.mu.Lock()
+	s.listener = listener
+	s.mu.Unlock()
+
+	// Only the current user may talk to the socket
+	if err := os.Chmod(s.socketPath, 0600); err != nil {
+		listener.Close()
+		return fmt.Errorf("failed to secure socket: %w", err)
+	}
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		go s.handleConn(conn)
+	}
+}
+
+// Close stops the server and removes the socket
+func (s *Server) Close() error {
+	s.mu.Lock()
+	listener := s.listener
+	s.mu.Unlock()
+	if listener == nil {
+		return nil
+	}
+	err := listener.Close()
+	os.Remove(s.socketPath)
+	return err
+}
+
+func (s *Server) handleConn(conn net.Conn) {
+	defer conn.Close()
+
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	encoder := json.NewEncoder(conn)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		var req Request
+		var resp Response
+		if err := json.Unmarshal([]byte(line), &req); err != nil {
+			resp.Error = fmt.Sprintf("invalid request: %v", err)
+		} else {
+			resp = s.Handle(req)
+		}
+
+		if err := encoder.Encode(resp); err != nil {
+			log.Printf("[ERROR] api: failed to write response: %v\n", err)
+			return
+		}
+	}
+}
+
+// Handle dispatches a single request
+func (s *Server) Handle(req Request) Response {
+	resp := Response{ID: req.ID}
+
+	result, err := s.dispatch(req)
+	if err != nil {
+		resp.Error = err.Error()
+		return resp
+	}
+	resp.Result = result
+	return resp
+}
+
+func (s *Server) dispatch(req Request) (any, error) {
+	switch req.Method {
+	case "list":
+		return s.list()
+
+	case "connect_info":
+		name, err := s.containerParam(req.Params)
+		if err != nil {
+			return nil, err
+		}
+		info := ConnectInfo{Name: name, Session: "main"}
+		customCommand := container.GetConnectCommand(name)
+		if customCommand != "" {
+			info.Session = ""
+		}
+		args := container.ConnectArgs(name, customCommand)
+		if customCommand == "" && !container.HasTmux(name) {
+			info.Session = ""
+			args = container.ShellArgs(name)
+		}
+		info.Command = append([]string{system.DockerBinary()}, args...)
+		return info, nil
+
+	case "stop":
+		name, err := s.containerParam(req.Params)
+		if err != nil {
+			return nil, err
+		}
+		if err := container.StopContainer(name); err != nil {
+			return nil, err
+		}
+		return map[string]string{"name": name, "status": "stopped"}, nil
+
+	case "restart":
+		name, err := s.containerParam(req.Params)
+		if err != nil {
+			return nil, err
+		}
+		if err := container.RestartContainer(name); err != nil {
+			return nil, err
+		}
+		return map[string]string{"name": name, "status": "restarted"}, nil
+
+	case "refresh_tokens":
+		name, err := s.containerParam(req.Params)
+		if err != nil {
+			return nil, err
+		}
+		if err := container.RefreshTokens(name, s.prefix); err != nil {
+			return nil, err
+		}
+		return map[string]string{"name": name, "status": "refreshed"}, nil
+
+	case "add_domain":
+		var p AddDomainParams
+		if err := decodeParams(req.Params, &p); err != nil {
+			return nil, err
+		}
+		if p.Domain == "" {
+			return nil, fmt.Errorf("missing required param: domain")
+		}
+		if p.Name == "" {
+			failed, err := container.AddDomainToAllContainers(p.Domain)
+			if err != nil {
+				return nil, err
+			}
+			if len(failed) > 0 {
+				return nil, fmt.Errorf("failed to add domain to %s", strings.Join(failed, ", "))
+			}
+			return map[string]string{"domain": p.Domain, "scope": "all"}, nil
+		}
+		name := s.resolveName(p.Name)
+		if err := container.AddDomainToContainer(name, p.Domain); err != nil {
+			return nil, err
+		}
+		return map[string]string{"domain": p.Domain, "scope": name}, nil
+
+	case "":
+		return nil, fmt.Errorf("missing method")
+
+	default:
+		return nil, fmt.Errorf("unknown method: %s", req.Method)
+	}
+}
+
+func (s *Server) list() ([]ContainerSummary, error) {
+	containers, err := container.GetAllContainers(s.prefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list containers: %w", err)
+	}
+
+	summaries := make([]ContainerSummary, 0, len(containers))
+	for _, c := range container.SortByPriority(containers) {
+		summaries = append(summaries, ContainerSummary{
+			Name:           c.Name,
+			ShortName:      c.ShortName,
+			Status:         c.Status,
+			Branch:         c.Branch,
+			NeedsAttention: c.NeedsAttention,
+			IsDormant:      c.IsDormant,
+			AuthStatus:     c.AuthStatus,
+			LastActivity:   c.LastActivity,
+			GitStatus:      strings.TrimSpace(c.GitStatus),
+			CreatedAt:      c.CreatedAt,
+		})
+	}
+	return summaries, nil
+}
+
+// containerParam decodes ContainerParams and returns the resolved container name
+func (s *Server) containerParam(raw json.RawMessage) (string, error) {
+	var p ContainerParams
+	if err := decodeParams(raw, &p); err != nil {
+		return "", err
+	}
+	if p.Name == "" {
+		return "", fmt.Errorf("missing required param: name")
+	}
+	return s.resolveName(p.Name), nil
+}
+
+// resolveName accepts either a full container name or a short name
+func (s *Server) resolveName(name string) string {
+	if strings.HasPrefix(name, s.prefix) ||
+		(container.ScansLegacyPrefix(s.prefix) && strings.HasPrefix(name, container.LegacyPrefix)) {
+		return name
+	}
+	return s.prefix + name
+}
+
+func decodeParams(raw json.RawMessage, v any) error {
+	if len(raw) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		return fmt.Errorf("invalid params: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestServerShutdown(t *testing.T) {
+	// Unix socket paths are limited to about 100 bytes, so keep this one short
+	dir, err := os.MkdirTemp("", "maestro-api-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	socketPath := filepath.Join(dir, "api.sock")
+
+	server := NewServer(socketPath, "maestro-")
+	served := make(chan error, 1)
+	go func() { served <- server.ListenAndServe() }()
+
+	var conn net.Conn
+	for deadline := time.Now().Add(5 * time.Second); ; {
+		if conn, err = net.Dial("unix", socketPath); err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("server never started listening: %v", err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	defer conn.Close()
+
+	// A request that doesn't touch docker shows the server is serving
+	if _, err := conn.Write([]byte(`{"id":1,"method":"nope"}` + "\n")); err != nil {
+		t.Fatal(err)
+	}
+	var resp Response
+	if err := json.NewDecoder(bufio.NewReader(conn)).Decode(&resp); err != nil {
+		t.Fatalf("failed to read response: %v", err)
+	}
+	if resp.Error != "unknown method: nope" {
+		t.Errorf("response error = %q, want the unknown method error", resp.Error)
+	}
+
+	if err := server.Close(); err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+	select {
+	case err := <-served:
+		if err != nil {
+			t.Errorf("ListenAndServe() = %v after Close, want nil", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("ListenAndServe didn't return after Close")
+	}
+	if _, err := os.Stat(socketPath); !os.IsNotExist(err) {
+		t.Errorf("socket still present after Close: %v", err)
+	}
+}
@@ -0,0 +1,209 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package configfile edits the maestro YAML config file in place. Every
+// writer goes through Update, which only touches the keys it is asked to, so
+// comments, key order, and settings owned by other commands survive.
+package configfile
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Document is a parsed config file. Keys are dotted paths such as
+// "firewall.allowed_domains" and match case-insensitively, like viper.
+type Document struct {
+	root *yaml.Node // Top-level mapping
+}
+
+// Update reads the config file at path, applies mutate, and writes the result
+// back. A missing file is treated as empty and created. Nothing is written if
+// mutate returns an error.
+func Update(path string, mutate func(doc *Document) error) error {
+	var file yaml.Node
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	if file.Kind == 0 {
+		file = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+	if len(file.Content) == 0 || file.Content[0].Kind != yaml.MappingNode {
+		return fmt.Errorf("%s is not a YAML mapping", path)
+	}
+
+	if err := mutate(&Document{root: file.Content[0]}); err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&file); err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+	enc.Close()
+
+	return writeFileAtomic(path, buf.Bytes())
+}
+
+// writeFileAtomic replaces path with data via a temp file and rename, so a
+// crash mid-write never leaves a truncated config. Existing permissions are
+// kept, and a symlinked config (e.g. into a dotfiles repo) stays a symlink:
+// its target is what gets replaced.
+func writeFileAtomic(path string, data []byte) error {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	}
+
+	mode := os.FileMode(0644)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, ".config-*.yml")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), mode); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Get decodes the value at key into out. It reports false if the key is absent.
+func (d *Document) Get(key string, out any) (bool, error) {
+	node := d.lookup(key)
+	if node == nil {
+		return false, nil
+	}
+	if err := node.Decode(out); err != nil {
+		return true, fmt.Errorf("invalid value for %s: %w", key, err)
+	}
+	return true, nil
+}
+
+// Set stores value at key, creating intermediate mappings as needed. Comments
+// attached to an existing value are kept.
+func (d *Document) Set(key string, value any) error {
+	var node yaml.Node
+	if err := node.Encode(value); err != nil {
+		return fmt.Errorf("failed to encode %s: %w", key, err)
+	}
+
+	parts := strings.Split(key, ".")
+	parent := d.root
+	for _, part := range parts[:len(parts)-1] {
+		child := mappingValue(parent, part)
+		if child == nil || child.Kind != yaml.MappingNode {
+			next := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			setMappingValue(parent, part, next)
+			child = next
+		}
+		parent = child
+	}
+
+	last := parts[len(parts)-1]
+	if old := mappingValue(parent, last); old != nil {
+		node.HeadComment = old.HeadComment
+		node.LineComment = old.LineComment
+		node.FootComment = old.FootComment
+	}
+	setMappingValue(parent, last, &node)
+	return nil
+}
+
+// Delete removes key if present
+func (d *Document) Delete(key string) {
+	parts := strings.Split(key, ".")
+	parent := d.root
+	for _, part := range parts[:len(parts)-1] {
+		parent = mappingValue(parent, part)
+		if parent == nil || parent.Kind != yaml.MappingNode {
+			return
+		}
+	}
+
+	last := parts[len(parts)-1]
+	for i := 0; i+1 < len(parent.Content); i += 2 {
+		if strings.EqualFold(parent.Content[i].Value, last) {
+			parent.Content = append(parent.Content[:i], parent.Content[i+2:]...)
+			return
+		}
+	}
+}
+
+// lookup returns the value node at key, or nil
+func (d *Document) lookup(key string) *yaml.Node {
+	node := d.root
+	for _, part := range strings.Split(key, ".") {
+		if node.Kind != yaml.MappingNode {
+			return nil
+		}
+		node = mappingValue(node, part)
+		if node == nil {
+			return nil
+		}
+	}
+	return node
+}
+
+// mappingValue returns the value for key in a mapping node, or nil
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if strings.EqualFold(mapping.Content[i].Value, key) {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// setMappingValue replaces the value for key in a mapping node, appending the
+// key if it isn't there yet
+func setMappingValue(mapping *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if strings.EqualFold(mapping.Content[i].Value, key) {
+			mapping.Content[i+1] = value
+			return
+		}
+	}
+	mapping.Content = append(mapping.Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key},
+		value)
+}
@@ -0,0 +1,178 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configfile
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const sample = `# Maestro config
+containers:
+  prefix: maestro-  # container name prefix
+  resources:
+    memory: 4g
+
+# Allowed outbound domains
+firewall:
+  allowed_domains:
+    - github.com
+
+apps: {}
+`
+
+func writeSample(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yml")
+	if err := os.WriteFile(path, []byte(sample), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestUpdatePreservesStructure(t *testing.T) {
+	path := writeSample(t)
+
+	err := Update(path, func(doc *Document) error {
+		var domains []string
+		if _, err := doc.Get("firewall.allowed_domains", &domains); err != nil {
+			return err
+		}
+		if err := doc.Set("firewall.allowed_domains", append(domains, "example.com")); err != nil {
+			return err
+		}
+		return doc.Set("apps", map[string]string{"tool": "~/bin/tool"})
+	})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	data, _ := os.ReadFile(path)
+	got := string(data)
+	for _, want := range []string{
+		"# Maestro config",
+		"prefix: maestro- # container name prefix",
+		"# Allowed outbound domains",
+		"- github.com\n    - example.com",
+		"tool: ~/bin/tool",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("updated config missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Index(got, "containers:") > strings.Index(got, "firewall:") {
+		t.Errorf("key order changed:\n%s", got)
+	}
+
+	info, _ := os.Stat(path)
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("file mode = %v, want 0600", info.Mode().Perm())
+	}
+}
+
+func TestUpdateCreatesMissingFileAndKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "config.yml")
+
+	err := Update(path, func(doc *Document) error {
+		return doc.Set("daemon.token_refresh.enabled", true)
+	})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	Update(path, func(doc *Document) error {
+		var enabled bool
+		found, err := doc.Get("Daemon.Token_Refresh.Enabled", &enabled)
+		if !found || err != nil || !enabled {
+			t.Errorf("Get() = (%v, %v), enabled = %v; want found and true", found, err, enabled)
+		}
+		return nil
+	})
+}
+
+func TestUpdateDelete(t *testing.T) {
+	path := writeSample(t)
+
+	Update(path, func(doc *Document) error {
+		doc.Delete("containers.resources.memory")
+		doc.Delete("missing.key")
+		return nil
+	})
+
+	Update(path, func(doc *Document) error {
+		var memory string
+		if found, _ := doc.Get("containers.resources.memory", &memory); found {
+			t.Errorf("containers.resources.memory = %q after Delete", memory)
+		}
+		var prefix string
+		if found, _ := doc.Get("containers.prefix", &prefix); !found || prefix != "maestro-" {
+			t.Errorf("containers.prefix = %q, want untouched", prefix)
+		}
+		return nil
+	})
+}
+
+func TestUpdateMutateErrorLeavesFileAlone(t *testing.T) {
+	path := writeSample(t)
+
+	err := Update(path, func(doc *Document) error {
+		doc.Set("containers.prefix", "changed-")
+		return errors.New("boom")
+	})
+	if err == nil {
+		t.Fatal("Update() error = nil, want mutate error")
+	}
+
+	data, _ := os.ReadFile(path)
+	if string(data) != sample {
+		t.Errorf("file changed after failed mutate:\n%s", data)
+	}
+}
+
+func TestUpdateKeepsSymlink(t *testing.T) {
+	target := writeSample(t)
+	link := filepath.Join(t.TempDir(), "config.yml")
+	if err := os.Symlink(target, link); err != nil {
+		t.Fatal(err)
+	}
+
+	err := Update(link, func(doc *Document) error {
+		return doc.Set("containers.prefix", "changed-")
+	})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	info, err := os.Lstat(link)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode()&os.ModeSymlink == 0 {
+		t.Fatalf("config is no longer a symlink: %v", info.Mode())
+	}
+	data, err := os.ReadFile(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "prefix: changed-") {
+		t.Errorf("symlink target not updated:\n%s", data)
+	}
+	if leftovers, _ := filepath.Glob(filepath.Join(filepath.Dir(link), ".config-*")); len(leftovers) > 0 {
+		t.Errorf("temp files left next to the symlink: %v", leftovers)
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import "sort"
+
+// UnknownBranch is reported when a container's branch can't be determined
+// (for example, when the container is stopped)
+const UnknownBranch = "unknown"
+
+// GroupByBranch groups containers by their git branch.
+// Groups are sorted by branch name, with the unknown group last.
+// Containers within each group keep priority order.
+func GroupByBranch(containers []Info) []BranchGroup {
+	byBranch := make(map[string][]Info)
+	for _, c := range SortByPriority(containers) {
+		branch := c.Branch
+		if branch == "" || branch == "-" || branch == NoGit {
+			branch = UnknownBranch
+		}
+		byBranch[branch] = append(byBranch[branch], c)
+	}
+
+	groups := make([]BranchGroup, 0, len(byBranch))
+	for branch, members := range byBranch {
+		groups = append(groups, BranchGroup{Branch: branch, Containers: members})
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].Branch == UnknownBranch {
+			return false
+		}
+		if groups[j].Branch == UnknownBranch {
+			return true
+		}
+		return groups[i].Branch < groups[j].Branch
+	})
+
+	return groups
+}
+
+// IsDuplicate reports whether more than one container is on a known branch
+func (g BranchGroup) IsDuplicate() bool {
+	return g.Branch != UnknownBranch && len(g.Containers) > 1
+}
+
+// FindByBranch returns the containers that have the given branch checked out.
+// Containers whose branch is unknown never match.
+func FindByBranch(containers []Info, branch string) []Info {
+	if branch == "" || branch == "-" || branch == UnknownBranch || branch == NoGit {
+		return nil
+	}
+
+	var matches []Info
+	for _, c := range containers {
+		if c.Branch == branch {
+			matches = append(matches, c)
+		}
+	}
+	return matches
+}
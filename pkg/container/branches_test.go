@@ -0,0 +1,61 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import "testing"
+
+func TestGroupByBranch(t *testing.T) {
+	containers := []Info{
+		{ShortName: "feat-b-1", Branch: "feat/b", Status: "running"},
+		{ShortName: "old-1", Branch: "unknown", Status: "exited"},
+		{ShortName: "feat-a-1", Branch: "feat/a", Status: "running"},
+		{ShortName: "feat-b-2", Branch: "feat/b", Status: "running"},
+		{ShortName: "blank-1", Branch: "", Status: "exited"},
+		{ShortName: "stuck-1", Branch: "-", Status: "created"},
+	}
+
+	groups := GroupByBranch(containers)
+
+	wantBranches := []string{"feat/a", "feat/b", UnknownBranch}
+	if len(groups) != len(wantBranches) {
+		t.Fatalf("GroupByBranch() returned %d groups, want %d", len(groups), len(wantBranches))
+	}
+
+	for i, want := range wantBranches {
+		if groups[i].Branch != want {
+			t.Errorf("group[%d].Branch = %q, want %q", i, groups[i].Branch, want)
+		}
+	}
+
+	tests := []struct {
+		branch    string
+		count     int
+		duplicate bool
+	}{
+		{"feat/a", 1, false},
+		{"feat/b", 2, true},
+		{UnknownBranch, 3, false},
+	}
+
+	for i, tt := range tests {
+		g := groups[i]
+		if len(g.Containers) != tt.count {
+			t.Errorf("%s: got %d containers, want %d", tt.branch, len(g.Containers), tt.count)
+		}
+		if g.IsDuplicate() != tt.duplicate {
+			t.Errorf("%s: IsDuplicate() = %v, want %v", tt.branch, g.IsDuplicate(), tt.duplicate)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import "strings"
+
+// StatusBundle is the status of a running container as gathered from inside
+// it. Each field matches what the corresponding single-purpose probe
+// (GetBranchName, GetGitStatus, CheckBellStatus, ...) would return.
+type StatusBundle struct {
+	Branch         string
+	GitStatus      string
+	NeedsAttention bool
+	IsDormant      bool
+	LastActivity   string
+	FirewallOff    bool
+}
+
+// bundleSectionMarker starts each section of statusBundleScript's output
+const bundleSectionMarker = "@@"
+
+// bundleFailed is printed as the last line of a section whose command failed
+const bundleFailed = "!failed"
+
+// statusBundleScript returns a script that prints one section per probe,
+// each starting with "@@<name>" and ending in "!failed" if its command didn't
+// succeed. The git sections are left out when git probing is disabled.
+func statusBundleScript() string {
+	var script strings.Builder
+	script.WriteString(`s() { echo "@@$1"; }` + "\n")
+	if gitEnabled {
+		script.WriteString(`s branch; git -C /workspace branch --show-current 2>/dev/null || echo '!failed'` + "\n")
+		script.WriteString(`s git; (` + gitCountsScript + `) || echo '!failed'` + "\n")
+	}
+	script.WriteString(`s bell; tmux list-windows -a -F '#{window_bell_flag}:#{window_silence_flag}' 2>/dev/null || echo '!failed'` + "\n")
+	script.WriteString(`s activity; tmux list-panes -a -F '#{pane_active_since}' 2>/dev/null || echo '!failed'` + "\n")
+	script.WriteString(`s ps; ps -eo stat,args 2>/dev/null || echo '!failed'` + "\n")
+	script.WriteString(`s firewall; test -f ` + FirewallDisabledMarker + ` || echo '!failed'` + "\n")
+	return script.String()
+}
+
+// GetContainerStatusBundle gathers branch, git status, attention flags,
+// activity, Claude liveness, and the firewall marker with a single docker
+// exec, instead of one exec per probe. Use it when you need most of these;
+// the individual functions remain for callers that need just one.
+func GetContainerStatusBundle(containerName string) StatusBundle {
+	// If the exec fails outright, every section is missing and each field
+	// gets the value its probe would return on failure
+	output, _ := commandOutput("docker", "exec", containerName, "sh", "-c", statusBundleScript())
+	return parseStatusBundle(string(output))
+}
+
+// parseStatusBundle parses statusBundleScript output. Missing or failed
+// sections get the same fallback values the individual probes use.
+func parseStatusBundle(output string) StatusBundle {
+	sections := make(map[string]string)
+	var name string
+	var lines []string
+	flush := func() {
+		content := strings.TrimSpace(strings.Join(lines, "\n"))
+		if name != "" && content != bundleFailed && !strings.HasSuffix(content, "\n"+bundleFailed) {
+			sections[name] = content
+		}
+	}
+	for _, line := range strings.Split(output, "\n") {
+		if strings.HasPrefix(line, bundleSectionMarker) {
+			flush()
+			name, lines = strings.TrimSpace(strings.TrimPrefix(line, bundleSectionMarker)), nil
+			continue
+		}
+		lines = append(lines, line)
+	}
+	flush()
+
+	bundle := StatusBundle{
+		Branch:       "unknown",
+		GitStatus:    padGitStatus("-"),
+		LastActivity: "-",
+		IsDormant:    true,
+	}
+	if !gitEnabled {
+		bundle.Branch = NoGit
+		bundle.GitStatus = padGitStatus(NoGit)
+	} else {
+		if branch, ok := sections["branch"]; ok {
+			bundle.Branch = branch
+		}
+		if counts, ok := sections["git"]; ok {
+			bundle.GitStatus = padGitStatus(formatGitCounts(counts))
+		}
+	}
+	if bell, ok := sections["bell"]; ok {
+		bundle.NeedsAttention = parseBellFlags(bell)
+	}
+	if activity, ok := sections["activity"]; ok {
+		bundle.LastActivity = parseLastActivity(activity)
+	}
+	if ps, ok := sections["ps"]; ok {
+		bundle.IsDormant = !parseClaudeRunning(ps)
+	}
+	_, bundle.FirewallOff = sections["firewall"]
+
+	return bundle
+}
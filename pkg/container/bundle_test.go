@@ -0,0 +1,85 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseStatusBundle(t *testing.T) {
+	activity := strconv.FormatInt(time.Now().Add(-5*time.Minute).Unix(), 10)
+
+	tests := []struct {
+		name   string
+		output string
+		want   StatusBundle
+	}{
+		{
+			name: "all sections",
+			output: "@@branch\nfeat/x\n@@git\n2|0|1\n@@bell\n0:0\n1:1\n@@activity\n" + activity +
+				"\n@@ps\nSTAT COMMAND\nSl+ claude --dangerously-skip-permissions\n@@firewall\n",
+			want: StatusBundle{
+				Branch:         "feat/x",
+				GitStatus:      padGitStatus("Δ2 ↓1"),
+				NeedsAttention: true,
+				LastActivity:   "5m",
+				FirewallOff:    true,
+			},
+		},
+		{
+			name: "failed sections",
+			output: "@@branch\n!failed\n@@git\n!failed\n@@bell\n!failed\n@@activity\n!failed\n" +
+				"@@ps\nSTAT COMMAND\nZ claude --dangerously-skip-permissions\n@@firewall\n!failed\n",
+			want: StatusBundle{
+				Branch:       "unknown",
+				GitStatus:    padGitStatus("-"),
+				LastActivity: "-",
+				IsDormant:    true,
+			},
+		},
+		{
+			name:   "no output",
+			output: "",
+			want: StatusBundle{
+				Branch:       "unknown",
+				GitStatus:    padGitStatus("-"),
+				LastActivity: "-",
+				IsDormant:    true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		if got := parseStatusBundle(tt.output); got != tt.want {
+			t.Errorf("%s: parseStatusBundle() = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestGetContainerStatusBundleUsesOneExec(t *testing.T) {
+	calls := fakeTmux(t, "", errors.New("exit status 1: No such container"))
+
+	got := GetContainerStatusBundle("maestro-a-1")
+	if len(*calls) != 1 || !strings.HasPrefix((*calls)[0], "docker exec maestro-a-1 sh -c") {
+		t.Errorf("commands = %v, want a single docker exec", *calls)
+	}
+	if got.Branch != "unknown" || !got.IsDormant || got.FirewallOff {
+		t.Errorf("GetContainerStatusBundle() on failure = %+v, want probe fallbacks", got)
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/uprockcom/maestro/pkg/system"
+)
+
+// DockerFreeSpace returns the bytes available on the filesystem holding
+// docker's data root, where container layers and volumes are written.
+// Docker Desktop keeps its data root inside a VM, so when the path doesn't
+// exist on the host the space is measured from a throwaway container of image.
+func DockerFreeSpace(image string) (int64, error) {
+	output, err := commandOutput("docker", "info", "--format", dataRootFormat())
+	if err != nil {
+		return 0, fmt.Errorf("failed to find docker data root: %w", err)
+	}
+	root := strings.TrimSpace(string(output))
+
+	if _, statErr := os.Stat(root); root != "" && statErr == nil {
+		output, err = commandOutput("df", "-Pk", root)
+	} else {
+		output, err = commandOutput("docker", "run", "--rm", "--pull", "never", "--entrypoint", "df", image, "-Pk", "/")
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to check free disk space: %w", err)
+	}
+	return parseDfAvailable(string(output))
+}
+
+// dataRootFormat is the docker info template printing the storage root; podman
+// reports it under Store rather than DockerRootDir
+func dataRootFormat() string {
+	if system.IsPodman() {
+		return "{{.Store.GraphRoot}}"
+	}
+	return "{{.DockerRootDir}}"
+}
+
+// parseDfAvailable returns the available bytes from POSIX `df -Pk` output
+func parseDfAvailable(output string) (int64, error) {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	if len(lines) < 2 {
+		return 0, fmt.Errorf("unexpected df output: %q", output)
+	}
+	// Filesystem 1024-blocks Used Available Capacity Mounted-on
+	fields := strings.Fields(lines[len(lines)-1])
+	if len(fields) < 4 {
+		return 0, fmt.Errorf("unexpected df output: %q", output)
+	}
+	kb, err := strconv.ParseInt(fields[3], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("unexpected df output: %q", output)
+	}
+	return kb * 1024, nil
+}
+
+// ParseByteSize parses a size such as "512m", "5g", or "1.5GB" into bytes,
+// using the same binary suffixes as docker's --memory. A bare number is bytes.
+func ParseByteSize(s string) (int64, error) {
+	value := strings.ToLower(strings.TrimSpace(s))
+	if value == "" {
+		return 0, fmt.Errorf("empty size")
+	}
+
+	value = strings.TrimSuffix(value, "b")
+	multiplier := int64(1)
+	if n := len(value); n > 0 {
+		switch value[n-1] {
+		case 'k':
+			multiplier = 1 << 10
+		case 'm':
+			multiplier = 1 << 20
+		case 'g':
+			multiplier = 1 << 30
+		case 't':
+			multiplier = 1 << 40
+		}
+		if multiplier > 1 {
+			value = value[:n-1]
+		}
+	}
+
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil || number < 0 {
+		return 0, fmt.Errorf("invalid size %q (expected e.g. 512m or 5g)", s)
+	}
+	return int64(number * float64(multiplier)), nil
+}
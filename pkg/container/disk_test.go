@@ -0,0 +1,81 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import "testing"
+
+func TestParseDfAvailable(t *testing.T) {
+	tests := []struct {
+		name    string
+		output  string
+		want    int64
+		wantErr bool
+	}{
+		{
+			name:   "linux",
+			output: "Filesystem     1024-blocks      Used Available Capacity Mounted on\n/dev/sda1        102400000  90000000  12400000      88% /var/lib/docker\n",
+			want:   12400000 * 1024,
+		},
+		{
+			name:   "overlay root inside a container",
+			output: "Filesystem 1024-blocks Used Available Capacity Mounted on\noverlay 61255492 40000000 18117048 69% /\n",
+			want:   18117048 * 1024,
+		},
+		{name: "header only", output: "Filesystem 1024-blocks Used Available Capacity Mounted on\n", wantErr: true},
+		{name: "garbage", output: "df: /missing: No such file or directory", wantErr: true},
+		{name: "non-numeric", output: "Filesystem 1024-blocks Used Available\n/dev/sda1 1 1 lots\n", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseDfAvailable(tt.output)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseDfAvailable() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseDfAvailable() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseByteSize(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    int64
+		wantErr bool
+	}{
+		{in: "1024", want: 1024},
+		{in: "512m", want: 512 << 20},
+		{in: "5g", want: 5 << 30},
+		{in: "5G", want: 5 << 30},
+		{in: "5gb", want: 5 << 30},
+		{in: "1.5g", want: 3 << 29},
+		{in: "2t", want: 2 << 40},
+		{in: "10k", want: 10 << 10},
+		{in: "", wantErr: true},
+		{in: "lots", wantErr: true},
+		{in: "-1g", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseByteSize(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseByteSize(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseByteSize(%q) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
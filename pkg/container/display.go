@@ -16,10 +16,38 @@ package container
 
 import (
 	"fmt"
+	"io"
 	"os"
-	"text/tabwriter"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
 )
 
+// FormatState returns a glyph and label for a docker container state. It
+// covers every state docker reports, so the CLI and the TUI show the same
+// status for each. Glyphs are plain text; the TUI table can't hold colors.
+func FormatState(state string) string {
+	switch state {
+	case "running":
+		return "● Running"
+	case "paused":
+		return "⏸ Paused"
+	case "restarting":
+		return "↻ Restarting"
+	case "created":
+		return "◌ Created"
+	case "exited":
+		return "○ Stopped"
+	case "removing":
+		return "⌫ Removing"
+	case "dead":
+		return "✗ Dead"
+	default:
+		return "? " + state
+	}
+}
+
 // SortByPriority sorts containers by logical priority groups, then by creation date within each group
 // Priority order:
 // 1. Needs Attention (running with bell/silence flag)
@@ -46,27 +74,36 @@ func SortByPriority(containers []Info) []Info {
 		return 3 // Stopped containers lowest priority
 	}
 
-	// Sort by priority, then by creation date
-	for i := 0; i < len(sorted); i++ {
-		for j := i + 1; j < len(sorted); j++ {
-			iPriority := getPriority(sorted[i])
-			jPriority := getPriority(sorted[j])
-
-			// First sort by priority
-			if iPriority > jPriority {
-				sorted[i], sorted[j] = sorted[j], sorted[i]
-			} else if iPriority == jPriority {
-				// Within same priority, sort by creation date (newest first)
-				if sorted[i].CreatedAt.Before(sorted[j].CreatedAt) {
-					sorted[i], sorted[j] = sorted[j], sorted[i]
-				}
-			}
+	// Sort by priority, then by creation date. The sort is stable and
+	// creationLess breaks ties by name, so the order never depends on docker ps.
+	sort.SliceStable(sorted, func(i, j int) bool {
+		iPriority, jPriority := getPriority(sorted[i]), getPriority(sorted[j])
+		if iPriority != jPriority {
+			return iPriority < jPriority
 		}
-	}
+		return creationLess(sorted[i], sorted[j])
+	})
 
 	return sorted
 }
 
+// sortByCreation orders containers newest first, then by name, so listings
+// are stable regardless of the order docker reports them in
+func sortByCreation(containers []Info) {
+	sort.SliceStable(containers, func(i, j int) bool {
+		return creationLess(containers[i], containers[j])
+	})
+}
+
+// creationLess reports whether a sorts before b: newer containers first,
+// then alphabetically by name
+func creationLess(a, b Info) bool {
+	if !a.CreatedAt.Equal(b.CreatedAt) {
+		return a.CreatedAt.After(b.CreatedAt)
+	}
+	return a.Name < b.Name
+}
+
 // Display shows containers in a consistent format
 // Returns the sorted list for use in selection
 func Display(containers []Info, opts DisplayOptions) []Info {
@@ -74,17 +111,22 @@ func Display(containers []Info, opts DisplayOptions) []Info {
 	sorted := SortByPriority(containers)
 
 	if opts.ShowTable {
-		// Table format with tabwriter for proper alignment
-		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		// Table format, aligned by display width so wide branch names line up
+		headers := []string{"NAME", "STATUS", "BRANCH", "GIT", "ACTIVITY", "AUTH"}
+		if opts.ShowAge {
+			headers = append(headers, "AGE")
+		}
+		headers = append(headers, "ATTENTION")
 
 		// Add number column header if showing numbers
 		if opts.ShowNumbers {
-			fmt.Fprintln(w, "#\tNAME\tSTATUS\tBRANCH\tGIT\tACTIVITY\tAUTH\tATTENTION")
-			fmt.Fprintln(w, "-\t----\t------\t------\t---\t--------\t----\t---------")
-		} else {
-			fmt.Fprintln(w, "NAME\tSTATUS\tBRANCH\tGIT\tACTIVITY\tAUTH\tATTENTION")
-			fmt.Fprintln(w, "----\t------\t------\t---\t--------\t----\t---------")
+			headers = append([]string{"#"}, headers...)
+		}
+		underlines := make([]string, len(headers))
+		for i, h := range headers {
+			underlines[i] = strings.Repeat("-", len(h))
 		}
+		rows := [][]string{headers, underlines}
 
 		for i, c := range sorted {
 			attention := ""
@@ -93,6 +135,9 @@ func Display(containers []Info, opts DisplayOptions) []Info {
 			} else if c.IsDormant {
 				attention = "💤"
 			}
+			if c.FirewallOff {
+				attention = strings.TrimSpace(attention + " ⚠️ FIREWALL OFF")
+			}
 
 			// Use default values for stopped containers
 			gitStatus := c.GitStatus
@@ -108,16 +153,23 @@ func Display(containers []Info, opts DisplayOptions) []Info {
 				lastActivity = "-"
 			}
 
+			row := []string{c.ShortName, FormatState(c.Status), c.Branch, gitStatus, lastActivity, authStatus}
+			if opts.ShowAge {
+				age := FormatAge(c.CreatedAt)
+				if age == "" {
+					age = "-"
+				}
+				row = append(row, age)
+			}
+			row = append(row, attention)
+
 			// Include number column if showing numbers
 			if opts.ShowNumbers {
-				fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
-					i+1, c.ShortName, c.Status, c.Branch, gitStatus, lastActivity, authStatus, attention)
-			} else {
-				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
-					c.ShortName, c.Status, c.Branch, gitStatus, lastActivity, authStatus, attention)
+				row = append([]string{fmt.Sprintf("%d", i+1)}, row...)
 			}
+			rows = append(rows, row)
 		}
-		w.Flush()
+		writeTable(os.Stdout, rows)
 	} else if opts.ShowNumbers {
 		// Numbered list format (for selection)
 		fmt.Println("\nContainers:")
@@ -130,7 +182,7 @@ func Display(containers []Info, opts DisplayOptions) []Info {
 			} else if c.IsDormant {
 				status = " 💤 DORMANT"
 			} else if c.Status != "running" {
-				status = " (stopped)"
+				status = " (" + FormatState(c.Status) + ")"
 			}
 			fmt.Printf("  %d) %s (branch: %s)%s\n", i+1, c.ShortName, c.Branch, status)
 		}
@@ -150,3 +202,31 @@ func Display(containers []Info, opts DisplayOptions) []Info {
 
 	return sorted
 }
+
+// writeTable writes rows as left-aligned columns separated by two spaces.
+// Widths are measured in terminal cells rather than runes (as text/tabwriter
+// does), so emoji and CJK text don't push later columns out of line.
+func writeTable(w io.Writer, rows [][]string) {
+	const gap = 2
+
+	var widths []int
+	for _, row := range rows {
+		for i, cell := range row {
+			if i >= len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[i] = max(widths[i], ansi.StringWidth(cell))
+		}
+	}
+
+	for _, row := range rows {
+		var line strings.Builder
+		for i, cell := range row {
+			line.WriteString(cell)
+			if i < len(row)-1 {
+				line.WriteString(strings.Repeat(" ", widths[i]-ansi.StringWidth(cell)+gap))
+			}
+		}
+		fmt.Fprintln(w, line.String())
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func names(containers []Info) []string {
+	var out []string
+	for _, c := range containers {
+		out = append(out, c.Name)
+	}
+	return out
+}
+
+func TestSortByCreation(t *testing.T) {
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	containers := []Info{
+		{Name: "b", CreatedAt: t0},
+		{Name: "old", CreatedAt: t0.Add(-time.Hour)},
+		{Name: "a", CreatedAt: t0},
+		{Name: "new", CreatedAt: t0.Add(time.Hour)},
+	}
+
+	sortByCreation(containers)
+	want := []string{"new", "a", "b", "old"}
+	if got := names(containers); !reflect.DeepEqual(got, want) {
+		t.Errorf("sortByCreation() = %v, want %v", got, want)
+	}
+}
+
+func TestSortByPriorityIsDeterministic(t *testing.T) {
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	containers := []Info{
+		{Name: "stopped", Status: "exited", CreatedAt: t0.Add(time.Hour)},
+		{Name: "run-b", Status: "running", CreatedAt: t0},
+		{Name: "dormant", Status: "running", IsDormant: true, CreatedAt: t0},
+		{Name: "run-a", Status: "running", CreatedAt: t0},
+		{Name: "bell", Status: "running", NeedsAttention: true, CreatedAt: t0.Add(-time.Hour)},
+	}
+	want := []string{"bell", "run-a", "run-b", "dormant", "stopped"}
+
+	// Any input order must produce the same result
+	for i := range containers {
+		rotated := append(append([]Info{}, containers[i:]...), containers[:i]...)
+		if got := names(SortByPriority(rotated)); !reflect.DeepEqual(got, want) {
+			t.Errorf("SortByPriority(rotation %d) = %v, want %v", i, got, want)
+		}
+	}
+}
+
+func TestFormatState(t *testing.T) {
+	tests := map[string]string{
+		"running":    "● Running",
+		"paused":     "⏸ Paused",
+		"restarting": "↻ Restarting",
+		"created":    "◌ Created",
+		"exited":     "○ Stopped",
+		"removing":   "⌫ Removing",
+		"dead":       "✗ Dead",
+		"unknown":    "? unknown",
+	}
+	for state, want := range tests {
+		if got := FormatState(state); got != want {
+			t.Errorf("FormatState(%q) = %q, want %q", state, got, want)
+		}
+	}
+}
+
+func TestWriteTableAlignsWideRunes(t *testing.T) {
+	rows := [][]string{
+		{"NAME", "BRANCH", "GIT"},
+		{"feat-1", "feat/login", "Δ2"},
+		{"feat-2", "feat/日本語-docs", "✓"},
+		{"feat-3", "fix/🚀-launch", "-"},
+	}
+
+	var buf bytes.Buffer
+	writeTable(&buf, rows)
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	if len(lines) != len(rows) {
+		t.Fatalf("got %d lines, want %d:\n%s", len(lines), len(rows), buf.String())
+	}
+
+	// The GIT column must start at the same cell on every line
+	for i, line := range lines {
+		last := rows[i][len(rows[i])-1]
+		col := ansi.StringWidth(strings.TrimSuffix(line, last))
+		if want := ansi.StringWidth(strings.TrimSuffix(lines[0], "GIT")); col != want {
+			t.Errorf("line %d: last column starts at cell %d, want %d:\n%s", i, col, want, buf.String())
+		}
+	}
+}
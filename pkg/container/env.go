@@ -0,0 +1,73 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// RedactedValue replaces the value of a redacted environment variable
+const RedactedValue = "<redacted>"
+
+// envRedactPatterns are the substrings (matched case-insensitively against
+// the variable name) of environment variables whose values are hidden
+var envRedactPatterns = []string{"TOKEN", "SECRET", "PASSWORD"}
+
+// SetEnvRedactPatterns sets the variable name substrings whose values are
+// redacted. An empty list redacts nothing.
+func SetEnvRedactPatterns(patterns []string) {
+	envRedactPatterns = patterns
+}
+
+// IsSecretEnv reports whether the variable name matches a redact pattern
+func IsSecretEnv(name string) bool {
+	upper := strings.ToUpper(name)
+	for _, pattern := range envRedactPatterns {
+		if pattern != "" && strings.Contains(upper, strings.ToUpper(pattern)) {
+			return true
+		}
+	}
+	return false
+}
+
+// RedactEnv returns a copy of env (NAME=value entries) with the values of
+// secret variables replaced by RedactedValue
+func RedactEnv(env []string) []string {
+	redacted := make([]string, len(env))
+	for i, entry := range env {
+		name, _, _ := strings.Cut(entry, "=")
+		if IsSecretEnv(name) {
+			entry = name + "=" + RedactedValue
+		}
+		redacted[i] = entry
+	}
+	return redacted
+}
+
+// GetContainerEnv returns a container's environment variables as NAME=value
+// entries, unredacted
+func GetContainerEnv(containerName string) ([]string, error) {
+	output, err := commandOutput("docker", "inspect", "--format", "{{json .Config.Env}}", containerName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect container: %w", err)
+	}
+	var env []string
+	if err := json.Unmarshal(output, &env); err != nil {
+		return nil, fmt.Errorf("failed to parse container environment: %w", err)
+	}
+	return env, nil
+}
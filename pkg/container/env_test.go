@@ -0,0 +1,86 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestRedactEnv(t *testing.T) {
+	defer SetEnvRedactPatterns(envRedactPatterns)
+
+	env := []string{
+		"PATH=/usr/bin",
+		"GH_TOKEN=ghp_abc",
+		"db_password=hunter2",
+		"SECRETLESS=",
+		"API_KEY=sk-123",
+		"EQUALS_IN_VALUE=a=b",
+		"NO_VALUE",
+	}
+
+	tests := []struct {
+		name     string
+		patterns []string
+		want     []string
+	}{
+		{"defaults", []string{"TOKEN", "SECRET", "PASSWORD"}, []string{
+			"PATH=/usr/bin",
+			"GH_TOKEN=<redacted>",
+			"db_password=<redacted>",
+			"SECRETLESS=<redacted>",
+			"API_KEY=sk-123",
+			"EQUALS_IN_VALUE=a=b",
+			"NO_VALUE",
+		}},
+		{"custom, case-insensitive", []string{"key", "value"}, []string{
+			"PATH=/usr/bin",
+			"GH_TOKEN=ghp_abc",
+			"db_password=hunter2",
+			"SECRETLESS=",
+			"API_KEY=<redacted>",
+			"EQUALS_IN_VALUE=<redacted>",
+			"NO_VALUE=<redacted>",
+		}},
+		{"empty list redacts nothing", nil, env},
+		{"empty pattern is ignored", []string{""}, env},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetEnvRedactPatterns(tt.patterns)
+			if got := RedactEnv(env); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RedactEnv() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetContainerEnv(t *testing.T) {
+	fakeTmux(t, `["PATH=/usr/bin","GH_TOKEN=ghp_abc"]`+"\n", nil)
+	got, err := GetContainerEnv("maestro-x-1")
+	if err != nil {
+		t.Fatalf("GetContainerEnv() error = %v", err)
+	}
+	if want := []string{"PATH=/usr/bin", "GH_TOKEN=ghp_abc"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GetContainerEnv() = %q, want %q", got, want)
+	}
+
+	fakeTmux(t, "", errors.New("No such object: maestro-x-1"))
+	if _, err := GetContainerEnv("maestro-x-1"); err == nil {
+		t.Error("GetContainerEnv() succeeded for a missing container")
+	}
+}
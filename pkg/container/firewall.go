@@ -0,0 +1,184 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/uprockcom/maestro/pkg/system"
+)
+
+// FirewallDisabledMarker exists inside a container while its firewall is disabled
+const FirewallDisabledMarker = "/etc/maestro-firewall-disabled"
+
+// disableFirewallScript opens up egress: ACCEPT policies, filter rules flushed
+// (the nat table keeps Docker's embedded DNS rules), dnsmasq stopped, and
+// resolv.conf pointed back at a real resolver.
+const disableFirewallScript = `set -e
+iptables -P INPUT ACCEPT
+iptables -P FORWARD ACCEPT
+iptables -P OUTPUT ACCEPT
+iptables -F
+killall dnsmasq 2>/dev/null || true
+if iptables-save -t nat | grep -q '127\.0\.0\.11'; then
+    echo "nameserver 127.0.0.11" > /etc/resolv.conf
+else
+    echo "nameserver 8.8.8.8" > /etc/resolv.conf
+fi
+touch ` + FirewallDisabledMarker
+
+// DisableFirewall removes all egress filtering from a running container until
+// the firewall is re-initialized
+func DisableFirewall(containerName string) error {
+	cmd := system.DockerCommand("exec", "-u", "root", containerName, "sh", "-c", disableFirewallScript)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to disable firewall: %s", strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// ClearFirewallDisabled removes the disabled marker once the firewall is re-applied
+func ClearFirewallDisabled(containerName string) error {
+	cmd := system.DockerCommand("exec", "-u", "root", containerName, "rm", "-f", FirewallDisabledMarker)
+	return cmd.Run()
+}
+
+// IsFirewallDisabled reports whether the firewall has been disabled in a running container
+func IsFirewallDisabled(containerName string) bool {
+	_, err := commandOutput("docker", "exec", containerName, "test", "-f", FirewallDisabledMarker)
+	return err == nil
+}
+
+// AllowedDomainsLabel is the container label holding the comma-separated
+// domains allowed for that container only (new --allow-domain)
+const AllowedDomainsLabel = "maestro.allowed-domains"
+
+// GetAllowedDomainsLabel returns the container's own allowed domains (its
+// AllowedDomainsLabel), or nil if it has none
+func GetAllowedDomainsLabel(containerName string) []string {
+	output, err := commandOutput("docker", "inspect", "-f",
+		fmt.Sprintf("{{index .Config.Labels %q}}", AllowedDomainsLabel), containerName)
+	if err != nil {
+		return nil
+	}
+	var domains []string
+	for _, domain := range strings.Split(strings.TrimSpace(string(output)), ",") {
+		if domain = strings.TrimSpace(domain); domain != "" && domain != "<no value>" {
+			domains = append(domains, domain)
+		}
+	}
+	return domains
+}
+
+// DnsmasqConfig is the dnsmasq config the firewall script generates. It lists
+// every allowed domain, including ones added at runtime with add-domain.
+const DnsmasqConfig = "/tmp/dnsmasq-firewall.conf"
+
+// FirewallDomains returns the domains a container's firewall currently
+// allows, read from its dnsmasq config. The config outlives dnsmasq itself,
+// so this works after dnsmasq has died (e.g. over a host sleep).
+func FirewallDomains(containerName string) ([]string, error) {
+	output, err := commandOutput("docker", "exec", containerName, "cat", DnsmasqConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", DnsmasqConfig, err)
+	}
+	return parseDnsmasqDomains(string(output)), nil
+}
+
+// parseDnsmasqDomains returns the domains of the ipset=/<domain>/allowed-domains
+// lines in a dnsmasq config, without the leading "." of wildcard entries and
+// without duplicates
+func parseDnsmasqDomains(conf string) []string {
+	var domains []string
+	seen := make(map[string]bool)
+	for _, line := range strings.Split(conf, "\n") {
+		rest, ok := strings.CutPrefix(strings.TrimSpace(line), "ipset=/")
+		if !ok {
+			continue
+		}
+		domain, set, ok := strings.Cut(rest, "/")
+		domain = strings.TrimPrefix(domain, ".")
+		if !ok || set != "allowed-domains" || domain == "" || seen[domain] {
+			continue
+		}
+		seen[domain] = true
+		domains = append(domains, domain)
+	}
+	return domains
+}
+
+// DomainCheck is the result of probing a domain from inside a container
+type DomainCheck struct {
+	Addresses    []string // IPs the domain resolved to; empty if it didn't resolve
+	HTTPStatus   string   // Status code of the HTTPS request; "" or "000" if no response
+	ConnectError string   // curl's error message when the request failed
+}
+
+// Resolved reports whether the domain resolved to at least one address
+func (c DomainCheck) Resolved() bool {
+	return len(c.Addresses) > 0
+}
+
+// Reachable reports whether an HTTPS request got any response. Any status
+// counts: a 403 or 404 still means the firewall let the connection through.
+func (c DomainCheck) Reachable() bool {
+	return c.HTTPStatus != "" && c.HTTPStatus != "000"
+}
+
+// CheckDomain resolves domain and makes an HTTPS request to it from inside a
+// running container, to tell whether the firewall lets it through
+func CheckDomain(containerName, domain string) DomainCheck {
+	var check DomainCheck
+
+	// dig exits 0 even when nothing resolves, so the addresses are what count
+	if output, err := commandOutput("docker", "exec", containerName, "dig", "+short", domain); err == nil {
+		check.Addresses = parseDigAddresses(string(output))
+	}
+
+	output, _ := commandCombinedOutput("docker", "exec", containerName,
+		"curl", "-sS", "--max-time", "5", "-o", "/dev/null", "-w", "%{http_code}\\n", "https://"+domain)
+	check.HTTPStatus, check.ConnectError = parseCurlResult(string(output))
+	return check
+}
+
+// parseDigAddresses returns the IP addresses in `dig +short` output, skipping
+// CNAME targets and resolver comments
+func parseDigAddresses(output string) []string {
+	var addresses []string
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if net.ParseIP(line) != nil {
+			addresses = append(addresses, line)
+		}
+	}
+	return addresses
+}
+
+// parseCurlResult splits curl's combined output into the status code written
+// by -w and whatever error curl printed to stderr
+func parseCurlResult(output string) (status, errMsg string) {
+	var errLines []string
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		line = strings.TrimSpace(line)
+		if len(line) == 3 && strings.Trim(line, "0123456789") == "" {
+			status = line
+		} else if line != "" {
+			errLines = append(errLines, line)
+		}
+	}
+	return status, strings.Join(errLines, "; ")
+}
@@ -0,0 +1,109 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseDigAddresses(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   []string
+	}{
+		{"addresses", "140.82.112.5\n140.82.112.6\n", []string{"140.82.112.5", "140.82.112.6"}},
+		{"cname chain", "api.example.com.cdn.net.\n93.184.216.34\n", []string{"93.184.216.34"}},
+		{"ipv6", "2606:2800:220:1::\n", []string{"2606:2800:220:1::"}},
+		{"nothing resolved", "", nil},
+		{"resolver error", ";; connection timed out; no servers could be reached\n", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseDigAddresses(tt.output); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseDigAddresses() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCurlResult(t *testing.T) {
+	tests := []struct {
+		name       string
+		output     string
+		wantStatus string
+		wantErr    string
+	}{
+		{"ok", "200\n", "200", ""},
+		{"http error still responds", "403\n", "403", ""},
+		{"timeout", "curl: (28) Connection timed out after 5001 milliseconds\n000\n", "000", "curl: (28) Connection timed out after 5001 milliseconds"},
+		{"dns failure", "curl: (6) Could not resolve host: nope.example\n000\n", "000", "curl: (6) Could not resolve host: nope.example"},
+		{"exec failed", "Error response from daemon: container is not running\n", "", "Error response from daemon: container is not running"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, errMsg := parseCurlResult(tt.output)
+			if status != tt.wantStatus || errMsg != tt.wantErr {
+				t.Errorf("parseCurlResult() = (%q, %q), want (%q, %q)", status, errMsg, tt.wantStatus, tt.wantErr)
+			}
+			check := DomainCheck{HTTPStatus: status}
+			if want := tt.wantStatus != "" && tt.wantStatus != "000"; check.Reachable() != want {
+				t.Errorf("Reachable() = %v, want %v", check.Reachable(), want)
+			}
+		})
+	}
+}
+
+func TestParseDnsmasqDomains(t *testing.T) {
+	conf := `no-resolv
+server=8.8.8.8
+log-facility=/tmp/dnsmasq.log
+ipset=/registry.npmjs.org/allowed-domains
+ipset=/github.com/allowed-domains
+ipset=/.github.com/allowed-domains
+ipset=/api.example.com/allowed-domains
+server=/api.example.com/8.8.8.8
+ipset=/other.example.com/some-other-set
+ipset=//allowed-domains
+`
+	want := []string{"registry.npmjs.org", "github.com", "api.example.com"}
+	if got := parseDnsmasqDomains(conf); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseDnsmasqDomains() = %q, want %q", got, want)
+	}
+}
+
+func TestGetAllowedDomainsLabel(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		err    error
+		want   []string
+	}{
+		{"domains", "api.example.com,billing.example.com\n", nil, []string{"api.example.com", "billing.example.com"}},
+		{"no label", "<no value>\n", nil, nil},
+		{"empty entries", "api.example.com,,\n", nil, []string{"api.example.com"}},
+		{"inspect fails", "", errors.New("no such container"), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeTmux(t, tt.output, tt.err)
+			if got := GetAllowedDomainsLabel("maestro-x-1"); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetAllowedDomainsLabel() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
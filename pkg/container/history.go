@@ -0,0 +1,161 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HistoryFile is the shell history inside a container. It lives on the
+// container's -history volume, so it survives restarts and rebuilds.
+const HistoryFile = "/commandhistory/.bash_history"
+
+// HistoryEntry is one command from a container's shell history
+type HistoryEntry struct {
+	Time    time.Time // Zero if the shell didn't record when it ran
+	Command string
+}
+
+// ReadShellHistory returns the commands in a container's shell history,
+// oldest first. docker cp is used rather than exec so stopped containers can
+// be read too.
+func ReadShellHistory(containerName string) ([]HistoryEntry, error) {
+	output, err := commandOutput("docker", "cp", containerName+":"+HistoryFile, "-")
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return nil, fmt.Errorf("failed to read shell history: %s", strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, fmt.Errorf("failed to read shell history: %w", err)
+	}
+
+	// docker cp writes a tar archive holding the one file
+	tr := tar.NewReader(bytes.NewReader(output))
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read shell history: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read shell history: %w", err)
+		}
+		return parseShellHistory(data), nil
+	}
+}
+
+// parseShellHistory parses a history file written by zsh (plain or with
+// extended ": <time>:<duration>;" prefixes) or bash (with optional "#<time>"
+// lines). Multi-line zsh commands, stored with a trailing backslash on each
+// continued line, become one entry.
+func parseShellHistory(data []byte) []HistoryEntry {
+	lines := strings.Split(string(unmetafy(data)), "\n")
+
+	var entries []HistoryEntry
+	var pending time.Time // From a bash "#<time>" line, for the next command
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		if line == "" {
+			continue
+		}
+		if ts, ok := parseBashTimestamp(line); ok {
+			pending = ts
+			continue
+		}
+
+		entry := HistoryEntry{Time: pending}
+		pending = time.Time{}
+		if ts, command, ok := parseExtendedHistory(line); ok {
+			entry.Time = ts
+			line = command
+		}
+		for strings.HasSuffix(line, "\\") && i+1 < len(lines) {
+			i++
+			line = line[:len(line)-1] + "\n" + lines[i]
+		}
+		entry.Command = line
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// parseExtendedHistory splits a zsh EXTENDED_HISTORY line,
+// ": 1700000000:0;command", into its start time and command
+func parseExtendedHistory(line string) (time.Time, string, bool) {
+	rest, ok := strings.CutPrefix(line, ": ")
+	if !ok {
+		return time.Time{}, "", false
+	}
+	meta, command, ok := strings.Cut(rest, ";")
+	if !ok {
+		return time.Time{}, "", false
+	}
+	start, _, ok := strings.Cut(meta, ":")
+	if !ok {
+		return time.Time{}, "", false
+	}
+	seconds, err := strconv.ParseInt(start, 10, 64)
+	if err != nil {
+		return time.Time{}, "", false
+	}
+	return time.Unix(seconds, 0), command, true
+}
+
+// parseBashTimestamp parses the "#1700000000" line bash writes before each
+// command when HISTTIMEFORMAT is set
+func parseBashTimestamp(line string) (time.Time, bool) {
+	digits, ok := strings.CutPrefix(line, "#")
+	if !ok || digits == "" {
+		return time.Time{}, false
+	}
+	seconds, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(seconds, 0), true
+}
+
+// unmetafy undoes zsh's history encoding, which writes bytes 0x83-0xa2 as
+// 0x83 followed by the byte xor 0x20
+func unmetafy(data []byte) []byte {
+	const meta = 0x83
+	if bytes.IndexByte(data, meta) < 0 {
+		return data
+	}
+	out := make([]byte, 0, len(data))
+	for i := 0; i < len(data); i++ {
+		if data[i] == meta && i+1 < len(data) {
+			i++
+			out = append(out, data[i]^0x20)
+			continue
+		}
+		out = append(out, data[i])
+	}
+	return out
+}
@@ -0,0 +1,101 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"archive/tar"
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseShellHistory(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want []HistoryEntry
+	}{
+		{
+			name: "plain",
+			data: "ls\ngit status\n\nnpm test\n",
+			want: []HistoryEntry{{Command: "ls"}, {Command: "git status"}, {Command: "npm test"}},
+		},
+		{
+			name: "zsh extended",
+			data: ": 1700000000:0;git push\n: 1700000060:12;make build\n",
+			want: []HistoryEntry{
+				{Time: time.Unix(1700000000, 0), Command: "git push"},
+				{Time: time.Unix(1700000060, 0), Command: "make build"},
+			},
+		},
+		{
+			name: "zsh multi-line command",
+			data: ": 1700000000:0;for f in *; do\\\necho $f\\\ndone\nls\n",
+			want: []HistoryEntry{
+				{Time: time.Unix(1700000000, 0), Command: "for f in *; do\necho $f\ndone"},
+				{Command: "ls"},
+			},
+		},
+		{
+			name: "bash timestamps",
+			data: "#1700000000\ncd /workspace\nls\n",
+			want: []HistoryEntry{{Time: time.Unix(1700000000, 0), Command: "cd /workspace"}, {Command: "ls"}},
+		},
+		{
+			name: "not a timestamp",
+			data: "#fix later\n: not extended\n",
+			want: []HistoryEntry{{Command: "#fix later"}, {Command: ": not extended"}},
+		},
+		{
+			name: "zsh metafied bytes",
+			data: "echo caf\xc3\x83\x89\n", // é is c3 a9; zsh writes a9 as 83 89
+			want: []HistoryEntry{{Command: "echo café"}},
+		},
+		{
+			name: "empty",
+			data: "",
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseShellHistory([]byte(tt.data)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseShellHistory() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadShellHistory(t *testing.T) {
+	var archive bytes.Buffer
+	tw := tar.NewWriter(&archive)
+	data := []byte(": 1700000000:0;git log\n")
+	tw.WriteHeader(&tar.Header{Name: ".bash_history", Mode: 0600, Size: int64(len(data)), Typeflag: tar.TypeReg})
+	tw.Write(data)
+	tw.Close()
+
+	calls := fakeTmux(t, archive.String(), nil)
+	got, err := ReadShellHistory("maestro-feat-1")
+	if err != nil {
+		t.Fatalf("ReadShellHistory() error = %v", err)
+	}
+	if want := []HistoryEntry{{Time: time.Unix(1700000000, 0), Command: "git log"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadShellHistory() = %v, want %v", got, want)
+	}
+	if want := []string{"docker cp maestro-feat-1:" + HistoryFile + " -"}; !reflect.DeepEqual(*calls, want) {
+		t.Errorf("ran %q, want %q", *calls, want)
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"strings"
+
+	"github.com/uprockcom/maestro/pkg/system"
+)
+
+// Lifecycle events that can trigger hooks
+const (
+	EventPostCreate = "post_create"
+	EventPreStop    = "pre_stop"
+	EventPostDelete = "post_delete"
+)
+
+// HookEvent describes a container lifecycle event passed to hooks
+type HookEvent struct {
+	Event     string
+	Name      string
+	ShortName string
+	Branch    string
+	Task      string
+}
+
+// HookFunc handles a container lifecycle event
+type HookFunc func(e HookEvent)
+
+var lifecycleHook HookFunc
+
+// SetLifecycleHook registers the function called for lifecycle events.
+// Pass nil to disable hooks.
+func SetLifecycleHook(fn HookFunc) {
+	lifecycleHook = fn
+}
+
+// FireHook calls the registered lifecycle hook, if any
+func FireHook(e HookEvent) {
+	if lifecycleHook != nil {
+		lifecycleHook(e)
+	}
+}
+
+// CaptureHookEvent builds an event with branch and task details read from the container.
+// For post_delete, capture before removing the container and fire afterwards.
+func CaptureHookEvent(event, containerName string) HookEvent {
+	e := HookEvent{Event: event, Name: containerName}
+	if lifecycleHook == nil {
+		return e
+	}
+
+	if branch := GetBranchName(containerName); branch != UnknownBranch && branch != NoGit {
+		e.Branch = branch
+	}
+	e.Task = GetTaskDescription(containerName)
+	return e
+}
+
+// FireLifecycleEvent gathers details from a running container and fires the hook
+func FireLifecycleEvent(event, containerName string) {
+	if lifecycleHook == nil {
+		return
+	}
+	FireHook(CaptureHookEvent(event, containerName))
+}
+
+// GetTaskDescription returns the first line of the task prompt sent to Claude
+// when the container was created, or an empty string if unavailable (as with
+// containers.private_prompt, which deletes the prompt file)
+func GetTaskDescription(containerName string) string {
+	cmd := system.DockerCommand("exec", containerName, "head", "-n", "1", "/tmp/prompt-input.txt")
+	output, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
@@ -0,0 +1,87 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ImageRevisionLabel is the image label carrying the revision of the maestro
+// image definition the image was built from
+const ImageRevisionLabel = "com.uprock.maestro.image-revision"
+
+// ImageInfo describes a local Docker image
+type ImageInfo struct {
+	ID       string
+	Created  time.Time
+	Revision int // 0 (unknown) if the image has no valid revision label
+}
+
+// InspectImage returns the ID, creation time, and revision of a local image
+func InspectImage(image string) (*ImageInfo, error) {
+	output, err := commandOutput("docker", "image", "inspect", "--format",
+		"{{.Id}}|{{.Created}}|{{json .Config.Labels}}", image)
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect image %s: %w", image, err)
+	}
+	return parseImageInspect(string(output))
+}
+
+// parseImageInspect parses the "id|created|labels-json" output of InspectImage
+func parseImageInspect(output string) (*ImageInfo, error) {
+	parts := strings.SplitN(strings.TrimSpace(output), "|", 3)
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("unexpected image inspect output: %q", output)
+	}
+
+	info := &ImageInfo{ID: parts[0]}
+	info.Created, _ = time.Parse(time.RFC3339Nano, parts[1])
+
+	var labels map[string]string
+	if err := json.Unmarshal([]byte(parts[2]), &labels); err == nil {
+		info.Revision, _ = strconv.Atoi(labels[ImageRevisionLabel])
+	}
+	return info, nil
+}
+
+// ContainerImage is the image a container was created from
+type ContainerImage struct {
+	Ref string // Image name as given to docker run
+	ID  string
+}
+
+// ContainerImages returns the image each container was created from, keyed by
+// container name. Containers that can't be inspected are omitted.
+func ContainerImages(containerNames []string) map[string]ContainerImage {
+	images := make(map[string]ContainerImage)
+	if len(containerNames) == 0 {
+		return images
+	}
+
+	// docker inspect still prints the containers it found if some are missing
+	args := append([]string{"inspect", "--format", "{{.Name}} {{.Config.Image}} {{.Image}}"}, containerNames...)
+	output, _ := commandOutput("docker", args...)
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 3 {
+			images[strings.TrimPrefix(fields[0], "/")] = ContainerImage{Ref: fields[1], ID: fields[2]}
+		}
+	}
+	return images
+}
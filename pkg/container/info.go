@@ -16,15 +16,247 @@ package container
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/uprockcom/maestro/pkg/paths"
+	"github.com/uprockcom/maestro/pkg/system"
+)
+
+// commandOutput runs a command and returns its standard output. "docker"
+// runs the configured docker CLI (system.DockerBinary). It is a variable so
+// tests can fake docker/tmux responses.
+var commandOutput = func(name string, args ...string) ([]byte, error) {
+	return exec.Command(resolveCommand(name), args...).Output()
+}
+
+// commandCombinedOutput is like commandOutput but also captures stderr
+var commandCombinedOutput = func(name string, args ...string) ([]byte, error) {
+	return exec.Command(resolveCommand(name), args...).CombinedOutput()
+}
+
+// resolveCommand maps "docker" to the configured docker CLI
+func resolveCommand(name string) string {
+	if name == "docker" {
+		return system.DockerBinary()
+	}
+	return name
+}
+
+// probeConcurrency bounds how many containers are probed at once when
+// gathering details. Each running container fans out into several docker
+// exec calls, so probing a large fleet all at once can overwhelm the daemon.
+var probeConcurrency = 8
+
+// gitEnabled controls whether branch and git status are gathered from /workspace
+var gitEnabled = true
+
+// NoGit is shown in place of the branch and git status when git features are disabled
+const NoGit = "—"
+
+// SetGitEnabled turns branch and git status gathering on or off
+func SetGitEnabled(enabled bool) {
+	gitEnabled = enabled
+}
+
+// ConnectCommandLabel is the container label holding a custom connect command
+const ConnectCommandLabel = "maestro.connect-cmd"
+
+// LaunchCommandLabel is the container label holding the command Claude was
+// started with in the tmux session, recorded at create time
+const LaunchCommandLabel = "maestro.launch-cmd"
+
+// ShortNameLabel is the container label holding the short name the container
+// was created with, so it can be found even after containers.prefix changes
+const ShortNameLabel = "maestro.short-name"
+
+// defaultConnectCommand is used for containers without a ConnectCommandLabel
+var defaultConnectCommand string
+
+// SetDefaultConnectCommand sets the connect command used when a container has no override.
+// Empty means attach to the tmux session.
+func SetDefaultConnectCommand(command string) {
+	defaultConnectCommand = command
+}
+
+// GetConnectCommand returns the custom shell command to run on connect,
+// or "" to attach to the tmux session.
+func GetConnectCommand(containerName string) string {
+	cmd := system.DockerCommand("inspect", "-f",
+		fmt.Sprintf("{{index .Config.Labels %q}}", ConnectCommandLabel), containerName)
+	if output, err := cmd.Output(); err == nil {
+		if command := strings.TrimSpace(string(output)); command != "" {
+			return command
+		}
+	}
+	return defaultConnectCommand
+}
+
+// ConnectArgs returns the docker arguments used to connect to a container
+// with the given connect command (from GetConnectCommand)
+func ConnectArgs(containerName, command string) []string {
+	if command != "" {
+		return []string{"exec", "-it", containerName, "sh", "-c", command}
+	}
+	args, _ := TmuxAttachArgs(containerName, "main", nil)
+	return args
+}
+
+// TmuxAttachArgs returns the docker arguments to attach to a tmux session,
+// passing through extra tmux options. Socket options (-L, -S) are placed
+// before the attach command; -d, -E, -r, -x, -c and -f are passed to attach.
+// Anything else is rejected so a typo can't turn into a confusing tmux error.
+func TmuxAttachArgs(containerName, session string, extra []string) ([]string, error) {
+	if session == "" {
+		session = "main"
+	}
+
+	var global, attach []string
+	for i := 0; i < len(extra); i++ {
+		arg := extra[i]
+		switch arg {
+		case "-L", "-S", "-c", "-f":
+			if i+1 >= len(extra) {
+				return nil, fmt.Errorf("tmux option %s needs a value", arg)
+			}
+			if arg == "-L" || arg == "-S" {
+				global = append(global, arg, extra[i+1])
+			} else {
+				attach = append(attach, arg, extra[i+1])
+			}
+			i++
+		case "-d", "-E", "-r", "-x":
+			attach = append(attach, arg)
+		case "-t":
+			return nil, fmt.Errorf("use --session instead of passing -t to tmux")
+		default:
+			return nil, fmt.Errorf("unsupported tmux option %q (supported: -d, -E, -r, -x, -c <dir>, -f <flags>, -L <name>, -S <path>)", arg)
+		}
+	}
+
+	args := []string{"exec", "-it", containerName, "tmux"}
+	args = append(args, global...)
+	args = append(args, "attach", "-t", session)
+	return append(args, attach...), nil
+}
+
+// ShellArgs returns the docker arguments for an interactive login shell,
+// used when a container has no tmux to attach to
+func ShellArgs(containerName string) []string {
+	return []string{"exec", "-it", containerName, "sh", "-l"}
+}
+
+// HasTmux reports whether tmux is installed in a container. Containers built
+// from custom images may not include it.
+func HasTmux(containerName string) bool {
+	return system.DockerCommand("exec", containerName, "sh", "-c", "command -v tmux").Run() == nil
+}
+
+// TmuxState describes a tmux session of a running container
+type TmuxState int
+
+const (
+	TmuxSessionRunning TmuxState = iota
+	TmuxNoSession                // The server is up but doesn't have the session
+	TmuxNoServer                 // No tmux server is running at all
+	TmuxStateUnknown             // The check itself failed
 )
 
+// GetTmuxState reports whether a tmux session, and the server behind it, are
+// running in a container
+func GetTmuxState(containerName, session string) TmuxState {
+	output, err := commandCombinedOutput("docker", "exec", containerName, "tmux", "has-session", "-t", session)
+	return parseTmuxState(string(output), err)
+}
+
+// parseTmuxState interprets the result of `tmux has-session`
+func parseTmuxState(output string, err error) TmuxState {
+	switch {
+	case err == nil:
+		return TmuxSessionRunning
+	case strings.Contains(output, "no server running"),
+		// tmux 3.x reports a missing socket this way instead
+		strings.Contains(output, "error connecting to"):
+		return TmuxNoServer
+	case strings.Contains(output, "can't find session"):
+		return TmuxNoSession
+	}
+	return TmuxStateUnknown
+}
+
+// TmuxWindow is a window of a tmux session in a container
+type TmuxWindow struct {
+	Session string
+	Index   int
+	Name    string
+	Path    string // Working directory of the window's active pane, if known
+}
+
+// ListTmuxWindows returns the windows of every tmux session in a running
+// container, or nil if no tmux server is running
+func ListTmuxWindows(containerName string) ([]TmuxWindow, error) {
+	output, err := commandCombinedOutput("docker", "exec", containerName,
+		"tmux", "list-windows", "-a", "-F", "#{session_name}\t#{window_index}\t#{window_name}\t#{pane_current_path}")
+	if err != nil {
+		if parseTmuxState(string(output), err) == TmuxNoServer {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to list tmux windows: %w", err)
+	}
+	return parseTmuxWindows(string(output)), nil
+}
+
+// parseTmuxWindows parses `tmux list-windows -a` output in ListTmuxWindows' format
+func parseTmuxWindows(output string) []TmuxWindow {
+	var windows []TmuxWindow
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		fields := strings.SplitN(line, "\t", 4)
+		if len(fields) < 3 {
+			continue
+		}
+		index, err := strconv.Atoi(fields[1])
+		if err != nil {
+			continue
+		}
+		window := TmuxWindow{Session: fields[0], Index: index, Name: fields[2]}
+		if len(fields) == 4 {
+			window.Path = fields[3]
+		}
+		windows = append(windows, window)
+	}
+	return windows
+}
+
+// recentLogsLimit caps the bytes of recent logs kept in ContainerDetails
+var recentLogsLimit = 16 << 10
+
+// SetRecentLogsLimit sets the maximum size of ContainerDetails.RecentLogs in
+// bytes. Values below 1 are ignored.
+func SetRecentLogsLimit(n int) {
+	if n > 0 {
+		recentLogsLimit = n
+	}
+}
+
+// SetProbeConcurrency sets the maximum number of containers probed in parallel.
+// Values below 1 are ignored.
+func SetProbeConcurrency(n int) {
+	if n > 0 {
+		probeConcurrency = n
+	}
+}
+
 // ReadCredentials loads and parses credentials from a file path
 func ReadCredentials(path string) (*Credentials, error) {
 	data, err := os.ReadFile(path)
@@ -33,11 +265,80 @@ func ReadCredentials(path string) (*Credentials, error) {
 	}
 	var creds Credentials
 	if err := json.Unmarshal(data, &creds); err != nil {
-		return nil, err
+		return nil, &CredentialsError{Path: path, Reason: "credentials file appears corrupt or not JSON", Err: err}
 	}
 	return &creds, nil
 }
 
+// containerClaudeDir is Claude's config directory inside containers
+const containerClaudeDir = "/home/node/.claude"
+
+// CredentialsPath returns where credentials are written inside containers
+func CredentialsPath() string {
+	return containerClaudeDir + "/" + paths.CredentialsFile()
+}
+
+// CopyCredentialsFromContainer copies a container's credentials file to dest,
+// trying each known filename. If none can be copied it returns docker's
+// output for the first attempt that failed for a reason other than a missing
+// file, or for the configured filename if they were all missing.
+func CopyCredentialsFromContainer(containerName, dest string) ([]byte, error) {
+	var firstOutput []byte
+	var firstErr error
+	for _, name := range paths.CredentialsFileCandidates() {
+		output, err := commandCombinedOutput("docker", "cp",
+			fmt.Sprintf("%s:%s/%s", containerName, containerClaudeDir, name), dest)
+		if err == nil {
+			return nil, nil
+		}
+		if !IsMissingPathError(string(output)) {
+			return output, err
+		}
+		if firstErr == nil {
+			firstOutput, firstErr = output, err
+		}
+	}
+	return firstOutput, firstErr
+}
+
+// CredentialsError explains why a credentials file can't be used
+type CredentialsError struct {
+	Path   string // Empty when the credentials didn't come from a file
+	Reason string
+	Err    error // Underlying parse error, if any
+}
+
+func (e *CredentialsError) Error() string {
+	msg := e.Reason
+	if e.Path != "" {
+		msg = e.Path + ": " + msg
+	}
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+func (e *CredentialsError) Unwrap() error {
+	return e.Err
+}
+
+// ValidateCredentials checks that parsed credentials have the fields needed
+// to authenticate. Valid JSON of the wrong shape parses without error into
+// zero values, so this is what catches it.
+func ValidateCredentials(creds *Credentials) error {
+	oauth := creds.ClaudeAiOauth
+	switch {
+	case oauth.AccessToken == "" && oauth.RefreshToken == "" && oauth.ExpiresAt == 0:
+		return &CredentialsError{Reason: "missing claudeAiOauth block"}
+	case oauth.AccessToken == "":
+		return &CredentialsError{Reason: "missing accessToken"}
+	case oauth.ExpiresAt == 0:
+		return &CredentialsError{Reason: "missing expiresAt"}
+	}
+	return nil
+}
+
 // IsTokenExpired checks if token is expired (true) or valid (false)
 func IsTokenExpired(creds *Credentials) bool {
 	currentTimeMs := time.Now().UnixMilli()
@@ -52,7 +353,7 @@ func TimeUntilExpiration(creds *Credentials) time.Duration {
 
 // IsDockerResponsive checks if Docker daemon is responding
 func IsDockerResponsive() bool {
-	cmd := exec.Command("docker", "info")
+	cmd := system.DockerCommand("info")
 	err := cmd.Run()
 	return err == nil
 }
@@ -69,151 +370,359 @@ func FormatExpiration(creds *Credentials) string {
 	return fmt.Sprintf("Valid for %.1fd", duration.Hours()/24)
 }
 
-// GetShortName removes the prefix from a container name
+// GetShortName removes the prefix from a container name. Names that don't
+// start with the prefix, or are nothing but the prefix, are returned whole
+// (without the leading "/" docker inspect puts on names).
 func GetShortName(containerName, prefix string) string {
-	if strings.HasPrefix(containerName, prefix) {
-		return containerName[len(prefix):]
+	containerName = strings.TrimPrefix(containerName, "/")
+	if short, ok := strings.CutPrefix(containerName, prefix); ok && short != "" && prefix != "" {
+		return short
 	}
 	return containerName
 }
 
+// GetShortNameLabel returns the short name a container was created with
+// (its ShortNameLabel), or "" for containers from before the label existed
+func GetShortNameLabel(containerName string) string {
+	output, err := commandOutput("docker", "inspect", "-f",
+		fmt.Sprintf("{{index .Config.Labels %q}}", ShortNameLabel), containerName)
+	if err != nil {
+		return ""
+	}
+	label := strings.TrimSpace(string(output))
+	if label == "<no value>" {
+		return ""
+	}
+	return label
+}
+
 // GetBranchName retrieves the current git branch from a container
 func GetBranchName(containerName string) string {
-	cmd := exec.Command("docker", "exec", containerName, "git", "-C", "/workspace", "branch", "--show-current")
-	output, err := cmd.Output()
+	if !gitEnabled {
+		return NoGit
+	}
+	output, err := commandOutput("docker", "exec", containerName, "git", "-C", "/workspace", "branch", "--show-current")
 	if err != nil {
 		return "unknown"
 	}
 	return strings.TrimSpace(string(output))
 }
 
-// CheckBellStatus checks if a container needs attention (bell or silence flags)
+// CheckBellStatus checks if a container needs attention (bell or silence
+// flags on any window of any tmux session)
 func CheckBellStatus(containerName string) bool {
-	cmd := exec.Command("docker", "exec", containerName,
-		"tmux", "list-windows", "-t", "main", "-F", "#{window_bell_flag}:#{window_silence_flag}")
-	output, err := cmd.Output()
+	output, err := commandOutput("docker", "exec", containerName,
+		"tmux", "list-windows", "-a", "-F", "#{window_bell_flag}:#{window_silence_flag}")
 	if err != nil {
 		return false
 	}
+	return parseBellFlags(string(output))
+}
 
-	// If any window has bell_flag=1 OR silence_flag=1, container needs attention
-	for _, line := range strings.Split(string(output), "\n") {
-		parts := strings.Split(strings.TrimSpace(line), ":")
-		if len(parts) == 2 {
-			bellFlag := parts[0]
-			silenceFlag := parts[1]
-			if bellFlag == "1" || silenceFlag == "1" {
-				return true
-			}
+// parseBellFlags reports whether any "bell:silence" line from tmux list-windows
+// has a flag set. Malformed lines are ignored.
+func parseBellFlags(output string) bool {
+	for _, line := range strings.Split(output, "\n") {
+		bellFlag, silenceFlag, ok := strings.Cut(strings.TrimSpace(line), ":")
+		if !ok || strings.Contains(silenceFlag, ":") {
+			continue
+		}
+		if bellFlag == "1" || silenceFlag == "1" {
+			return true
 		}
 	}
-
 	return false
 }
 
-// IsClaudeRunning checks if Claude process is running in a container
-// Excludes zombie/defunct processes
+// IsClaudeRunning checks if the interactive Claude session is running in a
+// container. Transient "claude --print" invocations and zombie/defunct
+// processes don't count.
 func IsClaudeRunning(containerName string) bool {
-	// Search for claude processes using [c]laude to avoid grep matching itself
-	// Then filter out zombies (STAT column starts with 'Z')
-	// The regex matches 7 columns followed by 'Z' at the start of the STAT column
-	cmd := exec.Command("docker", "exec", containerName,
-		"sh", "-c", "ps aux | grep -E '[c]laude' | grep -v -E '^\\S+\\s+\\S+\\s+\\S+\\s+\\S+\\s+\\S+\\s+\\S+\\s+\\S+\\s+Z'")
-	output, err := cmd.Output()
+	output, err := commandOutput("docker", "exec", containerName, "ps", "-eo", "stat,args")
 	if err != nil {
 		return false
 	}
+	return parseClaudeRunning(string(output))
+}
 
-	// If we got any output, claude is running (and not a zombie)
-	result := strings.TrimSpace(string(output))
-	return result != ""
+// parseClaudeRunning reports whether "ps -eo stat,args" output includes a
+// live interactive Claude process
+func parseClaudeRunning(output string) bool {
+	for _, line := range strings.Split(output, "\n") {
+		stat, args, ok := strings.Cut(strings.TrimSpace(line), " ")
+		if !ok || strings.HasPrefix(stat, "Z") {
+			continue
+		}
+		if isInteractiveClaude(args) {
+			return true
+		}
+	}
+	return false
+}
+
+// isInteractiveClaude reports whether a process command line is the
+// interactive Claude session launched in the tmux window. The tmux server and
+// the shell wrapping claude also mention it in their args, so only the claude
+// executable itself (possibly run via node) matches.
+func isInteractiveClaude(args string) bool {
+	fields := strings.Fields(args)
+	if len(fields) == 0 {
+		return false
+	}
+
+	switch filepath.Base(fields[0]) {
+	case "claude":
+	case "node":
+		if len(fields) < 2 || !strings.Contains(fields[1], "claude") {
+			return false
+		}
+		fields = fields[1:]
+	default:
+		return false
+	}
+
+	// Headless one-shot runs aren't the session
+	for _, f := range fields[1:] {
+		if f == "--print" || f == "-p" {
+			return false
+		}
+	}
+	return true
 }
 
 // GetAuthStatus retrieves the authentication status for a container
 func GetAuthStatus(containerName string) string {
-	// Extract credentials from container to temp file
-	tmpFile := fmt.Sprintf("/tmp/maestro-creds-%s.json", containerName)
+	status, _ := CheckAuthStatus(containerName)
+	return status
+}
+
+// CheckAuthStatus is GetAuthStatus plus, for "✗ INVALID", the reason the
+// credentials were rejected
+func CheckAuthStatus(containerName string) (string, error) {
+	// Extract credentials from container to a temp file of our own, since
+	// other maestro processes may be checking the same container
+	tmp, err := os.CreateTemp("", "maestro-creds-*.json")
+	if err != nil {
+		return "? UNKNOWN", nil
+	}
+	tmp.Close()
+	tmpFile := tmp.Name()
 	defer os.Remove(tmpFile)
 
-	copyCmd := exec.Command("docker", "cp",
-		fmt.Sprintf("%s:/home/node/.claude/.credentials.json", containerName),
-		tmpFile)
-	if err := copyCmd.Run(); err != nil {
-		return "✗ NO AUTH"
+	output, err := CopyCredentialsFromContainer(containerName, tmpFile)
+	if err != nil {
+		// Only a missing file means no auth; anything else (container
+		// restarting, daemon hiccup) leaves the status unknown
+		if IsMissingPathError(string(output)) {
+			return "✗ NO AUTH", nil
+		}
+		return "? UNKNOWN", nil
 	}
 
 	creds, err := ReadCredentials(tmpFile)
 	if err != nil {
-		return "✗ INVALID"
+		// The temp path means nothing to the user; report the reason alone
+		var credErr *CredentialsError
+		if errors.As(err, &credErr) {
+			credErr.Path = ""
+		}
+		return "✗ INVALID", err
+	}
+	if err := ValidateCredentials(creds); err != nil {
+		return "✗ INVALID", err
 	}
 
 	if IsTokenExpired(creds) {
-		return "✗ EXPIRED"
+		return "✗ EXPIRED", nil
 	}
 
 	duration := TimeUntilExpiration(creds)
 	if duration < 24*time.Hour {
-		return fmt.Sprintf("⚠ %.1fh", duration.Hours())
+		return fmt.Sprintf("⚠ %.1fh", duration.Hours()), nil
 	}
 
-	return fmt.Sprintf("✓ %.1fh", duration.Hours())
+	return fmt.Sprintf("✓ %.1fh", duration.Hours()), nil
 }
 
-// GetRunningContainers returns a list of all running containers with the given prefix
-func GetRunningContainers(prefix string) ([]Info, error) {
-	dockerCmd := exec.Command("docker", "ps", "--format",
-		"{{.Names}}\t{{.Status}}\t{{.State}}\t{{.CreatedAt}}")
-	output, err := dockerCmd.Output()
-	if err != nil {
-		return nil, err
-	}
+// IsMissingPathError reports whether docker cp output, or the stderr of a
+// command run in a container, indicates that a path doesn't exist
+func IsMissingPathError(output string) bool {
+	return strings.Contains(output, "Could not find the file") ||
+		strings.Contains(output, "No such container:path") ||
+		strings.Contains(output, "No such file or directory")
+}
 
-	// Parse basic container info first
-	type basicInfo struct {
-		name      string
-		status    string
-		state     string
-		createdAt time.Time
+// psFormat is the docker ps --format template parsed by parsePsOutput
+const psFormat = "{{.Names}}\t{{.Status}}\t{{.State}}\t{{.CreatedAt}}"
+
+// dockerPs runs docker ps with psFormat. Docker versions that can't render
+// the State field get it left empty, and parsePsOutput derives it from Status.
+func dockerPs(args ...string) ([]byte, error) {
+	output, err := commandOutput("docker", append(append([]string{"ps"}, args...), "--format", psFormat)...)
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && strings.Contains(string(exitErr.Stderr), "State") {
+		noState := strings.Replace(psFormat, "{{.State}}", "", 1)
+		return commandOutput("docker", append(append([]string{"ps"}, args...), "--format", noState)...)
 	}
-	var basics []basicInfo
+	return output, err
+}
 
-	for _, line := range strings.Split(string(output), "\n") {
+// psEntry is one container from docker ps
+type psEntry struct {
+	name      string
+	status    string // Human-readable, e.g. "Up 2 hours (Paused)"
+	state     string // Normalized docker state, e.g. "running"
+	createdAt time.Time
+}
+
+// psCreatedLayouts are the CreatedAt formats docker versions have printed
+var psCreatedLayouts = []string{
+	"2006-01-02 15:04:05 -0700 MST",
+	"2006-01-02 15:04:05 -0700",
+	time.RFC3339Nano,
+}
+
+// parsePsOutput parses docker ps output in psFormat, keeping containers whose
+// name starts with prefix. It tolerates stray whitespace, CRLF line endings,
+// missing CreatedAt, and an empty or unsupported State column (the state is
+// then derived from Status). Lines it can't make sense of are logged and skipped.
+func parsePsOutput(output, prefix string) []psEntry {
+	var entries []psEntry
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
 		if line == "" {
 			continue
 		}
 
 		parts := strings.Split(line, "\t")
-		if len(parts) < 4 {
+		for i := range parts {
+			parts[i] = strings.TrimSpace(parts[i])
+		}
+		if len(parts) < 2 || parts[0] == "" {
+			log.Printf("Skipping malformed docker ps line: %q", line)
 			continue
 		}
 
-		name := parts[0]
-		if !strings.HasPrefix(name, prefix) {
+		// A container can have several comma-separated names
+		name := ""
+		for _, n := range strings.Split(parts[0], ",") {
+			if strings.HasPrefix(n, prefix) {
+				name = n
+				break
+			}
+		}
+		if name == "" {
 			continue
 		}
 
-		// Parse creation time
-		createdAt, err := time.Parse("2006-01-02 15:04:05 -0700 MST", parts[3])
-		if err != nil {
-			createdAt = time.Time{}
+		entry := psEntry{name: name, status: parts[1]}
+		if len(parts) > 2 {
+			entry.state = normalizeState(parts[2])
+		}
+		if entry.state == "" {
+			entry.state = stateFromStatus(entry.status)
+		}
+		if entry.state == "" {
+			log.Printf("Skipping docker ps line with unknown state: %q", line)
+			continue
 		}
+		if len(parts) > 3 {
+			for _, layout := range psCreatedLayouts {
+				if createdAt, err := time.Parse(layout, parts[3]); err == nil {
+					entry.createdAt = createdAt
+					break
+				}
+			}
+		}
+
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// podmanStates maps podman container states to their docker equivalents
+var podmanStates = map[string]string{
+	"stopped":     "exited",
+	"configured":  "created",
+	"initialized": "created",
+	"stopping":    "running",
+}
+
+// normalizeState lowercases a docker state, maps podman's extra states to
+// docker's, and maps template placeholders for fields the docker version
+// doesn't know to "". Older podman prints the human status ("Up 5 minutes
+// ago") as the state; that is also mapped to "" so it's derived from Status.
+func normalizeState(state string) string {
+	state = strings.ToLower(strings.TrimSpace(state))
+	if state == "<no value>" || strings.Contains(state, " ") {
+		return ""
+	}
+	if docker, ok := podmanStates[state]; ok {
+		return docker
+	}
+	return state
+}
+
+// stateFromStatus derives the docker state from a ps Status string such as
+// "Up 2 hours (Paused)" or "Exited (0) 3 days ago", for docker versions
+// without a State column
+func stateFromStatus(status string) string {
+	lower := strings.ToLower(status)
+	switch {
+	case strings.HasPrefix(lower, "up") && strings.Contains(lower, "(paused)"):
+		return "paused"
+	case strings.HasPrefix(lower, "up"):
+		return "running"
+	case strings.HasPrefix(lower, "restarting"):
+		return "restarting"
+	case strings.HasPrefix(lower, "exited"):
+		return "exited"
+	case strings.HasPrefix(lower, "created"):
+		return "created"
+	case strings.HasPrefix(lower, "removal in progress"):
+		return "removing"
+	case strings.HasPrefix(lower, "dead"):
+		return "dead"
+	default:
+		return ""
+	}
+}
+
+// GetRunningContainersWithLegacy is GetRunningContainers, plus the running
+// containers under LegacyPrefix when ScansLegacyPrefix(prefix)
+func GetRunningContainersWithLegacy(prefix string) ([]Info, error) {
+	containers, err := GetRunningContainers(prefix)
+	if err != nil {
+		return nil, err
+	}
+	if ScansLegacyPrefix(prefix) {
+		legacyContainers, _ := GetRunningContainers(LegacyPrefix)
+		containers = append(containers, legacyContainers...)
+	}
+	return containers, nil
+}
 
-		basics = append(basics, basicInfo{
-			name:      name,
-			status:    parts[1],
-			state:     parts[2],
-			createdAt: createdAt,
-		})
+// GetRunningContainers returns a list of all running containers with the given prefix
+func GetRunningContainers(prefix string) ([]Info, error) {
+	output, err := dockerPs()
+	if err != nil {
+		return nil, err
 	}
 
+	// Parse basic container info first
+	basics := parsePsOutput(string(output), prefix)
+
 	// Fetch detailed info for all containers in parallel
 	containers := make([]Info, len(basics))
 	var wg sync.WaitGroup
+	sem := make(chan struct{}, probeConcurrency)
 
 	for i, b := range basics {
 		wg.Add(1)
-		go func(idx int, basic basicInfo) {
+		go func(idx int, basic psEntry) {
 			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
 
 			info := Info{
 				Name:          basic.name,
@@ -223,6 +732,12 @@ func GetRunningContainers(prefix string) ([]Info, error) {
 				CreatedAt:     basic.createdAt,
 			}
 
+			// Paused containers can't be exec'd into; they are frozen, not dormant
+			if basic.state == "paused" {
+				containers[idx] = info
+				return
+			}
+
 			// Fetch details in parallel
 			var detailWg sync.WaitGroup
 			var mu sync.Mutex
@@ -263,64 +778,85 @@ func GetRunningContainers(prefix string) ([]Info, error) {
 	}
 
 	wg.Wait()
+	sortByCreation(containers)
 	return containers, nil
 }
 
 // GetAllContainers returns a list of all containers (including stopped) with the given prefix
 func GetAllContainers(prefix string) ([]Info, error) {
-	dockerCmd := exec.Command("docker", "ps", "-a", "--format",
-		"{{.Names}}\t{{.Status}}\t{{.State}}\t{{.CreatedAt}}")
-	output, err := dockerCmd.Output()
+	return GatherAllContainers(prefix, GatherOptions{})
+}
+
+// GatherOptions lets GatherAllContainers callers follow its progress
+type GatherOptions struct {
+	// Progress, if set, is called once the container count is known and
+	// again as each container's details are gathered. Calls are serialized.
+	Progress func(done, total int)
+
+	// Timing, if set, is filled in with how long each phase took
+	Timing *GatherTiming
+
+	// Limits also fetches each running container's CPU and memory limits,
+	// which costs a docker inspect the first time a container is seen
+	Limits bool
+}
+
+// GatherTiming records where the time in GatherAllContainers went
+type GatherTiming struct {
+	List          time.Duration // docker ps
+	Gather        time.Duration // All containers' details, in parallel
+	Slowest       string        // Container whose details took longest
+	SlowestGather time.Duration
+}
+
+// GatherAllContainers is GetAllContainers with progress and timing reporting
+func GatherAllContainers(prefix string, opts GatherOptions) ([]Info, error) {
+	timing := opts.Timing
+	if timing == nil {
+		timing = &GatherTiming{}
+	}
+
+	start := time.Now()
+	output, err := dockerPs("-a")
+	timing.List = time.Since(start)
 	if err != nil {
 		return nil, err
 	}
 
 	// Parse basic container info first
-	type basicInfo struct {
-		name      string
-		status    string
-		state     string
-		createdAt time.Time
-	}
-	var basics []basicInfo
-
-	for _, line := range strings.Split(string(output), "\n") {
-		if line == "" {
-			continue
-		}
-
-		parts := strings.Split(line, "\t")
-		if len(parts) < 4 {
-			continue
+	basics := parsePsOutput(string(output), prefix)
+
+	var mu sync.Mutex
+	done := 0
+	finished := func(name string, took time.Duration) {
+		mu.Lock()
+		defer mu.Unlock()
+		if took > timing.SlowestGather {
+			timing.Slowest, timing.SlowestGather = name, took
 		}
-
-		name := parts[0]
-		if !strings.HasPrefix(name, prefix) {
-			continue
-		}
-
-		// Parse creation time
-		createdAt, err := time.Parse("2006-01-02 15:04:05 -0700 MST", parts[3])
-		if err != nil {
-			createdAt = time.Time{}
+		done++
+		if opts.Progress != nil {
+			opts.Progress(done, len(basics))
 		}
-
-		basics = append(basics, basicInfo{
-			name:      name,
-			status:    parts[1],
-			state:     parts[2],
-			createdAt: createdAt,
-		})
 	}
+	if opts.Progress != nil {
+		opts.Progress(0, len(basics))
+	}
+	gatherStart := time.Now()
+	defer func() { timing.Gather = time.Since(gatherStart) }()
 
 	// Fetch detailed info for all containers in parallel
 	containers := make([]Info, len(basics))
 	var wg sync.WaitGroup
+	sem := make(chan struct{}, probeConcurrency)
 
 	for i, b := range basics {
 		wg.Add(1)
-		go func(idx int, basic basicInfo) {
+		go func(idx int, basic psEntry) {
 			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			start := time.Now()
 
 			info := Info{
 				Name:          basic.name,
@@ -332,100 +868,136 @@ func GetAllContainers(prefix string) ([]Info, error) {
 				GitStatus:     "-",
 			}
 
-			// For running containers, fetch detailed info in parallel
+			// For running containers, fetch detailed info in parallel: one exec
+			// for everything inside the container, plus the credentials copy
 			if basic.state == "running" {
 				var detailWg sync.WaitGroup
-				var mu sync.Mutex
-
-				// Branch name
-				detailWg.Add(1)
-				go func() {
-					defer detailWg.Done()
-					branch := GetBranchName(basic.name)
-					mu.Lock()
-					info.Branch = branch
-					mu.Unlock()
-				}()
 
-				// Bell status
 				detailWg.Add(1)
 				go func() {
 					defer detailWg.Done()
-					needsAttention := CheckBellStatus(basic.name)
-					mu.Lock()
-					info.NeedsAttention = needsAttention
-					mu.Unlock()
+					info.AuthStatus = GetAuthStatus(basic.name)
 				}()
 
-				// Claude running check
-				detailWg.Add(1)
-				go func() {
-					defer detailWg.Done()
-					isDormant := !IsClaudeRunning(basic.name)
-					mu.Lock()
-					info.IsDormant = isDormant
-					mu.Unlock()
-				}()
-
-				// Auth status
-				detailWg.Add(1)
-				go func() {
-					defer detailWg.Done()
-					authStatus := GetAuthStatus(basic.name)
-					mu.Lock()
-					info.AuthStatus = authStatus
-					mu.Unlock()
-				}()
-
-				// Last activity
-				detailWg.Add(1)
-				go func() {
-					defer detailWg.Done()
-					lastActivity := GetLastActivity(basic.name)
-					mu.Lock()
-					info.LastActivity = lastActivity
-					mu.Unlock()
-				}()
+				if opts.Limits {
+					detailWg.Add(1)
+					go func() {
+						defer detailWg.Done()
+						if limits, err := GetResourceLimits(basic.name, basic.createdAt); err == nil {
+							info.Limits = &limits
+						}
+					}()
+				}
 
-				// Git status
-				detailWg.Add(1)
-				go func() {
-					defer detailWg.Done()
-					gitStatus := GetGitStatus(basic.name)
-					mu.Lock()
-					info.GitStatus = gitStatus
-					mu.Unlock()
-				}()
+				bundle := GetContainerStatusBundle(basic.name)
+				info.Branch = bundle.Branch
+				info.NeedsAttention = bundle.NeedsAttention
+				info.IsDormant = bundle.IsDormant
+				info.LastActivity = bundle.LastActivity
+				info.GitStatus = bundle.GitStatus
+				info.FirewallOff = bundle.FirewallOff
 
 				detailWg.Wait()
 			} else {
-				// For stopped containers, just get branch name
-				info.Branch = GetBranchName(basic.name)
+				// docker exec fails on anything that isn't running (exited,
+				// paused, or created but never started), so don't probe
+				info.Branch = "-"
 			}
 
 			containers[idx] = info
+			finished(basic.name, time.Since(start))
 		}(i, b)
 	}
 
 	wg.Wait()
+	sortByCreation(containers)
 	return containers, nil
 }
 
-// GetLastActivity gets the last activity time for a container
+// formatPortBindings renders docker inspect's NetworkSettings.Ports as sorted
+// "hostPort -> containerPort/proto" lines. Exposed ports without a host mapping
+// (null bindings) are skipped, and identical bindings (e.g. the IPv4 and IPv6
+// listeners docker creates for one port) are listed once.
+func formatPortBindings(ports map[string]interface{}) []string {
+	seen := make(map[string]bool)
+	var result []string
+	for key, bindings := range ports {
+		containerPort, proto, ok := strings.Cut(key, "/")
+		if !ok || proto == "" {
+			proto = "tcp"
+		}
+
+		bindingsList, ok := bindings.([]interface{})
+		if !ok {
+			continue
+		}
+		for _, binding := range bindingsList {
+			b, ok := binding.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			hostPort, ok := b["HostPort"].(string)
+			if !ok || hostPort == "" {
+				continue
+			}
+
+			line := fmt.Sprintf("%s -> %s/%s", hostPort, containerPort, proto)
+			if !seen[line] {
+				seen[line] = true
+				result = append(result, line)
+			}
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// GetLastActivity gets the last activity time for a container: the most
+// recent activity of any pane in any tmux session
 func GetLastActivity(containerName string) string {
 	// Check docker container stats for last activity via process CPU usage
 	// For now, we'll use a simpler approach: check tmux pane activity
-	cmd := exec.Command("docker", "exec", containerName,
-		"tmux", "display-message", "-t", "main:0", "-p", "#{pane_active_since}")
-	output, err := cmd.Output()
+	output, err := commandOutput("docker", "exec", containerName,
+		"tmux", "list-panes", "-a", "-F", "#{pane_active_since}")
 	if err != nil {
 		return "-"
 	}
+	return parseLastActivity(string(output))
+}
 
-	// Parse Unix timestamp
-	timestampStr := strings.TrimSpace(string(output))
-	timestamp, err := strconv.ParseInt(timestampStr, 10, 64)
+// GetLaunchCommand returns the command the Claude tmux window (main:0) was
+// started with, as reported by tmux, or "" if it can't be read
+func GetLaunchCommand(containerName string) string {
+	output, err := commandOutput("docker", "exec", containerName,
+		"tmux", "display-message", "-p", "-t", "main:0.0", "#{pane_start_command}")
 	if err != nil {
+		return ""
+	}
+	return parseLaunchCommand(string(output))
+}
+
+// parseLaunchCommand cleans up tmux's #{pane_start_command}, which is quoted
+// when the command was given as a single string
+func parseLaunchCommand(output string) string {
+	command := strings.TrimSpace(output)
+	if len(command) >= 2 && command[0] == '"' && command[len(command)-1] == '"' {
+		command = command[1 : len(command)-1]
+	}
+	return command
+}
+
+// parseLastActivity formats the latest of the tmux #{pane_active_since}
+// timestamps (one per line) as the time since then, or "-" if there are none
+func parseLastActivity(output string) string {
+	// Parse Unix timestamps, keeping the most recent
+	var timestamp int64
+	for _, line := range strings.Split(output, "\n") {
+		ts, err := strconv.ParseInt(strings.TrimSpace(line), 10, 64)
+		if err == nil && ts > timestamp {
+			timestamp = ts
+		}
+	}
+	if timestamp == 0 {
 		return "-"
 	}
 
@@ -435,6 +1007,15 @@ func GetLastActivity(containerName string) string {
 	return formatDuration(duration)
 }
 
+// FormatAge returns how long ago a container was created (e.g. "3.5d"),
+// or "" if the creation time is unknown
+func FormatAge(createdAt time.Time) string {
+	if createdAt.IsZero() {
+		return ""
+	}
+	return formatDuration(time.Since(createdAt))
+}
+
 // formatDuration formats a duration in human-readable form
 func formatDuration(d time.Duration) string {
 	if d < time.Minute {
@@ -452,65 +1033,133 @@ func formatDuration(d time.Duration) string {
 // GetGitStatus gets git status indicators for a container
 // Returns a fixed-width string for proper column alignment
 func GetGitStatus(containerName string) string {
-	// Check if git repo exists
-	checkCmd := exec.Command("docker", "exec", containerName, "test", "-d", "/workspace/.git")
-	if err := checkCmd.Run(); err != nil {
+	if !gitEnabled {
+		return padGitStatus(NoGit)
+	}
+
+	// One exec for the repo check and all three counts; run back to back they
+	// were the slowest part of probing a container
+	output, err := commandOutput("docker", "exec", containerName, "sh", "-c", gitCountsScript)
+	if err != nil {
 		return padGitStatus("-")
 	}
+	return padGitStatus(formatGitCounts(string(output)))
+}
 
-	var indicators []string
+// gitCountsScript prints "changes|ahead|behind" for /workspace, exiting
+// non-zero if it isn't a git repository. Ahead/behind are empty without an upstream.
+const gitCountsScript = `cd /workspace 2>/dev/null && test -d .git || exit 2
+echo "$(git status --porcelain 2>/dev/null | wc -l)|$(git rev-list --count @{u}..HEAD 2>/dev/null)|$(git rev-list --count HEAD..@{u} 2>/dev/null)"`
 
-	// Check for uncommitted changes
-	statusCmd := exec.Command("docker", "exec", containerName, "sh", "-c",
-		"cd /workspace && git status --porcelain 2>/dev/null | wc -l")
-	if output, err := statusCmd.Output(); err == nil {
-		count := strings.TrimSpace(string(output))
-		if count != "0" {
-			indicators = append(indicators, fmt.Sprintf("Δ%s", count))
+// formatGitCounts turns gitCountsScript output into indicators such as
+// "Δ3 ↑1", or "✓" for a clean tree
+func formatGitCounts(output string) string {
+	fields := strings.Split(strings.TrimSpace(output), "|")
+	var indicators []string
+	for i, symbol := range []string{"Δ", "↑", "↓"} {
+		if i < len(fields) {
+			if count := strings.TrimSpace(fields[i]); count != "0" && count != "" {
+				indicators = append(indicators, symbol+count)
+			}
 		}
 	}
 
-	// Check commits ahead of remote
-	aheadCmd := exec.Command("docker", "exec", containerName, "sh", "-c",
-		"cd /workspace && git rev-list --count @{u}..HEAD 2>/dev/null")
-	if output, err := aheadCmd.Output(); err == nil {
-		count := strings.TrimSpace(string(output))
-		if count != "0" && count != "" {
-			indicators = append(indicators, fmt.Sprintf("↑%s", count))
-		}
+	if len(indicators) == 0 {
+		return "✓"
 	}
+	return strings.Join(indicators, " ")
+}
 
-	// Check commits behind remote
-	behindCmd := exec.Command("docker", "exec", containerName, "sh", "-c",
-		"cd /workspace && git rev-list --count HEAD..@{u} 2>/dev/null")
-	if output, err := behindCmd.Output(); err == nil {
-		count := strings.TrimSpace(string(output))
-		if count != "0" && count != "" {
-			indicators = append(indicators, fmt.Sprintf("↓%s", count))
+// ParseGitStatus extracts the uncommitted change count and ahead/behind
+// commit counts from a status string produced by GetGitStatus
+func ParseGitStatus(status string) (changes, ahead, behind int) {
+	for _, field := range strings.Fields(status) {
+		switch {
+		case strings.HasPrefix(field, "Δ"):
+			changes, _ = strconv.Atoi(strings.TrimPrefix(field, "Δ"))
+		case strings.HasPrefix(field, "↑"):
+			ahead, _ = strconv.Atoi(strings.TrimPrefix(field, "↑"))
+		case strings.HasPrefix(field, "↓"):
+			behind, _ = strconv.Atoi(strings.TrimPrefix(field, "↓"))
 		}
 	}
+	return changes, ahead, behind
+}
 
-	if len(indicators) == 0 {
-		return padGitStatus("✓")
+// ReadContainerCredentials copies a container's Claude credentials to a temp file and parses them
+func ReadContainerCredentials(containerName string) (*Credentials, error) {
+	tmpFile, err := os.CreateTemp("", fmt.Sprintf("maestro-creds-%s-*.json", containerName))
+	if err != nil {
+		return nil, err
+	}
+	tmpFile.Close()
+	defer os.Remove(tmpFile.Name())
+
+	if _, err := CopyCredentialsFromContainer(containerName, tmpFile.Name()); err != nil {
+		return nil, fmt.Errorf("no credentials in container: %w", err)
 	}
 
-	return padGitStatus(strings.Join(indicators, " "))
+	return ReadCredentials(tmpFile.Name())
 }
 
-// padGitStatus pads git status to fixed width for alignment
+// padGitStatus pads git status to fixed width for alignment. Width is counted
+// in terminal cells, so multi-byte indicators (Δ, ↑, ↓) count once and wide
+// runes twice. Longer statuses are returned whole; ParseGitStatus needs every
+// indicator and table views truncate for display.
 func padGitStatus(status string) string {
-	// Pad to 10 characters for consistent column width
+	// Pad to 10 cells for consistent column width
 	const width = 10
-	if len(status) >= width {
+	n := ansi.StringWidth(status)
+	if n >= width {
 		return status
 	}
-	return status + strings.Repeat(" ", width-len(status))
+	return status + strings.Repeat(" ", width-n)
+}
+
+// GetGitFileStatus returns the per-file `git status --short` lines for a
+// container's workspace, or nil if git is disabled or the workspace is clean
+func GetGitFileStatus(containerName string) []string {
+	if !gitEnabled {
+		return nil
+	}
+
+	output, err := system.DockerCommand("exec", containerName, "sh", "-c",
+		"cd /workspace && git status --short 2>/dev/null").Output()
+	if err != nil {
+		return nil
+	}
+
+	var files []string
+	for _, line := range strings.Split(string(output), "\n") {
+		if strings.TrimSpace(line) != "" {
+			files = append(files, line)
+		}
+	}
+	return files
+}
+
+// GetResourceUsage returns live CPU and memory usage from a one-shot docker stats read.
+// Returns empty strings if stats are unavailable.
+func GetResourceUsage(containerName string) (cpu, memory string) {
+	statsCmd := system.DockerCommand("stats", "--no-stream", "--format",
+		"{{.CPUPerc}}\t{{.MemUsage}}\t{{.MemPerc}}", containerName)
+	output, err := statsCmd.Output()
+	if err != nil {
+		return "", ""
+	}
+
+	parts := strings.Split(strings.TrimSpace(string(output)), "\t")
+	if len(parts) < 3 {
+		return "", ""
+	}
+
+	return parts[0], fmt.Sprintf("%s (%s)", parts[1], parts[2])
 }
 
 // GetContainerDetails fetches comprehensive information about a container
 func GetContainerDetails(containerName, prefix string) (*ContainerDetails, error) {
 	// Use docker inspect to get detailed container info
-	inspectCmd := exec.Command("docker", "inspect", containerName)
+	inspectCmd := system.DockerCommand("inspect", containerName)
 	output, err := inspectCmd.Output()
 	if err != nil {
 		return nil, fmt.Errorf("failed to inspect container: %w", err)
@@ -526,13 +1175,88 @@ func GetContainerDetails(containerName, prefix string) (*ContainerDetails, error
 		return nil, fmt.Errorf("no container data returned")
 	}
 
-	data := inspectData[0]
-
 	details := &ContainerDetails{
 		Name:      containerName,
 		ShortName: GetShortName(containerName, prefix),
 	}
+	parseInspectData(inspectData[0], details)
 
+	// Get branch, git status, and auth status from existing functions. They
+	// all exec into the container, which only works while it's running.
+	if details.Status == "running" {
+		details.Branch = GetBranchName(containerName)
+		details.GitStatus = GetGitStatus(containerName)
+		details.GitFiles = GetGitFileStatus(containerName)
+		var authErr error
+		details.AuthStatus, authErr = CheckAuthStatus(containerName)
+		if authErr != nil {
+			details.AuthProblem = authErr.Error()
+		}
+		details.LastActivity = GetLastActivity(containerName)
+		details.CPUUsage, details.MemoryUsage = GetResourceUsage(containerName)
+		details.FirewallOff = IsFirewallDisabled(containerName)
+		if launch := GetLaunchCommand(containerName); launch != "" {
+			details.LaunchCommand = launch
+		}
+	} else {
+		details.Branch = "-"
+		details.GitStatus = "-"
+		details.AuthStatus = "-"
+		details.LastActivity = "-"
+	}
+
+	// Get recent logs (last 50 lines). A single line can be arbitrarily long,
+	// so the output is also capped in bytes and cleaned for display. These are
+	// the startup script's output only: the task prompt is typed in through
+	// docker exec, whose output never reaches docker logs.
+	logsCmd := system.DockerCommand("logs", "--tail", "50", containerName)
+	logsOutput, err := logsCmd.CombinedOutput()
+	if err == nil {
+		details.RecentLogs = sanitizeLogs(string(logsOutput), recentLogsLimit)
+	} else {
+		details.RecentLogs = "(logs unavailable)"
+	}
+
+	return details, nil
+}
+
+// sanitizeLogs makes container output safe to show in the TUI: escape
+// sequences and control characters other than newlines and tabs are removed,
+// invalid UTF-8 is replaced, and only the last limit bytes are kept, starting
+// at a line boundary where possible, behind a "(truncated ...)" marker.
+func sanitizeLogs(logs string, limit int) string {
+	logs = ansi.Strip(strings.ToValidUTF8(logs, "\uFFFD"))
+	logs = strings.ReplaceAll(logs, "\r\n", "\n")
+	logs = strings.Map(func(r rune) rune {
+		if r == '\n' || r == '\t' {
+			return r
+		}
+		if r < 0x20 || (r >= 0x7f && r < 0xa0) {
+			return -1
+		}
+		return r
+	}, logs)
+
+	if limit <= 0 || len(logs) <= limit {
+		return logs
+	}
+	total := len(logs)
+	tail := logs[total-limit:]
+	if i := strings.IndexByte(tail, '\n'); i >= 0 && i < len(tail)-1 {
+		tail = tail[i+1:]
+	} else {
+		// One huge line: drop the partial rune at the cut
+		for len(tail) > 0 && !utf8.RuneStart(tail[0]) {
+			tail = tail[1:]
+		}
+	}
+	return fmt.Sprintf("(truncated: showing last %d of %d bytes)\n%s", len(tail), total, tail)
+}
+
+// parseInspectData fills details from one element of docker inspect's JSON.
+// Every field is optional: missing, null, or unexpectedly typed values are
+// skipped so unusual containers never cause a panic.
+func parseInspectData(data map[string]interface{}, details *ContainerDetails) {
 	// Extract state information
 	if state, ok := data["State"].(map[string]interface{}); ok {
 		if status, ok := state["Status"].(string); ok {
@@ -548,8 +1272,13 @@ func GetContainerDetails(containerName, prefix string) (*ContainerDetails, error
 
 	// Extract host config (resources)
 	if hostConfig, ok := data["HostConfig"].(map[string]interface{}); ok {
+		quota, _ := hostConfig["CpuQuota"].(float64)
+		period, _ := hostConfig["CpuPeriod"].(float64)
 		if cpuCount, ok := hostConfig["NanoCpus"].(float64); ok && cpuCount > 0 {
 			details.CPUs = fmt.Sprintf("%.1f", cpuCount/1e9)
+		} else if quota > 0 && period > 0 {
+			// Podman may record --cpus only as a CFS quota
+			details.CPUs = fmt.Sprintf("%.1f", quota/period)
 		} else {
 			details.CPUs = "unlimited"
 		}
@@ -568,69 +1297,55 @@ func GetContainerDetails(containerName, prefix string) (*ContainerDetails, error
 		}
 
 		if ports, ok := networkSettings["Ports"].(map[string]interface{}); ok {
-			for containerPort, bindings := range ports {
-				if bindingsList, ok := bindings.([]interface{}); ok && len(bindingsList) > 0 {
-					for _, binding := range bindingsList {
-						if b, ok := binding.(map[string]interface{}); ok {
-							hostPort := b["HostPort"].(string)
-							details.Ports = append(details.Ports, fmt.Sprintf("%s -> %s", hostPort, containerPort))
-						}
-					}
-				}
-			}
+			details.Ports = formatPortBindings(ports)
 		}
 	}
 
 	// Extract mounts (volumes)
 	if mounts, ok := data["Mounts"].([]interface{}); ok {
 		for _, mount := range mounts {
-			if m, ok := mount.(map[string]interface{}); ok {
-				source := m["Source"].(string)
-				destination := m["Destination"].(string)
-				details.Volumes = append(details.Volumes, fmt.Sprintf("%s -> %s", source, destination))
+			m, ok := mount.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			destination, _ := m["Destination"].(string)
+			if destination == "" {
+				continue
+			}
+			// Anonymous volumes may have no Source; fall back to the volume name
+			source, _ := m["Source"].(string)
+			if source == "" {
+				source, _ = m["Name"].(string)
 			}
+			if source == "" {
+				source = "(anonymous)"
+			}
+			details.Volumes = append(details.Volumes, fmt.Sprintf("%s -> %s", source, destination))
 		}
 	}
 
-	// Extract environment variables (filter sensitive ones)
+	// Extract environment variables (redacting sensitive ones)
 	if config, ok := data["Config"].(map[string]interface{}); ok {
 		if env, ok := config["Env"].([]interface{}); ok {
+			var entries []string
 			for _, e := range env {
 				if envStr, ok := e.(string); ok {
-					// Filter out sensitive variables
-					if !strings.Contains(envStr, "TOKEN") && !strings.Contains(envStr, "SECRET") && !strings.Contains(envStr, "PASSWORD") {
-						details.Environment = append(details.Environment, envStr)
-					}
+					entries = append(entries, envStr)
 				}
 			}
+			details.Environment = RedactEnv(entries)
 		}
 
 		// Get status string
 		if status, ok := config["Status"].(string); ok {
 			details.StatusDetails = status
 		}
-	}
 
-	// Get branch, git status, and auth status from existing functions
-	details.Branch = GetBranchName(containerName)
-	if details.Status == "running" {
-		details.GitStatus = GetGitStatus(containerName)
-		details.AuthStatus = GetAuthStatus(containerName)
-		details.LastActivity = GetLastActivity(containerName)
-	} else {
-		details.GitStatus = "-"
-		details.AuthStatus = "-"
-		details.LastActivity = "-"
-	}
-
-	// Get recent logs (last 50 lines)
-	logsCmd := exec.Command("docker", "logs", "--tail", "50", containerName)
-	logsOutput, err := logsCmd.CombinedOutput()
-	if err == nil {
-		details.RecentLogs = string(logsOutput)
-	} else {
-		details.RecentLogs = "(logs unavailable)"
+		// Launch and connect commands recorded at create time. A running
+		// container's live tmux command replaces the launch label later.
+		if labels, ok := config["Labels"].(map[string]interface{}); ok {
+			details.LaunchCommand, _ = labels[LaunchCommandLabel].(string)
+			details.ConnectCommand, _ = labels[ConnectCommandLabel].(string)
+		}
 	}
-
-	return details, nil
 }
@@ -0,0 +1,652 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParseGitStatus(t *testing.T) {
+	tests := []struct {
+		status                 string
+		changes, ahead, behind int
+	}{
+		{"✓         ", 0, 0, 0},
+		{"-         ", 0, 0, 0},
+		{"Δ3        ", 3, 0, 0},
+		{"↑2 ↓5     ", 0, 2, 5},
+		{"Δ12 ↑1 ↓4", 12, 1, 4},
+		{"", 0, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			changes, ahead, behind := ParseGitStatus(tt.status)
+			if changes != tt.changes || ahead != tt.ahead || behind != tt.behind {
+				t.Errorf("ParseGitStatus(%q) = (%d, %d, %d), want (%d, %d, %d)",
+					tt.status, changes, ahead, behind, tt.changes, tt.ahead, tt.behind)
+			}
+		})
+	}
+}
+
+func TestPadGitStatus(t *testing.T) {
+	tests := []struct {
+		status string
+		want   string
+	}{
+		{"✓", "✓         "},
+		{"Δ3", "Δ3        "},
+		{"Δ12 ↑1 ↓4", "Δ12 ↑1 ↓4 "},
+		{"日本", "日本      "},             // Wide runes take two cells each
+		{"Δ123 ↑12 ↓5", "Δ123 ↑12 ↓5"}, // Too long: kept whole, never cut
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			if got := padGitStatus(tt.status); got != tt.want {
+				t.Errorf("padGitStatus(%q) = %q, want %q", tt.status, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatGitCounts(t *testing.T) {
+	tests := map[string]string{
+		"0|0|0\n": "✓",
+		"0||":     "✓",
+		"3|1|\n":  "Δ3 ↑1",
+		" 12|0|2": "Δ12 ↓2",
+		"":        "✓",
+	}
+	for output, want := range tests {
+		if got := formatGitCounts(output); got != want {
+			t.Errorf("formatGitCounts(%q) = %q, want %q", output, got, want)
+		}
+	}
+}
+
+func TestIsMissingPathError(t *testing.T) {
+	tests := []struct {
+		output string
+		want   bool
+	}{
+		{"Error: No such container:path: maestro-a-1:/home/node/.claude/.credentials.json", true},
+		{"Error response from daemon: Could not find the file /home/node/.claude/.credentials.json in container maestro-a-1", true},
+		{"ls: cannot access '/opt/sdk': No such file or directory", true},
+		{"Error response from daemon: Container maestro-a-1 is restarting, wait until the container is running", false},
+		{"Error response from daemon: No such container: maestro-a-1", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsMissingPathError(tt.output); got != tt.want {
+			t.Errorf("IsMissingPathError(%q) = %v, want %v", tt.output, got, tt.want)
+		}
+	}
+}
+
+func TestSanitizeLogs(t *testing.T) {
+	tests := []struct {
+		name  string
+		logs  string
+		limit int
+		want  string
+	}{
+		{"plain logs are unchanged", "one\ntwo\n", 100, "one\ntwo\n"},
+		{"escape sequences are stripped", "\x1b[31mred\x1b[0m\n\x1b]0;title\x07ok\n", 100, "red\nok\n"},
+		{"control characters are dropped", "a\r\nb\x00\x08c\td\n", 100, "a\nbc\td\n"},
+		{"invalid UTF-8 is replaced", "bad \xff byte", 100, "bad \uFFFD byte"},
+		{"long logs keep whole trailing lines", "first line\nsecond\nthird\n", 12,
+			"(truncated: showing last 6 of 24 bytes)\nthird\n"},
+		{"one long line is cut at a rune boundary", "xx世界", 4,
+			"(truncated: showing last 3 of 8 bytes)\n界"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sanitizeLogs(tt.logs, tt.limit); got != tt.want {
+				t.Errorf("sanitizeLogs() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatPortBindings(t *testing.T) {
+	// Shaped like docker inspect's NetworkSettings.Ports
+	raw := `{
+		"3000/tcp": [{"HostIp": "0.0.0.0", "HostPort": "3000"}, {"HostIp": "::", "HostPort": "3000"}],
+		"5353/udp": [{"HostIp": "0.0.0.0", "HostPort": "15353"}],
+		"8080/tcp": null,
+		"9000/tcp": [{"HostIp": "0.0.0.0", "HostPort": 9000}],
+		"9229/tcp": [{"HostIp": "0.0.0.0", "HostPort": ""}],
+		"4000": [{"HostPort": "4000"}, "garbage"]
+	}`
+	var ports map[string]interface{}
+	if err := json.Unmarshal([]byte(raw), &ports); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"15353 -> 5353/udp", "3000 -> 3000/tcp", "4000 -> 4000/tcp"}
+	if got := formatPortBindings(ports); !reflect.DeepEqual(got, want) {
+		t.Errorf("formatPortBindings() = %v, want %v", got, want)
+	}
+}
+
+func TestParseInspectData(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want ContainerDetails
+	}{
+		{
+			name: "typical container with edge-case mounts",
+			raw: `{
+				"State": {"Status": "running", "StartedAt": "not-a-time"},
+				"HostConfig": {"NanoCpus": 2000000000, "Memory": 4294967296},
+				"NetworkSettings": {"IPAddress": "172.17.0.2", "Ports": {"3000/tcp": null}},
+				"Mounts": [
+					{"Source": "/host/src", "Destination": "/workspace"},
+					{"Source": "", "Name": "abc123", "Destination": "/cache"},
+					{"Destination": "/tmp/anon"},
+					{"Source": "/no/destination"},
+					{"Source": 42, "Destination": "/numeric-source"},
+					"garbage",
+					null
+				],
+				"Config": {
+					"Env": ["PATH=/usr/bin", "GH_TOKEN=secret", 7, null],
+					"Labels": {"maestro.launch-cmd": "claude --dangerously-skip-permissions", "maestro.connect-cmd": "python repl.py"}
+				}
+			}`,
+			want: ContainerDetails{
+				Status:    "running",
+				CPUs:      "2.0",
+				Memory:    "4.0 GB",
+				IPAddress: "172.17.0.2",
+				Volumes: []string{
+					"/host/src -> /workspace",
+					"abc123 -> /cache",
+					"(anonymous) -> /tmp/anon",
+					"(anonymous) -> /numeric-source",
+				},
+				Environment:    []string{"PATH=/usr/bin", "GH_TOKEN=<redacted>"},
+				LaunchCommand:  "claude --dangerously-skip-permissions",
+				ConnectCommand: "python repl.py",
+			},
+		},
+		{
+			name: "podman inspect",
+			raw: `{
+				"State": {"Status": "running", "StartedAt": "not-a-time"},
+				"HostConfig": {"NanoCpus": 0, "CpuQuota": 150000, "CpuPeriod": 100000, "Memory": 2147483648},
+				"NetworkSettings": {"IPAddress": "", "Ports": {"8080/tcp": [{"HostIp": "", "HostPort": "18080"}]}},
+				"Mounts": [
+					{"Type": "volume", "Name": "maestro-feat-1-npm", "Source": "/home/u/.local/share/containers/storage/volumes/maestro-feat-1-npm/_data", "Destination": "/home/node/.npm"},
+					{"Type": "bind", "Source": "/home/u/src", "Destination": "/workspace"}
+				],
+				"Config": {"Env": ["HOME=/home/node"], "Labels": {"maestro.short-name": "feat-1"}}
+			}`,
+			want: ContainerDetails{
+				Status: "running",
+				CPUs:   "1.5",
+				Memory: "2.0 GB",
+				Ports:  []string{"18080 -> 8080/tcp"},
+				Volumes: []string{
+					"/home/u/.local/share/containers/storage/volumes/maestro-feat-1-npm/_data -> /home/node/.npm",
+					"/home/u/src -> /workspace",
+				},
+				Environment: []string{"HOME=/home/node"},
+			},
+		},
+		{
+			name: "nulls and wrong types everywhere",
+			raw: `{
+				"State": null,
+				"HostConfig": {"NanoCpus": "2", "Memory": null},
+				"NetworkSettings": {"IPAddress": null, "Ports": []},
+				"Mounts": {"not": "a list"},
+				"Config": {"Env": "PATH=/usr/bin", "Labels": {"maestro.launch-cmd": 7}}
+			}`,
+			want: ContainerDetails{CPUs: "unlimited", Memory: "unlimited"},
+		},
+		{
+			name: "empty object",
+			raw:  `{}`,
+			want: ContainerDetails{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var data map[string]interface{}
+			if err := json.Unmarshal([]byte(tt.raw), &data); err != nil {
+				t.Fatal(err)
+			}
+
+			var got ContainerDetails
+			parseInspectData(data, &got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseInspectData() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTmuxAttachArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		session string
+		extra   []string
+		want    []string
+		wantErr bool
+	}{
+		{"default", "", nil, []string{"exec", "-it", "c", "tmux", "attach", "-t", "main"}, false},
+		{"session", "scratch", nil, []string{"exec", "-it", "c", "tmux", "attach", "-t", "scratch"}, false},
+		{"attach flags", "main", []string{"-d", "-c", "/work"},
+			[]string{"exec", "-it", "c", "tmux", "attach", "-t", "main", "-d", "-c", "/work"}, false},
+		{"socket before attach", "main", []string{"-r", "-L", "other"},
+			[]string{"exec", "-it", "c", "tmux", "-L", "other", "attach", "-t", "main", "-r"}, false},
+		{"missing value", "main", []string{"-S"}, nil, true},
+		{"target", "main", []string{"-t", "x"}, nil, true},
+		{"unknown", "main", []string{"; rm -rf /"}, nil, true},
+	}
+
+	for _, tt := range tests {
+		got, err := TmuxAttachArgs("c", tt.session, tt.extra)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: TmuxAttachArgs() error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: TmuxAttachArgs() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestParseImageInspect(t *testing.T) {
+	tests := []struct {
+		name     string
+		output   string
+		revision int
+		wantErr  bool
+	}{
+		{"labeled", `sha256:abc|2025-06-01T10:00:00.123Z|{"com.uprock.maestro.image-revision":"3"}` + "\n", 3, false},
+		{"no labels", "sha256:abc|2025-06-01T10:00:00Z|null", 0, false},
+		{"bad revision", `sha256:abc|2025-06-01T10:00:00Z|{"com.uprock.maestro.image-revision":"x"}`, 0, false},
+		{"garbage", "Error: No such image", 0, true},
+	}
+
+	for _, tt := range tests {
+		info, err := parseImageInspect(tt.output)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: parseImageInspect() error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if info.ID != "sha256:abc" || info.Created.IsZero() || info.Revision != tt.revision {
+			t.Errorf("%s: parseImageInspect() = %+v, want ID sha256:abc, a creation time, and revision %d", tt.name, info, tt.revision)
+		}
+	}
+}
+
+func TestParsePsOutput(t *testing.T) {
+	created := time.Date(2025, 3, 4, 10, 20, 30, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		output string
+		want   []psEntry
+	}{
+		{
+			name: "docker 24",
+			output: "maestro-feat-1\tUp 2 hours\trunning\t2025-03-04 10:20:30 +0000 UTC\n" +
+				"other\tUp 1 hour\trunning\t2025-03-04 10:20:30 +0000 UTC\n" +
+				"maestro-fix-1\tExited (0) 3 days ago\texited\t2025-03-04 10:20:30 +0000 UTC\n",
+			want: []psEntry{
+				{name: "maestro-feat-1", status: "Up 2 hours", state: "running", createdAt: created},
+				{name: "maestro-fix-1", status: "Exited (0) 3 days ago", state: "exited", createdAt: created},
+			},
+		},
+		{
+			name:   "CRLF, padding, and capitalized state",
+			output: "  maestro-feat-1\t Up 2 hours (Paused) \tPaused\t2025-03-04 10:20:30 +0000 UTC\r\n\r\n",
+			want: []psEntry{
+				{name: "maestro-feat-1", status: "Up 2 hours (Paused)", state: "paused", createdAt: created},
+			},
+		},
+		{
+			name: "no State column",
+			output: "maestro-feat-1\tUp 2 hours (Paused)\t\t2025-03-04 10:20:30 +0000 UTC\n" +
+				"maestro-fix-1\tRestarting (1) 5 seconds ago\t<no value>\t2025-03-04T10:20:30Z\n" +
+				"maestro-new-1\tCreated\t\t\n",
+			want: []psEntry{
+				{name: "maestro-feat-1", status: "Up 2 hours (Paused)", state: "paused", createdAt: created},
+				{name: "maestro-fix-1", status: "Restarting (1) 5 seconds ago", state: "restarting", createdAt: created},
+				{name: "maestro-new-1", status: "Created", state: "created"},
+			},
+		},
+		{
+			name: "podman 4",
+			output: "maestro-feat-1\tUp 2 hours\trunning\t2025-03-04 10:20:30.123456789 +0000 UTC\n" +
+				"maestro-fix-1\tExited (0) 3 days ago\tstopped\t2025-03-04 10:20:30.5 +0000 UTC\n" +
+				"maestro-new-1\tCreated\tconfigured\t2025-03-04 10:20:30 +0000 UTC\n",
+			want: []psEntry{
+				{name: "maestro-feat-1", status: "Up 2 hours", state: "running", createdAt: created.Add(123456789)},
+				{name: "maestro-fix-1", status: "Exited (0) 3 days ago", state: "exited", createdAt: created.Add(500 * time.Millisecond)},
+				{name: "maestro-new-1", status: "Created", state: "created", createdAt: created},
+			},
+		},
+		{
+			name:   "podman 3 prints the status as the state",
+			output: "maestro-feat-1\tUp 2 hours ago\tUp 2 hours ago\t2025-03-04 10:20:30 +0000 UTC\n",
+			want: []psEntry{
+				{name: "maestro-feat-1", status: "Up 2 hours ago", state: "running", createdAt: created},
+			},
+		},
+		{
+			name:   "multiple names",
+			output: "link/alias,maestro-feat-1\tUp 1 minute\trunning\t2025-03-04 10:20:30 +0000\n",
+			want: []psEntry{
+				{name: "maestro-feat-1", status: "Up 1 minute", state: "running", createdAt: created},
+			},
+		},
+		{
+			name:   "malformed lines",
+			output: "maestro-feat-1\n\tUp 1 minute\trunning\nmaestro-odd-1\tSomething new\t\t\n",
+			want:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		got := parsePsOutput(tt.output, "maestro-")
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: parsePsOutput() = %+v, want %+v", tt.name, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i].name != tt.want[i].name || got[i].status != tt.want[i].status ||
+				got[i].state != tt.want[i].state || !got[i].createdAt.Equal(tt.want[i].createdAt) {
+				t.Errorf("%s: entry %d = %+v, want %+v", tt.name, i, got[i], tt.want[i])
+			}
+		}
+	}
+}
+
+// fakeFleet replaces the command runners with a fake docker that reports n
+// running containers and answers every probe, sleeping spawnCost per call to
+// model process start-up. It returns a counter of commands run.
+func fakeFleet(b testing.TB, n int, spawnCost time.Duration) *atomic.Int64 {
+	b.Helper()
+	var calls atomic.Int64
+	expires := time.Now().Add(48 * time.Hour).UnixMilli()
+	activity := strconv.FormatInt(time.Now().Add(-5*time.Minute).Unix(), 10)
+
+	fake := func(name string, args ...string) ([]byte, error) {
+		calls.Add(1)
+		time.Sleep(spawnCost)
+		cmdline := strings.Join(args, " ")
+		switch {
+		case args[0] == "ps":
+			var out strings.Builder
+			for i := 0; i < n; i++ {
+				fmt.Fprintf(&out, "maestro-task-%d\tUp 1 hour\trunning\t2025-03-04 10:20:30 +0000 UTC\n", i)
+			}
+			return []byte(out.String()), nil
+		case args[0] == "cp":
+			creds := fmt.Sprintf(`{"claudeAiOauth":{"expiresAt":%d}}`, expires)
+			return nil, os.WriteFile(args[len(args)-1], []byte(creds), 0600)
+		case strings.Contains(cmdline, "@@"):
+			return []byte("@@branch\nfeat/bench\n@@git\n3|1|\n@@bell\n0:0\n1:0\n@@activity\n" + activity +
+				"\n@@ps\nSTAT COMMAND\nSl+ claude --dangerously-skip-permissions\n@@firewall\n!failed\n"), nil
+		case strings.Contains(cmdline, FirewallDisabledMarker):
+			return nil, errors.New("exit status 1")
+		case strings.Contains(cmdline, "--show-current"):
+			return []byte("feat/bench\n"), nil
+		case strings.Contains(cmdline, "list-windows"):
+			return []byte("0:0\n1:0\n"), nil
+		case strings.Contains(cmdline, "stat,args"):
+			return []byte("STAT COMMAND\nSl+ claude --dangerously-skip-permissions\n"), nil
+		case strings.Contains(cmdline, "pane_active_since"):
+			return []byte(activity), nil
+		case strings.Contains(cmdline, "rev-list"):
+			return []byte("3|1|\n"), nil
+		}
+		return nil, nil
+	}
+
+	origOutput, origCombined := commandOutput, commandCombinedOutput
+	commandOutput, commandCombinedOutput = fake, fake
+	b.Cleanup(func() { commandOutput, commandCombinedOutput = origOutput, origCombined })
+	return &calls
+}
+
+// BenchmarkGetAllContainers lists a 40-container fleet against a fake docker
+// where every command costs 2ms, roughly a local docker exec. The execs/op
+// metric is the number of docker commands needed for one listing.
+func BenchmarkGetAllContainers(b *testing.B) {
+	calls := fakeFleet(b, 40, 2*time.Millisecond)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := GetAllContainers("maestro-"); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.ReportMetric(float64(calls.Load())/float64(b.N), "execs/op")
+}
+
+func TestGatherAllContainers(t *testing.T) {
+	fakeFleet(t, 5, time.Millisecond)
+
+	var calls [][2]int
+	var timing GatherTiming
+	containers, err := GatherAllContainers("maestro-", GatherOptions{
+		Progress: func(done, total int) {
+			calls = append(calls, [2]int{done, total})
+		},
+		Timing: &timing,
+	})
+	if err != nil {
+		t.Fatalf("GatherAllContainers() error = %v", err)
+	}
+	if len(containers) != 5 {
+		t.Fatalf("got %d containers, want 5", len(containers))
+	}
+	want := [][2]int{{0, 5}, {1, 5}, {2, 5}, {3, 5}, {4, 5}, {5, 5}}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("progress calls = %v, want %v", calls, want)
+	}
+	if timing.List <= 0 || timing.Gather <= 0 || timing.SlowestGather <= 0 || timing.SlowestGather > timing.Gather {
+		t.Errorf("implausible timing %+v", timing)
+	}
+	if !strings.HasPrefix(timing.Slowest, "maestro-task-") {
+		t.Errorf("Slowest = %q, want one of the fleet", timing.Slowest)
+	}
+}
+
+func TestGetAllContainersSkipsExecForNonRunning(t *testing.T) {
+	var execs []string
+	origOutput, origCombined := commandOutput, commandCombinedOutput
+	fake := func(name string, args ...string) ([]byte, error) {
+		if args[0] == "ps" {
+			return []byte("maestro-stuck-1\tCreated\tcreated\t2025-03-04 10:20:30 +0000 UTC\n" +
+				"maestro-done-1\tExited (0) 2 hours ago\texited\t2025-03-04 09:20:30 +0000 UTC\n" +
+				"maestro-frozen-1\tUp 1 hour (Paused)\tpaused\t2025-03-04 08:20:30 +0000 UTC\n"), nil
+		}
+		execs = append(execs, strings.Join(args, " "))
+		return nil, errors.New("container is not running")
+	}
+	commandOutput, commandCombinedOutput = fake, fake
+	t.Cleanup(func() { commandOutput, commandCombinedOutput = origOutput, origCombined })
+
+	containers, err := GetAllContainers("maestro-")
+	if err != nil {
+		t.Fatalf("GetAllContainers() error = %v", err)
+	}
+	if len(containers) != 3 {
+		t.Fatalf("got %d containers, want 3", len(containers))
+	}
+	for _, c := range containers {
+		if c.Branch != "-" || c.GitStatus != "-" || c.LastActivity != "-" {
+			t.Errorf("%s (%s): branch %q, git %q, activity %q; want all \"-\"", c.Name, c.Status, c.Branch, c.GitStatus, c.LastActivity)
+		}
+	}
+	if len(execs) > 0 {
+		t.Errorf("probed non-running containers: %q", execs)
+	}
+}
+
+func TestReadCredentialsCorrupt(t *testing.T) {
+	path := t.TempDir() + "/.credentials.json"
+	if err := os.WriteFile(path, []byte("<html>502 Bad Gateway</html>"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := ReadCredentials(path)
+	var credErr *CredentialsError
+	if !errors.As(err, &credErr) {
+		t.Fatalf("ReadCredentials() error = %v, want a *CredentialsError", err)
+	}
+	if !strings.Contains(err.Error(), path) || !strings.Contains(err.Error(), "corrupt or not JSON") {
+		t.Errorf("error %q should name the path and explain the problem", err)
+	}
+	var syntaxErr *json.SyntaxError
+	if !errors.As(err, &syntaxErr) {
+		t.Errorf("error %q should wrap the json error", err)
+	}
+}
+
+func TestValidateCredentials(t *testing.T) {
+	tests := []struct {
+		name       string
+		json       string
+		wantReason string // "" for valid
+	}{
+		{"valid", `{"claudeAiOauth":{"accessToken":"a","refreshToken":"r","expiresAt":1700000000000}}`, ""},
+		{"expired is still well-formed", `{"claudeAiOauth":{"accessToken":"a","expiresAt":1}}`, ""},
+		{"empty object", `{}`, "missing claudeAiOauth block"},
+		{"wrong shape", `{"token":"abc"}`, "missing claudeAiOauth block"},
+		{"no access token", `{"claudeAiOauth":{"refreshToken":"r","expiresAt":1700000000000}}`, "missing accessToken"},
+		{"no expiry", `{"claudeAiOauth":{"accessToken":"a","refreshToken":"r"}}`, "missing expiresAt"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var creds Credentials
+			if err := json.Unmarshal([]byte(tt.json), &creds); err != nil {
+				t.Fatal(err)
+			}
+			err := ValidateCredentials(&creds)
+			if tt.wantReason == "" {
+				if err != nil {
+					t.Errorf("ValidateCredentials() = %v, want nil", err)
+				}
+				return
+			}
+			var credErr *CredentialsError
+			if !errors.As(err, &credErr) || credErr.Reason != tt.wantReason {
+				t.Errorf("ValidateCredentials() = %v, want reason %q", err, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestCopyCredentialsFromContainer(t *testing.T) {
+	missing := []byte("Error response from daemon: Could not find the file /home/node/.claude/x in container")
+	tests := []struct {
+		name      string
+		responses map[string][]byte // file name -> docker output; absent means copied
+		wantErr   bool
+		wantTries int
+	}{
+		{"configured file", map[string][]byte{}, false, 1},
+		{"older name", map[string][]byte{".credentials.json": missing}, false, 2},
+		{"none found", map[string][]byte{".credentials.json": missing, "credentials.json": missing}, true, 2},
+		{"docker failure stops", map[string][]byte{".credentials.json": []byte("No such container: maestro-x-1")}, true, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var tries int
+			orig := commandCombinedOutput
+			commandCombinedOutput = func(name string, args ...string) ([]byte, error) {
+				tries++
+				file := args[1][strings.LastIndex(args[1], "/")+1:]
+				if output, ok := tt.responses[file]; ok {
+					return output, errors.New("exit status 1")
+				}
+				return nil, nil
+			}
+			t.Cleanup(func() { commandCombinedOutput = orig })
+
+			_, err := CopyCredentialsFromContainer("maestro-x-1", "/tmp/creds.json")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CopyCredentialsFromContainer() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tries != tt.wantTries {
+				t.Errorf("tried %d files, want %d", tries, tt.wantTries)
+			}
+		})
+	}
+}
+
+func TestParseLaunchCommand(t *testing.T) {
+	tests := []struct {
+		output string
+		want   string
+	}{
+		{"\"claude --dangerously-skip-permissions\"\n", "claude --dangerously-skip-permissions"},
+		{"claude --dangerously-skip-permissions\n", "claude --dangerously-skip-permissions"},
+		{"\"\n", "\""},
+		{"\n", ""},
+	}
+	for _, tt := range tests {
+		if got := parseLaunchCommand(tt.output); got != tt.want {
+			t.Errorf("parseLaunchCommand(%q) = %q, want %q", tt.output, got, tt.want)
+		}
+	}
+}
+
+func TestParseClaudeRunning(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   bool
+	}{
+		{"launched with the skip flag", "STAT COMMAND\nSl+  claude --dangerously-skip-permissions\n", true},
+		{"plain claude", "STAT COMMAND\nSl+  claude\n", true},
+		{"full path", "STAT COMMAND\nSl+  /usr/local/bin/claude --continue\n", true},
+		{"via node", "STAT COMMAND\nSl+  node /usr/local/lib/node_modules/@anthropic-ai/claude-code/cli.js\n", true},
+		{"headless print run", "STAT COMMAND\nS    claude -p summarize\n", false},
+		{"zombie", "STAT COMMAND\nZ    claude\n", false},
+		{"wrapping shell", "STAT COMMAND\nSs   bash -c claude --dangerously-skip-permissions\n", false},
+		{"tmux server", "STAT COMMAND\nSs   tmux new-session -d -s main claude\n", false},
+		{"no claude", "STAT COMMAND\nSs   bash\n", false},
+	}
+	for _, tt := range tests {
+		if got := parseClaudeRunning(tt.output); got != tt.want {
+			t.Errorf("parseClaudeRunning(%s) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
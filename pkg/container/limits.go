@@ -0,0 +1,130 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ResourceLimits are a container's CPU and memory caps. Zero means unlimited.
+type ResourceLimits struct {
+	CPUs        float64
+	MemoryBytes int64
+}
+
+// limitsFormat is the docker inspect template read by parseResourceLimits
+const limitsFormat = "{{.HostConfig.NanoCpus}} {{.HostConfig.CpuQuota}} {{.HostConfig.CpuPeriod}} {{.HostConfig.Memory}}"
+
+// limitsCache holds limits already inspected, by container name. Limits are
+// set at create time, so an entry stays valid until a container of the same
+// name with a different creation time replaces it.
+var limitsCache = struct {
+	sync.Mutex
+	entries map[string]cachedLimits
+}{entries: make(map[string]cachedLimits)}
+
+type cachedLimits struct {
+	createdAt time.Time
+	limits    ResourceLimits
+}
+
+// GetResourceLimits returns a container's CPU and memory limits, running
+// docker inspect only the first time a container is seen. createdAt tells a
+// recreated container apart from the one cached under its name.
+func GetResourceLimits(containerName string, createdAt time.Time) (ResourceLimits, error) {
+	limitsCache.Lock()
+	cached, ok := limitsCache.entries[containerName]
+	limitsCache.Unlock()
+	if ok && cached.createdAt.Equal(createdAt) {
+		return cached.limits, nil
+	}
+
+	output, err := commandOutput("docker", "inspect", "--format", limitsFormat, containerName)
+	if err != nil {
+		return ResourceLimits{}, fmt.Errorf("failed to inspect %s: %w", containerName, err)
+	}
+	limits, err := parseResourceLimits(string(output))
+	if err != nil {
+		return ResourceLimits{}, err
+	}
+
+	limitsCache.Lock()
+	limitsCache.entries[containerName] = cachedLimits{createdAt: createdAt, limits: limits}
+	limitsCache.Unlock()
+	return limits, nil
+}
+
+// parseResourceLimits parses limitsFormat output. As in the details view, a
+// CFS quota stands in for NanoCpus, which podman may leave unset.
+func parseResourceLimits(output string) (ResourceLimits, error) {
+	fields := strings.Fields(output)
+	if len(fields) != 4 {
+		return ResourceLimits{}, fmt.Errorf("unexpected docker inspect output: %q", output)
+	}
+	var values [4]int64
+	for i, field := range fields {
+		v, err := strconv.ParseInt(field, 10, 64)
+		if err != nil {
+			return ResourceLimits{}, fmt.Errorf("unexpected docker inspect output: %q", output)
+		}
+		values[i] = v
+	}
+	nanoCPUs, quota, period, memory := values[0], values[1], values[2], values[3]
+
+	var limits ResourceLimits
+	switch {
+	case nanoCPUs > 0:
+		limits.CPUs = float64(nanoCPUs) / 1e9
+	case quota > 0 && period > 0:
+		limits.CPUs = float64(quota) / float64(period)
+	}
+	if memory > 0 {
+		limits.MemoryBytes = memory
+	}
+	return limits, nil
+}
+
+// FormatCPULimit returns the CPU limit as a count such as "2" or "1.5"
+// (rounded to a tenth), or "∞" if unlimited
+func FormatCPULimit(cpus float64) string {
+	if cpus <= 0 {
+		return "∞"
+	}
+	return strconv.FormatFloat(math.Round(cpus*10)/10, 'f', -1, 64)
+}
+
+// FormatMemoryLimit returns the memory limit in docker's --memory notation,
+// such as "4g" or "1.5g" (rounded to a tenth), or "∞" if unlimited
+func FormatMemoryLimit(bytes int64) string {
+	if bytes <= 0 {
+		return "∞"
+	}
+	units := []struct {
+		suffix string
+		size   int64
+	}{{"t", 1 << 40}, {"g", 1 << 30}, {"m", 1 << 20}, {"k", 1 << 10}}
+	for _, unit := range units {
+		if bytes >= unit.size {
+			value := math.Round(float64(bytes)/float64(unit.size)*10) / 10
+			return strconv.FormatFloat(value, 'f', -1, 64) + unit.suffix
+		}
+	}
+	return strconv.FormatInt(bytes, 10)
+}
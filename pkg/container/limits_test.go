@@ -0,0 +1,88 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseResourceLimits(t *testing.T) {
+	tests := []struct {
+		name    string
+		output  string
+		want    ResourceLimits
+		wantErr bool
+	}{
+		{"docker --cpus and --memory", "2000000000 0 0 4294967296\n", ResourceLimits{CPUs: 2, MemoryBytes: 4 << 30}, false},
+		{"unlimited", "0 0 0 0\n", ResourceLimits{}, false},
+		{"podman quota", "0 150000 100000 536870912\n", ResourceLimits{CPUs: 1.5, MemoryBytes: 512 << 20}, false},
+		{"missing fields", "0 0\n", ResourceLimits{}, true},
+		{"garbage", "Error: no such object\n", ResourceLimits{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseResourceLimits(tt.output)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseResourceLimits() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseResourceLimits() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatLimits(t *testing.T) {
+	cpus := map[float64]string{0: "∞", 2: "2", 1.5: "1.5", 4.0 / 3: "1.3"}
+	for in, want := range cpus {
+		if got := FormatCPULimit(in); got != want {
+			t.Errorf("FormatCPULimit(%v) = %q, want %q", in, got, want)
+		}
+	}
+	memory := map[int64]string{0: "∞", 4 << 30: "4g", 3 << 29: "1.5g", 512 << 20: "512m", 1 << 40: "1t", 100: "100"}
+	for in, want := range memory {
+		if got := FormatMemoryLimit(in); got != want {
+			t.Errorf("FormatMemoryLimit(%d) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestGetResourceLimitsCaches(t *testing.T) {
+	calls := fakeTmux(t, "2000000000 0 0 0\n", nil)
+	created := time.Date(2025, 3, 4, 10, 20, 30, 0, time.UTC)
+
+	for i := 0; i < 2; i++ {
+		got, err := GetResourceLimits("maestro-cache-1", created)
+		if err != nil || got != (ResourceLimits{CPUs: 2}) {
+			t.Fatalf("GetResourceLimits() = %+v, %v", got, err)
+		}
+	}
+	if len(*calls) != 1 {
+		t.Errorf("docker inspect ran %d times, want 1", len(*calls))
+	}
+
+	// A recreated container of the same name is inspected again
+	if _, err := GetResourceLimits("maestro-cache-1", created.Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{
+		"docker inspect --format " + limitsFormat + " maestro-cache-1",
+		"docker inspect --format " + limitsFormat + " maestro-cache-1",
+	}; !reflect.DeepEqual(*calls, want) {
+		t.Errorf("ran %q, want %q", *calls, want)
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/uprockcom/maestro/pkg/paths"
+)
+
+// ErrFleetBusy is returned by LockFleet when another maestro process keeps
+// the fleet lock for longer than the wait allows
+var ErrFleetBusy = errors.New("another maestro operation is in progress")
+
+// fleetLockFile is the lock taken by operations that change every container
+const fleetLockFile = "fleet.lock"
+
+// fleetLockTimeout is how long LockFleet waits for another operation to
+// finish; fleetLockPoll is how often it checks (both replaced in tests)
+var (
+	fleetLockTimeout = 2 * time.Minute
+	fleetLockPoll    = 250 * time.Millisecond
+)
+
+// fleetLockPath returns the path of the fleet lock (replaced in tests)
+var fleetLockPath = func() string {
+	return filepath.Join(paths.StateDir(), fleetLockFile)
+}
+
+// LockFleet takes the advisory lock that serializes mutating fleet-wide
+// operations (app update, refresh-tokens, adding a domain everywhere). If
+// another live process holds it, waiting is called once with a description of
+// that operation and LockFleet retries until the holder finishes or the wait
+// times out with ErrFleetBusy. A lock left by a process that no longer exists
+// is taken over. The returned func releases the lock.
+func LockFleet(operation string, waiting func(holder string)) (func(), error) {
+	lockPath := fleetLockPath()
+	if err := os.MkdirAll(filepath.Dir(lockPath), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create state directory: %w", err)
+	}
+	deadline := time.Now().Add(fleetLockTimeout)
+	notified := false
+
+	// The lock is written to a temp file first and linked into place, so it
+	// never exists without its pid and operation
+	tmp, err := os.CreateTemp(filepath.Dir(lockPath), ".fleet-lock-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create lock file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	content := fmt.Sprintf("%d\n%s\n", os.Getpid(), operation)
+	_, err = tmp.WriteString(content)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to write lock file: %w", err)
+	}
+
+	for {
+		err := os.Link(tmp.Name(), lockPath)
+		if err == nil {
+			// Make sure another waiter's stale-lock takeover didn't replace ours
+			if ownsFleetLock(lockPath, content) {
+				return func() {
+					if ownsFleetLock(lockPath, content) {
+						os.Remove(lockPath)
+					}
+				}, nil
+			}
+			continue
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("failed to create lock file: %w", err)
+		}
+
+		pid, holder, ok := readFleetLock(lockPath)
+		if !ok {
+			// Stale or unreadable lock; take it over and try again straight away
+			removeStaleFleetLock(lockPath)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("%w: %s (pid %d)", ErrFleetBusy, holder, pid)
+		}
+		if !notified && waiting != nil {
+			waiting(fmt.Sprintf("%s (pid %d)", holder, pid))
+			notified = true
+		}
+		time.Sleep(fleetLockPoll)
+	}
+}
+
+// removeStaleFleetLock removes a lock found to be stale. Another waiter may
+// have replaced it with a live lock since it was read, so it's moved aside
+// and checked again first, and put back if it turns out to be live.
+func removeStaleFleetLock(lockPath string) {
+	aside := fmt.Sprintf("%s.stale-%d", lockPath, os.Getpid())
+	if err := os.Rename(lockPath, aside); err != nil {
+		return
+	}
+	if _, _, ok := readFleetLock(aside); ok {
+		os.Link(aside, lockPath)
+	}
+	os.Remove(aside)
+}
+
+// ownsFleetLock reports whether the lock file still holds content, as written
+// by this LockFleet call
+func ownsFleetLock(lockPath, content string) bool {
+	data, err := os.ReadFile(lockPath)
+	return err == nil && string(data) == content
+}
+
+// readFleetLock returns the pid and operation recorded in the lock file.
+// ok is false if the file is gone, malformed, or its process has exited.
+func readFleetLock(lockPath string) (pid int, operation string, ok bool) {
+	data, err := os.ReadFile(lockPath)
+	if err != nil {
+		return 0, "", false
+	}
+	pidLine, operation, _ := strings.Cut(string(data), "\n")
+	pid, err = strconv.Atoi(strings.TrimSpace(pidLine))
+	if err != nil || pid <= 0 {
+		return 0, "", false
+	}
+	operation = strings.TrimSpace(operation)
+	if operation == "" {
+		operation = "unknown operation"
+	}
+	return pid, operation, processAlive(pid)
+}
+
+// processAlive reports whether a process with the given pid exists
+func processAlive(pid int) bool {
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	// Signal 0 checks for existence without delivering anything. EPERM means
+	// the process exists but belongs to another user.
+	err = process.Signal(syscall.Signal(0))
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
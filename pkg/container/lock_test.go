@@ -0,0 +1,146 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLockFleet(t *testing.T) {
+	origPath, origTimeout, origPoll := fleetLockPath, fleetLockTimeout, fleetLockPoll
+	t.Cleanup(func() { fleetLockPath, fleetLockTimeout, fleetLockPoll = origPath, origTimeout, origPoll })
+	fleetLockTimeout = 50 * time.Millisecond
+	fleetLockPoll = 10 * time.Millisecond
+
+	// A pid far above any real pid_max stands in for a process that exited
+	const deadPID = 1 << 30
+
+	tests := []struct {
+		name        string
+		existing    string // Lock file content before LockFleet; "" for none
+		wantBusy    bool
+		wantWaiting string
+	}{
+		{name: "free", existing: ""},
+		{name: "held by a process that exited", existing: fmt.Sprintf("%d\napp update gh\n", deadPID)},
+		{name: "malformed lock", existing: "not a pid\n"},
+		{
+			name:        "held by a live process",
+			existing:    fmt.Sprintf("%d\nrefresh-tokens\n", os.Getpid()),
+			wantBusy:    true,
+			wantWaiting: fmt.Sprintf("refresh-tokens (pid %d)", os.Getpid()),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lockPath := filepath.Join(t.TempDir(), "state", fleetLockFile)
+			fleetLockPath = func() string { return lockPath }
+			if tt.existing != "" {
+				if err := os.MkdirAll(filepath.Dir(lockPath), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(lockPath, []byte(tt.existing), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			var waited []string
+			unlock, err := LockFleet("add domain example.com", func(holder string) {
+				waited = append(waited, holder)
+			})
+
+			if tt.wantBusy {
+				if !errors.Is(err, ErrFleetBusy) {
+					t.Fatalf("LockFleet() error = %v, want ErrFleetBusy", err)
+				}
+				if len(waited) != 1 || waited[0] != tt.wantWaiting {
+					t.Errorf("waiting called with %q, want [%q]", waited, tt.wantWaiting)
+				}
+				if data, _ := os.ReadFile(lockPath); string(data) != tt.existing {
+					t.Errorf("lock file = %q, want it left as %q", data, tt.existing)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("LockFleet() error = %v", err)
+			}
+			if len(waited) != 0 {
+				t.Errorf("waiting called with %q for a free lock", waited)
+			}
+			data, err := os.ReadFile(lockPath)
+			if err != nil {
+				t.Fatalf("lock file not written: %v", err)
+			}
+			if want := fmt.Sprintf("%d\nadd domain example.com\n", os.Getpid()); string(data) != want {
+				t.Errorf("lock file = %q, want %q", data, want)
+			}
+
+			unlock()
+			if _, err := os.Stat(lockPath); !os.IsNotExist(err) {
+				t.Errorf("lock file still present after unlock: %v", err)
+			}
+			entries, _ := os.ReadDir(filepath.Dir(lockPath))
+			for _, e := range entries {
+				if strings.HasPrefix(e.Name(), ".fleet-lock-") {
+					t.Errorf("temp file %s left behind", e.Name())
+				}
+			}
+		})
+	}
+}
+
+func TestProcessAlive(t *testing.T) {
+	tests := []struct {
+		name string
+		pid  int
+		want bool
+	}{
+		{"this process", os.Getpid(), true},
+		{"init, alive even when owned by another user", 1, true},
+		{"exited", 1 << 30, false},
+	}
+	for _, tt := range tests {
+		if got := processAlive(tt.pid); got != tt.want {
+			t.Errorf("processAlive(%s) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRemoveStaleFleetLockKeepsLiveLock(t *testing.T) {
+	// Another waiter took the stale lock over between our read and our removal
+	lockPath := filepath.Join(t.TempDir(), fleetLockFile)
+	live := fmt.Sprintf("%d\napp update gh\n", os.Getpid())
+	if err := os.WriteFile(lockPath, []byte(live), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	removeStaleFleetLock(lockPath)
+
+	if data, err := os.ReadFile(lockPath); err != nil || string(data) != live {
+		t.Errorf("live lock = %q, %v; want it kept as %q", data, err, live)
+	}
+	entries, _ := os.ReadDir(filepath.Dir(lockPath))
+	if len(entries) != 1 {
+		t.Errorf("directory has %d entries, want only the lock", len(entries))
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// LegacyPrefix is the container prefix maestro used before it was configurable
+const LegacyPrefix = "mcl-"
+
+// scanLegacyPrefix controls whether containers under LegacyPrefix are looked up too
+var scanLegacyPrefix = true
+
+// SetScanLegacyPrefix turns the lookup of LegacyPrefix containers on or off
+func SetScanLegacyPrefix(enabled bool) {
+	scanLegacyPrefix = enabled
+}
+
+// ScansLegacyPrefix reports whether containers under LegacyPrefix are looked
+// up alongside those under prefix
+func ScansLegacyPrefix(prefix string) bool {
+	return scanLegacyPrefix && prefix != LegacyPrefix
+}
+
+// dockerNameRegex matches names docker accepts for containers
+var dockerNameRegex = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+// ValidatePrefix checks that a container prefix can start a valid docker container name
+func ValidatePrefix(prefix string) error {
+	if prefix == "" {
+		return fmt.Errorf("container prefix must not be empty")
+	}
+	if !dockerNameRegex.MatchString(prefix) {
+		return fmt.Errorf("invalid container prefix %q: must start with a letter or digit and contain only letters, digits, '_', '.', or '-'", prefix)
+	}
+	return nil
+}
+
+// PrefixOverlap is a pair of container prefixes where Long starts with Short,
+// so containers named Long... are also picked up as Short... containers
+type PrefixOverlap struct {
+	Short string
+	Long  string
+}
+
+// OverlappingPrefixes returns every pair of distinct prefixes where one is a
+// prefix of the other, sorted by Short then Long
+func OverlappingPrefixes(prefixes []string) []PrefixOverlap {
+	unique := make(map[string]bool)
+	for _, p := range prefixes {
+		if p != "" {
+			unique[p] = true
+		}
+	}
+	sorted := make([]string, 0, len(unique))
+	for p := range unique {
+		sorted = append(sorted, p)
+	}
+	sort.Strings(sorted)
+
+	// After sorting, every string sharing a prefix with p follows p directly
+	var overlaps []PrefixOverlap
+	for i, short := range sorted {
+		for _, long := range sorted[i+1:] {
+			if !strings.HasPrefix(long, short) {
+				break
+			}
+			overlaps = append(overlaps, PrefixOverlap{Short: short, Long: long})
+		}
+	}
+	return overlaps
+}
@@ -0,0 +1,86 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidatePrefix(t *testing.T) {
+	tests := []struct {
+		prefix  string
+		wantErr bool
+	}{
+		{"maestro-", false},
+		{"mcl-", false},
+		{"my_team.v2-", false},
+		{"0dev-", false},
+		{"", true},
+		{"-maestro", true},
+		{".maestro", true},
+		{"my team-", true},
+		{"maestro/", true},
+		{"mäestro-", true},
+	}
+
+	for _, tt := range tests {
+		err := ValidatePrefix(tt.prefix)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ValidatePrefix(%q) error = %v, wantErr %v", tt.prefix, err, tt.wantErr)
+		}
+	}
+}
+
+func TestOverlappingPrefixes(t *testing.T) {
+	tests := []struct {
+		name     string
+		prefixes []string
+		want     []PrefixOverlap
+	}{
+		{"distinct", []string{"maestro-", "mcl-", "dev-"}, nil},
+		{"one inside another", []string{"dev-api-", "mcl-", "dev-"},
+			[]PrefixOverlap{{"dev-", "dev-api-"}}},
+		{"chain", []string{"d", "dev-", "dev-api-", "devops-"},
+			[]PrefixOverlap{{"d", "dev-"}, {"d", "dev-api-"}, {"d", "devops-"}, {"dev-", "dev-api-"}}},
+		{"duplicates and empties ignored", []string{"dev-", "dev-", "", "mcl-"}, nil},
+		{"none", nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := OverlappingPrefixes(tt.prefixes); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("OverlappingPrefixes(%q) = %v, want %v", tt.prefixes, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetShortName(t *testing.T) {
+	tests := []struct {
+		name, prefix, want string
+	}{
+		{"maestro-feat-1", "maestro-", "feat-1"},
+		{"/maestro-feat-1", "maestro-", "feat-1"},
+		{"mcl-feat-1", "maestro-", "mcl-feat-1"},
+		{"maestro-", "maestro-", "maestro-"},
+		{"maestro-feat-1", "", "maestro-feat-1"},
+		{"dev-api-feat-1", "dev-", "api-feat-1"},
+	}
+	for _, tt := range tests {
+		if got := GetShortName(tt.name, tt.prefix); got != tt.want {
+			t.Errorf("GetShortName(%q, %q) = %q, want %q", tt.name, tt.prefix, got, tt.want)
+		}
+	}
+}
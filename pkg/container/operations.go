@@ -17,12 +17,12 @@ package container
 import (
 	"fmt"
 	"os"
-	"os/exec"
-	"path/filepath"
+	"regexp"
 	"strings"
 	"time"
 
 	"github.com/uprockcom/maestro/pkg/paths"
+	"github.com/uprockcom/maestro/pkg/system"
 )
 
 // OperationType defines Docker operations that can be performed on containers
@@ -37,23 +37,49 @@ const (
 
 // StopContainer stops a running container
 func StopContainer(containerName string) error {
-	cmd := exec.Command("docker", "stop", containerName)
+	FireLifecycleEvent(EventPreStop, containerName)
+
+	cmd := system.DockerCommand("stop", containerName)
 	if err := cmd.Run(); err != nil {
 		return fmt.Errorf("failed to stop container: %w", err)
 	}
 	return nil
 }
 
+// StartContainer starts a stopped container
+func StartContainer(containerName string) error {
+	if output, err := system.DockerCommand("start", containerName).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to start container: %s", strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// PauseContainer freezes all processes in a running container
+func PauseContainer(containerName string) error {
+	if output, err := system.DockerCommand("pause", containerName).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to pause container: %s", strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// UnpauseContainer resumes a paused container
+func UnpauseContainer(containerName string) error {
+	if output, err := system.DockerCommand("unpause", containerName).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to unpause container: %s", strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
 // RestartContainer performs a full container restart (docker stop + start)
 func RestartContainer(containerName string) error {
 	// Stop container
-	stopCmd := exec.Command("docker", "stop", containerName)
+	stopCmd := system.DockerCommand("stop", containerName)
 	if err := stopCmd.Run(); err != nil {
 		return fmt.Errorf("failed to stop container: %w", err)
 	}
 
 	// Start container
-	startCmd := exec.Command("docker", "start", containerName)
+	startCmd := system.DockerCommand("start", containerName)
 	if err := startCmd.Run(); err != nil {
 		return fmt.Errorf("failed to start container: %w", err)
 	}
@@ -66,31 +92,56 @@ func RestartContainer(containerName string) error {
 
 // DeleteContainer removes a container and its volumes
 func DeleteContainer(containerName string) error {
+	// Capture details for the post_delete hook while the container still exists
+	hookEvent := CaptureHookEvent(EventPostDelete, containerName)
+
 	// Remove container with volumes
-	rmCmd := exec.Command("docker", "rm", "-f", "-v", containerName)
+	rmCmd := system.DockerCommand("rm", "-f", "-v", containerName)
 	if err := rmCmd.Run(); err != nil {
 		return fmt.Errorf("failed to remove container: %w", err)
 	}
 
 	// Remove associated named volumes
-	volumes := []string{
-		fmt.Sprintf("%s-npm", containerName),
-		fmt.Sprintf("%s-uv", containerName),
-		fmt.Sprintf("%s-history", containerName),
-	}
-
-	for _, volume := range volumes {
-		volCmd := exec.Command("docker", "volume", "rm", volume)
+	for _, volume := range ContainerVolumes(containerName) {
+		volCmd := system.DockerCommand("volume", "rm", volume)
 		volCmd.Run() // Ignore errors - volume might not exist
 	}
 
+	FireHook(hookEvent)
+
 	return nil
 }
 
-// RefreshTokens finds the freshest token and syncs it to a specific container
-func RefreshTokens(containerName string) error {
+// VolumeKinds are the named volumes every container gets, as suffixes of the
+// container name: npm and uv caches and shell history
+var VolumeKinds = []string{"npm", "uv", "history"}
+
+// ContainerVolumes returns the named volumes created alongside a container,
+// which DeleteContainer removes with it
+func ContainerVolumes(containerName string) []string {
+	volumes := make([]string, len(VolumeKinds))
+	for i, kind := range VolumeKinds {
+		volumes[i] = fmt.Sprintf("%s-%s", containerName, kind)
+	}
+	return volumes
+}
+
+// VolumeMountpoint returns where docker stores a named volume's data. With
+// Docker Desktop the path is inside its VM rather than on the host.
+func VolumeMountpoint(volume string) (string, error) {
+	output, err := commandOutput("docker", "volume", "inspect", "--format", "{{.Mountpoint}}", volume)
+	if err != nil {
+		return "", fmt.Errorf("failed to inspect volume %s: %w", volume, err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// RefreshTokens finds the freshest token among the host and the running
+// containers under prefix (and LegacyPrefix, see ScansLegacyPrefix) and syncs
+// it to a specific container
+func RefreshTokens(containerName, prefix string) error {
 	// Find freshest token by checking host and all containers
-	hostCredPath := filepath.Join(paths.AuthDir(), ".credentials.json")
+	hostCredPath := paths.FindCredentials(paths.AuthDir())
 
 	var freshestPath string
 	var freshestTime time.Time
@@ -102,21 +153,23 @@ func RefreshTokens(containerName string) error {
 	}
 
 	// Get all running containers to check their tokens
-	containers, err := GetRunningContainers("mcl-")
+	containers, err := GetRunningContainersWithLegacy(prefix)
 	if err != nil {
 		return fmt.Errorf("failed to list containers: %w", err)
 	}
 
 	// Check each container's credentials
 	for _, c := range containers {
-		tmpFile := filepath.Join(os.TempDir(), fmt.Sprintf("maestro-creds-%s.json", c.Name))
-		copyCmd := exec.Command("docker", "cp",
-			fmt.Sprintf("%s:/home/node/.claude/.credentials.json", c.Name),
-			tmpFile)
-		if err := copyCmd.Run(); err != nil {
+		tmp, err := os.CreateTemp("", "maestro-creds-*.json")
+		if err != nil {
 			continue
 		}
+		tmp.Close()
+		tmpFile := tmp.Name()
 		defer os.Remove(tmpFile)
+		if _, err := CopyCredentialsFromContainer(c.Name, tmpFile); err != nil {
+			continue
+		}
 
 		if creds, err := ReadCredentials(tmpFile); err == nil {
 			expiresAt := time.UnixMilli(creds.ClaudeAiOauth.ExpiresAt)
@@ -142,15 +195,15 @@ func RefreshTokens(containerName string) error {
 	}
 
 	// Copy freshest credentials to target container
-	copyCmd := exec.Command("docker", "cp", freshestPath,
-		fmt.Sprintf("%s:/home/node/.claude/.credentials.json", containerName))
+	copyCmd := system.DockerCommand("cp", freshestPath,
+		fmt.Sprintf("%s:%s", containerName, CredentialsPath()))
 	if err := copyCmd.Run(); err != nil {
 		return fmt.Errorf("failed to copy credentials to container: %w", err)
 	}
 
 	// Fix ownership
-	chownCmd := exec.Command("docker", "exec", "-u", "root", containerName,
-		"chown", "node:node", "/home/node/.claude/.credentials.json")
+	chownCmd := system.DockerCommand("exec", "-u", "root", containerName,
+		"chown", "node:node", CredentialsPath())
 	if err := chownCmd.Run(); err != nil {
 		return fmt.Errorf("failed to fix credentials ownership: %w", err)
 	}
@@ -158,43 +211,84 @@ func RefreshTokens(containerName string) error {
 	return nil
 }
 
-// AddDomainToAllContainers adds a domain to all running containers' firewall
-func AddDomainToAllContainers(domain string) error {
+// addDomainAttempts is how many times AddDomainToAllContainers tries each
+// container; a retry usually gets past a dnsmasq restart race
+const addDomainAttempts = 3
+
+// addDomainRetryDelay is the pause between attempts on the same container
+var addDomainRetryDelay = 500 * time.Millisecond
+
+// addDomainFunc adds a domain to one container (replaced in tests)
+var addDomainFunc = AddDomainToContainer
+
+// AddDomainToAllContainers adds a domain to all running containers' firewall.
+// Each container is retried a few times on failure; the names of containers
+// that still failed are returned so the caller can report them.
+func AddDomainToAllContainers(domain string) ([]string, error) {
+	unlock, err := LockFleet("add domain "+domain, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
 	// Get all running containers
-	cmd := exec.Command("docker", "ps", "--filter", "status=running", "--format", "{{.Names}}")
-	output, err := cmd.Output()
+	output, err := commandOutput("docker", "ps", "--filter", "status=running", "--format", "{{.Names}}")
 	if err != nil {
-		return fmt.Errorf("failed to list running containers: %w", err)
+		return nil, fmt.Errorf("failed to list running containers: %w", err)
 	}
 
 	containerNames := strings.Split(strings.TrimSpace(string(output)), "\n")
 	if len(containerNames) == 0 || (len(containerNames) == 1 && containerNames[0] == "") {
-		return nil // No running containers
+		return nil, nil // No running containers
 	}
 
 	// Add domain to each running container
+	var failed []string
 	for _, containerName := range containerNames {
-		if err := AddDomainToContainer(containerName, domain); err != nil {
+		for attempt := 1; attempt <= addDomainAttempts; attempt++ {
+			err = addDomainFunc(containerName, domain)
+			if err == nil {
+				break
+			}
+			if attempt < addDomainAttempts {
+				time.Sleep(addDomainRetryDelay)
+			}
+		}
+		if err != nil {
 			// Log error but continue with other containers
-			fmt.Fprintf(os.Stderr, "Warning: failed to add domain to %s: %v\n", containerName, err)
+			fmt.Fprintf(os.Stderr, "Warning: failed to add domain to %s after %d attempts: %v\n", containerName, addDomainAttempts, err)
+			failed = append(failed, containerName)
 		}
 	}
 
-	return nil
+	return failed, nil
+}
+
+// domainPattern matches a hostname such as "github.com" or "api.example.co.uk"
+var domainPattern = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9-]{0,61}[A-Za-z0-9])?(\.[A-Za-z0-9]([A-Za-z0-9-]{0,61}[A-Za-z0-9])?)*$`)
+
+// IsValidDomain reports whether domain is a plain hostname that is safe to
+// write into the firewall's dnsmasq config
+func IsValidDomain(domain string) bool {
+	return len(domain) <= 253 && domainPattern.MatchString(domain)
 }
 
 // AddDomainToContainer adds a domain to a specific container's firewall
 func AddDomainToContainer(containerName, domain string) error {
+	if !IsValidDomain(domain) {
+		return fmt.Errorf("invalid domain %q", domain)
+	}
+
 	dnsmasqConf := "/tmp/dnsmasq-firewall.conf"
 
 	// Check if domain already in config
-	checkConfCmd := exec.Command("docker", "exec", containerName, "grep", "-q", fmt.Sprintf("ipset=/%s/", domain), dnsmasqConf)
+	checkConfCmd := system.DockerCommand("exec", containerName, "grep", "-q", fmt.Sprintf("ipset=/%s/", domain), dnsmasqConf)
 	if checkConfCmd.Run() == nil {
 		return nil // Already configured
 	}
 
 	// Append domain to dnsmasq config
-	appendCmd := exec.Command("docker", "exec", "-u", "root", containerName, "sh", "-c",
+	appendCmd := system.DockerCommand("exec", "-u", "root", containerName, "sh", "-c",
 		fmt.Sprintf("echo 'ipset=/%s/allowed-domains' >> %s && echo 'server=/%s/8.8.8.8' >> %s",
 			domain, dnsmasqConf, domain, dnsmasqConf))
 	if err := appendCmd.Run(); err != nil {
@@ -202,14 +296,14 @@ func AddDomainToContainer(containerName, domain string) error {
 	}
 
 	// Restart dnsmasq
-	restartCmd := exec.Command("docker", "exec", "-u", "root", containerName, "sh", "-c",
+	restartCmd := system.DockerCommand("exec", "-u", "root", containerName, "sh", "-c",
 		"pkill -9 dnsmasq 2>/dev/null || true; sleep 0.2; dnsmasq --conf-file=/tmp/dnsmasq-firewall.conf")
 	if err := restartCmd.Run(); err != nil {
 		return fmt.Errorf("failed to restart dnsmasq: %w", err)
 	}
 
 	// Perform initial DNS resolution
-	resolveCmd := exec.Command("docker", "exec", containerName, "sh", "-c",
+	resolveCmd := system.DockerCommand("exec", containerName, "sh", "-c",
 		fmt.Sprintf("dig +short %s | head -5", domain))
 	_, _ = resolveCmd.Output() // Ignore errors from resolution
 
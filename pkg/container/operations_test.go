@@ -0,0 +1,83 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestIsValidDomain(t *testing.T) {
+	tests := map[string]bool{
+		"github.com":                 true,
+		"api.example.co.uk":          true,
+		"localhost":                  true,
+		"my-registry.internal":       true,
+		"":                           false,
+		"-bad.com":                   false,
+		"bad-.com":                   false,
+		"example..com":               false,
+		"https://github.com":         false,
+		"github.com; rm -rf /":       false,
+		"example.com' >> /etc/hosts": false,
+	}
+	for domain, want := range tests {
+		if got := IsValidDomain(domain); got != want {
+			t.Errorf("IsValidDomain(%q) = %v, want %v", domain, got, want)
+		}
+	}
+}
+
+func TestAddDomainToAllContainersRetries(t *testing.T) {
+	origOutput, origAdd, origDelay, origLock := commandOutput, addDomainFunc, addDomainRetryDelay, fleetLockPath
+	t.Cleanup(func() {
+		commandOutput, addDomainFunc, addDomainRetryDelay, fleetLockPath = origOutput, origAdd, origDelay, origLock
+	})
+
+	lockPath := filepath.Join(t.TempDir(), fleetLockFile)
+	fleetLockPath = func() string { return lockPath }
+
+	commandOutput = func(name string, args ...string) ([]byte, error) {
+		return []byte("maestro-flaky-1\nmaestro-ok-1\nmaestro-broken-1\n"), nil
+	}
+	addDomainRetryDelay = 0
+
+	// flaky fails once, broken never succeeds
+	attempts := make(map[string]int)
+	addDomainFunc = func(containerName, domain string) error {
+		attempts[containerName]++
+		switch {
+		case containerName == "maestro-broken-1":
+			return errors.New("dnsmasq failed to start")
+		case containerName == "maestro-flaky-1" && attempts[containerName] == 1:
+			return errors.New("dnsmasq restart race")
+		}
+		return nil
+	}
+
+	failed, err := AddDomainToAllContainers("api.example.com")
+	if err != nil {
+		t.Fatalf("AddDomainToAllContainers() error = %v", err)
+	}
+	if want := []string{"maestro-broken-1"}; !reflect.DeepEqual(failed, want) {
+		t.Errorf("failed = %q, want %q", failed, want)
+	}
+	wantAttempts := map[string]int{"maestro-flaky-1": 2, "maestro-ok-1": 1, "maestro-broken-1": addDomainAttempts}
+	if !reflect.DeepEqual(attempts, wantAttempts) {
+		t.Errorf("attempts = %v, want %v", attempts, wantAttempts)
+	}
+}
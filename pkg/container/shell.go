@@ -0,0 +1,71 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import "github.com/uprockcom/maestro/pkg/system"
+
+// shellFixScript patches .zshrc for a better terminal experience. It exits
+// early if the custom prompt is already present, so it is safe to run repeatedly.
+const shellFixScript = `grep -q 'Custom MCL prompt' /home/node/.zshrc && exit 0
+
+# Remove TERM override
+sed -i '/^export TERM=xterm$/d' /home/node/.zshrc
+
+# Disable powerlevel10k theme (causes missing font glyphs)
+sed -i 's/^ZSH_THEME=.*/ZSH_THEME=""/' /home/node/.zshrc
+
+# Add custom prompt with readable symbols and colors
+cat >> /home/node/.zshrc << 'PROMPT_EOF'
+
+# Custom MCL prompt with colors and git status
+autoload -Uz vcs_info
+precmd_vcs_info() { vcs_info }
+precmd_functions+=( precmd_vcs_info )
+setopt prompt_subst
+zstyle ':vcs_info:git:*' formats '%b'
+zstyle ':vcs_info:*' enable git
+
+# Git status indicators (matching maestro list command)
+git_status_symbols() {
+    if [[ -n ${vcs_info_msg_0_} ]]; then
+        local git_status=""
+        local changes=$(git status --porcelain 2>/dev/null | wc -l | tr -d ' ')
+        local ahead=$(git rev-list --count @{u}..HEAD 2>/dev/null || echo "0")
+        local behind=$(git rev-list --count HEAD..@{u} 2>/dev/null || echo "0")
+
+        [[ $changes -gt 0 ]] && git_status+="Δ$changes "
+        [[ $ahead -gt 0 ]] && git_status+="↑$ahead "
+        [[ $behind -gt 0 ]] && git_status+="↓$behind "
+        [[ -z $git_status ]] && git_status="✓ "
+
+        echo "$git_status"
+    fi
+}
+
+PROMPT='%F{green}%n%f  %F{blue}%~%f  %F{magenta}${vcs_info_msg_0_}%f %F{yellow}$(git_status_symbols)%f'
+PROMPT_EOF`
+
+// EnsureShellConfig applies the maestro shell fixes (no TERM override, no p10k,
+// custom prompt) to a running container. Containers that already have them are untouched.
+func EnsureShellConfig(containerName string) error {
+	cmd := system.DockerCommand("exec", containerName, "sh", "-c", shellFixScript)
+	return cmd.Run()
+}
+
+// HasShellConfig reports whether the maestro shell fixes are already applied
+func HasShellConfig(containerName string) bool {
+	cmd := system.DockerCommand("exec", containerName, "grep", "-q", "Custom MCL prompt", "/home/node/.zshrc")
+	return cmd.Run() == nil
+}
@@ -0,0 +1,176 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeTmux replaces commandOutput for the duration of a test, returning output
+// (or err) for every command run in the container
+func fakeTmux(t *testing.T, output string, err error) *[]string {
+	t.Helper()
+	var calls []string
+	orig := commandOutput
+	commandOutput = func(name string, args ...string) ([]byte, error) {
+		calls = append(calls, name+" "+strings.Join(args, " "))
+		return []byte(output), err
+	}
+	t.Cleanup(func() { commandOutput = orig })
+	return &calls
+}
+
+func TestCheckBellStatus(t *testing.T) {
+	errNoSession := errors.New("exit status 1: can't find session: main")
+
+	tests := []struct {
+		name   string
+		output string
+		err    error
+		want   bool
+	}{
+		{"no flags", "0:0\n0:0\n", nil, false},
+		{"bell on first window", "1:0\n0:0\n", nil, true},
+		{"silence on second window", "0:0\n0:1\n", nil, true},
+		{"both flags", "1:1\n", nil, true},
+		{"crlf line endings", "0:0\r\n0:1\r\n", nil, true},
+		{"missing session", "", errNoSession, false},
+		{"empty output", "", nil, false},
+		{"malformed lines ignored", "1\n:\n1:0:1\ngarbage\n0:0\n", nil, false},
+		{"malformed then valid", "oops\n0:1\n", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := fakeTmux(t, tt.output, tt.err)
+			if got := CheckBellStatus("maestro-a-1"); got != tt.want {
+				t.Errorf("CheckBellStatus() = %v, want %v", got, tt.want)
+			}
+			if len(*calls) != 1 || !strings.Contains((*calls)[0], "list-windows -a") {
+				t.Errorf("unexpected commands: %v", *calls)
+			}
+		})
+	}
+}
+
+func TestGetLastActivity(t *testing.T) {
+	ago := func(d time.Duration) string {
+		return strconv.FormatInt(time.Now().Add(-d).Unix(), 10) + "\n"
+	}
+
+	tests := []struct {
+		name   string
+		output string
+		err    error
+		want   string
+	}{
+		{"minutes", ago(5 * time.Minute), nil, "5m"},
+		{"latest of several sessions", ago(3*time.Hour) + ago(5*time.Minute) + ago(48*time.Hour), nil, "5m"},
+		{"unparseable panes skipped", "\n" + ago(3*time.Hour), nil, "3.0h"},
+		{"hours", ago(3 * time.Hour), nil, "3.0h"},
+		{"days", ago(48 * time.Hour), nil, "2.0d"},
+		{"missing session", "", errors.New("exit status 1"), "-"},
+		{"empty format", "\n", nil, "-"},
+		{"not a timestamp", "never\n", nil, "-"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeTmux(t, tt.output, tt.err)
+			if got := GetLastActivity("maestro-a-1"); got != tt.want {
+				t.Errorf("GetLastActivity() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsClaudeRunning(t *testing.T) {
+	const header = "STAT COMMAND\n"
+	tests := []struct {
+		name   string
+		output string
+		err    error
+		want   bool
+	}{
+		{"interactive session", header + "Ss   tmux new-session -d -s main claude --dangerously-skip-permissions\nS+   claude --dangerously-skip-permissions\n", nil, true},
+		{"run via node", header + "Sl+  node /usr/local/bin/claude --dangerously-skip-permissions\n", nil, true},
+		{"print mode only", header + "Sl   claude --print Generate branch name --model haiku --dangerously-skip-permissions\n", nil, false},
+		{"tmux server after claude exited", header + "Ss   tmux new-session -d -s main claude --dangerously-skip-permissions\nSs+  bash\n", nil, false},
+		{"shell wrapper only", header + "S    sh -c claude --dangerously-skip-permissions\n", nil, false},
+		{"zombie", header + "Z+   claude --dangerously-skip-permissions\n", nil, false},
+		{"docker exec fails", "", errors.New("exit status 1"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeTmux(t, tt.output, tt.err)
+			if got := IsClaudeRunning("maestro-test-1"); got != tt.want {
+				t.Errorf("IsClaudeRunning() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTmuxState(t *testing.T) {
+	exitErr := errors.New("exit status 1")
+	tests := []struct {
+		name   string
+		output string
+		err    error
+		want   TmuxState
+	}{
+		{"running", "", nil, TmuxSessionRunning},
+		{"session gone", "can't find session: main\n", exitErr, TmuxNoSession},
+		{"server gone", "no server running on /tmp/tmux-1000/default\n", exitErr, TmuxNoServer},
+		{"socket gone", "error connecting to /tmp/tmux-1000/default (No such file or directory)\n", exitErr, TmuxNoServer},
+		{"container stopped", "Error response from daemon: container abc is not running\n", exitErr, TmuxStateUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseTmuxState(tt.output, tt.err); got != tt.want {
+				t.Errorf("parseTmuxState() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTmuxWindows(t *testing.T) {
+	output := "main\t0\tclaude\nmain\t1\tshell\nscratch\t0\tmy window\nbogus line\n"
+	want := []TmuxWindow{
+		{Session: "main", Index: 0, Name: "claude"},
+		{Session: "main", Index: 1, Name: "shell"},
+		{Session: "scratch", Index: 0, Name: "my window"},
+	}
+	if got := parseTmuxWindows(output); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseTmuxWindows() = %+v, want %+v", got, want)
+	}
+	if got := parseTmuxWindows(""); got != nil {
+		t.Errorf("parseTmuxWindows(\"\") = %+v, want nil", got)
+	}
+
+	withPaths := "main\t0\tclaude\t/workspace\nmain\t2\tlogs\t/var/log/app\n"
+	want = []TmuxWindow{
+		{Session: "main", Index: 0, Name: "claude", Path: "/workspace"},
+		{Session: "main", Index: 2, Name: "logs", Path: "/var/log/app"},
+	}
+	if got := parseTmuxWindows(withPaths); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseTmuxWindows() with paths = %+v, want %+v", got, want)
+	}
+}
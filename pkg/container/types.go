@@ -35,35 +35,51 @@ type Info struct {
 	StatusDetails  string
 	Branch         string
 	NeedsAttention bool
-	IsDormant      bool      // Claude process not running
-	AuthStatus     string    // Token expiration status
-	LastActivity   string    // Time since last activity
-	GitStatus      string    // Git status indicators
-	CreatedAt      time.Time // Container creation time
+	IsDormant      bool            // Claude process not running
+	AuthStatus     string          // Token expiration status
+	LastActivity   string          // Time since last activity
+	GitStatus      string          // Git status indicators
+	CreatedAt      time.Time       // Container creation time
+	FirewallOff    bool            // Firewall disabled with 'maestro firewall disable'
+	Limits         *ResourceLimits // CPU and memory caps; nil if they couldn't be read
+}
+
+// BranchGroup holds all containers that are on the same git branch
+type BranchGroup struct {
+	Branch     string
+	Containers []Info
 }
 
 // DisplayOptions configures how containers are displayed
 type DisplayOptions struct {
 	ShowNumbers bool // Show selection numbers (for interactive selection)
 	ShowTable   bool // Show full table format with all columns
+	ShowAge     bool // Add an AGE column (time since creation) to the table
 }
 
 // ContainerDetails holds comprehensive information about a container for the details view
 type ContainerDetails struct {
-	Name          string
-	ShortName     string
-	Status        string
-	StatusDetails string
-	Branch        string
-	GitStatus     string
-	AuthStatus    string
-	LastActivity  string
-	Uptime        string
-	CPUs          string
-	Memory        string
-	IPAddress     string
-	Ports         []string
-	Volumes       []string
-	Environment   []string
-	RecentLogs    string
+	Name           string
+	ShortName      string
+	Status         string
+	StatusDetails  string
+	Branch         string
+	GitStatus      string
+	GitFiles       []string // Per-file `git status --short` lines (running containers only)
+	AuthStatus     string
+	AuthProblem    string // Why the credentials are "✗ INVALID", if they are
+	LastActivity   string
+	Uptime         string
+	CPUs           string
+	Memory         string
+	CPUUsage       string // Live CPU% from docker stats (running containers only)
+	MemoryUsage    string // Live memory usage from docker stats (running containers only)
+	IPAddress      string
+	FirewallOff    bool
+	Ports          []string
+	Volumes        []string
+	Environment    []string
+	RecentLogs     string
+	LaunchCommand  string // How Claude was started in tmux (live from tmux, else the create-time label)
+	ConnectCommand string // Custom command run on connect, if the container has one
 }
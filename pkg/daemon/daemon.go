@@ -22,11 +22,22 @@ import (
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"strconv"
 	"strings"
+	"syscall"
 	"time"
+
+	"github.com/uprockcom/maestro/pkg/paths"
+	"github.com/uprockcom/maestro/pkg/system"
 )
 
+// AttentionFile is the name of the file (in the maestro dir) listing containers that need attention
+const AttentionFile = "attention"
+
+// PIDFile is the name of the file (in the maestro dir) holding the running daemon's PID
+const PIDFile = "daemon.pid"
+
 // Config holds daemon configuration
 type Config struct {
 	CheckInterval      time.Duration
@@ -37,6 +48,8 @@ type Config struct {
 	QuietHoursStart    string
 	QuietHoursEnd      string
 	ContainerPrefix    string
+	WebhookURL         string // POST JSON events here when set
+	WebhookSecret      string // HMAC-SHA256 key for the signature header (optional)
 }
 
 // Daemon manages background monitoring and auto-refresh
@@ -44,6 +57,7 @@ type Daemon struct {
 	config            Config
 	logFile           *os.File
 	pidFile           string
+	attentionFile     string
 	stopChan          chan bool
 	containerStates   map[string]*ContainerState
 	iconPath          string // Cached icon path for notifications
@@ -58,6 +72,8 @@ type ContainerState struct {
 	LastActivity        time.Time
 	LastTokenCheck      time.Time
 	NotificationSent    bool
+	ClaudeSeen          bool // ClaudeRunning has been checked at least once
+	ClaudeRunning       bool
 }
 
 // New creates a new daemon instance
@@ -71,7 +87,8 @@ func New(config Config, mclDir string, iconData []byte) (*Daemon, error) {
 	d := &Daemon{
 		config:          config,
 		logFile:         logFile,
-		pidFile:         filepath.Join(mclDir, "daemon.pid"),
+		pidFile:         filepath.Join(mclDir, PIDFile),
+		attentionFile:   filepath.Join(mclDir, AttentionFile),
 		stopChan:        make(chan bool),
 		containerStates: make(map[string]*ContainerState),
 	}
@@ -192,10 +209,18 @@ func (d *Daemon) check() {
 
 		// Check attention status
 		d.checkAttentionStatus(container, state)
+
+		// Watch for Claude exiting (only reported via webhook)
+		if d.config.WebhookURL != "" {
+			d.checkClaudeExit(container, state)
+		}
 	}
 
 	// Cleanup states for removed containers
 	d.cleanupStates(containers)
+
+	// Publish attention state for cheap readers like 'maestro status --compact'
+	d.writeAttentionState()
 }
 
 // checkTokenExpiry checks and refreshes tokens if needed
@@ -207,13 +232,25 @@ func (d *Daemon) checkTokenExpiry(container string, state *ContainerState) {
 	state.LastTokenCheck = time.Now()
 
 	// Extract credentials
-	tmpFile := fmt.Sprintf("/tmp/maestro-creds-%s-%d.json", container, time.Now().Unix())
+	tmp, err := os.CreateTemp("", "maestro-creds-*.json")
+	if err != nil {
+		return
+	}
+	tmp.Close()
+	tmpFile := tmp.Name()
 	defer os.Remove(tmpFile)
 
-	copyCmd := exec.Command("docker", "cp",
-		fmt.Sprintf("%s:/home/node/.claude/.credentials.json", container),
-		tmpFile)
-	if err := copyCmd.Run(); err != nil {
+	copied := false
+	for _, name := range paths.CredentialsFileCandidates() {
+		copyCmd := system.DockerCommand("cp",
+			fmt.Sprintf("%s:/home/node/.claude/%s", container, name),
+			tmpFile)
+		if copyCmd.Run() == nil {
+			copied = true
+			break
+		}
+	}
+	if !copied {
 		return // No credentials, skip
 	}
 
@@ -254,6 +291,7 @@ func (d *Daemon) checkAttentionStatus(container string, state *ContainerState) {
 			state.AttentionStarted = &now
 			state.NotificationSent = false
 			d.logInfo("Container %s needs attention", d.getShortName(container))
+			d.sendWebhook(WebhookAttentionNeeded, container, "needs_attention")
 		}
 
 		// Check if we should notify
@@ -407,7 +445,9 @@ func (d *Daemon) isQuietHours() bool {
 // Helper functions
 
 func (d *Daemon) getRunningContainers() ([]string, error) {
-	cmd := exec.Command("docker", "ps", "--format", "{{.Names}}")
+	// Paused containers can't be exec'd into, so leave them out rather than
+	// mistaking them for containers where Claude exited
+	cmd := system.DockerCommand("ps", "--filter", "status=running", "--format", "{{.Names}}")
 	output, err := cmd.Output()
 	if err != nil {
 		return nil, err
@@ -429,8 +469,8 @@ func (d *Daemon) getRunningContainers() ([]string, error) {
 }
 
 func (d *Daemon) checkBellStatus(container string) bool {
-	cmd := exec.Command("docker", "exec", container,
-		"tmux", "list-windows", "-t", "main", "-F", "#{window_bell_flag}:#{window_silence_flag}")
+	cmd := system.DockerCommand("exec", container,
+		"tmux", "list-windows", "-a", "-F", "#{window_bell_flag}:#{window_silence_flag}")
 	output, err := cmd.Output()
 	if err != nil {
 		return false
@@ -460,6 +500,72 @@ func (d *Daemon) cleanupStates(activeContainers []string) {
 	}
 }
 
+// writeAttentionState records which containers currently need attention,
+// one name per line. Written atomically so readers never see a partial file.
+func (d *Daemon) writeAttentionState() {
+	var names []string
+	for name, state := range d.containerStates {
+		if state.AttentionStarted != nil {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	data := strings.Join(names, "\n")
+	if len(names) > 0 {
+		data += "\n"
+	}
+
+	tmpPath := d.attentionFile + ".tmp"
+	if err := os.WriteFile(tmpPath, []byte(data), 0644); err != nil {
+		d.logError("Failed to write attention state: %v", err)
+		return
+	}
+	if err := os.Rename(tmpPath, d.attentionFile); err != nil {
+		d.logError("Failed to write attention state: %v", err)
+	}
+}
+
+// ReadAttentionState returns the set of containers the daemon last saw needing attention
+func ReadAttentionState(mclDir string) (map[string]bool, error) {
+	data, err := os.ReadFile(filepath.Join(mclDir, AttentionFile))
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]bool)
+	for _, line := range strings.Split(string(data), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			names[line] = true
+		}
+	}
+	return names, nil
+}
+
+// IsRunning reads a daemon PID file and reports the PID and whether that
+// process is alive
+func IsRunning(pidFile string) (int, bool) {
+	data, err := os.ReadFile(pidFile)
+	if err != nil {
+		return 0, false
+	}
+
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return 0, false
+	}
+
+	// Check if process exists
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return 0, false
+	}
+
+	// Send signal 0 to check if process is alive
+	err = process.Signal(syscall.Signal(0))
+	return pid, err == nil
+}
+
 func (d *Daemon) writePID() error {
 	pid := os.Getpid()
 	return os.WriteFile(d.pidFile, []byte(strconv.Itoa(pid)), 0644)
@@ -467,6 +573,7 @@ func (d *Daemon) writePID() error {
 
 func (d *Daemon) cleanup() {
 	os.Remove(d.pidFile)
+	os.Remove(d.attentionFile)
 	d.logFile.Close()
 }
 
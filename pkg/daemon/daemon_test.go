@@ -0,0 +1,52 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package daemon
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+func TestIsRunning(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	tests := []struct {
+		name    string
+		pidFile string
+		wantPID int
+		want    bool
+	}{
+		{"this process", write("self.pid", strconv.Itoa(os.Getpid())+"\n"), os.Getpid(), true},
+		{"not a number", write("garbage.pid", "abc"), 0, false},
+		{"missing file", filepath.Join(dir, "missing.pid"), 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pid, running := IsRunning(tt.pidFile)
+			if pid != tt.wantPID || running != tt.want {
+				t.Errorf("IsRunning() = %d, %v, want %d, %v", pid, running, tt.wantPID, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package daemon
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/uprockcom/maestro/pkg/container"
+)
+
+// Webhook event types
+const (
+	WebhookAttentionNeeded = "attention_needed"
+	WebhookClaudeExited    = "claude_exited"
+)
+
+// SignatureHeader carries the HMAC-SHA256 signature of the webhook body
+const SignatureHeader = "X-Maestro-Signature"
+
+// WebhookPayload is the JSON body POSTed to the webhook URL
+type WebhookPayload struct {
+	Event     string    `json:"event"`
+	Container string    `json:"container"`
+	ShortName string    `json:"short_name"`
+	Branch    string    `json:"branch"`
+	Task      string    `json:"task"`
+	State     string    `json:"state"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+var webhookClient = &http.Client{Timeout: 10 * time.Second}
+
+// Container probes, shared with 'maestro list' so webhooks report the same
+// values (replaced in tests)
+var (
+	claudeRunning   = container.IsClaudeRunning
+	containerBranch = container.GetBranchName
+	containerTask   = container.GetTaskDescription
+)
+
+// signPayload returns the signature header value for body using secret
+func signPayload(body []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// sendWebhook POSTs an event for a container to the configured webhook URL
+func (d *Daemon) sendWebhook(event, container, state string) {
+	if d.config.WebhookURL == "" {
+		return
+	}
+
+	payload := WebhookPayload{
+		Event:     event,
+		Container: container,
+		ShortName: d.getShortName(container),
+		Branch:    containerBranch(container),
+		Task:      fmt.Sprintf("%.200s", containerTask(container)),
+		State:     state,
+		Timestamp: time.Now().UTC(),
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		d.logError("Failed to encode webhook payload: %v", err)
+		return
+	}
+
+	req, err := http.NewRequest(http.MethodPost, d.config.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		d.logError("Invalid webhook URL: %v", err)
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "maestro-daemon")
+	if d.config.WebhookSecret != "" {
+		req.Header.Set(SignatureHeader, signPayload(body, d.config.WebhookSecret))
+	}
+
+	resp, err := webhookClient.Do(req)
+	if err != nil {
+		d.logError("Webhook %s for %s failed: %v", event, payload.ShortName, err)
+		return
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		d.logError("Webhook %s for %s returned %s", event, payload.ShortName, resp.Status)
+		return
+	}
+	d.logInfo("Webhook %s sent for %s", event, payload.ShortName)
+}
+
+// checkClaudeExit sends a webhook when Claude stops running in a container
+func (d *Daemon) checkClaudeExit(container string, state *ContainerState) {
+	running := claudeRunning(container)
+	if state.ClaudeSeen && state.ClaudeRunning && !running {
+		d.logInfo("Claude exited in %s", d.getShortName(container))
+		d.sendWebhook(WebhookClaudeExited, container, "dormant")
+	}
+	state.ClaudeSeen = true
+	state.ClaudeRunning = running
+}
@@ -0,0 +1,111 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package daemon
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestSignPayload(t *testing.T) {
+	tests := []struct {
+		name   string
+		body   string
+		secret string
+		want   string
+	}{
+		{
+			// Reference value from RFC 4231 test case 2
+			name:   "rfc4231",
+			body:   "what do ya want for nothing?",
+			secret: "Jefe",
+			want:   "sha256=5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843",
+		},
+		{
+			name:   "empty body",
+			body:   "",
+			secret: "key",
+			want:   "sha256=5d5d139563c95b5967b9bd9a8c9b233a9dedb45072794cd232dc1b74832607d0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := signPayload([]byte(tt.body), tt.secret); got != tt.want {
+				t.Errorf("signPayload() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckClaudeExit(t *testing.T) {
+	origRunning, origBranch, origTask := claudeRunning, containerBranch, containerTask
+	t.Cleanup(func() { claudeRunning, containerBranch, containerTask = origRunning, origBranch, origTask })
+	containerBranch = func(string) string { return "feat/auth" }
+	containerTask = func(string) string { return "add auth" }
+
+	tests := []struct {
+		name   string
+		probes []bool // IsClaudeRunning results, one per daemon check
+		want   int    // claude_exited webhooks sent
+	}{
+		{"exits after running", []bool{true, true, false}, 1},
+		{"stays running", []bool{true, true, true}, 0},
+		{"never seen running", []bool{false, false}, 0},
+		{"starts after the first check", []bool{false, true}, 0},
+		{"exits once, stays exited", []bool{true, false, false, false}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var received []WebhookPayload
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var p WebhookPayload
+				json.NewDecoder(r.Body).Decode(&p)
+				mu.Lock()
+				received = append(received, p)
+				mu.Unlock()
+			}))
+			defer server.Close()
+
+			probes := tt.probes
+			claudeRunning = func(string) bool {
+				running := probes[0]
+				probes = probes[1:]
+				return running
+			}
+
+			d := &Daemon{config: Config{WebhookURL: server.URL, ContainerPrefix: "maestro-"}}
+			state := &ContainerState{}
+			for range tt.probes {
+				d.checkClaudeExit("maestro-feat-auth-1", state)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if len(received) != tt.want {
+				t.Fatalf("sent %d webhooks, want %d", len(received), tt.want)
+			}
+			for _, p := range received {
+				if p.Event != WebhookClaudeExited || p.ShortName != "feat-auth-1" || p.Branch != "feat/auth" || p.Task != "add auth" {
+					t.Errorf("payload = %+v", p)
+				}
+			}
+		})
+	}
+}
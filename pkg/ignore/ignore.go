@@ -0,0 +1,226 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ignore implements gitignore-style path matching for deciding which
+// project files are copied into containers.
+package ignore
+
+import (
+	"bufio"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Ignore files checked in the project root, in priority order
+const (
+	MaestroIgnoreFile = ".maestroignore"
+	GitIgnoreFile     = ".gitignore"
+)
+
+// rule is a single compiled ignore pattern
+type rule struct {
+	re      *regexp.Regexp
+	negate  bool // Pattern started with "!"
+	dirOnly bool // Pattern ended with "/"
+}
+
+// Matcher decides whether paths are ignored using gitignore semantics:
+// later patterns override earlier ones, "!" re-includes, a trailing "/"
+// matches only directories, and patterns containing "/" are anchored to the root.
+type Matcher struct {
+	rules []rule
+}
+
+// Parse compiles gitignore-style lines. Blank lines and comments are skipped.
+func Parse(lines []string) *Matcher {
+	m := &Matcher{}
+	for _, line := range lines {
+		line = strings.TrimRight(line, " \t\r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		var r rule
+		if strings.HasPrefix(line, "!") {
+			r.negate = true
+			line = line[1:]
+		} else if strings.HasPrefix(line, `\`) {
+			// "\#" and "\!" escape a literal leading character
+			line = line[1:]
+		}
+
+		if strings.HasSuffix(line, "/") {
+			r.dirOnly = true
+			line = strings.TrimRight(line, "/")
+		}
+		if line == "" {
+			continue
+		}
+
+		// A slash anywhere but the end anchors the pattern to the root
+		anchored := strings.Contains(line, "/")
+		line = strings.TrimPrefix(line, "/")
+
+		expr := globToRegexp(line)
+		if anchored {
+			expr = "^" + expr + "$"
+		} else {
+			expr = "^(?:.*/)?" + expr + "$"
+		}
+
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			continue // Skip patterns we can't express
+		}
+		r.re = re
+		m.rules = append(m.rules, r)
+	}
+	return m
+}
+
+// globToRegexp converts a gitignore glob to an unanchored regular expression
+func globToRegexp(glob string) string {
+	var b strings.Builder
+	for i := 0; i < len(glob); i++ {
+		c := glob[i]
+		switch c {
+		case '*':
+			if i+1 < len(glob) && glob[i+1] == '*' {
+				// "**/" matches zero or more directories; a bare "**" matches everything
+				if i+2 < len(glob) && glob[i+2] == '/' {
+					b.WriteString("(?:.*/)?")
+					i += 2
+				} else {
+					b.WriteString(".*")
+					i++
+				}
+				continue
+			}
+			b.WriteString("[^/]*")
+		case '?':
+			b.WriteString("[^/]")
+		case '[':
+			end := strings.IndexByte(glob[i+1:], ']')
+			if end < 0 {
+				b.WriteString(`\[`)
+				continue
+			}
+			class := glob[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + class + "]")
+			i += end + 1
+		case '\\':
+			if i+1 < len(glob) {
+				i++
+				b.WriteString(regexp.QuoteMeta(string(glob[i])))
+			}
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	return b.String()
+}
+
+// Match reports whether relPath (slash-separated, relative to the root) is ignored
+func (m *Matcher) Match(relPath string, isDir bool) bool {
+	if m == nil {
+		return false
+	}
+	relPath = filepath.ToSlash(relPath)
+
+	ignored := false
+	for _, r := range m.rules {
+		if r.dirOnly && !isDir {
+			continue
+		}
+		if r.re.MatchString(relPath) {
+			ignored = !r.negate
+		}
+	}
+	return ignored
+}
+
+// Load reads the ignore rules for a project directory. .maestroignore takes
+// precedence; .gitignore is used as a fallback. Returns the file used, or "" if neither exists.
+func Load(dir string) (*Matcher, string, error) {
+	for _, name := range []string{MaestroIgnoreFile, GitIgnoreFile} {
+		lines, err := readLines(filepath.Join(dir, name))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, "", err
+		}
+		return Parse(lines), name, nil
+	}
+	return &Matcher{}, "", nil
+}
+
+func readLines(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	return lines, scanner.Err()
+}
+
+// ListFiles walks root and returns the relative paths of all files, symlinks, and
+// directories that are not ignored. Names in alwaysSkip (e.g. "node_modules")
+// are skipped at any depth regardless of the rules. Contents of ignored
+// directories are never listed.
+func ListFiles(root string, m *Matcher, alwaysSkip []string) ([]string, error) {
+	skip := make(map[string]bool, len(alwaysSkip))
+	for _, name := range alwaysSkip {
+		skip[name] = true
+	}
+
+	var files []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == root {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		if skip[d.Name()] || m.Match(rel, d.IsDir()) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		files = append(files, rel)
+		return nil
+	})
+	return files, err
+}
@@ -0,0 +1,103 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ignore
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestMatch(t *testing.T) {
+	m := Parse([]string{
+		"# comment",
+		"",
+		"*.log",
+		"!keep.log",
+		"build/",
+		"/dist",
+		"docs/**/*.tmp",
+		"**/cache",
+		"src/gen?.go",
+	})
+
+	tests := []struct {
+		path  string
+		isDir bool
+		want  bool
+	}{
+		{"app.log", false, true},
+		{"logs/app.log", false, true},
+		{"keep.log", false, false},
+		{"build", true, true},
+		{"build", false, false}, // dir-only pattern
+		{"sub/build", true, true},
+		{"dist", true, true},
+		{"sub/dist", true, false}, // anchored to root
+		{"docs/a.tmp", false, true},
+		{"docs/x/y/a.tmp", false, true},
+		{"other/a.tmp", false, false},
+		{"cache", true, true},
+		{"a/b/cache", true, true},
+		{"src/gen1.go", false, true},
+		{"src/gen10.go", false, false},
+		{"main.go", false, false},
+	}
+
+	for _, tt := range tests {
+		if got := m.Match(tt.path, tt.isDir); got != tt.want {
+			t.Errorf("Match(%q, %v) = %v, want %v", tt.path, tt.isDir, got, tt.want)
+		}
+	}
+}
+
+func TestLoadPrefersMaestroIgnore(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, GitIgnoreFile), []byte("*.go\n"), 0644)
+
+	_, source, err := Load(dir)
+	if err != nil || source != GitIgnoreFile {
+		t.Fatalf("Load() source = %q, err = %v; want %q", source, err, GitIgnoreFile)
+	}
+
+	os.WriteFile(filepath.Join(dir, MaestroIgnoreFile), []byte("*.md\n"), 0644)
+	m, source, err := Load(dir)
+	if err != nil || source != MaestroIgnoreFile {
+		t.Fatalf("Load() source = %q, err = %v; want %q", source, err, MaestroIgnoreFile)
+	}
+	if m.Match("main.go", false) || !m.Match("README.md", false) {
+		t.Error("Load() should use .maestroignore rules only")
+	}
+}
+
+func TestListFiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, f := range []string{"main.go", "app.log", "build/out.bin", "node_modules/x/index.js", "src/lib.go"} {
+		path := filepath.Join(dir, f)
+		os.MkdirAll(filepath.Dir(path), 0755)
+		os.WriteFile(path, nil, 0644)
+	}
+
+	files, err := ListFiles(dir, Parse([]string{"*.log", "build/"}), []string{"node_modules"})
+	if err != nil {
+		t.Fatalf("ListFiles() error = %v", err)
+	}
+
+	want := []string{"main.go", "src", "src/lib.go"}
+	if !reflect.DeepEqual(files, want) {
+		t.Errorf("ListFiles() = %v, want %v", files, want)
+	}
+}
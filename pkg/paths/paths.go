@@ -56,6 +56,54 @@ func AuthDir() string {
 	return filepath.Join(GetConfigDir(), ".claude")
 }
 
+// DefaultCredentialsFile is the file Claude CLI keeps its OAuth credentials in
+const DefaultCredentialsFile = ".credentials.json"
+
+// knownCredentialsFiles are the names Claude CLI versions have used for the
+// credentials file, tried after the configured one when reading
+var knownCredentialsFiles = []string{DefaultCredentialsFile, "credentials.json"}
+
+// credentialsFile is the configured credentials filename (auth.credentials_file)
+var credentialsFile = DefaultCredentialsFile
+
+// SetCredentialsFile sets the credentials filename used when writing
+// credentials and tried first when reading them. Empty means the default.
+func SetCredentialsFile(name string) {
+	if name == "" {
+		name = DefaultCredentialsFile
+	}
+	credentialsFile = name
+}
+
+// CredentialsFile returns the configured credentials filename
+func CredentialsFile() string {
+	return credentialsFile
+}
+
+// CredentialsFileCandidates returns the filenames to try when reading
+// credentials: the configured one, then the other known names
+func CredentialsFileCandidates() []string {
+	candidates := []string{credentialsFile}
+	for _, name := range knownCredentialsFiles {
+		if name != credentialsFile {
+			candidates = append(candidates, name)
+		}
+	}
+	return candidates
+}
+
+// FindCredentials returns the path of the first credentials file candidate
+// that exists in dir, or the configured one if none does
+func FindCredentials(dir string) string {
+	for _, name := range CredentialsFileCandidates() {
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return filepath.Join(dir, credentialsFile)
+}
+
 // GitHubAuthDir returns the path to the GitHub CLI authentication directory.
 // Unix/macOS: ~/.maestro/gh
 // Windows: %APPDATA%\maestro\gh
@@ -70,6 +118,13 @@ func CertificatesDir() string {
 	return filepath.Join(GetConfigDir(), "certificates")
 }
 
+// StateDir returns the path to the directory for runtime state (e.g. last-used container).
+// Unix/macOS: ~/.maestro/state
+// Windows: %APPDATA%\maestro\state
+func StateDir() string {
+	return filepath.Join(GetConfigDir(), "state")
+}
+
 // LegacyConfigFile returns the old config file path for migration detection.
 // Returns empty string on Windows (no legacy path on Windows).
 func LegacyConfigFile() string {
@@ -128,6 +183,11 @@ func EnsureConfigDir() error {
 	return os.MkdirAll(GetConfigDir(), 0755)
 }
 
+// EnsureStateDir creates the state directory if it doesn't exist.
+func EnsureStateDir() error {
+	return os.MkdirAll(StateDir(), 0755)
+}
+
 // EnsureAuthDir creates the authentication directory if it doesn't exist.
 func EnsureAuthDir() error {
 	return os.MkdirAll(AuthDir(), 0755)
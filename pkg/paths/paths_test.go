@@ -99,6 +99,20 @@ func TestGitHubAuthDir(t *testing.T) {
 	}
 }
 
+func TestStateDir(t *testing.T) {
+	dir := StateDir()
+
+	if dir == "" {
+		t.Fatal("StateDir() returned empty string")
+	}
+
+	// Should be inside config directory
+	configDir := GetConfigDir()
+	if !strings.HasPrefix(dir, configDir) {
+		t.Errorf("StateDir() = %q, should be inside %q", dir, configDir)
+	}
+}
+
 func TestLegacyPaths(t *testing.T) {
 	if runtime.GOOS == "windows" {
 		// No legacy paths on Windows
@@ -157,3 +171,34 @@ func TestEnsureDirs(t *testing.T) {
 		// Skip actual execution
 	})
 }
+
+func TestFindCredentials(t *testing.T) {
+	t.Cleanup(func() { SetCredentialsFile("") })
+
+	tests := []struct {
+		name       string
+		configured string
+		existing   []string
+		want       string
+	}{
+		{"default", "", []string{".credentials.json"}, ".credentials.json"},
+		{"falls back to a known name", "", []string{"credentials.json"}, "credentials.json"},
+		{"configured name wins", "creds-v2.json", []string{".credentials.json", "creds-v2.json"}, "creds-v2.json"},
+		{"known names after the configured one", "creds-v2.json", []string{".credentials.json"}, ".credentials.json"},
+		{"nothing found", "creds-v2.json", nil, "creds-v2.json"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetCredentialsFile(tt.configured)
+			dir := t.TempDir()
+			for _, name := range tt.existing {
+				if err := os.WriteFile(filepath.Join(dir, name), []byte("{}"), 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if got := FindCredentials(dir); got != filepath.Join(dir, tt.want) {
+				t.Errorf("FindCredentials() = %q, want %q", got, filepath.Join(dir, tt.want))
+			}
+		})
+	}
+}
@@ -15,21 +15,38 @@
 package system
 
 import (
+	"context"
+	"errors"
 	"os/exec"
+	"strings"
+	"time"
 )
 
+// Messages returned by IsDockerAvailable when Docker can't be used
+const (
+	DockerNotFound   = "Docker command not found in PATH"
+	DockerNotRunning = "Docker daemon not running"
+	DockerNoAccess   = "Permission denied on the Docker socket"
+)
+
+// dockerProbeTimeout bounds the docker ps run by IsDockerPermissionError
+const dockerProbeTimeout = 3 * time.Second
+
 // IsDockerAvailable checks if Docker is installed and the daemon is running
 func IsDockerAvailable() (bool, string) {
 	// Check if docker command exists
-	_, err := exec.LookPath("docker")
+	_, err := exec.LookPath(DockerBinary())
 	if err != nil {
-		return false, "Docker command not found in PATH"
+		return false, DockerNotFound
 	}
 
 	// Check if docker daemon is running
-	cmd := exec.Command("docker", "ps")
-	if err := cmd.Run(); err != nil {
-		return false, "Docker daemon not running"
+	cmd := DockerCommand("ps")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		if isPermissionDenied(string(output)) {
+			return false, DockerNoAccess
+		}
+		return false, DockerNotRunning
 	}
 
 	return true, "Docker is available"
@@ -50,3 +67,58 @@ func IsClaudeAvailable() (bool, string) {
 
 	return true, "Claude CLI is available"
 }
+
+// DockerGuidance returns what to do about a message from IsDockerAvailable
+func DockerGuidance(message string) []string {
+	switch message {
+	case DockerNotFound:
+		return []string{
+			"Install Docker Desktop (macOS/Windows) or Docker Engine (Linux),",
+			"and make sure the docker command is on your PATH.",
+		}
+	case DockerNotRunning:
+		return []string{
+			"Start Docker Desktop, or on Linux: sudo systemctl start docker.",
+			"If it is running, check that your user can access it: docker ps",
+		}
+	case DockerNoAccess:
+		return []string{
+			"Your user can't access the docker socket. Add yourself to the docker group",
+			"(sudo usermod -aG docker $USER, then log out and back in) or use sudo.",
+		}
+	}
+	return nil
+}
+
+// IsDockerPermissionError reports whether err came from docker being refused
+// access to its socket. The message and any docker stderr in the chain are
+// checked first; errors that only say "exit status 1" are confirmed by
+// running docker ps.
+func IsDockerPermissionError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if isPermissionDenied(err.Error()) {
+		return true
+	}
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		return false
+	}
+	if isPermissionDenied(string(exitErr.Stderr)) {
+		return true
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), dockerProbeTimeout)
+	defer cancel()
+	output, probeErr := DockerCommandContext(ctx, "ps").CombinedOutput()
+	return probeErr != nil && isPermissionDenied(string(output))
+}
+
+// isPermissionDenied matches docker's "permission denied while trying to
+// connect to the Docker daemon socket" (and podman's equivalent)
+func isPermissionDenied(output string) bool {
+	output = strings.ToLower(output)
+	return strings.Contains(output, "permission denied") &&
+		(strings.Contains(output, "socket") || strings.Contains(output, "docker daemon"))
+}
@@ -0,0 +1,100 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// DockerEnv is the environment variable that overrides docker.binary
+const DockerEnv = "MAESTRO_DOCKER"
+
+// Container runtimes behind the docker CLI (docker.runtime in config)
+const (
+	RuntimeDocker = "docker"
+	RuntimePodman = "podman"
+)
+
+// dockerBinary is the docker-compatible CLI from config
+var dockerBinary = "docker"
+
+// dockerRuntime is docker.runtime from config; "" detects it from the binary
+var dockerRuntime = ""
+
+// SetDockerBinary sets the docker-compatible CLI maestro runs (docker.binary
+// in config). Empty means docker on PATH.
+func SetDockerBinary(binary string) {
+	binary = strings.TrimSpace(binary)
+	if binary == "" {
+		binary = "docker"
+	}
+	dockerBinary = binary
+}
+
+// DockerBinary returns the docker CLI to run: $MAESTRO_DOCKER if set,
+// otherwise docker.binary from config, otherwise docker on PATH. It may be a
+// bare name looked up on PATH or a full path to a wrapper such as podman.
+func DockerBinary() string {
+	if binary := strings.TrimSpace(os.Getenv(DockerEnv)); binary != "" {
+		return binary
+	}
+	return dockerBinary
+}
+
+// SetDockerRuntime sets which runtime the docker CLI talks to (docker.runtime
+// in config): RuntimeDocker, RuntimePodman, or "" to detect it from the binary
+func SetDockerRuntime(runtime string) error {
+	runtime = strings.ToLower(strings.TrimSpace(runtime))
+	switch runtime {
+	case "", RuntimeDocker, RuntimePodman:
+		dockerRuntime = runtime
+		return nil
+	default:
+		return fmt.Errorf("unknown runtime %q (expected %s or %s)", runtime, RuntimeDocker, RuntimePodman)
+	}
+}
+
+// DockerRuntime returns the runtime maestro is driving: docker.runtime if set,
+// otherwise RuntimePodman when the docker binary is podman, else RuntimeDocker.
+// A "docker" alias for podman needs docker.runtime set explicitly.
+func DockerRuntime() string {
+	if dockerRuntime != "" {
+		return dockerRuntime
+	}
+	if strings.HasPrefix(filepath.Base(DockerBinary()), RuntimePodman) {
+		return RuntimePodman
+	}
+	return RuntimeDocker
+}
+
+// IsPodman reports whether the docker CLI is podman (see DockerRuntime)
+func IsPodman() bool {
+	return DockerRuntime() == RuntimePodman
+}
+
+// DockerCommand returns a command running the docker CLI with args
+func DockerCommand(args ...string) *exec.Cmd {
+	return exec.Command(DockerBinary(), args...)
+}
+
+// DockerCommandContext is DockerCommand with a context that kills the command
+func DockerCommandContext(ctx context.Context, args ...string) *exec.Cmd {
+	return exec.CommandContext(ctx, DockerBinary(), args...)
+}
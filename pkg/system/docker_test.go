@@ -0,0 +1,111 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"testing"
+)
+
+func TestDockerBinary(t *testing.T) {
+	t.Cleanup(func() { SetDockerBinary("") })
+
+	tests := []struct {
+		name   string
+		config string
+		env    string
+		want   string
+	}{
+		{"default", "", "", "docker"},
+		{"config", "/opt/docker-24/bin/docker", "", "/opt/docker-24/bin/docker"},
+		{"env overrides config", "/opt/docker-24/bin/docker", "podman", "podman"},
+		{"blank env is ignored", "podman", "  ", "podman"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(DockerEnv, tt.env)
+			SetDockerBinary(tt.config)
+			if got := DockerBinary(); got != tt.want {
+				t.Errorf("DockerBinary() = %q, want %q", got, tt.want)
+			}
+			if got := DockerCommand("ps").Args[0]; got != tt.want {
+				t.Errorf("DockerCommand() runs %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDockerRuntime(t *testing.T) {
+	t.Cleanup(func() {
+		SetDockerBinary("")
+		SetDockerRuntime("")
+	})
+	t.Setenv(DockerEnv, "")
+
+	tests := []struct {
+		name    string
+		binary  string
+		runtime string
+		want    string
+		wantErr bool
+	}{
+		{name: "default", want: RuntimeDocker},
+		{name: "detected from the binary", binary: "/usr/bin/podman", want: RuntimePodman},
+		{name: "podman-remote", binary: "podman-remote", want: RuntimePodman},
+		{name: "docker alias for podman", binary: "docker", runtime: "Podman", want: RuntimePodman},
+		{name: "explicit docker wins over the name", binary: "podman", runtime: "docker", want: RuntimeDocker},
+		{name: "unknown runtime", runtime: "containerd", want: RuntimeDocker, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetDockerRuntime("")
+			SetDockerBinary(tt.binary)
+			if err := SetDockerRuntime(tt.runtime); (err != nil) != tt.wantErr {
+				t.Fatalf("SetDockerRuntime(%q) error = %v, wantErr %v", tt.runtime, err, tt.wantErr)
+			}
+			if got := DockerRuntime(); got != tt.want {
+				t.Errorf("DockerRuntime() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsDockerPermissionError(t *testing.T) {
+	// Point the docker ps probe at a binary that fails without output
+	t.Setenv(DockerEnv, "false")
+
+	denied := "permission denied while trying to connect to the Docker daemon socket at unix:///var/run/docker.sock: Get \"http://%2Fvar%2Frun%2Fdocker.sock/v1.24/containers/json\": dial unix /var/run/docker.sock: connect: permission denied"
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"docker message", fmt.Errorf("failed to list containers: %s", denied), true},
+		{"podman message", errors.New("Cannot connect to Podman socket: permission denied"), true},
+		{"docker stderr", fmt.Errorf("failed to start: %w", &exec.ExitError{Stderr: []byte(denied)}), true},
+		{"other docker stderr", &exec.ExitError{Stderr: []byte("Error: No such container: foo")}, false},
+		{"unrelated permission error", errors.New("open /etc/shadow: permission denied"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsDockerPermissionError(tt.err); got != tt.want {
+				t.Errorf("IsDockerPermissionError() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -65,6 +65,8 @@ type containersLoadedMsg struct {
 	containers       []container.Info
 	err              error
 	dockerResponsive bool
+	dockerMessage    string // Why Docker isn't usable, from system.IsDockerAvailable
+	daemonRunning    bool
 }
 
 // daemonStatusMsg is sent when daemon status is checked
@@ -106,6 +108,39 @@ type saveFirewallMsg struct {
 	applyToRunning bool
 }
 
+// firewallPreviewMsg describes what applying new domains to running containers would touch
+type firewallPreviewMsg struct {
+	domains    []string
+	containers []string
+	err        error
+}
+
+// applyFirewallMsg is sent when the user confirms applying domains to running containers
+type applyFirewallMsg struct {
+	domains    []string
+	containers []string
+}
+
+// addDomainRequestMsg is sent when the user picks "Add Domain" for a container
+type addDomainRequestMsg struct {
+	containerName string
+}
+
+// addDomainMsg is sent when the user submits the add-domain form
+type addDomainMsg struct {
+	containerName string
+	domain        string
+	save          bool // Also add the domain to firewall.allowed_domains
+}
+
+// addDomainResultMsg reports the outcome of adding a domain to a container
+type addDomainResultMsg struct {
+	containerName string
+	domain        string
+	save          bool
+	err           error
+}
+
 // Docker operation result messages
 type dockerOperationResult struct {
 	action        container.OperationType
@@ -114,6 +149,32 @@ type dockerOperationResult struct {
 	err           error
 }
 
+// softDeleteMsg reports that a container being deleted has been stopped and
+// is waiting out the delete grace period
+type softDeleteMsg struct {
+	containerName string
+	wasRunning    bool
+	err           error
+}
+
+// deleteGraceExpiredMsg fires when a soft-deleted container's grace period ends
+type deleteGraceExpiredMsg struct {
+	id int
+}
+
+// undoDeleteMsg reports the result of restoring a soft-deleted container
+type undoDeleteMsg struct {
+	containerName string
+	err           error
+}
+
+// containerDetailsMsg carries freshly fetched details for the open details modal
+type containerDetailsMsg struct {
+	containerName string
+	details       *container.ContainerDetails
+	err           error
+}
+
 // TUIResult is returned when the TUI exits, telling the caller what action to take
 type TUIResult struct {
 	Action          ActionType
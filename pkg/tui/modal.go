@@ -15,7 +15,9 @@
 package tui
 
 import (
+	"fmt"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/progress"
@@ -59,6 +61,7 @@ type Modal struct {
 	viewport       *viewport.Model   // Viewport for scrollable content (nil if not used)
 	useViewport    bool              // Whether to use viewport for content
 	DisableEsc     bool              // Disable Esc key for modal dismissal (for wizard)
+	containerName  string            // Container shown by a ModalContainerDetails
 
 	// Form fields (for ModalForm)
 	textarea      *textarea.Model   // Multiline text input
@@ -66,6 +69,7 @@ type Modal struct {
 	checkboxes    []bool            // Checkbox states
 	focusedField  int               // Currently focused field index
 	fieldLabels   []string          // Labels for form fields
+	textareaHeight int              // Requested textarea height; View shrinks it to keep buttons on screen
 }
 
 // ModalAction represents a button in the modal
@@ -200,6 +204,34 @@ func NewScrollableHelpModal(title, content string, contentHeight int) *Modal {
 	}
 }
 
+// SetContent replaces the modal's content, keeping the scroll position of
+// scrollable modals where the new content is long enough
+func (m *Modal) SetContent(content string) {
+	m.Content = content
+	if m.viewport != nil {
+		m.viewport.SetContent(content)
+	}
+}
+
+// StartRefresh shows a spinner under the content until FinishRefresh
+func (m *Modal) StartRefresh() tea.Cmd {
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+	s.Style = lipgloss.NewStyle().Foreground(style.OceanTide)
+	m.spinner = &s
+	return m.spinner.Tick
+}
+
+// Refreshing reports whether a refresh started with StartRefresh is running
+func (m *Modal) Refreshing() bool {
+	return m.spinner != nil
+}
+
+// FinishRefresh hides the refresh spinner
+func (m *Modal) FinishRefresh() {
+	m.spinner = nil
+}
+
 // SetProgress updates the progress bar percentage (0.0 to 1.0)
 func (m *Modal) SetProgress(percent float64) tea.Cmd {
 	if m.progress != nil {
@@ -241,6 +273,8 @@ func (m *Modal) Update(msg tea.Msg) (*Modal, tea.Cmd) {
 	case tea.KeyMsg:
 		// Handle form input for ModalForm
 		if m.Type == ModalForm {
+			m.normalizeFocus()
+
 			// Determine which field type is focused
 			actionsStartIdx := 1 + len(m.textinputs) + len(m.checkboxes)
 			checkboxStartIdx := 1 + len(m.textinputs)
@@ -249,18 +283,36 @@ func (m *Modal) Update(msg tea.Msg) (*Modal, tea.Cmd) {
 			onTextarea := m.focusedField == 0
 			onTextinput := m.focusedField > 0 && m.focusedField < checkboxStartIdx
 
+			// Bracketed paste arrives as a single message; send it straight to the
+			// focused text field so pasted tabs, spaces, or letters never act as shortcuts
+			if msg.Paste {
+				var cmd tea.Cmd
+				if onTextarea && m.textarea != nil {
+					*m.textarea, cmd = m.textarea.Update(msg)
+				} else if onTextinput && m.focusedField-1 < len(m.textinputs) {
+					m.textinputs[m.focusedField-1], cmd = m.textinputs[m.focusedField-1].Update(msg)
+				}
+				return m, cmd
+			}
+
 			switch msg.String() {
 			case "tab":
 				// Tab: move to next field (including action buttons)
 				m.blurFocused()
 				totalFields := 1 + len(m.textinputs) + len(m.checkboxes) + len(m.Actions)
 				m.focusedField = (m.focusedField + 1) % totalFields
+				if m.focusedField == 0 && m.textarea == nil {
+					m.focusedField = 1
+				}
 				m.focusField()
 				return m, nil
 			case "shift+tab":
 				// Shift+Tab: move to previous field
 				m.blurFocused()
 				m.focusedField--
+				if m.focusedField == 0 && m.textarea == nil {
+					m.focusedField = -1
+				}
 				if m.focusedField < 0 {
 					totalFields := 1 + len(m.textinputs) + len(m.checkboxes) + len(m.Actions)
 					m.focusedField = totalFields - 1
@@ -309,7 +361,15 @@ func (m *Modal) Update(msg tea.Msg) (*Modal, tea.Cmd) {
 					}
 					return nil, nil
 				}
-				// Not on action button, fall through to textarea/textinput
+				// Single-line inputs have no use for Enter, so it submits like Ctrl+S
+				if onTextinput && len(m.Actions) > 0 && m.Actions[0].OnSelect != nil {
+					cmd := m.Actions[0].OnSelect()
+					if cmd != nil {
+						return nil, func() tea.Msg { return cmd }
+					}
+					return nil, nil
+				}
+				// Textarea: fall through so Enter inserts a new line
 			case " ":
 				// Space: toggle checkbox ONLY if focused on checkbox
 				if onCheckbox {
@@ -421,6 +481,28 @@ func (m *Modal) Update(msg tea.Msg) (*Modal, tea.Cmd) {
 	return m, nil
 }
 
+// textareaCount formats the size of textarea content, e.g. "1234/20000 chars · 12 lines"
+func textareaCount(value string, limit int) string {
+	lines := 0
+	if value != "" {
+		lines = strings.Count(value, "\n") + 1
+	}
+
+	count := fmt.Sprintf("%d", utf8.RuneCountInString(value))
+	if limit > 0 {
+		count += fmt.Sprintf("/%d", limit)
+	}
+	return fmt.Sprintf("%s chars · %d lines", count, lines)
+}
+
+// normalizeFocus moves focus off field 0 (the textarea) for forms that have
+// no textarea, so keys reach the first text input
+func (m *Modal) normalizeFocus() {
+	if m.focusedField == 0 && m.textarea == nil {
+		m.focusedField = 1
+	}
+}
+
 // blurFocused removes focus from the currently focused form field
 func (m *Modal) blurFocused() {
 	if m.focusedField == 0 && m.textarea != nil {
@@ -452,10 +534,28 @@ func (m *Modal) GetContextHelp() []key.Binding {
 		return nil
 	}
 
-	// Only ModalForm currently supports context-specific help
+	if m.Type == ModalContainerDetails {
+		return []key.Binding{
+			key.NewBinding(
+				key.WithKeys("up", "down", "k", "j"),
+				key.WithHelp("↑↓/jk", "scroll"),
+			),
+			key.NewBinding(
+				key.WithKeys("r"),
+				key.WithHelp("r", "refresh"),
+			),
+			key.NewBinding(
+				key.WithKeys("esc", "enter"),
+				key.WithHelp("esc", "close"),
+			),
+		}
+	}
+
+	// Otherwise only ModalForm supports context-specific help
 	if m.Type != ModalForm {
 		return nil
 	}
+	m.normalizeFocus()
 
 	// Determine which field type is focused
 	actionsStartIdx := 1 + len(m.textinputs) + len(m.checkboxes)
@@ -465,6 +565,8 @@ func (m *Modal) GetContextHelp() []key.Binding {
 	onTextarea := m.focusedField == 0
 	onTextinput := m.focusedField > 0 && m.focusedField < checkboxStartIdx
 
+	submit := m.submitLabel()
+
 	var bindings []key.Binding
 
 	if onTextarea {
@@ -480,7 +582,7 @@ func (m *Modal) GetContextHelp() []key.Binding {
 			),
 			key.NewBinding(
 				key.WithKeys("ctrl+s"),
-				key.WithHelp("ctrl+s", "create"),
+				key.WithHelp("ctrl+s", submit),
 			),
 			key.NewBinding(
 				key.WithKeys("esc"),
@@ -492,7 +594,7 @@ func (m *Modal) GetContextHelp() []key.Binding {
 		bindings = append(bindings,
 			key.NewBinding(
 				key.WithKeys("enter"),
-				key.WithHelp("↵", "create"),
+				key.WithHelp("↵", submit),
 			),
 			key.NewBinding(
 				key.WithKeys("tab", "shift+tab"),
@@ -500,7 +602,7 @@ func (m *Modal) GetContextHelp() []key.Binding {
 			),
 			key.NewBinding(
 				key.WithKeys("ctrl+s"),
-				key.WithHelp("ctrl+s", "create"),
+				key.WithHelp("ctrl+s", submit),
 			),
 			key.NewBinding(
 				key.WithKeys("esc"),
@@ -520,7 +622,7 @@ func (m *Modal) GetContextHelp() []key.Binding {
 			),
 			key.NewBinding(
 				key.WithKeys("ctrl+s"),
-				key.WithHelp("ctrl+s", "create"),
+				key.WithHelp("ctrl+s", submit),
 			),
 			key.NewBinding(
 				key.WithKeys("esc"),
@@ -552,11 +654,52 @@ func (m *Modal) GetContextHelp() []key.Binding {
 	return bindings
 }
 
-// View renders just the modal box (not placed)
+// submitLabel names the form's primary action for key hints, e.g. "save"
+func (m *Modal) submitLabel() string {
+	if len(m.Actions) == 0 {
+		return "submit"
+	}
+	return strings.ToLower(m.Actions[0].Label)
+}
+
+// formHint is the line shown above a form's buttons so submitting is
+// discoverable without reading the help bar
+func (m *Modal) formHint() string {
+	hint := "ctrl+s to " + m.submitLabel() + " · tab to move between fields"
+	if m.focusedField == 0 && m.textarea != nil {
+		hint = "enter adds a new line · " + hint
+	}
+	return hint
+}
+
+// minTextareaHeight is the smallest a form textarea is shrunk to on short terminals
+const minTextareaHeight = 3
+
+// View renders just the modal box (not placed). Forms taller than the screen
+// get a shorter textarea so the buttons are never pushed off screen.
 func (m *Modal) View(screenWidth, screenHeight int) string {
 	if m == nil {
 		return ""
 	}
+	if m.Type != ModalForm || m.textarea == nil || screenHeight <= 0 {
+		return m.render(screenWidth, screenHeight)
+	}
+
+	if m.textareaHeight == 0 {
+		m.textareaHeight = m.textarea.Height()
+	}
+	box := m.render(screenWidth, screenHeight)
+	height := m.textarea.Height() - (lipgloss.Height(box) - screenHeight)
+	height = min(max(height, minTextareaHeight), m.textareaHeight)
+	if height == m.textarea.Height() {
+		return box
+	}
+	m.textarea.SetHeight(height)
+	return m.render(screenWidth, screenHeight)
+}
+
+// render draws the modal box at its current size
+func (m *Modal) render(screenWidth, screenHeight int) string {
 
 	// Calculate modal dimensions
 	modalWidth := m.Width
@@ -619,7 +762,14 @@ func (m *Modal) View(screenWidth, screenHeight int) string {
 				Width(modalWidth - 4).
 				Align(lipgloss.Left)
 			formParts = append(formParts, textareaStyle.Render(m.textarea.View()))
-			formParts = append(formParts, "") // Spacing after textarea
+
+			// Character/line count so long pasted text is visibly captured
+			countStyle := lipgloss.NewStyle().
+				Foreground(style.DimGray).
+				Background(modalBg).
+				Width(modalWidth - 4).
+				Align(lipgloss.Right)
+			formParts = append(formParts, countStyle.Render(textareaCount(m.textarea.Value(), m.textarea.CharLimit)))
 			fieldIdx++
 		}
 
@@ -730,6 +880,14 @@ func (m *Modal) View(screenWidth, screenHeight int) string {
 			}
 		}
 
+		// Submit hint, so Enter-in-textarea users can find the way out
+		hintStyle := lipgloss.NewStyle().
+			Foreground(style.DimGray).
+			Background(modalBg).
+			Width(modalWidth - 4).
+			Align(lipgloss.Center)
+		formParts = append(formParts, "", hintStyle.Render(m.formHint()))
+
 		// Join form parts with newlines
 		contentStyle := lipgloss.NewStyle().
 			Foreground(style.GhostWhite).
@@ -930,7 +1088,9 @@ func (m *Modal) RenderWithBackground(background string, screenWidth, screenHeigh
 	return strings.Join(result, "\n")
 }
 
-// compositeModalLine centers a modal line on a background line using ANSI-aware operations
+// compositeModalLine centers a modal line on a background line using ANSI-aware
+// operations. The result is always exactly screenWidth cells wide; a
+// double-width rune cut by the modal's edge is replaced with a space.
 func compositeModalLine(modalLine, bgLine string, screenWidth int) string {
 	// Calculate the visual width of the modal line (ignoring ANSI codes)
 	modalWidth := ansi.StringWidth(modalLine)
@@ -943,39 +1103,33 @@ func compositeModalLine(modalLine, bgLine string, screenWidth int) string {
 	// Calculate left padding for centering
 	leftPad := (screenWidth - modalWidth) / 2
 
-	// Get the visual width of the background (may have ANSI codes)
-	bgWidth := ansi.StringWidth(bgLine)
-
-	// If background is too short, pad it with spaces
-	if bgWidth < screenWidth {
-		bgLine += strings.Repeat(" ", screenWidth-bgWidth)
-	} else if bgWidth > screenWidth {
-		// Truncate background if it's too long
+	// Fit the background (which may have ANSI codes) to the screen width
+	if ansi.StringWidth(bgLine) > screenWidth {
 		bgLine = ansi.Truncate(bgLine, screenWidth, "")
 	}
+	if bgWidth := ansi.StringWidth(bgLine); bgWidth < screenWidth {
+		bgLine += strings.Repeat(" ", screenWidth-bgWidth)
+	}
 
-	// Build the composite line by extracting background segments and inserting modal
-	// Left segment: background from 0 to leftPad
+	// Left segment: the first leftPad cells of the background. Truncate stops
+	// short of a wide rune that doesn't fit, so pad back to leftPad.
 	leftSegment := ""
 	if leftPad > 0 {
 		leftSegment = ansi.Truncate(bgLine, leftPad, "")
+		if w := ansi.StringWidth(leftSegment); w < leftPad {
+			leftSegment += strings.Repeat(" ", leftPad-w)
+		}
 	}
 
-	// Right segment: background after modal
+	// Right segment: the background after the modal, keeping its styling.
+	// TruncateLeft keeps a wide rune that starts under the modal, so skip it
+	// and pad its visible half instead.
 	rightSegment := ""
 	rightStart := leftPad + modalWidth
-	if rightStart < screenWidth {
-		// We need to skip the first 'rightStart' visual characters of bgLine
-		// Since ansi.Truncate gives us the first N chars, we need a different approach
-		// Extract the full background, then skip characters
-		bgRunes := []rune(ansi.Strip(bgLine))
-		if rightStart < len(bgRunes) {
-			rightSegment = string(bgRunes[rightStart:])
-			// Truncate to remaining width
-			remainingWidth := screenWidth - rightStart
-			if ansi.StringWidth(rightSegment) > remainingWidth {
-				rightSegment = ansi.Truncate(rightSegment, remainingWidth, "")
-			}
+	if remaining := screenWidth - rightStart; remaining > 0 {
+		rightSegment = ansi.TruncateLeft(bgLine, rightStart, "")
+		if ansi.StringWidth(rightSegment) > remaining {
+			rightSegment = " " + ansi.TruncateLeft(bgLine, rightStart+1, "")
 		}
 	}
 
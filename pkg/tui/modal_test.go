@@ -0,0 +1,180 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+const (
+	dim   = "\x1b[2m"
+	bold  = "\x1b[1m"
+	reset = "\x1b[0m"
+)
+
+func TestCompositeModalLine(t *testing.T) {
+	tests := []struct {
+		name        string
+		modal       string
+		bg          string
+		width       int
+		wantPlain   string // Expected output with ANSI codes stripped; "" to skip
+		wantContent string // Modal text that must survive; defaults to the stripped modal
+	}{
+		{
+			name:      "plain background",
+			modal:     "[MODAL]",
+			bg:        "abcdefghijklmnopqrst",
+			width:     20,
+			wantPlain: "abcdef[MODAL]nopqrst",
+		},
+		{
+			name:      "styled background and modal",
+			modal:     bold + "[MODAL]" + reset,
+			bg:        dim + "abcdefghijklmnopqrst" + reset,
+			width:     20,
+			wantPlain: "abcdef[MODAL]nopqrst",
+		},
+		{
+			name:      "odd leftover puts the extra cell on the right",
+			modal:     "[MODAL]",
+			bg:        "abcdefghijklmnopqrstu",
+			width:     21,
+			wantPlain: "abcdefg[MODAL]opqrstu",
+		},
+		{
+			name:      "short background is padded",
+			modal:     "[MODAL]",
+			bg:        dim + "abc" + reset,
+			width:     15,
+			wantPlain: "abc [MODAL]    ",
+		},
+		{
+			name:      "long background is cut at the screen edge",
+			modal:     "[M]",
+			bg:        dim + strings.Repeat("x", 40) + reset,
+			width:     9,
+			wantPlain: "xxx[M]xxx",
+		},
+		{
+			name:      "modal filling the screen",
+			modal:     "[0123456]",
+			bg:        "abcdefghi",
+			width:     9,
+			wantPlain: "[0123456]",
+		},
+		{
+			name:        "modal wider than the screen is truncated",
+			modal:       bold + "[0123456789abcdef]" + reset,
+			bg:          "background",
+			width:       10,
+			wantPlain:   "[012345...",
+			wantContent: "[012345",
+		},
+		{
+			name:      "wide rune cut by the modal's right edge",
+			modal:     "[MODAL]",
+			bg:        dim + "ab世界世界世界cd" + reset, // 世 at cells 10-11 straddles the modal's end at 11
+			width:     16,
+			wantPlain: "ab世[MODAL] 界cd",
+		},
+		{
+			name:      "wide rune cut by the modal's left edge",
+			modal:     "[MODAL]",
+			bg:        "a世界世界世界世b", // 界 at cells 3-4 straddles the modal's start at 4
+			width:     16,
+			wantPlain: "a世 [MODAL]界世b",
+		},
+		{
+			name:      "wide runes in the modal",
+			modal:     "│ 🚀 新しい │",
+			bg:        strings.Repeat("-", 20),
+			width:     20,
+			wantPlain: "---│ 🚀 新しい │----",
+		},
+		{
+			name:  "wide runes everywhere at a narrow width",
+			modal: "[界界界]",
+			bg:    strings.Repeat("世", 20),
+			width: 11,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := compositeModalLine(tt.modal, tt.bg, tt.width)
+
+			if w := ansi.StringWidth(got); w != tt.width {
+				t.Errorf("visible width = %d, want %d (%q)", w, tt.width, got)
+			}
+
+			plain := ansi.Strip(got)
+			content := tt.wantContent
+			if content == "" {
+				content = ansi.Strip(tt.modal)
+			}
+			if !strings.Contains(plain, content) {
+				t.Errorf("output %q lost modal content %q", plain, content)
+			}
+			if tt.wantPlain != "" && plain != tt.wantPlain {
+				t.Errorf("output = %q, want %q", plain, tt.wantPlain)
+			}
+		})
+	}
+}
+
+func TestCompositeModalLineKeepsBackgroundStyle(t *testing.T) {
+	got := compositeModalLine("[M]", dim+"abcdefghi"+reset, 9)
+
+	// Both background segments should still be dimmed
+	left, right, ok := strings.Cut(got, "[M]")
+	if !ok {
+		t.Fatalf("modal missing from %q", got)
+	}
+	if !strings.HasPrefix(left, dim) {
+		t.Errorf("left segment %q lost its style", left)
+	}
+	if !strings.HasPrefix(right, dim) {
+		t.Errorf("right segment %q lost its style", right)
+	}
+}
+
+func TestRenderWithBackground(t *testing.T) {
+	const width, height = 60, 20
+
+	var bg []string
+	for i := 0; i < height; i++ {
+		bg = append(bg, strings.Repeat("背景", width/4))
+	}
+
+	modal := NewErrorModal("Failed", "Something went wrong 🚨")
+	out := modal.RenderWithBackground(strings.Join(bg, "\n"), width, height)
+
+	lines := strings.Split(out, "\n")
+	if len(lines) != height {
+		t.Fatalf("got %d lines, want %d", len(lines), height)
+	}
+	for i, line := range lines {
+		if w := ansi.StringWidth(line); w != width {
+			t.Errorf("line %d width = %d, want %d: %q", i, w, width, ansi.Strip(line))
+		}
+	}
+	if !strings.Contains(ansi.Strip(out), "Something went wrong 🚨") {
+		t.Errorf("modal content missing from output:\n%s", ansi.Strip(out))
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
@@ -29,12 +30,15 @@ import (
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/uprockcom/maestro/pkg/paths"
 	"github.com/mistakenelf/teacup/statusbar"
 	"github.com/spf13/viper"
 	"go.dalton.dog/bubbleup"
 
+	"github.com/uprockcom/maestro/pkg/configfile"
 	"github.com/uprockcom/maestro/pkg/container"
+	"github.com/uprockcom/maestro/pkg/daemon"
 	"github.com/uprockcom/maestro/pkg/system"
 	"github.com/uprockcom/maestro/pkg/tui/style"
 	"github.com/uprockcom/maestro/pkg/tui/views"
@@ -48,22 +52,28 @@ type Model struct {
 	result              *TUIResult
 	homeView            *views.HomeModel
 	containerPrefix     string
-	modal               *Modal              // Active modal (nil if none)
-	help                help.Model          // Help component for keybindings
-	keys                keyMap              // Keybindings
-	cachedCursorPos     int                 // Cursor position to restore from cache
-	spinner             spinner.Model       // Loading spinner
-	loading             bool                // Whether we're currently loading
-	alert               bubbleup.AlertModel // Toast notifications
-	statusbar           statusbar.Model     // Status bar for persistent state
-	containerCount      int                 // Number of containers
-	operationStatus     string              // Current operation status
-	daemonRunning       bool                // Whether daemon is running
-	dockerResponsive    bool                // Whether Docker daemon is responding
-	workingDir          string              // Current working directory (relative to ~)
-	animationFrame      int                 // Animation frame counter for pulsing effects
-	operationInProgress bool                // Whether an operation is currently running
-	operationSpinner    spinner.Model       // Spinner for operations in statusbar
+	modal               *Modal               // Active modal (nil if none)
+	help                help.Model           // Help component for keybindings
+	keys                keyMap               // Keybindings
+	cachedCursorPos     int                  // Cursor position to restore from cache
+	spinner             spinner.Model        // Loading spinner
+	loading             bool                 // Whether we're currently loading
+	alert               bubbleup.AlertModel  // Toast notifications
+	statusbar           statusbar.Model      // Status bar for persistent state
+	containerCount      int                  // Number of containers
+	operationStatus     string               // Current operation status
+	daemonRunning       bool                 // Whether daemon is running
+	dockerResponsive    bool                 // Whether Docker daemon is responding
+	dockerMessage       string               // Why Docker isn't usable, when it isn't
+	workingDir          string               // Current working directory (relative to ~)
+	animationFrame      int                  // Animation frame counter for pulsing effects
+	operationInProgress bool                 // Whether an operation is currently running
+	operationSpinner    spinner.Model        // Spinner for operations in statusbar
+	pendingLoad         *containersLoadedMsg // Load that finished under a modal, applied once it closes
+	pendingDeletes      []pendingDelete      // Stopped containers waiting out the delete grace period
+	nextDeleteID        int                  // Identifies pending deletes, so a stale timer can't remove a re-deleted container
+	errorLog            []errorEntry         // Errors seen this session, oldest first
+	errorDismissed      bool                 // Whether the latest error is hidden from the statusbar
 
 	// Wizard state
 	wizardMode        bool     // Whether we're in wizard/onboarding mode
@@ -79,16 +89,20 @@ type Model struct {
 // keyMap defines keybindings for different contexts
 type keyMap struct {
 	// Normal view keys
-	Up       key.Binding
-	Down     key.Binding
-	Connect  key.Binding
-	Actions  key.Binding
-	Info     key.Binding
-	New      key.Binding
-	Settings key.Binding
-	Firewall key.Binding
-	Help     key.Binding
-	Quit     key.Binding
+	Up         key.Binding
+	Down       key.Binding
+	Connect    key.Binding
+	Actions    key.Binding
+	Info       key.Binding
+	Delete     key.Binding
+	Errors     key.Binding
+	Attention  key.Binding
+	New        key.Binding
+	Settings   key.Binding
+	Firewall   key.Binding
+	EditConfig key.Binding
+	Help       key.Binding
+	Quit       key.Binding
 
 	// Modal keys (set dynamically based on modal type)
 	ModalSelect   key.Binding
@@ -109,8 +123,8 @@ func (k keyMap) ShortHelp() []key.Binding {
 // FullHelp returns keybindings for the expanded help view
 func (k keyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
-		{k.Up, k.Down, k.Connect, k.Actions, k.Info, k.New, k.Settings, k.Firewall},
-		{k.Help, k.Quit},
+		{k.Up, k.Down, k.Connect, k.Actions, k.Info, k.Delete, k.Attention, k.New, k.Settings, k.Firewall, k.EditConfig},
+		{k.Errors, k.Help, k.Quit},
 	}
 }
 
@@ -143,20 +157,12 @@ func isFirstRun() bool {
 	}
 
 	// Check if credentials exist
-	credPath := viper.GetString("claude.auth_path")
-	if credPath == "" {
-		credPath = paths.AuthDir()
-	}
-	// Expand ~ in path
-	if strings.HasPrefix(credPath, "~") {
-		home, err := os.UserHomeDir()
-		if err != nil {
-			return false // Can't determine, skip wizard
-		}
-		credPath = filepath.Join(home, credPath[1:])
+	credPath, err := authDir()
+	if err != nil {
+		return false // Can't determine, skip wizard
 	}
 
-	credsFile := filepath.Join(credPath, ".credentials.json")
+	credsFile := paths.FindCredentials(credPath)
 	if _, err := os.Stat(credsFile); os.IsNotExist(err) {
 		return true // No credentials = first run
 	}
@@ -164,6 +170,32 @@ func isFirstRun() bool {
 	return false
 }
 
+// authDir returns the configured claude.auth_path with ~ expanded
+func authDir() (string, error) {
+	dir := viper.GetString("claude.auth_path")
+	if dir == "" {
+		dir = paths.AuthDir()
+	}
+	if strings.HasPrefix(dir, "~") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(home, dir[1:])
+	}
+	return dir, nil
+}
+
+// isDaemonRunning reports whether the background daemon is running
+func isDaemonRunning() bool {
+	dir, err := authDir()
+	if err != nil {
+		return false
+	}
+	_, running := daemon.IsRunning(filepath.Join(dir, daemon.PIDFile))
+	return running
+}
+
 // NewWithCache creates a new TUI model with optional cached state
 func NewWithCache(containerPrefix string, cached *CachedState) *Model {
 	// Initialize spinner with Ocean Tide color
@@ -247,7 +279,7 @@ func NewWithCache(containerPrefix string, cached *CachedState) *Model {
 		statusbar:           sb,
 		containerCount:      0,
 		operationStatus:     "Ready",
-		daemonRunning:       true, // TODO: Check actual daemon status
+		daemonRunning:       isDaemonRunning(),
 		dockerResponsive:    true, // Assume true until first check completes
 		workingDir:          relPath,
 		animationFrame:      0,
@@ -274,6 +306,18 @@ func NewWithCache(containerPrefix string, cached *CachedState) *Model {
 				key.WithKeys("i"),
 				key.WithHelp("i", "details"),
 			),
+			Delete: key.NewBinding(
+				key.WithKeys("d"),
+				key.WithHelp("d", "delete"),
+			),
+			Attention: key.NewBinding(
+				key.WithKeys("w"),
+				key.WithHelp("w", "waiting only"),
+			),
+			Errors: key.NewBinding(
+				key.WithKeys("!"),
+				key.WithHelp("!", "errors"),
+			),
 			New: key.NewBinding(
 				key.WithKeys("n"),
 				key.WithHelp("n", "new"),
@@ -286,6 +330,10 @@ func NewWithCache(containerPrefix string, cached *CachedState) *Model {
 				key.WithKeys("f"),
 				key.WithHelp("f", "firewall"),
 			),
+			EditConfig: key.NewBinding(
+				key.WithKeys("e"),
+				key.WithHelp("e", "edit config"),
+			),
 			Help: key.NewBinding(
 				key.WithKeys("?"),
 				key.WithHelp("?", "help"),
@@ -402,9 +450,27 @@ func animationTick() tea.Cmd {
 	})
 }
 
-// refreshTick creates a command that sends refresh tick messages every 30 seconds
+// defaultRefreshInterval is used when tui.refresh_interval is unset or invalid
+const defaultRefreshInterval = 30 * time.Second
+
+// refreshInterval returns how often the container list is reloaded in the
+// background (tui.refresh_interval); 0 disables background refresh
+func refreshInterval() time.Duration {
+	interval, err := time.ParseDuration(viper.GetString("tui.refresh_interval"))
+	if err != nil || interval < 0 {
+		return defaultRefreshInterval
+	}
+	return interval
+}
+
+// refreshTick creates a command that sends a refresh tick message after the
+// refresh interval, or nil if background refresh is disabled
 func refreshTick() tea.Cmd {
-	return tea.Tick(30*time.Second, func(t time.Time) tea.Msg {
+	interval := refreshInterval()
+	if interval == 0 {
+		return nil
+	}
+	return tea.Tick(interval, func(t time.Time) tea.Msg {
 		return refreshTickMsg(t)
 	})
 }
@@ -423,36 +489,51 @@ func (m Model) GetState() *CachedState {
 
 // loadContainers fetches container data
 func (m Model) loadContainers() tea.Cmd {
+	// Limits cost a docker inspect per container, so only fetch them for the column
+	opts := container.GatherOptions{Limits: m.homeView != nil && m.homeView.ShowsResources()}
 	return func() tea.Msg {
-		// Check if Docker is responsive first
-		dockerResponsive := container.IsDockerResponsive()
-		if !dockerResponsive {
+		daemonRunning := isDaemonRunning()
+
+		// Check that Docker is installed and responsive first
+		if available, message := system.IsDockerAvailable(); !available {
 			return containersLoadedMsg{
 				containers:       []container.Info{},
 				err:              nil,
 				dockerResponsive: false,
+				dockerMessage:    message,
+				daemonRunning:    daemonRunning,
 			}
 		}
 
-		containers, err := container.GetAllContainers(m.containerPrefix)
+		containers, err := container.GatherAllContainers(m.containerPrefix, opts)
 		if err != nil {
 			// Return empty list on error, but Docker was responsive
 			return containersLoadedMsg{
 				containers:       []container.Info{},
-				err:              nil,
+				err:              err,
 				dockerResponsive: true,
+				daemonRunning:    daemonRunning,
 			}
 		}
 		return containersLoadedMsg{
 			containers:       containers,
 			err:              nil,
 			dockerResponsive: true,
+			daemonRunning:    daemonRunning,
 		}
 	}
 }
 
 // Update handles messages and updates state
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	// Apply a container load held back while a modal was open, now that it's closed
+	if m.pendingLoad != nil && m.modal == nil {
+		pending := *m.pendingLoad
+		m.pendingLoad = nil
+		next, cmd := m.Update(msg)
+		return next, tea.Batch(cmd, func() tea.Msg { return pending })
+	}
+
 	// Always update alert model for lifecycle management (even when modal is active)
 	outAlert, alertCmd := m.alert.Update(msg)
 	m.alert = outAlert.(bubbleup.AlertModel)
@@ -482,7 +563,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, alertCmd
 
 	case refreshTickMsg:
-		// Background refresh tick (30s)
+		// Background refresh tick (tui.refresh_interval)
 		// Skip refresh if modal is active or operation in progress
 		if m.modal != nil || m.operationInProgress {
 			return m, tea.Batch(refreshTick(), alertCmd)
@@ -658,6 +739,34 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 	}
 
+	// Rebuilding the table under an open modal resets state behind it, so a
+	// load that lands now waits until the modal closes
+	if loaded, ok := msg.(containersLoadedMsg); ok && m.modal != nil {
+		m.pendingLoad = &loaded
+		return m, alertCmd
+	}
+
+	// The details modal refreshes in place with r
+	if refreshed, ok := msg.(containerDetailsMsg); ok {
+		if m.modal == nil || m.modal.Type != ModalContainerDetails || m.modal.containerName != refreshed.containerName {
+			return m, alertCmd
+		}
+		m.modal.FinishRefresh()
+		if refreshed.err != nil {
+			m.recordError(fmt.Sprintf("Failed to refresh details for %s: %v", refreshed.containerName, refreshed.err))
+			m.updateStatusBar()
+			return m, tea.Batch(m.errorAlert(fmt.Sprintf("Failed to refresh details: %v", refreshed.err)), alertCmd)
+		}
+		m.modal.SetContent(containerDetailsContent(refreshed.details))
+		return m, alertCmd
+	}
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && keyMsg.String() == "r" && m.modal != nil && m.modal.Type == ModalContainerDetails {
+		if m.modal.Refreshing() {
+			return m, alertCmd
+		}
+		return m, tea.Batch(m.modal.StartRefresh(), fetchContainerDetails(m.modal.containerName, m.containerPrefix), alertCmd)
+	}
+
 	// If modal is active, it gets priority for keyboard input
 	if m.modal != nil {
 		var modalCmd tea.Cmd
@@ -714,30 +823,30 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, tea.Batch(cmds...)
 
 	case containersLoadedMsg:
-		// Save currently selected container name for cursor preservation
-		var selectedContainerName string
-		if m.homeView != nil && len(m.homeView.GetContainers()) > 0 {
-			cursor := m.homeView.GetCursor()
-			containers := m.homeView.GetContainers()
-			if cursor >= 0 && cursor < len(containers) {
-				selectedContainerName = containers[cursor].Name
+		if m.ready && m.dockerResponsive && !msg.dockerResponsive {
+			m.recordError(msg.dockerMessage)
+		}
+		if msg.err != nil {
+			m.recordError("Failed to load containers: " + msg.err.Error())
+			if m.homeView != nil {
+				// Keep showing the last good list rather than an empty one
+				m.loading = false
+				m.operationStatus = "Ready"
+				m.updateStatusBar()
+				return m, nil
 			}
 		}
 
-		// Initialize home view with loaded data
-		m.homeView = views.NewHomeModel(msg.containers, false, viper.GetBool("bedrock.enabled"))
-		if m.width > 0 && m.height > 0 {
-			// Subtract 9 lines: title banner (6) + help (1) + blank line (1) + statusbar (1)
-			m.homeView.SetSize(m.width, m.height-9)
-		}
-
-		// Restore cursor to same container if it still exists
-		if selectedContainerName != "" {
-			for i, c := range msg.containers {
-				if c.Name == selectedContainerName {
-					m.homeView.SetCursor(i)
-					break
-				}
+		useAWSAuth := viper.GetBool("bedrock.enabled")
+		if m.homeView != nil && m.homeView.UsesAWSAuth() == useAWSAuth {
+			// Refresh in place so the selection (by container name) and view toggles survive
+			m.homeView.RefreshContainers(msg.containers, msg.daemonRunning)
+		} else {
+			// Initialize home view with loaded data
+			m.homeView = views.NewHomeModel(msg.containers, msg.daemonRunning, useAWSAuth)
+			if m.width > 0 && m.height > 0 {
+				// Subtract 9 lines: title banner (6) + help (1) + blank line (1) + statusbar (1)
+				m.homeView.SetSize(m.width, m.height-9)
 			}
 		}
 
@@ -748,6 +857,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Update container count and Docker status
 		m.containerCount = len(msg.containers)
 		m.dockerResponsive = msg.dockerResponsive
+		m.dockerMessage = msg.dockerMessage
+		m.daemonRunning = msg.daemonRunning
 		m.updateStatusBar()
 
 		// Only show toast for initial load, not background refreshes
@@ -755,6 +866,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.ready {
 			// Background refresh - silent
 			toastCmd = nil
+		} else if !m.dockerResponsive {
+			// The Docker error screen explains the empty list
+			m.ready = true
 		} else {
 			// Initial load - show toast
 			toastCmd = m.alert.NewAlertCmd("Success", fmt.Sprintf("Loaded %d containers", len(msg.containers)))
@@ -771,6 +885,21 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, tea.Quit
 
+	case views.EditConfigRequestMsg:
+		// User pressed 'e' - exit TUI so the caller can open the editor
+		m.result = &TUIResult{
+			Action:   ActionEditConfig,
+			FilePath: configFilePath(),
+		}
+		return m, tea.Quit
+
+	case views.ResourcesShownMsg:
+		// The list was loaded without limits; fetch them for the new column
+		return m, m.loadContainers()
+
+	case views.DeleteRequestMsg:
+		return m.handleContainerAction(ContainerActionMsg{Action: container.OperationDelete, ContainerName: msg.Container.Name})
+
 	case views.ShowActionsMenuMsg:
 		// Show actions menu for container
 		m.modal = createActionsModal(msg.Container)
@@ -791,28 +920,26 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// User saved settings - update viper and write config
 		m.modal = nil // Close settings modal
 
+		settings := map[string]any{
+			"daemon.show_nag":              msg.showNag,
+			"daemon.token_refresh.enabled": msg.autoRefreshTokens,
+			"daemon.notifications.enabled": msg.enableNotifications,
+		}
 		// Update container resource defaults
 		if msg.memory != "" {
-			viper.Set("containers.resources.memory", msg.memory)
+			settings["containers.resources.memory"] = msg.memory
 		}
 		if msg.cpus != "" {
-			viper.Set("containers.resources.cpus", msg.cpus)
+			settings["containers.resources.cpus"] = msg.cpus
 		}
 
-		// Update daemon settings
-		viper.Set("daemon.show_nag", msg.showNag)
-		viper.Set("daemon.token_refresh.enabled", msg.autoRefreshTokens)
-		viper.Set("daemon.notifications.enabled", msg.enableNotifications)
-
 		// Write config to file
-		if err := viper.WriteConfig(); err != nil {
-			// If config file doesn't exist, create it
-			if err := viper.SafeWriteConfig(); err != nil {
-				toastCmd := m.alert.NewAlertCmd("Error", "Failed to save settings: "+err.Error())
-				return m, toastCmd
-			}
+		if err := saveConfigKeys(settings); err != nil {
+			toastCmd := m.errorAlert("Failed to save settings: " + err.Error())
+			return m, toastCmd
 		}
 
+		m.updateStatusBar() // The daemon reminder may have been turned off
 		toastCmd := m.alert.NewAlertCmd("Success", "Settings saved successfully")
 		return m, toastCmd
 
@@ -834,14 +961,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		oldDomains := viper.GetStringSlice("firewall.allowed_domains")
 
 		// Update config with new domains
-		viper.Set("firewall.allowed_domains", newDomains)
-
-		// Write config to file
-		if err := viper.WriteConfig(); err != nil {
-			if err := viper.SafeWriteConfig(); err != nil {
-				toastCmd := m.alert.NewAlertCmd("Error", "Failed to save firewall: "+err.Error())
-				return m, toastCmd
-			}
+		if err := saveConfigKeys(map[string]any{"firewall.allowed_domains": newDomains}); err != nil {
+			toastCmd := m.errorAlert("Failed to save firewall: " + err.Error())
+			return m, toastCmd
 		}
 
 		// If "apply to running" is checked, add new domains to all running containers
@@ -861,24 +983,114 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 			}
 
-			// Apply new domains to running containers
+			// Look up affected containers, then confirm before touching them
 			if len(addedDomains) > 0 {
-				go func() {
-					for _, domain := range addedDomains {
-						// Call add-domain command for each new domain
-						// This will apply to all running containers
-						container.AddDomainToAllContainers(domain)
+				prefix := m.containerPrefix
+				return m, func() tea.Msg {
+					// Same listing as the CLI, so legacy containers are included per scan_legacy_prefix
+					containers, err := container.GetRunningContainersWithLegacy(prefix)
+					names := make([]string, len(containers))
+					for i, c := range containers {
+						names[i] = c.Name
 					}
-				}()
-				toastMsg := fmt.Sprintf("Firewall saved. Adding %d new domain(s) to running containers...", len(addedDomains))
-				toastCmd := m.alert.NewAlertCmd("Info", toastMsg)
-				return m, toastCmd
+					return firewallPreviewMsg{domains: addedDomains, containers: names, err: err}
+				}
 			}
 		}
 
 		toastCmd := m.alert.NewAlertCmd("Success", "Firewall configuration saved")
 		return m, toastCmd
 
+	case firewallPreviewMsg:
+		if msg.err != nil {
+			toastCmd := m.errorAlert("Firewall saved, but listing containers failed: " + msg.err.Error())
+			return m, toastCmd
+		}
+		if len(msg.containers) == 0 {
+			toastCmd := m.alert.NewAlertCmd("Success", "Firewall configuration saved (no running containers)")
+			return m, toastCmd
+		}
+
+		shortNames := make([]string, len(msg.containers))
+		for i, name := range msg.containers {
+			shortNames[i] = container.GetShortName(name, m.containerPrefix)
+		}
+
+		preview := msg
+		m.modal = NewConfirmModal(
+			"Apply Firewall Changes",
+			firewallApplySummary(msg.domains, shortNames),
+			func() tea.Msg {
+				return applyFirewallMsg{domains: preview.domains, containers: preview.containers}
+			},
+			nil, // Config is already saved; cancelling only skips running containers
+		)
+		return m, nil
+
+	case applyFirewallMsg:
+		go func() {
+			for _, name := range msg.containers {
+				for _, domain := range msg.domains {
+					container.AddDomainToContainer(name, domain)
+				}
+			}
+		}()
+		toastMsg := fmt.Sprintf("Adding %d new domain(s) to %d running container(s)...", len(msg.domains), len(msg.containers))
+		toastCmd := m.alert.NewAlertCmd("Info", toastMsg)
+		return m, toastCmd
+
+	case addDomainRequestMsg:
+		m.modal = createAddDomainModal(msg.containerName, container.GetShortName(msg.containerName, m.containerPrefix))
+		return m, nil
+
+	case addDomainMsg:
+		m.modal = nil
+		if !container.IsValidDomain(msg.domain) {
+			m.modal = NewErrorModal("Invalid Domain", fmt.Sprintf("%q is not a valid domain.\n\nEnter a hostname such as api.example.com.", msg.domain))
+			return m, nil
+		}
+
+		m.operationInProgress = true
+		m.operationStatus = "Adding domain..."
+		req := msg
+		return m, tea.Batch(func() tea.Msg {
+			err := container.AddDomainToContainer(req.containerName, req.domain)
+			return addDomainResultMsg{containerName: req.containerName, domain: req.domain, save: req.save, err: err}
+		}, m.operationSpinner.Tick)
+
+	case addDomainResultMsg:
+		m.operationInProgress = false
+		m.operationStatus = "Ready"
+		shortName := container.GetShortName(msg.containerName, m.containerPrefix)
+		if msg.err != nil {
+			m.recordError(fmt.Sprintf("Failed to add %s to %s: %v", msg.domain, shortName, msg.err))
+			m.modal = NewErrorModal("Add Domain Failed", fmt.Sprintf("Failed to add %s to %s:\n\n%v", msg.domain, shortName, msg.err))
+			return m, nil
+		}
+
+		content := fmt.Sprintf("%s is now allowed in %s.", msg.domain, shortName)
+		if msg.save {
+			domains := viper.GetStringSlice("firewall.allowed_domains")
+			saved := false
+			for _, d := range domains {
+				if d == msg.domain {
+					saved = true
+					break
+				}
+			}
+			if !saved {
+				if err := saveConfigKeys(map[string]any{"firewall.allowed_domains": append(domains, msg.domain)}); err != nil {
+					m.modal = NewErrorModal("Config Not Saved", fmt.Sprintf("%s\n\nSaving it to the config failed:\n%v", content, err))
+					return m, nil
+				}
+			}
+			content += "\n\nSaved to config for new containers."
+		} else {
+			content += "\n\nThis lasts until the container is recreated."
+		}
+		m.modal = NewInfoModal("Domain Added", content)
+		return m, nil
+
 	case ContainerActionMsg:
 		// Handle container action
 		return m.handleContainerAction(msg)
@@ -886,6 +1098,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case ConfirmActionMsg:
 		// Mark operation in progress and update status
 		m.operationInProgress = true
+		if msg.Action == container.OperationDelete && deleteGracePeriod() > 0 {
+			// Stop now, remove once the grace period passes without an undo
+			m.operationStatus = "Stopping..."
+			return m, tea.Batch(m.softDelete(msg.ContainerName), m.operationSpinner.Tick)
+		}
 		if msg.Action == container.OperationDelete {
 			m.operationStatus = "Deleting..."
 		} else if msg.Action == container.OperationStop {
@@ -895,6 +1112,49 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Execute confirmed action asynchronously
 		return m, tea.Batch(m.performDockerOperation(msg.Action, msg.ContainerName), m.operationSpinner.Tick)
 
+	case softDeleteMsg:
+		m.operationInProgress = false
+		m.operationStatus = "Ready"
+		if msg.err != nil {
+			m.recordError(fmt.Sprintf("Failed to delete container %s: %v", msg.containerName, msg.err))
+			m.modal = NewErrorModal("Operation Failed", fmt.Sprintf("Failed to delete container %s:\n\n%v", msg.containerName, msg.err))
+			return m, nil
+		}
+
+		m.nextDeleteID++
+		id := m.nextDeleteID
+		m.pendingDeletes = append(m.pendingDeletes, pendingDelete{id: id, containerName: msg.containerName, wasRunning: msg.wasRunning})
+		grace := deleteGracePeriod()
+		toastCmd := m.alert.NewAlertCmd("Warning", fmt.Sprintf("Deleting %s in %s (press u to undo)", msg.containerName, grace))
+		timerCmd := tea.Tick(grace, func(time.Time) tea.Msg {
+			return deleteGraceExpiredMsg{id: id}
+		})
+		return m, tea.Batch(toastCmd, timerCmd, m.loadContainers())
+
+	case deleteGraceExpiredMsg:
+		for i, pending := range m.pendingDeletes {
+			if pending.id != msg.id {
+				continue
+			}
+			m.pendingDeletes = append(m.pendingDeletes[:i:i], m.pendingDeletes[i+1:]...)
+			m.operationInProgress = true
+			m.operationStatus = "Deleting..."
+			return m, tea.Batch(m.performDockerOperation(container.OperationDelete, pending.containerName), m.operationSpinner.Tick)
+		}
+		// Undone in the meantime
+		return m, nil
+
+	case undoDeleteMsg:
+		m.operationInProgress = false
+		m.operationStatus = "Ready"
+		if msg.err != nil {
+			m.recordError(fmt.Sprintf("Failed to restart %s after undoing its delete: %v", msg.containerName, msg.err))
+			m.modal = NewErrorModal("Undo Failed", fmt.Sprintf("Container %s was kept but could not be started:\n\n%v", msg.containerName, msg.err))
+			return m, m.loadContainers()
+		}
+		toastCmd := m.alert.NewAlertCmd("Success", fmt.Sprintf("Delete of %s undone", msg.containerName))
+		return m, tea.Batch(toastCmd, m.loadContainers())
+
 	case dockerOperationResult:
 		// Clear operation in progress flag
 		m.operationInProgress = false
@@ -920,6 +1180,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		} else {
 			// Error - reset to Ready and show modal
 			m.operationStatus = "Ready"
+			m.recordError(fmt.Sprintf("Failed to %s container %s: %v", msg.action, msg.containerName, msg.err))
 			m.modal = NewErrorModal("Operation Failed", fmt.Sprintf("Failed to %s container %s:\n\n%v", msg.action, msg.containerName, msg.err))
 			return m, nil
 		}
@@ -946,15 +1207,34 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.modal = createHelpModal()
 			}
 			return m, nil
+		case "!":
+			// Show the error history; seeing it acknowledges the latest one
+			m.errorDismissed = true
+			m.updateStatusBar()
+			m.modal = createErrorLogModal(m.errorLog)
+			return m, nil
+		case "x":
+			// Hide the latest error from the statusbar
+			m.errorDismissed = true
+			m.updateStatusBar()
+			return m, nil
+		case "u":
+			// Undo the most recent delete that is still in its grace period
+			if len(m.pendingDeletes) == 0 {
+				return m, nil
+			}
+			pending := m.pendingDeletes[len(m.pendingDeletes)-1]
+			m.pendingDeletes = m.pendingDeletes[:len(m.pendingDeletes)-1]
+			m.operationInProgress = true
+			m.operationStatus = "Restoring..."
+			return m, tea.Batch(undoDelete(pending), m.operationSpinner.Tick)
 		case "i":
 			// Show container details for selected container
-			if m.homeView != nil && len(m.homeView.GetContainers()) > 0 {
-				selectedIdx := m.homeView.GetCursor()
-				containers := m.homeView.GetContainers()
-				if selectedIdx >= 0 && selectedIdx < len(containers) {
-					selected := containers[selectedIdx]
+			if m.homeView != nil {
+				if selected, ok := m.homeView.SelectedContainer(); ok {
 					details, err := container.GetContainerDetails(selected.Name, m.containerPrefix)
 					if err != nil {
+						m.recordError(fmt.Sprintf("Failed to fetch details for %s: %v", selected.Name, err))
 						m.modal = NewErrorModal("Error", fmt.Sprintf("Failed to fetch container details:\n\n%v", err))
 					} else {
 						m.modal = createContainerDetailsModal(details)
@@ -995,7 +1275,15 @@ func createHelpModal() *Modal {
 
 Actions:
   a             Container actions menu
-  i             View container details
+  i             View container details (r refreshes them)
+  d             Delete container
+  u             Undo a delete during its grace period
+  t             Toggle CREATED/AGE column
+  c             Toggle CPU/MEM column (resource limits, ∞ = none)
+  w             Show only containers waiting for you (again for all)
+  e             Edit config file in $EDITOR
+  !             Show recent errors
+  x             Dismiss the error in the status bar
   ?             Show this help
   q             Quit Maestro
 
@@ -1345,57 +1633,83 @@ func (m *Model) getWizardModal() *Modal {
 	}
 }
 
-// saveWizardConfig saves the wizard configuration to the config file
+// saveWizardConfig saves the wizard configuration to the config file,
+// updating only the wizard keys
 func (m *Model) saveWizardConfig(msg saveWizardConfigMsg) error {
-	// Get config file path
-	configPath := paths.ConfigFile()
-
-	// Check if config file exists
-	fileExists := false
-	if _, err := os.Stat(configPath); err == nil {
-		fileExists = true
+	// If running auth now, enable wizard to continue after auth completes
+	// (they still need to complete remaining wizard steps: firewall, defaults, completion).
+	// Otherwise the wizard is completing normally (Finish button) - clear resume flag.
+	if err := saveConfigKeys(map[string]any{
+		"containers.resources.memory": msg.memory,
+		"containers.resources.cpus":   msg.cpus,
+		"firewall.allowed_domains":    msg.domains,
+		"wizard.resume_after_auth":    msg.runAuthNow,
+	}); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
 	}
 
-	if fileExists {
-		// Config file exists - update only the wizard keys
-		// Re-read the config to ensure we have the latest values
-		viper.SetConfigFile(configPath)
-		if err := viper.ReadInConfig(); err != nil {
-			return fmt.Errorf("failed to read existing config: %w", err)
-		}
-	}
+	return nil
+}
 
-	// Update only the wizard-specific keys
-	viper.Set("containers.resources.memory", msg.memory)
-	viper.Set("containers.resources.cpus", msg.cpus)
-	viper.Set("firewall.allowed_domains", msg.domains)
+// configFilePath returns the config file viper loaded (honouring --config),
+// falling back to the default location
+func configFilePath() string {
+	if configFile := viper.ConfigFileUsed(); configFile != "" {
+		return configFile
+	}
+	return paths.ConfigFile()
+}
 
-	// If running auth now, enable wizard to continue after auth completes
-	// (they still need to complete remaining wizard steps: firewall, defaults, completion)
-	if msg.runAuthNow {
-		viper.Set("wizard.resume_after_auth", true)
-	} else {
-		// Wizard is completing normally (Finish button) - clear resume flag
-		viper.Set("wizard.resume_after_auth", false)
+// saveConfigKeys writes the given dotted keys to the config file, leaving the
+// rest of the file untouched, and mirrors them into viper
+func saveConfigKeys(values map[string]any) error {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
 	}
+	sort.Strings(keys)
 
-	// Write the config file
-	if err := viper.WriteConfig(); err != nil {
-		// If WriteConfig fails (file doesn't exist), use WriteConfigAs
-		if err := viper.WriteConfigAs(configPath); err != nil {
-			return fmt.Errorf("failed to write config file: %w", err)
+	err := configfile.Update(configFilePath(), func(doc *configfile.Document) error {
+		for _, key := range keys {
+			if err := doc.Set(key, values[key]); err != nil {
+				return err
+			}
 		}
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
-	return nil
+	// Re-read rather than viper.Set, so the file stays the source of truth
+	// and later edits to it aren't shadowed by in-memory overrides
+	return viper.ReadInConfig()
 }
 
-// createContainerDetailsModal creates a scrollable modal showing comprehensive container information
+// createContainerDetailsModal creates a scrollable modal showing comprehensive
+// container information. r re-fetches the details in place.
 func createContainerDetailsModal(details *container.ContainerDetails) *Modal {
+	// Use scrollable info modal with 20 lines visible and 100 character width
+	modal := NewScrollableInfoModalWide("Container Details", containerDetailsContent(details), 20, 100)
+	modal.Type = ModalContainerDetails
+	modal.containerName = details.Name
+	return modal
+}
+
+// fetchContainerDetails re-reads a container's details for the details modal
+func fetchContainerDetails(containerName, prefix string) tea.Cmd {
+	return func() tea.Msg {
+		details, err := container.GetContainerDetails(containerName, prefix)
+		return containerDetailsMsg{containerName: containerName, details: details, err: err}
+	}
+}
+
+// containerDetailsContent renders the body of the container details modal
+func containerDetailsContent(details *container.ContainerDetails) string {
 	var content strings.Builder
 
 	// Header section
-	content.WriteString(fmt.Sprintf("Container: %s\n", details.ShortName))
+	content.WriteString(fmt.Sprintf("Container: %s  (as of %s, r to refresh)\n", details.ShortName, time.Now().Format("15:04:05")))
 	content.WriteString(strings.Repeat("─", 96) + "\n\n")
 
 	// Status and Basic Info
@@ -1406,17 +1720,42 @@ func createContainerDetailsModal(details *container.ContainerDetails) *Modal {
 	content.WriteString(fmt.Sprintf("Branch:       %s\n", details.Branch))
 	content.WriteString(fmt.Sprintf("Git Status:   %s\n", strings.TrimSpace(details.GitStatus)))
 	content.WriteString(fmt.Sprintf("Auth Status:  %s\n", details.AuthStatus))
+	if details.AuthProblem != "" {
+		content.WriteString(fmt.Sprintf("              %s\n", details.AuthProblem))
+	}
 	content.WriteString(fmt.Sprintf("Last Activity: %s\n", details.LastActivity))
 	if details.Uptime != "" {
 		content.WriteString(fmt.Sprintf("Uptime:       %s\n", details.Uptime))
 	}
+	if details.LaunchCommand != "" {
+		content.WriteString(fmt.Sprintf("Claude:       %s\n", details.LaunchCommand))
+	}
+	if details.ConnectCommand != "" {
+		content.WriteString(fmt.Sprintf("Connect:      %s\n", details.ConnectCommand))
+	}
 	content.WriteString("\n")
 
+	// Full per-file git status; the summary above only has counts
+	if len(details.GitFiles) > 0 {
+		content.WriteString(fmt.Sprintf("Changed Files (%d):\n", len(details.GitFiles)))
+		content.WriteString(strings.Repeat("─", 96) + "\n")
+		for _, line := range details.GitFiles {
+			content.WriteString(fmt.Sprintf("  %s\n", line))
+		}
+		content.WriteString("\n")
+	}
+
 	// Resources
 	content.WriteString("Resources:\n")
 	content.WriteString(strings.Repeat("─", 96) + "\n")
 	content.WriteString(fmt.Sprintf("CPUs:         %s\n", details.CPUs))
+	if details.CPUUsage != "" {
+		content.WriteString(fmt.Sprintf("CPU Usage:    %s\n", details.CPUUsage))
+	}
 	content.WriteString(fmt.Sprintf("Memory:       %s\n", details.Memory))
+	if details.MemoryUsage != "" {
+		content.WriteString(fmt.Sprintf("Memory Usage: %s\n", details.MemoryUsage))
+	}
 	content.WriteString("\n")
 
 	// Network
@@ -1427,6 +1766,9 @@ func createContainerDetailsModal(details *container.ContainerDetails) *Modal {
 	} else {
 		content.WriteString("IP Address:   (none)\n")
 	}
+	if details.FirewallOff {
+		content.WriteString("Firewall:     ⚠ DISABLED (re-enable: maestro firewall enable " + details.ShortName + ")\n")
+	}
 	if len(details.Ports) > 0 {
 		content.WriteString("Ports:\n")
 		for _, port := range details.Ports {
@@ -1466,8 +1808,7 @@ func createContainerDetailsModal(details *container.ContainerDetails) *Modal {
 	content.WriteString(strings.Repeat("─", 96) + "\n")
 	content.WriteString(details.RecentLogs)
 
-	// Use scrollable info modal with 20 lines visible and 100 character width
-	return NewScrollableInfoModalWide("Container Details", content.String(), 20, 100)
+	return content.String()
 }
 
 // createContainerCreateModal creates the interactive form for creating a new container
@@ -1478,7 +1819,8 @@ func createContainerCreateModal() *Modal {
 	ta.SetWidth(90)
 	ta.SetHeight(5)
 	ta.Focus()
-	ta.CharLimit = 2000
+	ta.CharLimit = 20000                             // Room for detailed task specs
+	ta.MaxHeight = 0                                 // Don't cap the line count, or pasted specs lose their tail
 	ta.FocusedStyle.CursorLine = lipgloss.NewStyle() // Remove cursor line highlighting
 	ta.FocusedStyle.Base = lipgloss.NewStyle().Foreground(lipgloss.Color("252"))
 	ta.FocusedStyle.Prompt = lipgloss.NewStyle().Foreground(style.OceanTide)
@@ -1705,6 +2047,51 @@ func createFirewallModal() *Modal {
 	return modal
 }
 
+// createAddDomainModal creates the form for adding one domain to a running
+// container's firewall, like 'maestro add-domain'
+func createAddDomainModal(containerName, shortName string) *Modal {
+	domainInput := textinput.New()
+	domainInput.Placeholder = "e.g., api.example.com"
+	domainInput.Width = 90
+	domainInput.CharLimit = 253
+	domainInput.PromptStyle = lipgloss.NewStyle().Foreground(style.OceanTide)
+	domainInput.TextStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("252"))
+	domainInput.PlaceholderStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	domainInput.Cursor.Style = lipgloss.NewStyle().Foreground(style.OceanSurge)
+	domainInput.Focus()
+
+	modal := &Modal{
+		Type:         ModalForm,
+		Title:        "Add Domain to " + shortName,
+		Width:        100,
+		Height:       15,
+		textinputs:   []textinput.Model{domainInput},
+		checkboxes:   []bool{false}, // Save to config
+		focusedField: 0,
+		fieldLabels: []string{
+			"Domain (applied to this container immediately):",
+			"Also save to config for new containers",
+		},
+		Actions: []ModalAction{
+			{Label: "Add", Key: "ctrl+s", IsPrimary: true},
+			{Label: "Cancel", Key: "esc", IsPrimary: false},
+		},
+	}
+
+	modal.Actions[0].OnSelect = func() tea.Msg {
+		msg := addDomainMsg{containerName: containerName}
+		if len(modal.textinputs) > 0 {
+			msg.domain = strings.TrimSpace(modal.textinputs[0].Value())
+		}
+		if len(modal.checkboxes) > 0 {
+			msg.save = modal.checkboxes[0]
+		}
+		return msg
+	}
+
+	return modal
+}
+
 // createActionsModal creates the container actions menu modal
 func createActionsModal(containerInfo container.Info) *Modal {
 	content := "Select an action for: " + containerInfo.ShortName
@@ -1713,7 +2100,7 @@ func createActionsModal(containerInfo container.Info) *Modal {
 		Type:    ModalActions,
 		Title:   "Container Actions",
 		Content: content,
-		Width:   90,
+		Width:   104, // Wide enough for every action button on one line
 		Actions: []ModalAction{
 			{
 				Label:     "Connect",
@@ -1747,6 +2134,14 @@ func createActionsModal(containerInfo container.Info) *Modal {
 					return ContainerActionMsg{Action: container.OperationDelete, ContainerName: containerInfo.Name}
 				},
 			},
+			{
+				Label:     "Add Domain",
+				Key:       "a",
+				IsPrimary: false,
+				OnSelect: func() tea.Msg {
+					return addDomainRequestMsg{containerName: containerInfo.Name}
+				},
+			},
 			{
 				Label:     "Refresh Tokens",
 				Key:       "t",
@@ -1766,6 +2161,28 @@ func createActionsModal(containerInfo container.Info) *Modal {
 	}
 }
 
+// firewallApplySummary describes the domains and containers a firewall apply
+// will touch, listing at most a few of each
+func firewallApplySummary(domains, containers []string) string {
+	const maxListed = 5
+
+	list := func(items []string) string {
+		var b strings.Builder
+		for i, item := range items {
+			if i == maxListed {
+				fmt.Fprintf(&b, "\n  …and %d more", len(items)-maxListed)
+				break
+			}
+			b.WriteString("\n  " + item)
+		}
+		return b.String()
+	}
+
+	return fmt.Sprintf("Add %d new domain(s) to %d running container(s)?\n"+
+		"dnsmasq will be restarted in each container.\n\nDomains:%s\n\nContainers:%s",
+		len(domains), len(containers), list(domains), list(containers))
+}
+
 // ContainerActionMsg signals a container action should be performed
 type ContainerActionMsg struct {
 	Action        container.OperationType
@@ -1786,9 +2203,14 @@ func (m Model) handleContainerAction(msg ContainerActionMsg) (tea.Model, tea.Cmd
 		action := msg.Action
 		containerName := msg.ContainerName
 
+		content := fmt.Sprintf("Are you sure you want to %s container '%s'?", actionVerb, msg.ContainerName)
+		if msg.Action == container.OperationDelete {
+			content = deleteConfirmText(msg.ContainerName, m.containerBranch(msg.ContainerName), deleteGracePeriod())
+		}
+
 		m.modal = NewConfirmModal(
 			"Confirm "+strings.Title(string(msg.Action)),
-			fmt.Sprintf("Are you sure you want to %s container '%s'?", actionVerb, msg.ContainerName),
+			content,
 			func() tea.Msg {
 				return ConfirmActionMsg{
 					Action:        action,
@@ -1797,6 +2219,11 @@ func (m Model) handleContainerAction(msg ContainerActionMsg) (tea.Model, tea.Cmd
 			},
 			nil, // OnCancel just dismisses
 		)
+		if msg.Action == container.OperationDelete {
+			// Start on "No" so an Enter carried over from the actions menu
+			// can't confirm an irreversible delete
+			m.modal.SelectedAction = 1
+		}
 		return m, nil
 
 	case container.OperationRestart:
@@ -1825,6 +2252,107 @@ func (m Model) handleContainerAction(msg ContainerActionMsg) (tea.Model, tea.Cmd
 	}
 }
 
+// containerBranch returns the branch of a listed container, or "" if unknown
+func (m Model) containerBranch(containerName string) string {
+	if m.homeView == nil {
+		return ""
+	}
+	for _, c := range m.homeView.GetContainers() {
+		if c.Name == containerName {
+			return c.Branch
+		}
+	}
+	return ""
+}
+
+// containerRunning reports whether a listed container is running
+func (m Model) containerRunning(containerName string) bool {
+	if m.homeView == nil {
+		return false
+	}
+	for _, c := range m.homeView.GetContainers() {
+		if c.Name == containerName {
+			return c.Status == "running"
+		}
+	}
+	return false
+}
+
+// deleteConfirmText describes what deleting a container destroys, naming its
+// branch so similar-looking containers can be told apart
+func deleteConfirmText(containerName, branch string, grace time.Duration) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Permanently remove container '%s'", containerName)
+	if branch != "" && branch != "-" && branch != container.UnknownBranch && branch != container.NoGit {
+		fmt.Fprintf(&b, "\n(branch %s)", branch)
+	}
+	b.WriteString("?\n\nIts volumes are deleted too, including shell history:")
+	for _, volume := range container.ContainerVolumes(containerName) {
+		b.WriteString("\n  " + volume)
+	}
+	if grace > 0 {
+		fmt.Fprintf(&b, "\n\nThe container is stopped now and removed after %s;\npress u before then to undo.", grace)
+	} else {
+		b.WriteString("\n\nThis cannot be undone.")
+	}
+	return b.String()
+}
+
+// defaultDeleteGracePeriod is used when tui.delete_grace_period is unset or invalid
+const defaultDeleteGracePeriod = 10 * time.Second
+
+// deleteGracePeriod returns how long a deleted container is kept stopped
+// before it and its volumes are removed (tui.delete_grace_period); 0 removes
+// it immediately
+func deleteGracePeriod() time.Duration {
+	grace, err := time.ParseDuration(viper.GetString("tui.delete_grace_period"))
+	if err != nil || grace < 0 {
+		return defaultDeleteGracePeriod
+	}
+	return grace
+}
+
+// pendingDelete is a stopped container waiting out the delete grace period
+type pendingDelete struct {
+	id            int
+	containerName string
+	wasRunning    bool // Started again if the delete is undone
+}
+
+// softDelete stops a container as the first step of a delete, remembering
+// whether it was running so an undo can put it back
+func (m Model) softDelete(containerName string) tea.Cmd {
+	wasRunning := m.containerRunning(containerName)
+	return func() tea.Msg {
+		var err error
+		if wasRunning {
+			err = container.StopContainer(containerName)
+		}
+		return softDeleteMsg{containerName: containerName, wasRunning: wasRunning, err: err}
+	}
+}
+
+// undoDelete restores a soft-deleted container to how it was before the delete
+func undoDelete(pending pendingDelete) tea.Cmd {
+	return func() tea.Msg {
+		var err error
+		if pending.wasRunning {
+			err = container.StartContainer(pending.containerName)
+		}
+		return undoDeleteMsg{containerName: pending.containerName, err: err}
+	}
+}
+
+// finishPendingDeletes removes containers whose grace period was still
+// running when the TUI exited. Leaving the TUI ends the chance to undo.
+func (m Model) finishPendingDeletes() {
+	for _, pending := range m.pendingDeletes {
+		if err := container.DeleteContainer(pending.containerName); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to delete %s: %v\n", pending.containerName, err)
+		}
+	}
+}
+
 // ConfirmActionMsg signals that a confirmed action should be executed
 type ConfirmActionMsg struct {
 	Action        container.OperationType
@@ -1844,7 +2372,7 @@ func (m Model) performDockerOperation(action container.OperationType, containerN
 		case container.OperationDelete:
 			err = container.DeleteContainer(containerName)
 		case container.OperationRefreshTokens:
-			err = container.RefreshTokens(containerName)
+			err = container.RefreshTokens(containerName, m.containerPrefix)
 		default:
 			err = fmt.Errorf("unknown operation: %s", action)
 		}
@@ -2173,6 +2701,59 @@ func (m Model) renderWizardAnimation() string {
 	)
 }
 
+// maxErrorLog is how many errors the error history keeps
+const maxErrorLog = 50
+
+// maxStatusErrorWidth caps how much of the latest error the statusbar shows
+const maxStatusErrorWidth = 60
+
+// errorEntry is one error in the TUI's error history
+type errorEntry struct {
+	at      time.Time
+	message string
+}
+
+// recordError adds an error to the history and shows it in the statusbar
+// until it is dismissed
+func (m *Model) recordError(message string) {
+	message = strings.Join(strings.Fields(message), " ")
+	if message == "" {
+		return
+	}
+	m.errorLog = append(m.errorLog, errorEntry{at: time.Now(), message: message})
+	if len(m.errorLog) > maxErrorLog {
+		m.errorLog = m.errorLog[len(m.errorLog)-maxErrorLog:]
+	}
+	m.errorDismissed = false
+	m.updateStatusBar()
+}
+
+// errorAlert records an error and returns a toast showing it
+func (m *Model) errorAlert(message string) tea.Cmd {
+	m.recordError(message)
+	return m.alert.NewAlertCmd("Error", message)
+}
+
+// latestError returns the most recent error unless it has been dismissed
+func (m Model) latestError() (errorEntry, bool) {
+	if m.errorDismissed || len(m.errorLog) == 0 {
+		return errorEntry{}, false
+	}
+	return m.errorLog[len(m.errorLog)-1], true
+}
+
+// createErrorLogModal lists the session's errors, newest first
+func createErrorLogModal(entries []errorEntry) *Modal {
+	if len(entries) == 0 {
+		return NewInfoModal("Recent Errors", "No errors this session.")
+	}
+	var content strings.Builder
+	for i := len(entries) - 1; i >= 0; i-- {
+		fmt.Fprintf(&content, "%s  %s\n", entries[i].at.Format("15:04:05"), entries[i].message)
+	}
+	return NewScrollableInfoModalWide("Recent Errors", strings.TrimRight(content.String(), "\n"), 20, 100)
+}
+
 // updateStatusBar refreshes the statusbar content with manual background styling
 func (m *Model) updateStatusBar() {
 	// Column 1: Daemon status + Container count (DeepSpace background)
@@ -2191,6 +2772,9 @@ func (m *Model) updateStatusBar() {
 		}
 		daemonColor := style.GetDaemonShade(shade)
 		daemonIndicator = lipgloss.NewStyle().Foreground(daemonColor).Render("●")
+	} else if viper.GetBool("daemon.show_nag") {
+		// Not running; say so unless the user turned the reminder off
+		daemonIndicator = "○ daemon off"
 	} else {
 		daemonIndicator = "○" // Not running
 	}
@@ -2226,6 +2810,13 @@ func (m *Model) updateStatusBar() {
 			Background(style.CrimsonPulse).
 			Bold(true).
 			Render(" Is Docker running? ")
+	} else if latest, ok := m.latestError(); ok && !m.operationInProgress {
+		// Most recent error stays until dismissed with x or viewed with !
+		text := fmt.Sprintf(" ✗ %s %s (! for details, x to dismiss) ", latest.at.Format("15:04"), latest.message)
+		col3 = lipgloss.NewStyle().
+			Foreground(style.GhostWhite).
+			Background(style.CrimsonPulse).
+			Render(ansi.Truncate(text, maxStatusErrorWidth, "… "))
 	} else if m.operationInProgress {
 		// Style both spinner and text with matching background
 		spinnerPart := m.operationSpinner.View()
@@ -2256,6 +2847,38 @@ func (m *Model) updateStatusBar() {
 	m.statusbar.SetContent(col1, col2, col3, col4)
 }
 
+// renderDockerUnavailable renders the screen shown in place of the container
+// list when Docker is missing or not running
+func (m Model) renderDockerUnavailable() string {
+	message := m.dockerMessage
+	if message == "" {
+		message = system.DockerNotRunning
+	}
+
+	lines := []string{
+		lipgloss.NewStyle().Foreground(style.CrimsonPulse).Bold(true).Render("⚠ Docker is not available"),
+		"",
+		message,
+		"",
+	}
+	lines = append(lines, system.DockerGuidance(message)...)
+	lines = append(lines, "", lipgloss.NewStyle().Foreground(style.GhostWhite).Faint(true).
+		Render("Containers appear on the next refresh once Docker is up."))
+
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(style.CrimsonPulse).
+		Padding(1, 2).
+		Render(lipgloss.JoinVertical(lipgloss.Center, lines...))
+
+	// Same space the container list gets
+	height := m.height - 9
+	if height < lipgloss.Height(box) {
+		height = lipgloss.Height(box)
+	}
+	return lipgloss.Place(m.width, height, lipgloss.Center, lipgloss.Center, box)
+}
+
 func (m Model) View() string {
 	// Wizard mode: Show opening animation
 	if m.wizardMode && m.wizardStep == 0 {
@@ -2306,6 +2929,9 @@ func (m Model) View() string {
 	titleBanner := m.renderTitleBanner()
 
 	baseView := m.homeView.View()
+	if !m.dockerResponsive {
+		baseView = m.renderDockerUnavailable()
+	}
 
 	// Combine title and main view for modal background
 	combinedView := titleBanner + "\n" + baseView
@@ -0,0 +1,329 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/spf13/viper"
+	"github.com/uprockcom/maestro/pkg/container"
+	"github.com/uprockcom/maestro/pkg/system"
+	"github.com/uprockcom/maestro/pkg/tui/views"
+)
+
+// collectLoads runs cmd and any batched commands it returns, keeping the
+// container loads
+func collectLoads(cmd tea.Cmd) []containersLoadedMsg {
+	if cmd == nil {
+		return nil
+	}
+	var loads []containersLoadedMsg
+	switch msg := cmd().(type) {
+	case containersLoadedMsg:
+		loads = append(loads, msg)
+	case tea.BatchMsg:
+		for _, c := range msg {
+			loads = append(loads, collectLoads(c)...)
+		}
+	}
+	return loads
+}
+
+func TestContainerLoadWaitsForModal(t *testing.T) {
+	m := *New("maestro-")
+	m.wizardMode = false
+	m.homeView = views.NewHomeModel([]container.Info{{Name: "maestro-old-1", ShortName: "old-1"}}, false, false)
+	m.modal = NewErrorModal("Failed", "Something went wrong")
+
+	load := containersLoadedMsg{
+		containers:       []container.Info{{Name: "maestro-new-1", ShortName: "new-1"}},
+		dockerResponsive: true,
+	}
+	next, _ := m.Update(load)
+	m = next.(Model)
+
+	if got := m.homeView.GetContainers(); len(got) != 1 || got[0].Name != "maestro-old-1" {
+		t.Fatalf("table rebuilt under an open modal: %+v", got)
+	}
+	if m.pendingLoad == nil {
+		t.Fatal("load was dropped instead of held")
+	}
+
+	// Once the modal closes, the next message replays the held load
+	m.modal = nil
+	next, cmd := m.Update(tea.WindowSizeMsg{Width: 100, Height: 40})
+	m = next.(Model)
+	if m.pendingLoad != nil {
+		t.Error("pending load not cleared")
+	}
+	loads := collectLoads(cmd)
+	if len(loads) != 1 || loads[0].containers[0].Name != "maestro-new-1" {
+		t.Fatalf("held load not replayed, got %+v", loads)
+	}
+
+	next, _ = m.Update(loads[0])
+	m = next.(Model)
+	if got := m.homeView.GetContainers(); len(got) != 1 || got[0].Name != "maestro-new-1" {
+		t.Errorf("table not updated after modal closed: %+v", got)
+	}
+}
+
+func TestRefreshInterval(t *testing.T) {
+	t.Cleanup(func() { viper.Set("tui.refresh_interval", nil) })
+
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", defaultRefreshInterval},
+		{"10s", 10 * time.Second},
+		{"2m", 2 * time.Minute},
+		{"0", 0},
+		{"-5s", defaultRefreshInterval},
+		{"often", defaultRefreshInterval},
+	}
+	for _, tt := range tests {
+		viper.Set("tui.refresh_interval", tt.value)
+		if got := refreshInterval(); got != tt.want {
+			t.Errorf("refreshInterval() with %q = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+
+	viper.Set("tui.refresh_interval", "0")
+	if refreshTick() != nil {
+		t.Error("refreshTick() should be nil when background refresh is disabled")
+	}
+}
+
+func TestDeleteConfirmation(t *testing.T) {
+	m := *New("maestro-")
+	m.wizardMode = false
+	m.homeView = views.NewHomeModel([]container.Info{
+		{Name: "maestro-feat-auth-1", ShortName: "feat-auth-1", Branch: "feat/auth-login", Status: "running"},
+	}, false, false)
+
+	next, _ := m.handleContainerAction(ContainerActionMsg{Action: container.OperationDelete, ContainerName: "maestro-feat-auth-1"})
+	modal := next.(Model).modal
+	if modal == nil || modal.Type != ModalConfirm {
+		t.Fatalf("delete did not ask for confirmation: %+v", modal)
+	}
+	for _, want := range []string{"maestro-feat-auth-1", "feat/auth-login", "maestro-feat-auth-1-history", "press u"} {
+		if !strings.Contains(modal.Content, want) {
+			t.Errorf("confirmation %q should mention %q", modal.Content, want)
+		}
+	}
+	if got := modal.Actions[modal.SelectedAction].Label; got != "No" {
+		t.Errorf("delete confirmation starts on %q, want No", got)
+	}
+
+	// An Enter arriving straight away dismisses instead of deleting
+	_, cmd := modal.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd != nil {
+		if _, ok := cmd().(ConfirmActionMsg); ok {
+			t.Error("Enter confirmed the delete")
+		}
+	}
+
+	next, _ = m.handleContainerAction(ContainerActionMsg{Action: container.OperationStop, ContainerName: "maestro-feat-auth-1"})
+	if modal := next.(Model).modal; modal.Actions[modal.SelectedAction].Label != "Yes" {
+		t.Error("stop confirmation should still default to Yes")
+	}
+}
+
+func TestDeleteConfirmTextWithoutGracePeriod(t *testing.T) {
+	text := deleteConfirmText("maestro-feat-auth-1", "main", 0)
+	if !strings.Contains(text, "cannot be undone") || strings.Contains(text, "undo.") {
+		t.Errorf("immediate delete confirmation = %q", text)
+	}
+}
+
+func TestSoftDeleteUndo(t *testing.T) {
+	t.Cleanup(func() { viper.Set("tui.delete_grace_period", nil) })
+	viper.Set("tui.delete_grace_period", "10s")
+
+	m := *New("maestro-")
+	m.wizardMode = false
+	// Stopped containers need no docker calls to soft-delete or restore
+	m.homeView = views.NewHomeModel([]container.Info{
+		{Name: "maestro-a-1", ShortName: "a-1", Status: "exited"},
+		{Name: "maestro-b-1", ShortName: "b-1", Status: "exited"},
+	}, false, false)
+
+	softDelete := func(name string) {
+		t.Helper()
+		msg, ok := m.softDelete(name)().(softDeleteMsg)
+		if !ok || msg.err != nil || msg.wasRunning {
+			t.Fatalf("softDelete(%s) = %+v", name, msg)
+		}
+		next, _ := m.Update(msg)
+		m = next.(Model)
+	}
+
+	softDelete("maestro-a-1")
+	softDelete("maestro-b-1")
+	if len(m.pendingDeletes) != 2 {
+		t.Fatalf("pending deletes = %+v, want 2", m.pendingDeletes)
+	}
+
+	// u undoes the most recent delete only
+	next, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("u")})
+	m = next.(Model)
+	if len(m.pendingDeletes) != 1 || m.pendingDeletes[0].containerName != "maestro-a-1" {
+		t.Fatalf("after undo, pending deletes = %+v", m.pendingDeletes)
+	}
+
+	// The undone container's timer fires without deleting anything
+	next, _ = m.Update(deleteGraceExpiredMsg{id: 2})
+	m = next.(Model)
+	if m.operationStatus == "Deleting..." {
+		t.Error("expired timer of an undone delete started deleting")
+	}
+
+	// The remaining one is deleted when its grace period ends
+	next, _ = m.Update(deleteGraceExpiredMsg{id: 1})
+	m = next.(Model)
+	if len(m.pendingDeletes) != 0 || m.operationStatus != "Deleting..." {
+		t.Errorf("grace period end left pending = %+v, status %q", m.pendingDeletes, m.operationStatus)
+	}
+}
+
+func TestDockerUnavailableScreen(t *testing.T) {
+	m := *New("maestro-")
+	m.wizardMode = false
+	next, _ := m.Update(tea.WindowSizeMsg{Width: 100, Height: 40})
+	m = next.(Model)
+
+	next, _ = m.Update(containersLoadedMsg{
+		containers:    []container.Info{},
+		dockerMessage: system.DockerNotFound,
+	})
+	m = next.(Model)
+
+	view := ansi.Strip(m.View())
+	for _, want := range []string{"Docker is not available", system.DockerNotFound, "docker command is on your PATH"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view is missing %q", want)
+		}
+	}
+
+	// Once Docker responds the list comes back
+	next, _ = m.Update(containersLoadedMsg{
+		containers:       []container.Info{{Name: "maestro-a-1", ShortName: "a-1"}},
+		dockerResponsive: true,
+	})
+	m = next.(Model)
+	if strings.Contains(ansi.Strip(m.View()), "Docker is not available") {
+		t.Error("error screen still shown after Docker came back")
+	}
+}
+
+func TestErrorLog(t *testing.T) {
+	m := *New("maestro-")
+	m.wizardMode = false
+	m.ready = true
+	m.dockerResponsive = true
+	m.homeView = views.NewHomeModel([]container.Info{{Name: "maestro-a-1", ShortName: "a-1"}}, false, false)
+
+	// A failed refresh is recorded and keeps the last good list
+	next, _ := m.Update(containersLoadedMsg{dockerResponsive: true, err: errors.New("docker ps timed out")})
+	m = next.(Model)
+	if got := m.homeView.GetContainers(); len(got) != 1 {
+		t.Errorf("failed refresh replaced the list: %+v", got)
+	}
+	latest, ok := m.latestError()
+	if !ok || !strings.Contains(latest.message, "docker ps timed out") {
+		t.Fatalf("latest error = %+v, %v", latest, ok)
+	}
+
+	// x hides it from the statusbar but keeps it in the history
+	next, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	m = next.(Model)
+	if _, ok := m.latestError(); ok {
+		t.Error("x did not dismiss the error")
+	}
+
+	// A new error shows again, and ! lists both, newest first
+	m.recordError("Failed to stop container maestro-a-1:\n  exit status 1")
+	if latest, ok := m.latestError(); !ok || latest.message != "Failed to stop container maestro-a-1: exit status 1" {
+		t.Errorf("latest error = %+v, %v", latest, ok)
+	}
+	next, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("!")})
+	m = next.(Model)
+	if m.modal == nil {
+		t.Fatal("! did not open the error history")
+	}
+	content := ansi.Strip(m.modal.Content)
+	stop, load := strings.Index(content, "Failed to stop"), strings.Index(content, "docker ps timed out")
+	if stop < 0 || load < 0 || stop > load {
+		t.Errorf("error history not newest first:\n%s", content)
+	}
+
+	for i := 0; i < maxErrorLog+5; i++ {
+		m.recordError("error")
+	}
+	if len(m.errorLog) != maxErrorLog {
+		t.Errorf("error history holds %d entries, want %d", len(m.errorLog), maxErrorLog)
+	}
+}
+
+func TestContainerDetailsRefresh(t *testing.T) {
+	m := *New("maestro-")
+	m.wizardMode = false
+	m.modal = createContainerDetailsModal(&container.ContainerDetails{
+		Name: "maestro-a-1", ShortName: "a-1", Status: "running", RecentLogs: "old log line",
+	})
+
+	// r starts a refresh and keeps the modal open
+	next, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	m = next.(Model)
+	if m.modal == nil || !m.modal.Refreshing() || cmd == nil {
+		t.Fatalf("r did not start a refresh: modal = %+v", m.modal)
+	}
+
+	// Details for another container are ignored
+	next, _ = m.Update(containerDetailsMsg{containerName: "maestro-b-1", details: &container.ContainerDetails{Name: "maestro-b-1"}})
+	m = next.(Model)
+	if !m.modal.Refreshing() {
+		t.Error("details for another container ended the refresh")
+	}
+
+	next, _ = m.Update(containerDetailsMsg{
+		containerName: "maestro-a-1",
+		details:       &container.ContainerDetails{Name: "maestro-a-1", ShortName: "a-1", Status: "running", RecentLogs: "new log line"},
+	})
+	m = next.(Model)
+	if m.modal == nil || m.modal.Refreshing() {
+		t.Fatalf("refresh did not finish: modal = %+v", m.modal)
+	}
+	if !strings.Contains(m.modal.Content, "new log line") || strings.Contains(m.modal.Content, "old log line") {
+		t.Errorf("modal content not updated:\n%s", m.modal.Content)
+	}
+
+	// A failed refresh keeps the old content and records the error
+	m.modal.StartRefresh()
+	next, _ = m.Update(containerDetailsMsg{containerName: "maestro-a-1", err: errors.New("no such container")})
+	m = next.(Model)
+	if m.modal == nil || m.modal.Refreshing() || !strings.Contains(m.modal.Content, "new log line") {
+		t.Errorf("failed refresh changed the modal: %+v", m.modal)
+	}
+	if got, ok := m.latestError(); !ok || !strings.Contains(got.message, "no such container") {
+		t.Errorf("latestError() = %+v, want the refresh error", got)
+	}
+}
@@ -15,6 +15,10 @@
 package tui
 
 import (
+	"io"
+	"log"
+	"os"
+
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/uprockcom/maestro/pkg/container"
@@ -35,6 +39,10 @@ func Run(containerPrefix string, cachedState *CachedState) (*TUIResult, *CachedS
 	// tea.WithMouseCellMotion() enables mouse support (optional)
 	p := tea.NewProgram(model, tea.WithAltScreen())
 
+	// Log lines (e.g. skipped docker ps output) would draw over the screen
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
 	finalModel, err := p.Run()
 	if err != nil {
 		return nil, nil, err
@@ -42,6 +50,7 @@ func Run(containerPrefix string, cachedState *CachedState) (*TUIResult, *CachedS
 
 	// Extract result and state from final model
 	if m, ok := finalModel.(Model); ok {
+		m.finishPendingDeletes()
 		return m.GetResult(), m.GetState(), nil
 	}
 
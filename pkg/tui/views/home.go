@@ -15,9 +15,12 @@
 package views
 
 import (
+	"fmt"
+
 	"github.com/charmbracelet/bubbles/table"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 
 	"github.com/uprockcom/maestro/pkg/container"
 	"github.com/uprockcom/maestro/pkg/tui/style"
@@ -33,8 +36,9 @@ type columnConfig struct {
 // Maximum table width before centering kicks in
 const maxTableWidth = 160
 
-// getColumnConfigs returns column definitions based on whether AWS auth is used
-func getColumnConfigs(useAWSAuth bool) []columnConfig {
+// getColumnConfigs returns column definitions based on whether AWS auth is
+// used and whether the CPU/MEM limits column is shown
+func getColumnConfigs(useAWSAuth, showResources bool) []columnConfig {
 	configs := []columnConfig{
 		{title: "NAME", baseSize: 25, minSize: 15},
 		{title: "STATUS", baseSize: 14, minSize: 12},
@@ -46,6 +50,9 @@ func getColumnConfigs(useAWSAuth bool) []columnConfig {
 	if !useAWSAuth {
 		configs = append(configs, columnConfig{title: "AUTH", baseSize: 12, minSize: 10})
 	}
+	if showResources {
+		configs = append(configs, columnConfig{title: "CPU/MEM", baseSize: 10, minSize: 9})
+	}
 	configs = append(configs, columnConfig{title: "CREATED", baseSize: 12, minSize: 10})
 	return configs
 }
@@ -65,14 +72,18 @@ type HomeModel struct {
 	width         int
 	height        int
 	animState     int
-	containers    []container.Info
+	containers    []container.Info // Rows shown, after filtering
+	all           []container.Info // Every container, before filtering
 	daemonRunning bool
 	useAWSAuth    bool // Whether AWS/Bedrock auth is being used (hides AUTH column)
+	showAge       bool // Show time since creation instead of the creation date
+	showResources bool // Show the CPU/MEM limits column
+	attentionOnly bool // Show only containers waiting for the user
 }
 
 // calculateColumnWidths returns column widths scaled to fit the given width
-func calculateColumnWidths(availableWidth int, useAWSAuth bool) []table.Column {
-	columnConfigs := getColumnConfigs(useAWSAuth)
+func calculateColumnWidths(availableWidth int, useAWSAuth, showResources bool) []table.Column {
+	columnConfigs := getColumnConfigs(useAWSAuth, showResources)
 	totalBaseWidth := getTotalBaseWidth(columnConfigs)
 
 	// Account for table borders and padding (roughly 4 chars for borders + spacing)
@@ -115,11 +126,11 @@ func calculateColumnWidths(availableWidth int, useAWSAuth bool) []table.Column {
 
 // NewHomeModel creates a new home view
 func NewHomeModel(containers []container.Info, daemonRunning bool, useAWSAuth bool) *HomeModel {
-	columnConfigs := getColumnConfigs(useAWSAuth)
+	columnConfigs := getColumnConfigs(useAWSAuth, false)
 	totalBaseWidth := getTotalBaseWidth(columnConfigs)
 
 	// Start with base column widths
-	columns := calculateColumnWidths(totalBaseWidth, useAWSAuth)
+	columns := calculateColumnWidths(totalBaseWidth, useAWSAuth, false)
 
 	t := table.New(
 		table.WithColumns(columns),
@@ -146,6 +157,7 @@ func NewHomeModel(containers []container.Info, daemonRunning bool, useAWSAuth bo
 	h := &HomeModel{
 		table:         t,
 		containers:    containers,
+		all:           containers,
 		daemonRunning: daemonRunning,
 		useAWSAuth:    useAWSAuth,
 	}
@@ -193,6 +205,35 @@ func (h *HomeModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 			}
 			return h, nil
+		case "d":
+			// Delete the selected container (confirmed by the parent model)
+			if len(h.containers) > 0 {
+				selectedIdx := h.table.Cursor()
+				if selectedIdx >= 0 && selectedIdx < len(h.containers) {
+					selected := h.containers[selectedIdx]
+					return h, func() tea.Msg {
+						return DeleteRequestMsg{Container: selected}
+					}
+				}
+			}
+			return h, nil
+		case "t":
+			h.ToggleAge()
+			return h, nil
+		case "c":
+			h.ToggleResources()
+			if h.showResources {
+				return h, func() tea.Msg { return ResourcesShownMsg{} }
+			}
+			return h, nil
+		case "w":
+			h.ToggleAttentionOnly()
+			return h, nil
+		case "e":
+			// Open the config file in $EDITOR
+			return h, func() tea.Msg {
+				return EditConfigRequestMsg{}
+			}
 		case "up", "k":
 			h.table, cmd = h.table.Update(msg)
 			return h, cmd
@@ -211,15 +252,34 @@ type ConnectRequestMsg struct {
 	ContainerName string
 }
 
+// EditConfigRequestMsg signals that the user wants to edit the config file
+type EditConfigRequestMsg struct{}
+
+// ResourcesShownMsg signals that the CPU/MEM column was turned on, so the
+// containers need reloading with their limits
+type ResourcesShownMsg struct{}
+
 // ShowActionsMenuMsg signals to show the actions menu for a container
 type ShowActionsMenuMsg struct {
 	Container container.Info
 }
 
+// DeleteRequestMsg signals that the user wants to delete a container
+type DeleteRequestMsg struct {
+	Container container.Info
+}
+
 // View renders the home view
 func (h *HomeModel) View() string {
 	// Container table
 	tableView := h.table.View()
+	if h.attentionOnly {
+		banner := fmt.Sprintf("Showing %d of %d containers: only those waiting for you (w to show all)", len(h.containers), len(h.all))
+		if len(h.containers) == 0 {
+			banner = "Nothing is waiting for you (w to show all containers)"
+		}
+		tableView = lipgloss.NewStyle().Foreground(style.SunsetGlow).Render(banner) + "\n" + tableView
+	}
 
 	// Center the table horizontally
 	return lipgloss.Place(
@@ -239,6 +299,9 @@ func (h *HomeModel) SetSize(width, height int) {
 	// Adjust table height to fill screen
 	// Title (1) + empty (1) + empty (1) + help bar (1) = 4 lines overhead
 	tableHeight := height - 4
+	if h.attentionOnly {
+		tableHeight-- // Filter banner
+	}
 	if tableHeight < 5 {
 		tableHeight = 5
 	}
@@ -252,8 +315,7 @@ func (h *HomeModel) SetSize(width, height int) {
 	}
 
 	// Update column widths proportionally
-	columns := calculateColumnWidths(effectiveWidth, h.useAWSAuth)
-	h.table.SetColumns(columns)
+	h.table.SetColumns(h.columns(effectiveWidth))
 
 	// Only set table viewport width if we're filling the space
 	// When viewport > max, don't set width so lipgloss.Place can center
@@ -262,6 +324,67 @@ func (h *HomeModel) SetSize(width, height int) {
 	} else {
 		h.table.SetWidth(maxTableWidth)
 	}
+
+	// Re-truncate cells for the new column widths
+	h.updateTableRows()
+}
+
+// columns returns the table columns for the given width, titling the last
+// column AGE or CREATED depending on the toggle
+func (h *HomeModel) columns(width int) []table.Column {
+	columns := calculateColumnWidths(width, h.useAWSAuth, h.showResources)
+	if h.showAge {
+		columns[len(columns)-1].Title = "AGE"
+	}
+	return columns
+}
+
+// ToggleAge switches the last column between creation date and age
+func (h *HomeModel) ToggleAge() {
+	h.showAge = !h.showAge
+	h.relayoutColumns()
+}
+
+// ToggleResources shows or hides the CPU/MEM column of resource limits
+func (h *HomeModel) ToggleResources() {
+	h.showResources = !h.showResources
+	h.relayoutColumns()
+}
+
+// ShowsResources reports whether the CPU/MEM column is shown
+func (h *HomeModel) ShowsResources() bool {
+	return h.showResources
+}
+
+// relayoutColumns rebuilds the columns and rows after a column toggle
+func (h *HomeModel) relayoutColumns() {
+	width := h.width
+	if width > maxTableWidth {
+		width = maxTableWidth
+	}
+	if width == 0 {
+		width = getTotalBaseWidth(getColumnConfigs(h.useAWSAuth, h.showResources))
+	}
+	// Clear rows first so the table never renders rows against stale columns
+	h.table.SetRows(nil)
+	h.table.SetColumns(h.columns(width))
+	h.updateTableRows()
+}
+
+// ToggleAttentionOnly switches between showing every container and only those
+// waiting for the user, keeping the selected container selected when it is
+// still shown
+func (h *HomeModel) ToggleAttentionOnly() {
+	h.attentionOnly = !h.attentionOnly
+	if h.width > 0 && h.height > 0 {
+		h.SetSize(h.width, h.height)
+	}
+	h.setContainers(h.all)
+}
+
+// AttentionOnly reports whether only containers waiting for the user are shown
+func (h *HomeModel) AttentionOnly() bool {
+	return h.attentionOnly
 }
 
 // SetAnimationState updates the animation state for pulsing indicators
@@ -269,17 +392,72 @@ func (h *HomeModel) SetAnimationState(state int) {
 	h.animState = state
 }
 
-// RefreshContainers updates the container list
+// RefreshContainers updates the container list, keeping the cursor on the
+// same container even if rows were reordered. If the selected container is
+// gone, the cursor stays at the nearest row.
 func (h *HomeModel) RefreshContainers(containers []container.Info, daemonRunning bool) {
-	h.containers = containers
 	h.daemonRunning = daemonRunning
+	h.setContainers(containers)
+}
+
+// setContainers replaces the container list and rebuilds the rows through the
+// attention filter, keeping the cursor on the selected container
+func (h *HomeModel) setContainers(containers []container.Info) {
+	cursor := h.table.Cursor()
+	var selectedName string
+	if cursor >= 0 && cursor < len(h.containers) {
+		selectedName = h.containers[cursor].Name
+	}
+
+	h.all = containers
+	h.containers = filterAttention(containers, h.attentionOnly)
 	h.updateTableRows()
+
+	h.table.SetCursor(selectionIndex(h.containers, selectedName, cursor))
+}
+
+// filterAttention returns the containers needing attention if attentionOnly
+// is set, otherwise all of them
+func filterAttention(containers []container.Info, attentionOnly bool) []container.Info {
+	if !attentionOnly {
+		return containers
+	}
+	filtered := []container.Info{}
+	for _, c := range containers {
+		if c.NeedsAttention {
+			filtered = append(filtered, c)
+		}
+	}
+	return filtered
+}
+
+// selectionIndex returns the row of the container named selectedName, or
+// prevCursor clamped to the list if it is no longer present
+func selectionIndex(containers []container.Info, selectedName string, prevCursor int) int {
+	for i, c := range containers {
+		if selectedName != "" && c.Name == selectedName {
+			return i
+		}
+	}
+	if prevCursor >= len(containers) {
+		prevCursor = len(containers) - 1
+	}
+	if prevCursor < 0 {
+		prevCursor = 0
+	}
+	return prevCursor
 }
 
 // updateTableRows converts container data to table rows
 func (h *HomeModel) updateTableRows() {
 	rows := make([]table.Row, 0, len(h.containers))
 
+	// Column order matches getColumnConfigs: NAME, STATUS, BRANCH, GIT, ...
+	var widths []int
+	for _, col := range h.table.Columns() {
+		widths = append(widths, col.Width)
+	}
+
 	for _, c := range h.containers {
 		row := table.Row{
 			h.formatName(c),
@@ -292,32 +470,53 @@ func (h *HomeModel) updateTableRows() {
 		if !h.useAWSAuth {
 			row = append(row, h.formatAuth(c))
 		}
+		if h.showResources {
+			row = append(row, h.formatResources(c))
+		}
 		row = append(row, h.formatCreated(c))
+
+		// Truncate by display width so emoji and CJK text can't overflow a column
+		for i := range row {
+			if i < len(widths) {
+				row[i] = truncateCell(row[i], widths[i])
+			}
+		}
 		rows = append(rows, row)
 	}
 
 	h.table.SetRows(rows)
 }
 
+// truncateCell shortens s to fit a column of the given width, marking the cut
+// with a trailing "…". Width is measured in terminal cells, so wide runes and
+// ANSI sequences are handled. A width of 0 (columns not laid out yet) leaves s as is.
+func truncateCell(s string, width int) string {
+	if width <= 0 || ansi.StringWidth(s) <= width {
+		return s
+	}
+	return ansi.Truncate(s, width, "…")
+}
+
 // formatName returns the container short name
 func (h *HomeModel) formatName(c container.Info) string {
 	return c.ShortName
 }
 
-// formatStatus returns the status indicator
+// formatStatus returns the status indicator, flagging running containers that
+// are waiting or have the firewall off
 // Using plain text without colors to avoid ANSI bleeding issues in the table
 func (h *HomeModel) formatStatus(c container.Info) string {
-	switch c.Status {
-	case "running":
-		if c.NeedsAttention {
-			return "⚠ Waiting"
-		}
-		return "● Running"
-	case "exited":
-		return "○ Stopped"
-	default:
-		return "? " + c.Status
+	status := container.FormatState(c.Status)
+	if c.Status != "running" {
+		return status
+	}
+	if c.NeedsAttention {
+		status = "⚠ Waiting"
+	}
+	if c.FirewallOff {
+		status += " ⚠FW"
 	}
+	return status
 }
 
 // formatBranch returns the branch name
@@ -352,24 +551,59 @@ func (h *HomeModel) formatAuth(c container.Info) string {
 	return c.AuthStatus
 }
 
-// formatCreated returns when the container was created
+// formatResources returns the CPU and memory limits, "∞" where unlimited
+func (h *HomeModel) formatResources(c container.Info) string {
+	if c.Limits == nil {
+		return "—"
+	}
+	return container.FormatCPULimit(c.Limits.CPUs) + " / " + container.FormatMemoryLimit(c.Limits.MemoryBytes)
+}
+
+// formatCreated returns when the container was created, or its age if toggled
 func (h *HomeModel) formatCreated(c container.Info) string {
 	if c.CreatedAt.IsZero() {
 		return "—"
 	}
+	if h.showAge {
+		return container.FormatAge(c.CreatedAt)
+	}
 	return c.CreatedAt.Format("Jan 2 15:04")
 }
 
-// GetContainers returns the current container list for caching
+// UsesAWSAuth reports whether the view was built without the AUTH column
+func (h *HomeModel) UsesAWSAuth() bool {
+	return h.useAWSAuth
+}
+
+// GetContainers returns the full container list, including any hidden by
+// the attention filter, for caching
 func (h *HomeModel) GetContainers() []container.Info {
-	return h.containers
+	return h.all
 }
 
-// GetCursor returns the current cursor position for caching
+// GetCursor returns the selected container's position in GetContainers, for caching
 func (h *HomeModel) GetCursor() int {
+	selected, ok := h.SelectedContainer()
+	if !ok {
+		return h.table.Cursor()
+	}
+	for i, c := range h.all {
+		if c.Name == selected.Name {
+			return i
+		}
+	}
 	return h.table.Cursor()
 }
 
+// SelectedContainer returns the container under the cursor, if any
+func (h *HomeModel) SelectedContainer() (container.Info, bool) {
+	cursor := h.table.Cursor()
+	if cursor < 0 || cursor >= len(h.containers) {
+		return container.Info{}, false
+	}
+	return h.containers[cursor], true
+}
+
 // SetCursor sets the cursor position (used when restoring from cache)
 func (h *HomeModel) SetCursor(pos int) {
 	if pos >= 0 && pos < len(h.containers) {
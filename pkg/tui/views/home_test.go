@@ -0,0 +1,143 @@
+// Copyright 2025 Christopher O'Connell
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package views
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/uprockcom/maestro/pkg/container"
+)
+
+func TestSelectionIndex(t *testing.T) {
+	containers := []container.Info{{Name: "a"}, {Name: "b"}, {Name: "c"}}
+
+	tests := []struct {
+		name       string
+		containers []container.Info
+		selected   string
+		prev       int
+		want       int
+	}{
+		{"follows moved container", containers, "c", 0, 2},
+		{"vanished keeps position", containers, "gone", 1, 1},
+		{"vanished clamps to last row", containers, "gone", 5, 2},
+		{"no selection", containers, "", 1, 1},
+		{"empty list", nil, "a", 3, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := selectionIndex(tt.containers, tt.selected, tt.prev); got != tt.want {
+				t.Errorf("selectionIndex() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTruncateCell(t *testing.T) {
+	tests := []struct {
+		name  string
+		s     string
+		width int
+		want  string
+	}{
+		{"fits", "main", 10, "main"},
+		{"exact fit", "feature-x", 9, "feature-x"},
+		{"too long", "feature/very-long-branch-name", 10, "feature/v…"},
+		{"wide runes", "日本語ブランチ", 7, "日本語…"},
+		{"ansi sequences", "\x1b[31mfeature/branch\x1b[0m", 8, "\x1b[31mfeature…\x1b[0m"},
+		{"unknown width", "feature/very-long-branch-name", 0, "feature/very-long-branch-name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := truncateCell(tt.s, tt.width)
+			if got != tt.want {
+				t.Errorf("truncateCell(%q, %d) = %q, want %q", tt.s, tt.width, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToggleAttentionOnly(t *testing.T) {
+	containers := []container.Info{
+		{Name: "maestro-a-1", NeedsAttention: true},
+		{Name: "maestro-b-1"},
+		{Name: "maestro-c-1", NeedsAttention: true},
+	}
+	h := NewHomeModel(containers, false, false)
+	h.SetCursor(2)
+
+	h.ToggleAttentionOnly()
+	selected, ok := h.SelectedContainer()
+	if len(h.containers) != 2 || !ok || selected.Name != "maestro-c-1" {
+		t.Fatalf("filtered rows = %+v, selected %q", h.containers, selected.Name)
+	}
+	if got := h.GetContainers(); len(got) != 3 {
+		t.Errorf("GetContainers() = %d containers, want all 3", len(got))
+	}
+	if got := h.GetCursor(); got != 2 {
+		t.Errorf("GetCursor() = %d, want the position in the full list (2)", got)
+	}
+
+	// Refreshes keep the filter; a container that stopped waiting drops out
+	h.RefreshContainers([]container.Info{
+		{Name: "maestro-a-1", NeedsAttention: true},
+		{Name: "maestro-b-1"},
+		{Name: "maestro-c-1"},
+	}, false)
+	if len(h.containers) != 1 || h.containers[0].Name != "maestro-a-1" {
+		t.Errorf("filtered rows after refresh = %+v", h.containers)
+	}
+
+	h.SetCursor(0)
+	h.ToggleAttentionOnly()
+	if selected, _ := h.SelectedContainer(); len(h.containers) != 3 || selected.Name != "maestro-a-1" {
+		t.Errorf("unfiltered rows = %+v, selected %q", h.containers, selected.Name)
+	}
+}
+
+func TestToggleResources(t *testing.T) {
+	containers := []container.Info{
+		{Name: "maestro-a-1", ShortName: "a-1", Limits: &container.ResourceLimits{CPUs: 2, MemoryBytes: 4 << 30}},
+		{Name: "maestro-b-1", ShortName: "b-1", Limits: &container.ResourceLimits{}},
+		{Name: "maestro-c-1", ShortName: "c-1"},
+	}
+	h := NewHomeModel(containers, false, false)
+	h.SetSize(120, 20)
+	columns := len(h.table.Columns())
+
+	h.ToggleResources()
+	if got := len(h.table.Columns()); got != columns+1 {
+		t.Fatalf("columns = %d, want %d", got, columns+1)
+	}
+	col := len(h.table.Columns()) - 2 // Just before CREATED
+	if title := h.table.Columns()[col].Title; title != "CPU/MEM" {
+		t.Fatalf("column %d = %q, want CPU/MEM", col, title)
+	}
+	var got []string
+	for _, row := range h.table.Rows() {
+		got = append(got, row[col])
+	}
+	if want := []string{"2 / 4g", "∞ / ∞", "—"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("CPU/MEM cells = %q, want %q", got, want)
+	}
+
+	h.ToggleResources()
+	if got := len(h.table.Columns()); got != columns {
+		t.Errorf("columns after hiding = %d, want %d", got, columns)
+	}
+}
@@ -38,6 +38,11 @@ var (
 	BuiltBy = "unknown"
 )
 
+// ImageRevision is the revision of the container image definition (docker/)
+// this binary expects. It must match the com.uprock.maestro.image-revision
+// label in docker/Dockerfile and is bumped whenever the image changes.
+const ImageRevision = 1
+
 // Info returns formatted version information for display.
 func Info() string {
 	var builder strings.Builder